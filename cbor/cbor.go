@@ -19,11 +19,13 @@ import (
 )
 
 const (
-	CborTypeByteString uint8 = 0x40
-	CborTypeTextString uint8 = 0x60
-	CborTypeArray      uint8 = 0x80
-	CborTypeMap        uint8 = 0xa0
-	CborTypeTag        uint8 = 0xc0
+	CborTypeUnsignedInt uint8 = 0x00
+	CborTypeNegativeInt uint8 = 0x20
+	CborTypeByteString  uint8 = 0x40
+	CborTypeTextString  uint8 = 0x60
+	CborTypeArray       uint8 = 0x80
+	CborTypeMap         uint8 = 0xa0
+	CborTypeTag         uint8 = 0xc0
 
 	// Only the top 3 bytes are used to specify the type
 	CborTypeMask uint8 = 0xe0
@@ -35,7 +35,7 @@ const (
 	CborTagAlternative1Max = 127
 	CborTagAlternative2Min = 1280
 	CborTagAlternative2Max = 1400
-	CborTagAlternative3    = 101
+	CborTagAlternative3    = 102
 )
 
 var customTagSet _cbor.TagSet
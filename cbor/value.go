@@ -368,6 +368,13 @@ func (l *LazyValue) UnmarshalCBOR(data []byte) error {
 	return nil
 }
 
+func (l LazyValue) MarshalCBOR() ([]byte, error) {
+	if l.value == nil {
+		return Encode(nil)
+	}
+	return l.value.Cbor(), nil
+}
+
 func (l *LazyValue) MarshalJSON() ([]byte, error) {
 	if l.Value() == nil {
 		// Try to decode if we can, but don't blow up if we can't
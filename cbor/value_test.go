@@ -315,8 +315,8 @@ var constructorTestDefs = []struct {
 		),
 	},
 	{
-		// 101([999, [6, 7]])
-		cborHex: "D865821903E7820607",
+		// 102([999, [6, 7]])
+		cborHex: "D866821903E7820607",
 		expectedObj: cbor.NewConstructor(
 			999,
 			[]any{uint64(6), uint64(7)},
@@ -108,11 +108,14 @@ func (b *AllegraBlock) Transactions() []common.Transaction {
 	return ret
 }
 
-func (b *AllegraBlock) Utxorpc() *utxorpc.Block {
+func (b *AllegraBlock) Utxorpc() (*utxorpc.Block, error) {
 	var txs []*utxorpc.Tx
 	tmpHash, _ := hex.DecodeString(b.Hash())
 	for _, t := range b.Transactions() {
-		tx := t.Utxorpc()
+		tx, err := t.Utxorpc()
+		if err != nil {
+			return nil, err
+		}
 		txs = append(txs, tx)
 	}
 	body := &utxorpc.BlockBody{
@@ -127,7 +130,7 @@ func (b *AllegraBlock) Utxorpc() *utxorpc.Block {
 		Body:   body,
 		Header: header,
 	}
-	return block
+	return block, nil
 }
 
 type AllegraBlockHeader struct {
@@ -260,7 +263,7 @@ func (t AllegraTransaction) Metadata() *cbor.LazyValue {
 	return t.TxMetadata
 }
 
-func (t AllegraTransaction) Utxorpc() *utxorpc.Tx {
+func (t AllegraTransaction) Utxorpc() (*utxorpc.Tx, error) {
 	return t.Body.Utxorpc()
 }
 
@@ -34,6 +34,24 @@ var UtxoValidationRules = []common.UtxoValidationRuleFunc{
 	UtxoValidateMaxTxSizeUtxo,
 }
 
+var UtxowValidationRules = []common.UtxoValidationRuleFunc{
+	UtxowValidateInvalidWitnesses,
+	UtxowValidateMissingVKeyWitnesses,
+	UtxowValidateMissingScriptWitnesses,
+	UtxowValidateScriptWitnessNotValidating,
+	UtxowValidateMetadataHash,
+}
+
+var CertValidationRules = []common.UtxoValidationRuleFunc{
+	DelegValidateWithdrawalsNotInRewards,
+	DelegValidateStakeKeyAlreadyRegistered,
+	DelegValidateStakeKeyNotRegistered,
+	DelegValidateStakeKeyNonZeroAccountBalance,
+	DelegValidateDelegateeNotRegistered,
+	PoolValidateStakePoolNotRegisteredOnKey,
+	PoolValidateStakePoolRetirementWrongEpoch,
+}
+
 // UtxoValidateOutsideValidityIntervalUtxo ensures that the current tip slot has reached the specified validity interval
 func UtxoValidateOutsideValidityIntervalUtxo(tx common.Transaction, slot uint64, _ common.LedgerState, _ common.ProtocolParameters) error {
 	validityIntervalStart := tx.ValidityIntervalStart()
@@ -97,3 +115,55 @@ func UtxoValidateMaxTxSizeUtxo(tx common.Transaction, slot uint64, ls common.Led
 	}
 	return shelley.UtxoValidateMaxTxSizeUtxo(tx, slot, ls, &tmpPparams.ShelleyProtocolParameters)
 }
+
+func UtxowValidateInvalidWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.UtxowValidateInvalidWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateMissingVKeyWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.UtxowValidateMissingVKeyWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateMissingScriptWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.UtxowValidateMissingScriptWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateScriptWitnessNotValidating(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.UtxowValidateScriptWitnessNotValidating(tx, slot, ls, pp)
+}
+
+func UtxowValidateMetadataHash(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.UtxowValidateMetadataHash(tx, slot, ls, pp)
+}
+
+func DelegValidateWithdrawalsNotInRewards(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.DelegValidateWithdrawalsNotInRewards(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyAlreadyRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.DelegValidateStakeKeyAlreadyRegistered(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.DelegValidateStakeKeyNotRegistered(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyNonZeroAccountBalance(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.DelegValidateStakeKeyNonZeroAccountBalance(tx, slot, ls, pp)
+}
+
+func DelegValidateDelegateeNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.DelegValidateDelegateeNotRegistered(tx, slot, ls, pp)
+}
+
+func PoolValidateStakePoolNotRegisteredOnKey(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.PoolValidateStakePoolNotRegisteredOnKey(tx, slot, ls, pp)
+}
+
+func PoolValidateStakePoolRetirementWrongEpoch(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*AllegraProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return shelley.PoolValidateStakePoolRetirementWrongEpoch(tx, slot, ls, &tmpPparams.ShelleyProtocolParameters)
+}
@@ -28,8 +28,12 @@ import (
 )
 
 type testLedgerState struct {
-	networkId uint
-	utxos     []common.Utxo
+	networkId          uint
+	utxos              []common.Utxo
+	stakeRegistrations map[common.Blake2b224]*common.StakeRegistration
+	poolRegistrations  map[common.PoolKeyHash]*common.PoolRegistration
+	drepRegistrations  map[common.Blake2b224]*common.DrepRegistration
+	committeeMembers   map[common.Blake2b224]*common.CommitteeMember
 }
 
 func (ls testLedgerState) NetworkId() uint {
@@ -49,6 +53,22 @@ func (ls testLedgerState) UtxoById(id common.TransactionInput) (common.Utxo, err
 	return common.Utxo{}, fmt.Errorf("not found")
 }
 
+func (ls testLedgerState) StakeRegistration(cred common.Blake2b224) (*common.StakeRegistration, error) {
+	return ls.stakeRegistrations[cred], nil
+}
+
+func (ls testLedgerState) PoolRegistration(pool common.PoolKeyHash) (*common.PoolRegistration, error) {
+	return ls.poolRegistrations[pool], nil
+}
+
+func (ls testLedgerState) DrepRegistration(cred common.Blake2b224) (*common.DrepRegistration, error) {
+	return ls.drepRegistrations[cred], nil
+}
+
+func (ls testLedgerState) CommitteeMember(cred common.Blake2b224) (*common.CommitteeMember, error) {
+	return ls.committeeMembers[cred], nil
+}
+
 func TestUtxoValidateOutsideValidityIntervalUtxo(t *testing.T) {
 	var testSlot uint64 = 555666777
 	var testZeroSlot uint64 = 0
@@ -117,11 +117,14 @@ func (b *AlonzoBlock) Transactions() []common.Transaction {
 	return ret
 }
 
-func (b *AlonzoBlock) Utxorpc() *utxorpc.Block {
+func (b *AlonzoBlock) Utxorpc() (*utxorpc.Block, error) {
 	var txs []*utxorpc.Tx
 	tmpHash, _ := hex.DecodeString(b.Hash())
 	for _, t := range b.Transactions() {
-		tx := t.Utxorpc()
+		tx, err := t.Utxorpc()
+		if err != nil {
+			return nil, err
+		}
 		txs = append(txs, tx)
 	}
 	body := &utxorpc.BlockBody{
@@ -136,7 +139,7 @@ func (b *AlonzoBlock) Utxorpc() *utxorpc.Block {
 		Body:   body,
 		Header: header,
 	}
-	return block
+	return block, nil
 }
 
 type AlonzoBlockHeader struct {
@@ -158,7 +161,7 @@ type AlonzoTransactionBody struct {
 	TxScriptDataHash  *common.Blake2b256                `cbor:"11,keyasint,omitempty"`
 	TxCollateral      []shelley.ShelleyTransactionInput `cbor:"13,keyasint,omitempty"`
 	TxRequiredSigners []common.Blake2b224               `cbor:"14,keyasint,omitempty"`
-	NetworkId         uint8                             `cbor:"15,keyasint,omitempty"`
+	NetworkId         *uint8                            `cbor:"15,keyasint,omitempty"`
 }
 
 func (b *AlonzoTransactionBody) UnmarshalCBOR(cborData []byte) error {
@@ -271,7 +274,7 @@ func (o AlonzoTransactionOutput) Datum() *cbor.LazyValue {
 	return nil
 }
 
-func (o AlonzoTransactionOutput) Utxorpc() *utxorpc.TxOutput {
+func (o AlonzoTransactionOutput) Utxorpc() (*utxorpc.TxOutput, error) {
 	var assets []*utxorpc.Multiasset
 	if o.Assets() != nil {
 		tmpAssets := o.Assets()
@@ -291,14 +294,18 @@ func (o AlonzoTransactionOutput) Utxorpc() *utxorpc.TxOutput {
 		}
 	}
 
+	addressBytes, err := o.OutputAddress.Bytes()
+	if err != nil {
+		return nil, err
+	}
 	return &utxorpc.TxOutput{
-		Address: o.OutputAddress.Bytes(),
+		Address: addressBytes,
 		Coin:    o.Amount(),
 		Assets:  assets,
 		Datum: &utxorpc.Datum{
 			Hash: o.TxOutputDatumHash.Bytes(),
 		},
-	}
+	}, nil
 }
 
 type AlonzoRedeemer struct {
@@ -434,6 +441,11 @@ func (t AlonzoTransaction) ScriptDataHash() *common.Blake2b256 {
 	return t.Body.ScriptDataHash()
 }
 
+// NetworkId returns the network ID specified in the transaction body, if any
+func (t AlonzoTransaction) NetworkId() *uint8 {
+	return t.Body.NetworkId
+}
+
 func (t AlonzoTransaction) VotingProcedures() common.VotingProcedures {
 	return t.Body.VotingProcedures()
 }
@@ -504,7 +516,7 @@ func (t *AlonzoTransaction) Cbor() []byte {
 	tmpObj := []any{
 		cbor.RawMessage(t.Body.Cbor()),
 		cbor.RawMessage(t.WitnessSet.Cbor()),
-		t.IsValid,
+		t.IsValid(),
 	}
 	if t.TxMetadata != nil {
 		tmpObj = append(tmpObj, cbor.RawMessage(t.TxMetadata.Cbor()))
@@ -516,7 +528,7 @@ func (t *AlonzoTransaction) Cbor() []byte {
 	return cborData
 }
 
-func (t *AlonzoTransaction) Utxorpc() *utxorpc.Tx {
+func (t *AlonzoTransaction) Utxorpc() (*utxorpc.Tx, error) {
 	return t.Body.Utxorpc()
 }
 
@@ -0,0 +1,146 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alonzo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+type NoCollateralInputsError struct{}
+
+func (NoCollateralInputsError) Error() string {
+	return "no collateral inputs"
+}
+
+type TooManyCollateralInputsError struct {
+	Provided uint
+	Max      uint
+}
+
+func (e TooManyCollateralInputsError) Error() string {
+	return fmt.Sprintf(
+		"too many collateral inputs: provided %d, maximum %d",
+		e.Provided,
+		e.Max,
+	)
+}
+
+type InsufficientCollateralError struct {
+	Provided uint64
+	Required uint64
+}
+
+func (e InsufficientCollateralError) Error() string {
+	return fmt.Sprintf(
+		"insufficient collateral: provided %d, required %d",
+		e.Provided,
+		e.Required,
+	)
+}
+
+type CollateralContainsNonADAError struct {
+	Inputs []common.TransactionInput
+}
+
+func (e CollateralContainsNonADAError) Error() string {
+	tmpInputs := make([]string, 0, len(e.Inputs))
+	for _, tmpInput := range e.Inputs {
+		tmpInputs = append(tmpInputs, tmpInput.String())
+	}
+	return fmt.Sprintf(
+		"collateral contains non-ADA assets: %s",
+		strings.Join(tmpInputs, ", "),
+	)
+}
+
+type ScriptsNotPaidUtxoError struct {
+	Inputs []common.TransactionInput
+}
+
+func (e ScriptsNotPaidUtxoError) Error() string {
+	tmpInputs := make([]string, 0, len(e.Inputs))
+	for _, tmpInput := range e.Inputs {
+		tmpInputs = append(tmpInputs, tmpInput.String())
+	}
+	return fmt.Sprintf(
+		"collateral input(s) not locked by a payment key: %s",
+		strings.Join(tmpInputs, ", "),
+	)
+}
+
+type ExUnitsTooBigUtxoError struct {
+	TotalExUnits common.ExUnit
+	MaxTxExUnits common.ExUnit
+}
+
+func (e ExUnitsTooBigUtxoError) Error() string {
+	return fmt.Sprintf(
+		"ExUnits too big: total %d/%d, maximum %d/%d",
+		e.TotalExUnits.Mem,
+		e.TotalExUnits.Steps,
+		e.MaxTxExUnits.Mem,
+		e.MaxTxExUnits.Steps,
+	)
+}
+
+type WrongNetworkInTxBodyError struct {
+	NetId   uint
+	TxNetId uint
+}
+
+func (e WrongNetworkInTxBodyError) Error() string {
+	return fmt.Sprintf(
+		"wrong network ID in transaction body: expected %d, got %d",
+		e.NetId,
+		e.TxNetId,
+	)
+}
+
+type MissingScriptDataHashError struct{}
+
+func (MissingScriptDataHashError) Error() string {
+	return "missing script data hash"
+}
+
+type ExtraneousScriptDataHashError struct {
+	Provided common.Blake2b256
+}
+
+func (e ExtraneousScriptDataHashError) Error() string {
+	return fmt.Sprintf(
+		"extraneous script data hash: %s",
+		e.Provided.String(),
+	)
+}
+
+type ScriptDataHashMismatchError struct {
+	Provided   common.Blake2b256
+	Calculated *common.Blake2b256
+}
+
+func (e ScriptDataHashMismatchError) Error() string {
+	calculated := "<none>"
+	if e.Calculated != nil {
+		calculated = e.Calculated.String()
+	}
+	return fmt.Sprintf(
+		"script data hash mismatch: provided %s, calculated %s",
+		e.Provided.String(),
+		calculated,
+	)
+}
@@ -24,7 +24,7 @@ import (
 type AlonzoProtocolParameters struct {
 	mary.MaryProtocolParameters
 	MinPoolCost          uint64
-	AdaPerUtxoByte       uint64
+	CoinsPerUtxoWord     uint64
 	CostModels           map[uint][]int64
 	ExecutionCosts       common.ExUnitPrice
 	MaxTxExUnits         common.ExUnit
@@ -43,8 +43,8 @@ func (p *AlonzoProtocolParameters) Update(
 	if paramUpdate.MinPoolCost != nil {
 		p.MinPoolCost = *paramUpdate.MinPoolCost
 	}
-	if paramUpdate.AdaPerUtxoByte != nil {
-		p.AdaPerUtxoByte = *paramUpdate.AdaPerUtxoByte
+	if paramUpdate.CoinsPerUtxoWord != nil {
+		p.CoinsPerUtxoWord = *paramUpdate.CoinsPerUtxoWord
 	}
 	if paramUpdate.CostModels != nil {
 		p.CostModels = paramUpdate.CostModels
@@ -73,7 +73,7 @@ func (p *AlonzoProtocolParameters) UpdateFromGenesis(genesis *AlonzoGenesis) {
 	if genesis == nil {
 		return
 	}
-	p.AdaPerUtxoByte = genesis.LovelacePerUtxoWord / 8
+	p.CoinsPerUtxoWord = genesis.LovelacePerUtxoWord
 	p.MaxValueSize = genesis.MaxValueSize
 	p.CollateralPercentage = genesis.CollateralPercentage
 	p.MaxCollateralInputs = genesis.MaxCollateralInputs
@@ -100,7 +100,7 @@ func (p *AlonzoProtocolParameters) UpdateFromGenesis(genesis *AlonzoGenesis) {
 type AlonzoProtocolParameterUpdate struct {
 	mary.MaryProtocolParameterUpdate
 	MinPoolCost          *uint64             `cbor:"16,keyasint"`
-	AdaPerUtxoByte       *uint64             `cbor:"17,keyasint"`
+	CoinsPerUtxoWord     *uint64             `cbor:"17,keyasint"`
 	CostModels           map[uint][]int64    `cbor:"18,keyasint"`
 	ExecutionCosts       *common.ExUnitPrice `cbor:"19,keyasint"`
 	MaxTxExUnits         *common.ExUnit      `cbor:"20,keyasint"`
@@ -116,7 +116,7 @@ func (u *AlonzoProtocolParameterUpdate) UnmarshalCBOR(data []byte) error {
 
 func (p *AlonzoProtocolParameters) Utxorpc() *cardano.PParams {
 	return &cardano.PParams{
-		CoinsPerUtxoByte:         p.AdaPerUtxoByte,
+		CoinsPerUtxoByte:         p.CoinsPerUtxoWord / 8,
 		MaxTxSize:                uint64(p.MaxTxSize),
 		MinFeeCoefficient:        uint64(p.MinFeeA),
 		MinFeeConstant:           uint64(p.MinFeeB),
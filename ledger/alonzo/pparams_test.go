@@ -160,7 +160,7 @@ func TestAlonzoProtocolParamsUpdateFromGenesis(t *testing.T) {
 						},
 					},
 				},
-				AdaPerUtxoByte: 34482 / 8,
+				CoinsPerUtxoWord: 34482,
 			},
 		},
 	}
@@ -205,7 +205,7 @@ func TestAlonzoUtxorpc(t *testing.T) {
 				},
 			},
 		},
-		AdaPerUtxoByte:       44 / 8,
+		CoinsPerUtxoWord:     44,
 		MinPoolCost:          340000000,
 		MaxValueSize:         1024,
 		CollateralPercentage: 150,
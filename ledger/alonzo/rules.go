@@ -0,0 +1,670 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alonzo
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/allegra"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+const (
+	// Fixed size (in words) of a UTxO entry excluding the value
+	utxoEntrySizeWithoutVal = 27
+	// Size (in words) of an ADA-only value
+	coinSize = 2
+	// Size (in words) of a datum hash
+	dataHashSize = 10
+)
+
+var UtxoValidationRules = []common.UtxoValidationRuleFunc{
+	UtxoValidateOutsideValidityIntervalUtxo,
+	UtxoValidateInputSetEmptyUtxo,
+	UtxoValidateFeeTooSmallUtxo,
+	UtxoValidateInsufficientCollateral,
+	UtxoValidateCollateralContainsNonAda,
+	UtxoValidateScriptsNotPaidUtxo,
+	UtxoValidateNoCollateralInputs,
+	UtxoValidateBadInputsUtxo,
+	UtxoValidateValueNotConservedUtxo,
+	UtxoValidateOutputTooSmallUtxo,
+	UtxoValidateOutputTooBigUtxo,
+	UtxoValidateOutputBootAddrAttrsTooBig,
+	UtxoValidateWrongNetwork,
+	UtxoValidateWrongNetworkWithdrawal,
+	UtxoValidateWrongNetworkInTxBody,
+	UtxoValidateMaxTxSizeUtxo,
+	UtxoValidateExUnitsTooBigUtxo,
+	UtxoValidateTooManyCollateralInputs,
+	UtxoValidateScriptDataHash,
+}
+
+var UtxowValidationRules = []common.UtxoValidationRuleFunc{
+	UtxowValidateInvalidWitnesses,
+	UtxowValidateMissingVKeyWitnesses,
+	UtxowValidateMissingScriptWitnesses,
+	UtxowValidateScriptWitnessNotValidating,
+	UtxowValidateMetadataHash,
+}
+
+var CertValidationRules = []common.UtxoValidationRuleFunc{
+	DelegValidateWithdrawalsNotInRewards,
+	DelegValidateStakeKeyAlreadyRegistered,
+	DelegValidateStakeKeyNotRegistered,
+	DelegValidateStakeKeyNonZeroAccountBalance,
+	DelegValidateDelegateeNotRegistered,
+	PoolValidateStakePoolNotRegisteredOnKey,
+	PoolValidateStakePoolRetirementWrongEpoch,
+	PoolValidateStakePoolCostTooLow,
+}
+
+// UtxoValidateInsufficientCollateral ensures that the collateral covers the required percentage of the fee
+func UtxoValidateInsufficientCollateral(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*AlonzoProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	// There's nothing to check if there are no redeemers
+	if !HasRedeemers(tx) {
+		return nil
+	}
+	var totalCollateral uint64
+	for _, collateralInput := range tx.Collateral() {
+		utxo, err := ls.UtxoById(collateralInput)
+		// Ignore errors fetching the UTxO and exclude it from calculations
+		if err != nil {
+			continue
+		}
+		totalCollateral += utxo.Output.Amount()
+	}
+	// Compare using percentages to avoid rounding
+	if totalCollateral*100 >= tx.Fee()*uint64(tmpPparams.CollateralPercentage) {
+		return nil
+	}
+	// Round up the required collateral for the error
+	requiredCollateral := (tx.Fee()*uint64(tmpPparams.CollateralPercentage) + 99) / 100
+	return InsufficientCollateralError{
+		Provided: totalCollateral,
+		Required: requiredCollateral,
+	}
+}
+
+// UtxoValidateCollateralContainsNonAda ensures that collateral inputs don't contain non-ADA assets
+func UtxoValidateCollateralContainsNonAda(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	if !HasRedeemers(tx) {
+		return nil
+	}
+	var badInputs []common.TransactionInput
+	for _, collateralInput := range tx.Collateral() {
+		utxo, err := ls.UtxoById(collateralInput)
+		// Ignore errors fetching the UTxO, which are covered by other rules
+		if err != nil {
+			continue
+		}
+		assets := utxo.Output.Assets()
+		if assets == nil || len(assets.Policies()) == 0 {
+			continue
+		}
+		badInputs = append(badInputs, collateralInput)
+	}
+	if len(badInputs) == 0 {
+		return nil
+	}
+	return CollateralContainsNonADAError{
+		Inputs: badInputs,
+	}
+}
+
+// UtxoValidateScriptsNotPaidUtxo ensures that all collateral inputs are locked by a payment key
+func UtxoValidateScriptsNotPaidUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	if !HasRedeemers(tx) {
+		return nil
+	}
+	var badInputs []common.TransactionInput
+	for _, collateralInput := range tx.Collateral() {
+		utxo, err := ls.UtxoById(collateralInput)
+		// Ignore errors fetching the UTxO, which are covered by other rules
+		if err != nil {
+			continue
+		}
+		switch utxo.Output.Address().Type() {
+		case common.AddressTypeScriptKey,
+			common.AddressTypeScriptScript,
+			common.AddressTypeScriptPointer,
+			common.AddressTypeScriptNone:
+			badInputs = append(badInputs, collateralInput)
+		}
+	}
+	if len(badInputs) == 0 {
+		return nil
+	}
+	return ScriptsNotPaidUtxoError{
+		Inputs: badInputs,
+	}
+}
+
+// UtxoValidateNoCollateralInputs ensures that collateral inputs are provided when the transaction runs scripts
+func UtxoValidateNoCollateralInputs(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	if !HasRedeemers(tx) {
+		return nil
+	}
+	if len(tx.Collateral()) > 0 {
+		return nil
+	}
+	return NoCollateralInputsError{}
+}
+
+// UtxoValidateBadInputsUtxo ensures that all inputs and collateral inputs are present in the ledger state (have not been spent)
+func UtxoValidateBadInputsUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	var badInputs []common.TransactionInput
+	for _, tmpInput := range slices.Concat(tx.Inputs(), tx.Collateral()) {
+		_, err := ls.UtxoById(tmpInput)
+		if err != nil {
+			badInputs = append(badInputs, tmpInput)
+		}
+	}
+	if len(badInputs) == 0 {
+		return nil
+	}
+	return shelley.BadInputsUtxoError{
+		Inputs: badInputs,
+	}
+}
+
+// UtxoValidateOutputTooSmallUtxo ensures that outputs have at least the minimum value
+func UtxoValidateOutputTooSmallUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	var badOutputs []common.TransactionOutput
+	for _, tmpOutput := range tx.Outputs() {
+		minCoin, err := MinCoinTxOut(tmpOutput, pp)
+		if err != nil {
+			return err
+		}
+		if tmpOutput.Amount() < minCoin {
+			badOutputs = append(badOutputs, tmpOutput)
+		}
+	}
+	if len(badOutputs) == 0 {
+		return nil
+	}
+	return shelley.OutputTooSmallUtxoError{
+		Outputs: badOutputs,
+	}
+}
+
+// UtxoValidateOutputTooBigUtxo ensures that transaction output values are not too large
+func UtxoValidateOutputTooBigUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*AlonzoProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	var badOutputs []common.TransactionOutput
+	for _, txOutput := range tx.Outputs() {
+		tmpOutput, ok := txOutput.(*AlonzoTransactionOutput)
+		if !ok {
+			return fmt.Errorf("transaction output is not expected type")
+		}
+		outputValBytes, err := cbor.Encode(&tmpOutput.OutputAmount)
+		if err != nil {
+			return err
+		}
+		if uint(len(outputValBytes)) <= tmpPparams.MaxValueSize {
+			continue
+		}
+		badOutputs = append(badOutputs, tmpOutput)
+	}
+	if len(badOutputs) == 0 {
+		return nil
+	}
+	return mary.OutputTooBigUtxoError{
+		Outputs: badOutputs,
+	}
+}
+
+// UtxoValidateWrongNetworkInTxBody ensures that the network ID in the transaction body, if specified, is correct
+func UtxoValidateWrongNetworkInTxBody(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	// This covers transactions from Alonzo onward, which can specify a network ID in the body
+	tmpTx, ok := tx.(interface{ NetworkId() *uint8 })
+	if !ok {
+		return fmt.Errorf("transaction is not expected type")
+	}
+	txNetworkId := tmpTx.NetworkId()
+	if txNetworkId == nil {
+		return nil
+	}
+	if uint(*txNetworkId) == ls.NetworkId() {
+		return nil
+	}
+	return WrongNetworkInTxBodyError{
+		NetId:   ls.NetworkId(),
+		TxNetId: uint(*txNetworkId),
+	}
+}
+
+// UtxoValidateExUnitsTooBigUtxo ensures that the total ExUnits of all redeemers don't exceed the per-transaction maximum
+func UtxoValidateExUnitsTooBigUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*AlonzoProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	totalExUnits := TotalExUnits(tx)
+	if totalExUnits.Mem <= tmpPparams.MaxTxExUnits.Mem &&
+		totalExUnits.Steps <= tmpPparams.MaxTxExUnits.Steps {
+		return nil
+	}
+	return ExUnitsTooBigUtxoError{
+		TotalExUnits: totalExUnits,
+		MaxTxExUnits: tmpPparams.MaxTxExUnits,
+	}
+}
+
+// UtxoValidateTooManyCollateralInputs ensures that the number of collateral inputs doesn't exceed the maximum
+func UtxoValidateTooManyCollateralInputs(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*AlonzoProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	collateralCount := uint(len(tx.Collateral()))
+	if collateralCount <= tmpPparams.MaxCollateralInputs {
+		return nil
+	}
+	return TooManyCollateralInputsError{
+		Provided: collateralCount,
+		Max:      tmpPparams.MaxCollateralInputs,
+	}
+}
+
+// UtxoValidateScriptDataHash ensures that the script data hash is present if and only if the transaction
+// contains redeemers or datums, and that it matches the hash calculated from the transaction
+func UtxoValidateScriptDataHash(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*AlonzoProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return ValidateScriptDataHash(tx, ls, tmpPparams.CostModels)
+}
+
+// ValidateScriptDataHash ensures that the script data hash is present if and only if the transaction
+// contains redeemers or datums, and that it matches the hash calculated using the provided cost models
+func ValidateScriptDataHash(tx common.Transaction, ls common.LedgerState, costModels map[uint][]int64) error {
+	scriptDataHash := tx.ScriptDataHash()
+	needsScriptDataHash := HasRedeemers(tx) ||
+		len(tx.Witnesses().PlutusData()) > 0
+	if needsScriptDataHash && scriptDataHash == nil {
+		return MissingScriptDataHashError{}
+	}
+	if !needsScriptDataHash && scriptDataHash != nil {
+		return ExtraneousScriptDataHashError{
+			Provided: *scriptDataHash,
+		}
+	}
+	if !needsScriptDataHash {
+		return nil
+	}
+	redeemers, datums, err := ScriptIntegrityData(tx)
+	if err != nil {
+		return err
+	}
+	calculated, err := common.CalculateScriptDataHash(
+		costModels,
+		ScriptLanguages(tx, ls),
+		redeemers,
+		datums,
+	)
+	if err != nil {
+		return err
+	}
+	if calculated == nil || *calculated != *scriptDataHash {
+		return ScriptDataHashMismatchError{
+			Provided:   *scriptDataHash,
+			Calculated: calculated,
+		}
+	}
+	return nil
+}
+
+// ScriptIntegrityData returns the CBOR encoding of the redeemers and datums from the transaction witness
+// set, which are covered by the script data hash. The original CBOR is used when available, since the hash
+// depends on the exact encoding
+func ScriptIntegrityData(tx common.Transaction) ([]byte, []byte, error) {
+	witnesses := tx.Witnesses()
+	var redeemers, datums []byte
+	if tmpWitnesses, ok := witnesses.(interface{ Cbor() []byte }); ok && len(tmpWitnesses.Cbor()) > 0 {
+		var tmpFields map[uint]cbor.RawMessage
+		if _, err := cbor.Decode(tmpWitnesses.Cbor(), &tmpFields); err != nil {
+			return nil, nil, err
+		}
+		// Datums and redeemers use keys 4 and 5 in the witness set
+		redeemers = tmpFields[5]
+		datums = tmpFields[4]
+	} else {
+		if datumValues := witnesses.PlutusData(); len(datumValues) > 0 {
+			tmpDatums := make([]cbor.RawMessage, len(datumValues))
+			for idx, datum := range datumValues {
+				tmpDatums[idx] = cbor.RawMessage(datum.Cbor())
+			}
+			var err error
+			datums, err = cbor.Encode(tmpDatums)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if len(redeemers) == 0 {
+		tmpRedeemers := witnesses.Redeemers()
+		// Make sure empty redeemers are encoded as an empty list rather than null
+		if r, ok := tmpRedeemers.(AlonzoRedeemers); ok && r == nil {
+			tmpRedeemers = AlonzoRedeemers{}
+		}
+		var err error
+		redeemers, err = cbor.Encode(tmpRedeemers)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return redeemers, datums, nil
+}
+
+// ScriptLanguages returns the Plutus script types of the scripts that are required by the transaction,
+// from either the witness set or reference scripts
+func ScriptLanguages(tx common.Transaction, ls common.LedgerState) []uint8 {
+	scriptTypes := make(map[common.Blake2b224]uint8)
+	witnesses := tx.Witnesses()
+	for _, script := range witnesses.PlutusV1Scripts() {
+		scriptTypes[common.ScriptHash(common.ScriptTypePlutusV1, script)] = common.ScriptTypePlutusV1
+	}
+	for _, script := range witnesses.PlutusV2Scripts() {
+		scriptTypes[common.ScriptHash(common.ScriptTypePlutusV2, script)] = common.ScriptTypePlutusV2
+	}
+	for _, script := range witnesses.PlutusV3Scripts() {
+		scriptTypes[common.ScriptHash(common.ScriptTypePlutusV3, script)] = common.ScriptTypePlutusV3
+	}
+	// Reference scripts
+	type referenceScriptOutput interface {
+		ReferenceScript() (uint, []byte, error)
+	}
+	for _, tmpInput := range slices.Concat(tx.Inputs(), tx.ReferenceInputs()) {
+		utxo, err := ls.UtxoById(tmpInput)
+		if err != nil {
+			continue
+		}
+		tmpOutput, ok := utxo.Output.(referenceScriptOutput)
+		if !ok {
+			continue
+		}
+		scriptType, script, err := tmpOutput.ReferenceScript()
+		if err != nil || script == nil || scriptType == common.ScriptTypeNative {
+			continue
+		}
+		scriptTypes[common.ScriptHash(uint8(scriptType), script)] = uint8(scriptType) // #nosec G115
+	}
+	var ret []uint8
+	for _, scriptHash := range shelley.RequiredScriptHashes(tx, ls) {
+		scriptType, ok := scriptTypes[scriptHash]
+		if !ok || slices.Contains(ret, scriptType) {
+			continue
+		}
+		ret = append(ret, scriptType)
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+func UtxoValidateOutsideValidityIntervalUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.UtxoValidateOutsideValidityIntervalUtxo(tx, slot, ls, pp)
+}
+
+func UtxoValidateInputSetEmptyUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.UtxoValidateInputSetEmptyUtxo(tx, slot, ls, pp)
+}
+
+// UtxoValidateFeeTooSmallUtxo ensures that the fee is at least the calculated minimum
+func UtxoValidateFeeTooSmallUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	minFee, err := MinFeeTx(tx, pp)
+	if err != nil {
+		return err
+	}
+	if tx.Fee() >= minFee {
+		return nil
+	}
+	return shelley.FeeTooSmallUtxoError{
+		Provided: tx.Fee(),
+		Min:      minFee,
+	}
+}
+
+func UtxoValidateWrongNetwork(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.UtxoValidateWrongNetwork(tx, slot, ls, pp)
+}
+
+func UtxoValidateWrongNetworkWithdrawal(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.UtxoValidateWrongNetworkWithdrawal(tx, slot, ls, pp)
+}
+
+func UtxoValidateValueNotConservedUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*AlonzoProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return shelley.UtxoValidateValueNotConservedUtxo(tx, slot, ls, &tmpPparams.ShelleyProtocolParameters)
+}
+
+func UtxoValidateOutputBootAddrAttrsTooBig(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return shelley.UtxoValidateOutputBootAddrAttrsTooBig(tx, slot, ls, pp)
+}
+
+func UtxoValidateMaxTxSizeUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*AlonzoProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return shelley.UtxoValidateMaxTxSizeUtxo(tx, slot, ls, &tmpPparams.ShelleyProtocolParameters)
+}
+
+// MinFeeTx calculates the minimum required fee for a transaction based on protocol parameters,
+// including the cost of script execution
+func MinFeeTx(tx common.Transaction, pparams common.ProtocolParameters) (uint64, error) {
+	tmpPparams, ok := pparams.(*AlonzoProtocolParameters)
+	if !ok {
+		return 0, fmt.Errorf("pparams are not expected type")
+	}
+	txBytes := tx.Cbor()
+	minFee := uint64((tmpPparams.MinFeeA * uint(len(txBytes))) + tmpPparams.MinFeeB)
+	minFee += ScriptFee(TotalExUnits(tx), tmpPparams.ExecutionCosts)
+	return minFee, nil
+}
+
+// ScriptFee calculates the fee for the specified execution units using the provided prices
+func ScriptFee(exUnits common.ExUnit, prices common.ExUnitPrice) uint64 {
+	fee := new(big.Rat)
+	if prices.MemPrice != nil && prices.MemPrice.Rat != nil {
+		fee.Add(
+			fee,
+			new(big.Rat).Mul(
+				prices.MemPrice.Rat,
+				new(big.Rat).SetUint64(uint64(exUnits.Mem)),
+			),
+		)
+	}
+	if prices.StepPrice != nil && prices.StepPrice.Rat != nil {
+		fee.Add(
+			fee,
+			new(big.Rat).Mul(
+				prices.StepPrice.Rat,
+				new(big.Rat).SetUint64(uint64(exUnits.Steps)),
+			),
+		)
+	}
+	// Round up to the nearest lovelace
+	ret, rem := new(big.Int).QuoRem(fee.Num(), fee.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		ret.Add(ret, big.NewInt(1))
+	}
+	return ret.Uint64()
+}
+
+// TotalExUnits returns the sum of the ExUnits for all redeemers in a transaction
+func TotalExUnits(tx common.Transaction) common.ExUnit {
+	var ret common.ExUnit
+	redeemers := tx.Witnesses().Redeemers()
+	if redeemers == nil {
+		return ret
+	}
+	for _, tag := range redeemerTags {
+		for _, idx := range redeemers.Indexes(tag) {
+			_, exUnits := redeemers.Value(idx, tag)
+			ret.Mem += uint(exUnits.Memory)
+			ret.Steps += uint(exUnits.Steps)
+		}
+	}
+	return ret
+}
+
+var redeemerTags = []common.RedeemerTag{
+	common.RedeemerTagSpend,
+	common.RedeemerTagMint,
+	common.RedeemerTagCert,
+	common.RedeemerTagReward,
+	common.RedeemerTagVoting,
+	common.RedeemerTagProposing,
+}
+
+// HasRedeemers returns true if the transaction contains any redeemers, which means that it runs Plutus scripts
+func HasRedeemers(tx common.Transaction) bool {
+	redeemers := tx.Witnesses().Redeemers()
+	if redeemers == nil {
+		return false
+	}
+	for _, tag := range redeemerTags {
+		if len(redeemers.Indexes(tag)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MinCoinTxOut calculates the minimum coin for a transaction output based on protocol parameters
+func MinCoinTxOut(txOut common.TransactionOutput, pparams common.ProtocolParameters) (uint64, error) {
+	tmpPparams, ok := pparams.(*AlonzoProtocolParameters)
+	if !ok {
+		return 0, fmt.Errorf("pparams are not expected type")
+	}
+	entrySize := uint64(utxoEntrySizeWithoutVal)
+	entrySize += valueSize(txOut.Assets())
+	if txOut.DatumHash() != nil {
+		entrySize += dataHashSize
+	}
+	return entrySize * tmpPparams.CoinsPerUtxoWord, nil
+}
+
+// valueSize returns the size in words of an output value as calculated by the Alonzo ledger rules
+func valueSize(assets *common.MultiAsset[common.MultiAssetTypeOutput]) uint64 {
+	if assets == nil {
+		return coinSize
+	}
+	policies := assets.Policies()
+	if len(policies) == 0 {
+		return coinSize
+	}
+	var numAssets, sumAssetNameLengths uint64
+	for _, policyId := range policies {
+		for _, assetName := range assets.Assets(policyId) {
+			numAssets++
+			sumAssetNameLengths += uint64(len(assetName))
+		}
+	}
+	numPolicies := uint64(len(policies))
+	// Round up to the nearest word
+	sizeBytes := (numAssets * 12) + sumAssetNameLengths + (numPolicies * 28)
+	return 6 + ((sizeBytes + 7) / 8)
+}
+
+func UtxowValidateInvalidWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.UtxowValidateInvalidWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateMissingVKeyWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.UtxowValidateMissingVKeyWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateMissingScriptWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.UtxowValidateMissingScriptWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateScriptWitnessNotValidating(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.UtxowValidateScriptWitnessNotValidating(tx, slot, ls, pp)
+}
+
+func UtxowValidateMetadataHash(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.UtxowValidateMetadataHash(tx, slot, ls, pp)
+}
+
+func DelegValidateWithdrawalsNotInRewards(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.DelegValidateWithdrawalsNotInRewards(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyAlreadyRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.DelegValidateStakeKeyAlreadyRegistered(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.DelegValidateStakeKeyNotRegistered(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyNonZeroAccountBalance(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.DelegValidateStakeKeyNonZeroAccountBalance(tx, slot, ls, pp)
+}
+
+func DelegValidateDelegateeNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.DelegValidateDelegateeNotRegistered(tx, slot, ls, pp)
+}
+
+func PoolValidateStakePoolNotRegisteredOnKey(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return mary.PoolValidateStakePoolNotRegisteredOnKey(tx, slot, ls, pp)
+}
+
+func PoolValidateStakePoolRetirementWrongEpoch(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*AlonzoProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return mary.PoolValidateStakePoolRetirementWrongEpoch(tx, slot, ls, &tmpPparams.MaryProtocolParameters)
+}
+
+// PoolValidateStakePoolCostTooLow ensures that registered pools have a cost of at least the minimum pool cost
+func PoolValidateStakePoolCostTooLow(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*AlonzoProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	for _, cert := range tx.Certificates() {
+		c, ok := cert.(*common.PoolRegistrationCertificate)
+		if !ok {
+			continue
+		}
+		if c.Cost < tmpPparams.MinPoolCost {
+			return shelley.StakePoolCostTooLowError{
+				Cost:    c.Cost,
+				MinCost: tmpPparams.MinPoolCost,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,899 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alonzo_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/allegra"
+	"github.com/blinklabs-io/gouroboros/ledger/alonzo"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLedgerState struct {
+	networkId          uint
+	utxos              []common.Utxo
+	stakeRegistrations map[common.Blake2b224]*common.StakeRegistration
+	poolRegistrations  map[common.PoolKeyHash]*common.PoolRegistration
+	drepRegistrations  map[common.Blake2b224]*common.DrepRegistration
+	committeeMembers   map[common.Blake2b224]*common.CommitteeMember
+}
+
+func (ls testLedgerState) NetworkId() uint {
+	return ls.networkId
+}
+
+func (ls testLedgerState) UtxoById(id common.TransactionInput) (common.Utxo, error) {
+	for _, tmpUtxo := range ls.utxos {
+		if id.Index() != tmpUtxo.Id.Index() {
+			continue
+		}
+		if string(id.Id().Bytes()) != string(tmpUtxo.Id.Id().Bytes()) {
+			continue
+		}
+		return tmpUtxo, nil
+	}
+	return common.Utxo{}, fmt.Errorf("not found")
+}
+
+func (ls testLedgerState) StakeRegistration(cred common.Blake2b224) (*common.StakeRegistration, error) {
+	return ls.stakeRegistrations[cred], nil
+}
+
+func (ls testLedgerState) PoolRegistration(pool common.PoolKeyHash) (*common.PoolRegistration, error) {
+	return ls.poolRegistrations[pool], nil
+}
+
+func (ls testLedgerState) DrepRegistration(cred common.Blake2b224) (*common.DrepRegistration, error) {
+	return ls.drepRegistrations[cred], nil
+}
+
+func (ls testLedgerState) CommitteeMember(cred common.Blake2b224) (*common.CommitteeMember, error) {
+	return ls.committeeMembers[cred], nil
+}
+
+const testCollateralTxId = "d228b482a1aae768e4a796380f49e021d9c21f70d3c12cb186b188dedfc0ee22"
+
+var testRedeemers = alonzo.AlonzoRedeemers{
+	{
+		Tag:   common.RedeemerTagSpend,
+		Index: 0,
+		ExUnits: common.RedeemerExUnits{
+			Memory: 1000,
+			Steps:  2000,
+		},
+	},
+}
+
+func testAddress(t *testing.T, addrType uint8) common.Address {
+	tmpHash := make([]byte, 28)
+	if _, err := rand.Read(tmpHash); err != nil {
+		t.Fatalf("could not read random bytes")
+	}
+	addr, err := common.NewAddressFromParts(addrType, 0, tmpHash, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return addr
+}
+
+func testCollateralTx(redeemers alonzo.AlonzoRedeemers, collateralCount int) *alonzo.AlonzoTransaction {
+	var collateral []shelley.ShelleyTransactionInput
+	for idx := range collateralCount {
+		collateral = append(
+			collateral,
+			shelley.NewShelleyTransactionInput(testCollateralTxId, idx),
+		)
+	}
+	return &alonzo.AlonzoTransaction{
+		Body: alonzo.AlonzoTransactionBody{
+			TxCollateral: collateral,
+		},
+		WitnessSet: alonzo.AlonzoTransactionWitnessSet{
+			WsRedeemers: redeemers,
+		},
+	}
+}
+
+func testCollateralLedgerState(outputs ...alonzo.AlonzoTransactionOutput) testLedgerState {
+	ls := testLedgerState{}
+	for idx, output := range outputs {
+		ls.utxos = append(
+			ls.utxos,
+			common.Utxo{
+				Id:     shelley.NewShelleyTransactionInput(testCollateralTxId, idx),
+				Output: output,
+			},
+		)
+	}
+	return ls
+}
+
+func TestUtxoValidateFeeTooSmallUtxo(t *testing.T) {
+	// 7*3 + 53 + ceil(1000*0.0577 + 2000*0.0000721)
+	var testExactFee uint64 = 132
+	var testBelowFee uint64 = 131
+	testTxCbor, _ := hex.DecodeString("abcdef")
+	testTx := testCollateralTx(testRedeemers, 0)
+	testTx.SetCbor(testTxCbor)
+	testProtocolParams := &alonzo.AlonzoProtocolParameters{
+		MaryProtocolParameters: mary.MaryProtocolParameters{
+			AllegraProtocolParameters: allegra.AllegraProtocolParameters{
+				ShelleyProtocolParameters: shelley.ShelleyProtocolParameters{
+					MinFeeA: 7,
+					MinFeeB: 53,
+				},
+			},
+		},
+		ExecutionCosts: common.ExUnitPrice{
+			MemPrice:  &cbor.Rat{Rat: big.NewRat(577, 10000)},
+			StepPrice: &cbor.Rat{Rat: big.NewRat(721, 10000000)},
+		},
+	}
+	testLedgerState := testLedgerState{}
+	testSlot := uint64(0)
+	// Exact fee
+	t.Run(
+		"exact fee",
+		func(t *testing.T) {
+			testTx.Body.TxFee = testExactFee
+			err := alonzo.UtxoValidateFeeTooSmallUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateFeeTooSmallUtxo should succeed when provided the exact fee including script costs\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Fee too low
+	t.Run(
+		"fee too low",
+		func(t *testing.T) {
+			testTx.Body.TxFee = testBelowFee
+			err := alonzo.UtxoValidateFeeTooSmallUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateFeeTooSmallUtxo should fail when provided too low of a fee",
+				)
+				return
+			}
+			testErrType := shelley.FeeTooSmallUtxoError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateInsufficientCollateral(t *testing.T) {
+	var testFee uint64 = 200000
+	testProtocolParams := &alonzo.AlonzoProtocolParameters{
+		CollateralPercentage: 150,
+	}
+	testSlot := uint64(0)
+	testRun := func(t *testing.T, name string, collateralAmount uint64, redeemers alonzo.AlonzoRedeemers, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testTx := testCollateralTx(redeemers, 1)
+				testTx.Body.TxFee = testFee
+				testLedgerState := testCollateralLedgerState(
+					alonzo.AlonzoTransactionOutput{
+						OutputAmount: mary.MaryTransactionOutputValue{
+							Amount: collateralAmount,
+						},
+					},
+				)
+				err := alonzo.UtxoValidateInsufficientCollateral(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	// Exact collateral
+	testRun(
+		t,
+		"exact collateral",
+		300000,
+		testRedeemers,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateInsufficientCollateral should succeed when provided the exact required collateral\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Not enough collateral
+	testRun(
+		t,
+		"insufficient collateral",
+		299999,
+		testRedeemers,
+		func(t *testing.T, err error) {
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateInsufficientCollateral should fail when provided too little collateral",
+				)
+				return
+			}
+			testErrType := alonzo.InsufficientCollateralError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+	// No redeemers
+	testRun(
+		t,
+		"no redeemers",
+		0,
+		nil,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateInsufficientCollateral should succeed when there are no redeemers\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+}
+
+func TestUtxoValidateCollateralContainsNonAda(t *testing.T) {
+	testPolicyId := make([]byte, 28)
+	testAssets := common.NewMultiAsset[common.MultiAssetTypeOutput](
+		map[common.Blake2b224]map[cbor.ByteString]uint64{
+			common.NewBlake2b224(testPolicyId): {
+				cbor.NewByteString([]byte("test")): 1,
+			},
+		},
+	)
+	testTx := testCollateralTx(testRedeemers, 1)
+	testProtocolParams := &alonzo.AlonzoProtocolParameters{}
+	testSlot := uint64(0)
+	// ADA only
+	t.Run(
+		"ADA only",
+		func(t *testing.T) {
+			testLedgerState := testCollateralLedgerState(
+				alonzo.AlonzoTransactionOutput{
+					OutputAmount: mary.MaryTransactionOutputValue{
+						Amount: 5000000,
+					},
+				},
+			)
+			err := alonzo.UtxoValidateCollateralContainsNonAda(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateCollateralContainsNonAda should succeed when collateral contains only ADA\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Collateral with assets
+	t.Run(
+		"collateral with assets",
+		func(t *testing.T) {
+			testLedgerState := testCollateralLedgerState(
+				alonzo.AlonzoTransactionOutput{
+					OutputAmount: mary.MaryTransactionOutputValue{
+						Amount: 5000000,
+						Assets: &testAssets,
+					},
+				},
+			)
+			err := alonzo.UtxoValidateCollateralContainsNonAda(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateCollateralContainsNonAda should fail when collateral contains non-ADA assets",
+				)
+				return
+			}
+			testErrType := alonzo.CollateralContainsNonADAError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateScriptsNotPaidUtxo(t *testing.T) {
+	testTx := testCollateralTx(testRedeemers, 1)
+	testProtocolParams := &alonzo.AlonzoProtocolParameters{}
+	testSlot := uint64(0)
+	// Key address
+	t.Run(
+		"key address",
+		func(t *testing.T) {
+			testLedgerState := testCollateralLedgerState(
+				alonzo.AlonzoTransactionOutput{
+					OutputAddress: testAddress(t, common.AddressTypeKeyNone),
+				},
+			)
+			err := alonzo.UtxoValidateScriptsNotPaidUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateScriptsNotPaidUtxo should succeed when collateral is locked by a payment key\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Script address
+	t.Run(
+		"script address",
+		func(t *testing.T) {
+			testLedgerState := testCollateralLedgerState(
+				alonzo.AlonzoTransactionOutput{
+					OutputAddress: testAddress(t, common.AddressTypeScriptNone),
+				},
+			)
+			err := alonzo.UtxoValidateScriptsNotPaidUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateScriptsNotPaidUtxo should fail when collateral is locked by a script",
+				)
+				return
+			}
+			testErrType := alonzo.ScriptsNotPaidUtxoError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateNoCollateralInputs(t *testing.T) {
+	testLedgerState := testLedgerState{}
+	testProtocolParams := &alonzo.AlonzoProtocolParameters{}
+	testSlot := uint64(0)
+	// Collateral provided
+	t.Run(
+		"collateral provided",
+		func(t *testing.T) {
+			err := alonzo.UtxoValidateNoCollateralInputs(
+				testCollateralTx(testRedeemers, 1),
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateNoCollateralInputs should succeed when collateral is provided\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// No collateral
+	t.Run(
+		"no collateral",
+		func(t *testing.T) {
+			err := alonzo.UtxoValidateNoCollateralInputs(
+				testCollateralTx(testRedeemers, 0),
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateNoCollateralInputs should fail when no collateral is provided",
+				)
+				return
+			}
+			testErrType := alonzo.NoCollateralInputsError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateBadInputsUtxo(t *testing.T) {
+	testTx := testCollateralTx(testRedeemers, 2)
+	testProtocolParams := &alonzo.AlonzoProtocolParameters{}
+	testSlot := uint64(0)
+	// All collateral present
+	t.Run(
+		"all collateral present",
+		func(t *testing.T) {
+			testLedgerState := testCollateralLedgerState(
+				alonzo.AlonzoTransactionOutput{},
+				alonzo.AlonzoTransactionOutput{},
+			)
+			err := alonzo.UtxoValidateBadInputsUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateBadInputsUtxo should succeed when all collateral inputs are available\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Missing collateral
+	t.Run(
+		"missing collateral",
+		func(t *testing.T) {
+			testLedgerState := testCollateralLedgerState(
+				alonzo.AlonzoTransactionOutput{},
+			)
+			err := alonzo.UtxoValidateBadInputsUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateBadInputsUtxo should fail when a collateral input is not available",
+				)
+				return
+			}
+			testErrType := shelley.BadInputsUtxoError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateOutputTooSmallUtxo(t *testing.T) {
+	testDatumHash := common.NewBlake2b256(make([]byte, 32))
+	testTx := &alonzo.AlonzoTransaction{
+		Body: alonzo.AlonzoTransactionBody{
+			TxOutputs: []alonzo.AlonzoTransactionOutput{
+				// Empty placeholder output
+				{},
+			},
+		},
+	}
+	testLedgerState := testLedgerState{}
+	testSlot := uint64(0)
+	// Protocol parameter update setting coinsPerUTxOWord to the mainnet value of 34482
+	testUpdateCbor, _ := hex.DecodeString("a1111986b2")
+	var testUpdate alonzo.AlonzoProtocolParameterUpdate
+	if _, err := cbor.Decode(testUpdateCbor, &testUpdate); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testProtocolParams := &alonzo.AlonzoProtocolParameters{}
+	testProtocolParams.Update(&testUpdate)
+	testDefs := []struct {
+		name      string
+		amount    uint64
+		datumHash *common.Blake2b256
+		expectErr bool
+	}{
+		{
+			// The minimum UTxO value for an ADA-only output on mainnet during Alonzo was 999978 lovelace
+			name:   "ADA only, exact amount",
+			amount: 999978,
+		},
+		{
+			name:      "ADA only, too small",
+			amount:    29*34482 - 1,
+			expectErr: true,
+		},
+		{
+			name:      "datum hash, exact amount",
+			amount:    39 * 34482,
+			datumHash: &testDatumHash,
+		},
+		{
+			name:      "datum hash, too small",
+			amount:    39*34482 - 1,
+			datumHash: &testDatumHash,
+			expectErr: true,
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(
+			testDef.name,
+			func(t *testing.T) {
+				testTx.Body.TxOutputs[0].OutputAmount.Amount = testDef.amount
+				testTx.Body.TxOutputs[0].TxOutputDatumHash = testDef.datumHash
+				err := alonzo.UtxoValidateOutputTooSmallUtxo(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				if !testDef.expectErr {
+					if err != nil {
+						t.Errorf(
+							"UtxoValidateOutputTooSmallUtxo should succeed when outputs have the minimum value\n  got error: %v",
+							err,
+						)
+					}
+					return
+				}
+				if err == nil {
+					t.Errorf(
+						"UtxoValidateOutputTooSmallUtxo should fail when outputs are below the minimum value",
+					)
+					return
+				}
+				testErrType := shelley.OutputTooSmallUtxoError{}
+				assert.IsType(
+					t,
+					testErrType,
+					err,
+					"did not get expected error type: got %T, wanted %T",
+					err,
+					testErrType,
+				)
+			},
+		)
+	}
+}
+
+func TestUtxoValidateWrongNetworkInTxBody(t *testing.T) {
+	testLedgerState := testLedgerState{
+		networkId: common.AddressNetworkMainnet,
+	}
+	testProtocolParams := &alonzo.AlonzoProtocolParameters{}
+	testSlot := uint64(0)
+	testRun := func(t *testing.T, name string, txNetworkId *uint8, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testTx := &alonzo.AlonzoTransaction{
+					Body: alonzo.AlonzoTransactionBody{
+						NetworkId: txNetworkId,
+					},
+				}
+				err := alonzo.UtxoValidateWrongNetworkInTxBody(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	testMainnet := uint8(common.AddressNetworkMainnet)
+	testTestnet := uint8(common.AddressNetworkTestnet)
+	// Network ID not specified
+	testRun(
+		t,
+		"no network ID",
+		nil,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateWrongNetworkInTxBody should succeed when no network ID is specified\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Correct network ID
+	testRun(
+		t,
+		"correct network ID",
+		&testMainnet,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateWrongNetworkInTxBody should succeed when the correct network ID is specified\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Wrong network ID
+	testRun(
+		t,
+		"wrong network ID",
+		&testTestnet,
+		func(t *testing.T, err error) {
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateWrongNetworkInTxBody should fail when the wrong network ID is specified",
+				)
+				return
+			}
+			testErrType := alonzo.WrongNetworkInTxBodyError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateExUnitsTooBigUtxo(t *testing.T) {
+	testTx := testCollateralTx(testRedeemers, 0)
+	testLedgerState := testLedgerState{}
+	testSlot := uint64(0)
+	// ExUnits within limits
+	t.Run(
+		"ExUnits within limits",
+		func(t *testing.T) {
+			testProtocolParams := &alonzo.AlonzoProtocolParameters{
+				MaxTxExUnits: common.ExUnit{
+					Mem:   1000,
+					Steps: 2000,
+				},
+			}
+			err := alonzo.UtxoValidateExUnitsTooBigUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateExUnitsTooBigUtxo should succeed when ExUnits are within limits\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// ExUnits too big
+	t.Run(
+		"ExUnits too big",
+		func(t *testing.T) {
+			testProtocolParams := &alonzo.AlonzoProtocolParameters{
+				MaxTxExUnits: common.ExUnit{
+					Mem:   999,
+					Steps: 2000,
+				},
+			}
+			err := alonzo.UtxoValidateExUnitsTooBigUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateExUnitsTooBigUtxo should fail when ExUnits exceed the maximum",
+				)
+				return
+			}
+			testErrType := alonzo.ExUnitsTooBigUtxoError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateTooManyCollateralInputs(t *testing.T) {
+	testLedgerState := testLedgerState{}
+	testProtocolParams := &alonzo.AlonzoProtocolParameters{
+		MaxCollateralInputs: 3,
+	}
+	testSlot := uint64(0)
+	// Max collateral inputs
+	t.Run(
+		"max collateral inputs",
+		func(t *testing.T) {
+			err := alonzo.UtxoValidateTooManyCollateralInputs(
+				testCollateralTx(testRedeemers, 3),
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateTooManyCollateralInputs should succeed with the max number of collateral inputs\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Too many collateral inputs
+	t.Run(
+		"too many collateral inputs",
+		func(t *testing.T) {
+			err := alonzo.UtxoValidateTooManyCollateralInputs(
+				testCollateralTx(testRedeemers, 4),
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateTooManyCollateralInputs should fail with too many collateral inputs",
+				)
+				return
+			}
+			testErrType := alonzo.TooManyCollateralInputsError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateScriptDataHash(t *testing.T) {
+	// The test transaction has no scripts, so the language views are an empty map
+	testRedeemersCbor, err := cbor.Encode(testRedeemers)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testScriptDataHash := common.Blake2b256Hash(append(testRedeemersCbor, 0xa0))
+	testWrongScriptDataHash := common.NewBlake2b256(make([]byte, 32))
+	testLedgerState := testLedgerState{}
+	testProtocolParams := &alonzo.AlonzoProtocolParameters{}
+	testSlot := uint64(0)
+	testDefs := []struct {
+		name           string
+		redeemers      alonzo.AlonzoRedeemers
+		scriptDataHash *common.Blake2b256
+		expectedErr    error
+	}{
+		{
+			name:           "redeemers with hash",
+			redeemers:      testRedeemers,
+			scriptDataHash: &testScriptDataHash,
+		},
+		{
+			name:           "redeemers with wrong hash",
+			redeemers:      testRedeemers,
+			scriptDataHash: &testWrongScriptDataHash,
+			expectedErr:    alonzo.ScriptDataHashMismatchError{},
+		},
+		{
+			name: "no redeemers without hash",
+		},
+		{
+			name:        "redeemers without hash",
+			redeemers:   testRedeemers,
+			expectedErr: alonzo.MissingScriptDataHashError{},
+		},
+		{
+			name:           "no redeemers with hash",
+			scriptDataHash: &testScriptDataHash,
+			expectedErr:    alonzo.ExtraneousScriptDataHashError{},
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(
+			testDef.name,
+			func(t *testing.T) {
+				testTx := testCollateralTx(testDef.redeemers, 0)
+				testTx.Body.TxScriptDataHash = testDef.scriptDataHash
+				err := alonzo.UtxoValidateScriptDataHash(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				if testDef.expectedErr == nil {
+					if err != nil {
+						t.Errorf(
+							"UtxoValidateScriptDataHash should succeed\n  got error: %v",
+							err,
+						)
+					}
+					return
+				}
+				if err == nil {
+					t.Errorf(
+						"UtxoValidateScriptDataHash should fail",
+					)
+					return
+				}
+				assert.IsType(
+					t,
+					testDef.expectedErr,
+					err,
+					"did not get expected error type: got %T, wanted %T",
+					err,
+					testDef.expectedErr,
+				)
+			},
+		)
+	}
+}
@@ -17,6 +17,7 @@ package babbage
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/blinklabs-io/gouroboros/cbor"
@@ -118,11 +119,14 @@ func (b *BabbageBlock) Transactions() []common.Transaction {
 	return ret
 }
 
-func (b *BabbageBlock) Utxorpc() *utxorpc.Block {
+func (b *BabbageBlock) Utxorpc() (*utxorpc.Block, error) {
 	var txs []*utxorpc.Tx
 	tmpHash, _ := hex.DecodeString(b.Hash())
 	for _, t := range b.Transactions() {
-		tx := t.Utxorpc()
+		tx, err := t.Utxorpc()
+		if err != nil {
+			return nil, err
+		}
 		txs = append(txs, tx)
 	}
 	body := &utxorpc.BlockBody{
@@ -137,7 +141,7 @@ func (b *BabbageBlock) Utxorpc() *utxorpc.Block {
 		Body:   body,
 		Header: header,
 	}
-	return block
+	return block, nil
 }
 
 type BabbageBlockHeader struct {
@@ -154,14 +158,8 @@ type BabbageBlockHeader struct {
 		VrfResult     common.VrfResult
 		BlockBodySize uint64
 		BlockBodyHash common.Blake2b256
-		OpCert        struct {
-			cbor.StructAsArray
-			HotVkey        []byte
-			SequenceNumber uint32
-			KesPeriod      uint32
-			Signature      []byte
-		}
-		ProtoVersion struct {
+		OpCert        common.OperationalCertificate
+		ProtoVersion  struct {
 			cbor.StructAsArray
 			Major uint64
 			Minor uint64
@@ -263,7 +261,7 @@ func (b *BabbageTransactionBody) TotalCollateral() uint64 {
 	return b.TxTotalCollateral
 }
 
-func (b *BabbageTransactionBody) Utxorpc() *utxorpc.Tx {
+func (b *BabbageTransactionBody) Utxorpc() (*utxorpc.Tx, error) {
 	var txi, txri []*utxorpc.TxInput
 	var txo []*utxorpc.TxOutput
 	for _, i := range b.Inputs() {
@@ -271,7 +269,10 @@ func (b *BabbageTransactionBody) Utxorpc() *utxorpc.Tx {
 		txi = append(txi, input)
 	}
 	for _, o := range b.Outputs() {
-		output := o.Utxorpc()
+		output, err := o.Utxorpc()
+		if err != nil {
+			return nil, err
+		}
 		txo = append(txo, output)
 	}
 	for _, ri := range b.ReferenceInputs() {
@@ -280,7 +281,7 @@ func (b *BabbageTransactionBody) Utxorpc() *utxorpc.Tx {
 	}
 	tmpHash, err := hex.DecodeString(b.Hash())
 	if err != nil {
-		return &utxorpc.Tx{}
+		return nil, err
 	}
 	tx := &utxorpc.Tx{
 		Inputs:  txi,
@@ -297,7 +298,7 @@ func (b *BabbageTransactionBody) Utxorpc() *utxorpc.Tx {
 		// Validity:     b.Validity(),
 		Hash: tmpHash,
 	}
-	return tx
+	return tx, nil
 }
 
 const (
@@ -305,6 +306,13 @@ const (
 	DatumOptionTypeData = 1
 )
 
+const (
+	ScriptRefTypeNativeScript = 0
+	ScriptRefTypePlutusV1     = 1
+	ScriptRefTypePlutusV2     = 2
+	ScriptRefTypePlutusV3     = 3
+)
+
 type BabbageTransactionOutputDatumOption struct {
 	hash *common.Blake2b256
 	data *cbor.LazyValue
@@ -443,12 +451,56 @@ func (o BabbageTransactionOutput) Datum() *cbor.LazyValue {
 	return nil
 }
 
-func (o BabbageTransactionOutput) Utxorpc() *utxorpc.TxOutput {
-	var address []byte
-	if o.OutputAddress.Bytes() == nil {
-		address = []byte{}
-	} else {
-		address = o.OutputAddress.Bytes()
+// ReferenceScript decodes the reference script attached to the output, if any, returning the
+// script type and the script bytes. Plutus scripts are returned as-is, and native scripts are
+// returned as their CBOR encoding
+func (o BabbageTransactionOutput) ReferenceScript() (uint, []byte, error) {
+	if o.ScriptRef == nil {
+		return 0, nil, nil
+	}
+	if o.ScriptRef.Number != 24 {
+		return 0, nil, fmt.Errorf(
+			"unexpected reference script CBOR tag: %d",
+			o.ScriptRef.Number,
+		)
+	}
+	scriptRefCbor, ok := o.ScriptRef.Content.([]byte)
+	if !ok {
+		return 0, nil, errors.New("reference script content is not a byte string")
+	}
+	var tmpScriptRef struct {
+		cbor.StructAsArray
+		Type   uint
+		Script cbor.RawMessage
+	}
+	if _, err := cbor.Decode(scriptRefCbor, &tmpScriptRef); err != nil {
+		return 0, nil, err
+	}
+	switch tmpScriptRef.Type {
+	case ScriptRefTypeNativeScript:
+		var tmpScript common.NativeScript
+		if _, err := cbor.Decode(tmpScriptRef.Script, &tmpScript); err != nil {
+			return 0, nil, err
+		}
+		return tmpScriptRef.Type, []byte(tmpScriptRef.Script), nil
+	case ScriptRefTypePlutusV1, ScriptRefTypePlutusV2, ScriptRefTypePlutusV3:
+		var tmpScript []byte
+		if _, err := cbor.Decode(tmpScriptRef.Script, &tmpScript); err != nil {
+			return 0, nil, err
+		}
+		return tmpScriptRef.Type, tmpScript, nil
+	default:
+		return 0, nil, fmt.Errorf(
+			"unknown reference script type: %d",
+			tmpScriptRef.Type,
+		)
+	}
+}
+
+func (o BabbageTransactionOutput) Utxorpc() (*utxorpc.TxOutput, error) {
+	address, err := o.OutputAddress.Bytes()
+	if err != nil {
+		return nil, err
 	}
 
 	var assets []*utxorpc.Multiasset
@@ -486,7 +538,7 @@ func (o BabbageTransactionOutput) Utxorpc() *utxorpc.TxOutput {
 			// OriginalCbor: o.Datum().Cbor(),
 		},
 		// Script:    o.ScriptRef,
-	}
+	}, nil
 }
 
 type BabbageTransactionWitnessSet struct {
@@ -575,6 +627,11 @@ func (t BabbageTransaction) ScriptDataHash() *common.Blake2b256 {
 	return t.Body.ScriptDataHash()
 }
 
+// NetworkId returns the network ID specified in the transaction body, if any
+func (t BabbageTransaction) NetworkId() *uint8 {
+	return t.Body.NetworkId
+}
+
 func (t BabbageTransaction) VotingProcedures() common.VotingProcedures {
 	return t.Body.VotingProcedures()
 }
@@ -660,7 +717,7 @@ func (t *BabbageTransaction) Cbor() []byte {
 	tmpObj := []any{
 		cbor.RawMessage(t.Body.Cbor()),
 		cbor.RawMessage(t.WitnessSet.Cbor()),
-		t.IsValid,
+		t.IsValid(),
 	}
 	if t.TxMetadata != nil {
 		tmpObj = append(tmpObj, cbor.RawMessage(t.TxMetadata.Cbor()))
@@ -672,7 +729,7 @@ func (t *BabbageTransaction) Cbor() []byte {
 	return cborData
 }
 
-func (t *BabbageTransaction) Utxorpc() *utxorpc.Tx {
+func (t *BabbageTransaction) Utxorpc() (*utxorpc.Tx, error) {
 	return t.Body.Utxorpc()
 }
 
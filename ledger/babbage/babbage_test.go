@@ -2738,7 +2738,8 @@ func TestBabbageBlock_Utxorpc(t *testing.T) {
 	assert.NotNil(t, babbageBlock.TransactionBodies)
 	assert.NotNil(t, babbageBlock.TransactionWitnessSets)
 
-	utxoBlock := babbageBlock.Utxorpc()
+	utxoBlock, err := babbageBlock.Utxorpc()
+	assert.NoError(t, err)
 
 	// Validate the resulting utxorpc.Block
 	assert.NotNil(t, utxoBlock)
@@ -2783,7 +2784,8 @@ func TestBabbageTransactionOutput_Utxorpc_DatumOptionNil(t *testing.T) {
 		DatumOption: &BabbageTransactionOutputDatumOption{},
 	}
 
-	txOutput := output.Utxorpc()
+	txOutput, err := output.Utxorpc()
+	assert.NoError(t, err)
 
 	assert.NotNil(t, txOutput)
 	assert.Equal(t, []byte{}, txOutput.Datum.Hash)
@@ -0,0 +1,65 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package babbage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+type IncorrectTotalCollateralFieldError struct {
+	Provided        uint64
+	TotalCollateral uint64
+}
+
+func (e IncorrectTotalCollateralFieldError) Error() string {
+	return fmt.Sprintf(
+		"incorrect total collateral field: collateral balance %d, total collateral %d",
+		e.Provided,
+		e.TotalCollateral,
+	)
+}
+
+type NonDisjointRefInputsError struct {
+	Inputs []common.TransactionInput
+}
+
+func (e NonDisjointRefInputsError) Error() string {
+	tmpInputs := make([]string, 0, len(e.Inputs))
+	for _, tmpInput := range e.Inputs {
+		tmpInputs = append(tmpInputs, tmpInput.String())
+	}
+	return fmt.Sprintf(
+		"reference input(s) also used as regular input(s): %s",
+		strings.Join(tmpInputs, ", "),
+	)
+}
+
+type MalformedReferenceScriptsError struct {
+	Outputs []common.TransactionOutput
+}
+
+func (e MalformedReferenceScriptsError) Error() string {
+	tmpOutputs := make([]string, 0, len(e.Outputs))
+	for _, tmpOutput := range e.Outputs {
+		tmpOutputs = append(tmpOutputs, fmt.Sprintf("%#v", tmpOutput))
+	}
+	return fmt.Sprintf(
+		"malformed reference script(s) in output(s): %s",
+		strings.Join(tmpOutputs, ", "),
+	)
+}
@@ -228,7 +228,7 @@ func UpgradePParams(
 		ProtocolMajor:        prevPParams.ProtocolMajor,
 		ProtocolMinor:        prevPParams.ProtocolMinor,
 		MinPoolCost:          prevPParams.MinPoolCost,
-		AdaPerUtxoByte:       prevPParams.AdaPerUtxoByte,
+		AdaPerUtxoByte:       prevPParams.CoinsPerUtxoWord / 8,
 		CostModels:           prevPParams.CostModels,
 		ExecutionCosts:       prevPParams.ExecutionCosts,
 		MaxTxExUnits:         prevPParams.MaxTxExUnits,
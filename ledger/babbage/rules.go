@@ -0,0 +1,584 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package babbage
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/alonzo"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+const (
+	// Fixed overhead (in bytes) of a UTxO entry used in the min-UTxO calculation
+	utxoEntrySizeOverhead = 160
+)
+
+var UtxoValidationRules = []common.UtxoValidationRuleFunc{
+	UtxoValidateOutsideValidityIntervalUtxo,
+	UtxoValidateInputSetEmptyUtxo,
+	UtxoValidateFeeTooSmallUtxo,
+	UtxoValidateInsufficientCollateral,
+	UtxoValidateCollateralContainsNonAda,
+	UtxoValidateScriptsNotPaidUtxo,
+	UtxoValidateNoCollateralInputs,
+	UtxoValidateIncorrectTotalCollateralField,
+	UtxoValidateBadInputsUtxo,
+	UtxoValidateNonDisjointRefInputs,
+	UtxoValidateValueNotConservedUtxo,
+	UtxoValidateOutputTooSmallUtxo,
+	UtxoValidateOutputTooBigUtxo,
+	UtxoValidateOutputBootAddrAttrsTooBig,
+	UtxoValidateWrongNetwork,
+	UtxoValidateWrongNetworkWithdrawal,
+	UtxoValidateWrongNetworkInTxBody,
+	UtxoValidateMaxTxSizeUtxo,
+	UtxoValidateExUnitsTooBigUtxo,
+	UtxoValidateTooManyCollateralInputs,
+	UtxoValidateScriptDataHash,
+	UtxoValidateMalformedReferenceScripts,
+}
+
+var UtxowValidationRules = []common.UtxoValidationRuleFunc{
+	UtxowValidateInvalidWitnesses,
+	UtxowValidateMissingVKeyWitnesses,
+	UtxowValidateMissingScriptWitnesses,
+	UtxowValidateScriptWitnessNotValidating,
+	UtxowValidateMetadataHash,
+}
+
+var CertValidationRules = []common.UtxoValidationRuleFunc{
+	DelegValidateWithdrawalsNotInRewards,
+	DelegValidateStakeKeyAlreadyRegistered,
+	DelegValidateStakeKeyNotRegistered,
+	DelegValidateStakeKeyNonZeroAccountBalance,
+	DelegValidateDelegateeNotRegistered,
+	PoolValidateStakePoolNotRegisteredOnKey,
+	PoolValidateStakePoolRetirementWrongEpoch,
+	PoolValidateStakePoolCostTooLow,
+}
+
+// UtxoValidateInsufficientCollateral ensures that the collateral balance (the collateral inputs less the
+// collateral return) covers the required percentage of the fee
+func UtxoValidateInsufficientCollateral(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*BabbageProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	// There's nothing to check if there are no redeemers
+	if !alonzo.HasRedeemers(tx) {
+		return nil
+	}
+	collateralBalance := CollateralBalance(tx, ls)
+	// Compare using percentages to avoid rounding
+	if collateralBalance*100 >= tx.Fee()*uint64(tmpPparams.CollateralPercentage) {
+		return nil
+	}
+	// Round up the required collateral for the error
+	requiredCollateral := (tx.Fee()*uint64(tmpPparams.CollateralPercentage) + 99) / 100
+	return alonzo.InsufficientCollateralError{
+		Provided: collateralBalance,
+		Required: requiredCollateral,
+	}
+}
+
+// UtxoValidateCollateralContainsNonAda ensures that the collateral balance doesn't contain non-ADA assets.
+// Collateral inputs may contain assets, as long as they are all sent to the collateral return
+func UtxoValidateCollateralContainsNonAda(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	if !alonzo.HasRedeemers(tx) {
+		return nil
+	}
+	collateralReturn := tx.CollateralReturn()
+	if collateralReturn == nil {
+		return alonzo.UtxoValidateCollateralContainsNonAda(tx, slot, ls, pp)
+	}
+	// Calculate the asset balance between the collateral inputs and the collateral return
+	type assetKey struct {
+		policyId  common.Blake2b224
+		assetName string
+	}
+	assetBalance := make(map[assetKey]*big.Int)
+	var assetInputs []common.TransactionInput
+	for _, collateralInput := range tx.Collateral() {
+		utxo, err := ls.UtxoById(collateralInput)
+		// Ignore errors fetching the UTxO, which are covered by other rules
+		if err != nil {
+			continue
+		}
+		assets := utxo.Output.Assets()
+		if assets == nil || len(assets.Policies()) == 0 {
+			continue
+		}
+		assetInputs = append(assetInputs, collateralInput)
+		for _, policyId := range assets.Policies() {
+			for _, assetName := range assets.Assets(policyId) {
+				key := assetKey{policyId, string(assetName)}
+				if _, ok := assetBalance[key]; !ok {
+					assetBalance[key] = new(big.Int)
+				}
+				assetBalance[key].Add(
+					assetBalance[key],
+					new(big.Int).SetUint64(assets.Asset(policyId, assetName)),
+				)
+			}
+		}
+	}
+	if assets := collateralReturn.Assets(); assets != nil {
+		for _, policyId := range assets.Policies() {
+			for _, assetName := range assets.Assets(policyId) {
+				key := assetKey{policyId, string(assetName)}
+				if _, ok := assetBalance[key]; !ok {
+					assetBalance[key] = new(big.Int)
+				}
+				assetBalance[key].Sub(
+					assetBalance[key],
+					new(big.Int).SetUint64(assets.Asset(policyId, assetName)),
+				)
+			}
+		}
+	}
+	for _, amount := range assetBalance {
+		if amount.Sign() != 0 {
+			return alonzo.CollateralContainsNonADAError{
+				Inputs: assetInputs,
+			}
+		}
+	}
+	return nil
+}
+
+// UtxoValidateIncorrectTotalCollateralField ensures that the total collateral, if specified, matches the collateral balance
+func UtxoValidateIncorrectTotalCollateralField(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	totalCollateral := tx.TotalCollateral()
+	if totalCollateral == 0 {
+		return nil
+	}
+	collateralBalance := CollateralBalance(tx, ls)
+	if collateralBalance == totalCollateral {
+		return nil
+	}
+	return IncorrectTotalCollateralFieldError{
+		Provided:        collateralBalance,
+		TotalCollateral: totalCollateral,
+	}
+}
+
+// UtxoValidateBadInputsUtxo ensures that all inputs, collateral inputs and reference inputs are present in the ledger state (have not been spent)
+func UtxoValidateBadInputsUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	var badInputs []common.TransactionInput
+	for _, tmpInput := range slices.Concat(tx.Inputs(), tx.Collateral(), tx.ReferenceInputs()) {
+		_, err := ls.UtxoById(tmpInput)
+		if err != nil {
+			badInputs = append(badInputs, tmpInput)
+		}
+	}
+	if len(badInputs) == 0 {
+		return nil
+	}
+	return shelley.BadInputsUtxoError{
+		Inputs: badInputs,
+	}
+}
+
+// UtxoValidateNonDisjointRefInputs ensures that reference inputs are not also used as regular inputs
+func UtxoValidateNonDisjointRefInputs(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	inputs := make(map[string]bool)
+	for _, tmpInput := range tx.Inputs() {
+		inputs[tmpInput.String()] = true
+	}
+	var badInputs []common.TransactionInput
+	for _, refInput := range tx.ReferenceInputs() {
+		if inputs[refInput.String()] {
+			badInputs = append(badInputs, refInput)
+		}
+	}
+	if len(badInputs) == 0 {
+		return nil
+	}
+	return NonDisjointRefInputsError{
+		Inputs: badInputs,
+	}
+}
+
+// UtxoValidateOutputTooSmallUtxo ensures that outputs (including the collateral return) have at least the minimum value
+func UtxoValidateOutputTooSmallUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	var badOutputs []common.TransactionOutput
+	for _, tmpOutput := range allOutputs(tx) {
+		minCoin, err := MinCoinTxOut(tmpOutput, pp)
+		if err != nil {
+			return err
+		}
+		if tmpOutput.Amount() < minCoin {
+			badOutputs = append(badOutputs, tmpOutput)
+		}
+	}
+	if len(badOutputs) == 0 {
+		return nil
+	}
+	return shelley.OutputTooSmallUtxoError{
+		Outputs: badOutputs,
+	}
+}
+
+// UtxoValidateOutputTooBigUtxo ensures that transaction output values (including the collateral return) are not too large
+func UtxoValidateOutputTooBigUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*BabbageProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	var badOutputs []common.TransactionOutput
+	for _, txOutput := range allOutputs(tx) {
+		tmpOutput, ok := txOutput.(*BabbageTransactionOutput)
+		if !ok {
+			return fmt.Errorf("transaction output is not expected type")
+		}
+		outputValBytes, err := cbor.Encode(&tmpOutput.OutputAmount)
+		if err != nil {
+			return err
+		}
+		if uint(len(outputValBytes)) <= tmpPparams.MaxValueSize {
+			continue
+		}
+		badOutputs = append(badOutputs, tmpOutput)
+	}
+	if len(badOutputs) == 0 {
+		return nil
+	}
+	return mary.OutputTooBigUtxoError{
+		Outputs: badOutputs,
+	}
+}
+
+// UtxoValidateOutputBootAddrAttrsTooBig ensures that bootstrap (Byron) addresses in outputs (including the collateral
+// return) don't have attributes that are too large
+func UtxoValidateOutputBootAddrAttrsTooBig(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	var badOutputs []common.TransactionOutput
+	for _, tmpOutput := range allOutputs(tx) {
+		addr := tmpOutput.Address()
+		if addr.Type() != common.AddressTypeByron {
+			continue
+		}
+		attr := addr.ByronAttr()
+		attrBytes, err := cbor.Encode(attr)
+		if err != nil {
+			return err
+		}
+		if len(attrBytes) <= 64 {
+			continue
+		}
+		badOutputs = append(badOutputs, tmpOutput)
+	}
+	if len(badOutputs) == 0 {
+		return nil
+	}
+	return shelley.OutputBootAddrAttrsTooBigError{
+		Outputs: badOutputs,
+	}
+}
+
+// UtxoValidateWrongNetworkInTxBody ensures that the network ID in the transaction body, if specified, is correct
+func UtxoValidateWrongNetworkInTxBody(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxoValidateWrongNetworkInTxBody(tx, slot, ls, pp)
+}
+
+// UtxoValidateExUnitsTooBigUtxo ensures that the total ExUnits of all redeemers don't exceed the per-transaction maximum
+func UtxoValidateExUnitsTooBigUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*BabbageProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	totalExUnits := alonzo.TotalExUnits(tx)
+	if totalExUnits.Mem <= tmpPparams.MaxTxExUnits.Mem &&
+		totalExUnits.Steps <= tmpPparams.MaxTxExUnits.Steps {
+		return nil
+	}
+	return alonzo.ExUnitsTooBigUtxoError{
+		TotalExUnits: totalExUnits,
+		MaxTxExUnits: tmpPparams.MaxTxExUnits,
+	}
+}
+
+// UtxoValidateTooManyCollateralInputs ensures that the number of collateral inputs doesn't exceed the maximum
+func UtxoValidateTooManyCollateralInputs(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*BabbageProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	collateralCount := uint(len(tx.Collateral()))
+	if collateralCount <= tmpPparams.MaxCollateralInputs {
+		return nil
+	}
+	return alonzo.TooManyCollateralInputsError{
+		Provided: collateralCount,
+		Max:      tmpPparams.MaxCollateralInputs,
+	}
+}
+
+// UtxoValidateMaxTxSizeUtxo ensures that a transaction does not exceed the max size
+func UtxoValidateMaxTxSizeUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*BabbageProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	txBytes, err := cbor.Encode(tx)
+	if err != nil {
+		return err
+	}
+	if uint(len(txBytes)) <= tmpPparams.MaxTxSize {
+		return nil
+	}
+	return shelley.MaxTxSizeUtxoError{
+		TxSize:    uint(len(txBytes)),
+		MaxTxSize: tmpPparams.MaxTxSize,
+	}
+}
+
+// UtxoValidateMalformedReferenceScripts ensures that all reference scripts in outputs (including the collateral return) are well-formed
+func UtxoValidateMalformedReferenceScripts(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	var badOutputs []common.TransactionOutput
+	for _, txOutput := range allOutputs(tx) {
+		tmpOutput, ok := txOutput.(*BabbageTransactionOutput)
+		if !ok {
+			return fmt.Errorf("transaction output is not expected type")
+		}
+		scriptType, _, err := tmpOutput.ReferenceScript()
+		// Plutus V3 scripts are not available until Conway
+		if err == nil && scriptType <= ScriptRefTypePlutusV2 {
+			continue
+		}
+		badOutputs = append(badOutputs, tmpOutput)
+	}
+	if len(badOutputs) == 0 {
+		return nil
+	}
+	return MalformedReferenceScriptsError{
+		Outputs: badOutputs,
+	}
+}
+
+// UtxoValidateFeeTooSmallUtxo ensures that the fee is at least the calculated minimum
+func UtxoValidateFeeTooSmallUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	minFee, err := MinFeeTx(tx, pp)
+	if err != nil {
+		return err
+	}
+	if tx.Fee() >= minFee {
+		return nil
+	}
+	return shelley.FeeTooSmallUtxoError{
+		Provided: tx.Fee(),
+		Min:      minFee,
+	}
+}
+
+func UtxoValidateOutsideValidityIntervalUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxoValidateOutsideValidityIntervalUtxo(tx, slot, ls, pp)
+}
+
+func UtxoValidateInputSetEmptyUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxoValidateInputSetEmptyUtxo(tx, slot, ls, pp)
+}
+
+func UtxoValidateScriptsNotPaidUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxoValidateScriptsNotPaidUtxo(tx, slot, ls, pp)
+}
+
+func UtxoValidateNoCollateralInputs(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxoValidateNoCollateralInputs(tx, slot, ls, pp)
+}
+
+func UtxoValidateValueNotConservedUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*BabbageProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return shelley.UtxoValidateValueNotConservedUtxo(tx, slot, ls, shelleyPparams(tmpPparams))
+}
+
+func UtxoValidateWrongNetwork(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxoValidateWrongNetwork(tx, slot, ls, pp)
+}
+
+func UtxoValidateWrongNetworkWithdrawal(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxoValidateWrongNetworkWithdrawal(tx, slot, ls, pp)
+}
+
+func UtxoValidateScriptDataHash(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*BabbageProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return alonzo.ValidateScriptDataHash(tx, ls, tmpPparams.CostModels)
+}
+
+// MinFeeTx calculates the minimum required fee for a transaction based on protocol parameters,
+// including the cost of script execution
+func MinFeeTx(tx common.Transaction, pparams common.ProtocolParameters) (uint64, error) {
+	tmpPparams, ok := pparams.(*BabbageProtocolParameters)
+	if !ok {
+		return 0, fmt.Errorf("pparams are not expected type")
+	}
+	txBytes := tx.Cbor()
+	minFee := uint64((tmpPparams.MinFeeA * uint(len(txBytes))) + tmpPparams.MinFeeB)
+	minFee += alonzo.ScriptFee(alonzo.TotalExUnits(tx), tmpPparams.ExecutionCosts)
+	return minFee, nil
+}
+
+// MinCoinTxOut calculates the minimum coin for a transaction output based on protocol parameters.
+// This uses the serialized size of the output, which includes any inline datum and reference script
+func MinCoinTxOut(txOut common.TransactionOutput, pparams common.ProtocolParameters) (uint64, error) {
+	tmpPparams, ok := pparams.(*BabbageProtocolParameters)
+	if !ok {
+		return 0, fmt.Errorf("pparams are not expected type")
+	}
+	txOutBytes := txOut.Cbor()
+	if len(txOutBytes) == 0 {
+		var err error
+		txOutBytes, err = cbor.Encode(txOut)
+		if err != nil {
+			return 0, err
+		}
+	}
+	minCoinTxOut := (utxoEntrySizeOverhead + uint64(len(txOutBytes))) * tmpPparams.AdaPerUtxoByte
+	return minCoinTxOut, nil
+}
+
+// CollateralBalance returns the total ADA in the collateral inputs less the collateral return. Collateral
+// inputs that cannot be found in the ledger state are excluded from the calculation
+func CollateralBalance(tx common.Transaction, ls common.LedgerState) uint64 {
+	var totalCollateral uint64
+	for _, collateralInput := range tx.Collateral() {
+		utxo, err := ls.UtxoById(collateralInput)
+		if err != nil {
+			continue
+		}
+		totalCollateral += utxo.Output.Amount()
+	}
+	if collateralReturn := tx.CollateralReturn(); collateralReturn != nil {
+		if collateralReturn.Amount() > totalCollateral {
+			return 0
+		}
+		totalCollateral -= collateralReturn.Amount()
+	}
+	return totalCollateral
+}
+
+// allOutputs returns the transaction outputs along with the collateral return, if any
+func allOutputs(tx common.Transaction) []common.TransactionOutput {
+	ret := tx.Outputs()
+	if collateralReturn := tx.CollateralReturn(); collateralReturn != nil {
+		ret = append(ret, collateralReturn)
+	}
+	return ret
+}
+
+func UtxowValidateInvalidWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxowValidateInvalidWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateMissingVKeyWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxowValidateMissingVKeyWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateMissingScriptWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxowValidateMissingScriptWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateScriptWitnessNotValidating(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxowValidateScriptWitnessNotValidating(tx, slot, ls, pp)
+}
+
+func UtxowValidateMetadataHash(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxowValidateMetadataHash(tx, slot, ls, pp)
+}
+
+func DelegValidateWithdrawalsNotInRewards(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.DelegValidateWithdrawalsNotInRewards(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyAlreadyRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.DelegValidateStakeKeyAlreadyRegistered(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.DelegValidateStakeKeyNotRegistered(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyNonZeroAccountBalance(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.DelegValidateStakeKeyNonZeroAccountBalance(tx, slot, ls, pp)
+}
+
+func DelegValidateDelegateeNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.DelegValidateDelegateeNotRegistered(tx, slot, ls, pp)
+}
+
+func PoolValidateStakePoolNotRegisteredOnKey(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.PoolValidateStakePoolNotRegisteredOnKey(tx, slot, ls, pp)
+}
+
+func PoolValidateStakePoolRetirementWrongEpoch(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*BabbageProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return shelley.PoolValidateStakePoolRetirementWrongEpoch(tx, slot, ls, shelleyPparams(tmpPparams))
+}
+
+// PoolValidateStakePoolCostTooLow ensures that registered pools have a cost of at least the minimum pool cost
+func PoolValidateStakePoolCostTooLow(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*BabbageProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	for _, cert := range tx.Certificates() {
+		c, ok := cert.(*common.PoolRegistrationCertificate)
+		if !ok {
+			continue
+		}
+		if c.Cost < tmpPparams.MinPoolCost {
+			return shelley.StakePoolCostTooLowError{
+				Cost:    c.Cost,
+				MinCost: tmpPparams.MinPoolCost,
+			}
+		}
+	}
+	return nil
+}
+
+// shelleyPparams returns the Shelley equivalent of the provided protocol parameters, which allows
+// reusing the Shelley rules that only depend on parameters common to both eras
+func shelleyPparams(pparams *BabbageProtocolParameters) *shelley.ShelleyProtocolParameters {
+	return &shelley.ShelleyProtocolParameters{
+		MinFeeA:            pparams.MinFeeA,
+		MinFeeB:            pparams.MinFeeB,
+		MaxBlockBodySize:   pparams.MaxBlockBodySize,
+		MaxTxSize:          pparams.MaxTxSize,
+		MaxBlockHeaderSize: pparams.MaxBlockHeaderSize,
+		KeyDeposit:         pparams.KeyDeposit,
+		PoolDeposit:        pparams.PoolDeposit,
+		MaxEpoch:           pparams.MaxEpoch,
+		NOpt:               pparams.NOpt,
+		A0:                 pparams.A0,
+		Rho:                pparams.Rho,
+		Tau:                pparams.Tau,
+		ProtocolMajor:      pparams.ProtocolMajor,
+		ProtocolMinor:      pparams.ProtocolMinor,
+	}
+}
@@ -0,0 +1,805 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package babbage_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/alonzo"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLedgerState struct {
+	networkId          uint
+	utxos              []common.Utxo
+	stakeRegistrations map[common.Blake2b224]*common.StakeRegistration
+	poolRegistrations  map[common.PoolKeyHash]*common.PoolRegistration
+	drepRegistrations  map[common.Blake2b224]*common.DrepRegistration
+	committeeMembers   map[common.Blake2b224]*common.CommitteeMember
+}
+
+func (ls testLedgerState) NetworkId() uint {
+	return ls.networkId
+}
+
+func (ls testLedgerState) UtxoById(id common.TransactionInput) (common.Utxo, error) {
+	for _, tmpUtxo := range ls.utxos {
+		if id.Index() != tmpUtxo.Id.Index() {
+			continue
+		}
+		if string(id.Id().Bytes()) != string(tmpUtxo.Id.Id().Bytes()) {
+			continue
+		}
+		return tmpUtxo, nil
+	}
+	return common.Utxo{}, fmt.Errorf("not found")
+}
+
+func (ls testLedgerState) StakeRegistration(cred common.Blake2b224) (*common.StakeRegistration, error) {
+	return ls.stakeRegistrations[cred], nil
+}
+
+func (ls testLedgerState) PoolRegistration(pool common.PoolKeyHash) (*common.PoolRegistration, error) {
+	return ls.poolRegistrations[pool], nil
+}
+
+func (ls testLedgerState) DrepRegistration(cred common.Blake2b224) (*common.DrepRegistration, error) {
+	return ls.drepRegistrations[cred], nil
+}
+
+func (ls testLedgerState) CommitteeMember(cred common.Blake2b224) (*common.CommitteeMember, error) {
+	return ls.committeeMembers[cred], nil
+}
+
+const testInputTxId = "d228b482a1aae768e4a796380f49e021d9c21f70d3c12cb186b188dedfc0ee22"
+
+var testRedeemers = alonzo.AlonzoRedeemers{
+	{
+		Tag:   common.RedeemerTagSpend,
+		Index: 0,
+		ExUnits: common.RedeemerExUnits{
+			Memory: 1000,
+			Steps:  2000,
+		},
+	},
+}
+
+func testAddress(t *testing.T) common.Address {
+	tmpHash := make([]byte, 28)
+	if _, err := rand.Read(tmpHash); err != nil {
+		t.Fatalf("could not read random bytes")
+	}
+	addr, err := common.NewAddressFromParts(common.AddressTypeKeyNone, 0, tmpHash, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return addr
+}
+
+func testScriptRef(t *testing.T, scriptType uint, script any) *cbor.Tag {
+	scriptRefCbor, err := cbor.Encode([]any{scriptType, script})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return &cbor.Tag{
+		Number:  24,
+		Content: scriptRefCbor,
+	}
+}
+
+func testCollateralTx(redeemers alonzo.AlonzoRedeemers, collateralCount int) *babbage.BabbageTransaction {
+	var collateral []shelley.ShelleyTransactionInput
+	for idx := range collateralCount {
+		collateral = append(
+			collateral,
+			shelley.NewShelleyTransactionInput(testInputTxId, idx),
+		)
+	}
+	return &babbage.BabbageTransaction{
+		Body: babbage.BabbageTransactionBody{
+			AlonzoTransactionBody: alonzo.AlonzoTransactionBody{
+				TxCollateral: collateral,
+			},
+		},
+		WitnessSet: babbage.BabbageTransactionWitnessSet{
+			AlonzoTransactionWitnessSet: alonzo.AlonzoTransactionWitnessSet{
+				WsRedeemers: redeemers,
+			},
+		},
+	}
+}
+
+func testInputLedgerState(outputs ...babbage.BabbageTransactionOutput) testLedgerState {
+	ls := testLedgerState{}
+	for idx, output := range outputs {
+		ls.utxos = append(
+			ls.utxos,
+			common.Utxo{
+				Id:     shelley.NewShelleyTransactionInput(testInputTxId, idx),
+				Output: &output,
+			},
+		)
+	}
+	return ls
+}
+
+func TestBabbageTransactionOutputReferenceScript(t *testing.T) {
+	testScript := []byte{0xde, 0xad, 0xbe, 0xef}
+	testOutput := babbage.BabbageTransactionOutput{
+		OutputAddress: testAddress(t),
+		OutputAmount: mary.MaryTransactionOutputValue{
+			Amount: 1000000,
+		},
+		ScriptRef: testScriptRef(t, babbage.ScriptRefTypePlutusV2, testScript),
+	}
+	// Round-trip through CBOR to make sure that we can decode what we see on chain
+	testOutputCbor, err := cbor.Encode(&testOutput)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var tmpOutput babbage.BabbageTransactionOutput
+	if _, err := cbor.Decode(testOutputCbor, &tmpOutput); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	scriptType, script, err := tmpOutput.ReferenceScript()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if scriptType != babbage.ScriptRefTypePlutusV2 {
+		t.Errorf("did not get expected script type: got %d, wanted %d", scriptType, babbage.ScriptRefTypePlutusV2)
+	}
+	assert.Equal(t, testScript, script)
+	// No reference script
+	tmpOutput.ScriptRef = nil
+	if _, script, err := tmpOutput.ReferenceScript(); err != nil || script != nil {
+		t.Errorf("did not get expected empty reference script: got %x, %v", script, err)
+	}
+}
+
+func TestUtxoValidateInsufficientCollateral(t *testing.T) {
+	var testFee uint64 = 200000
+	testProtocolParams := &babbage.BabbageProtocolParameters{
+		CollateralPercentage: 150,
+	}
+	testSlot := uint64(0)
+	testLedgerState := testInputLedgerState(
+		babbage.BabbageTransactionOutput{
+			OutputAmount: mary.MaryTransactionOutputValue{
+				Amount: 5000000,
+			},
+		},
+	)
+	testRun := func(t *testing.T, name string, returnAmount uint64, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testTx := testCollateralTx(testRedeemers, 1)
+				testTx.Body.TxFee = testFee
+				testTx.Body.TxCollateralReturn = &babbage.BabbageTransactionOutput{
+					OutputAmount: mary.MaryTransactionOutputValue{
+						Amount: returnAmount,
+					},
+				}
+				err := babbage.UtxoValidateInsufficientCollateral(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	// Exact collateral balance
+	testRun(
+		t,
+		"exact collateral",
+		4700000,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateInsufficientCollateral should succeed when the collateral balance is exactly the required collateral\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Too much returned
+	testRun(
+		t,
+		"insufficient collateral",
+		4700001,
+		func(t *testing.T, err error) {
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateInsufficientCollateral should fail when the collateral return leaves too little collateral",
+				)
+				return
+			}
+			testErrType := alonzo.InsufficientCollateralError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateCollateralContainsNonAda(t *testing.T) {
+	testPolicyId := make([]byte, 28)
+	testAssets := common.NewMultiAsset[common.MultiAssetTypeOutput](
+		map[common.Blake2b224]map[cbor.ByteString]uint64{
+			common.NewBlake2b224(testPolicyId): {
+				cbor.NewByteString([]byte("test")): 1,
+			},
+		},
+	)
+	testProtocolParams := &babbage.BabbageProtocolParameters{}
+	testSlot := uint64(0)
+	testLedgerState := testInputLedgerState(
+		babbage.BabbageTransactionOutput{
+			OutputAmount: mary.MaryTransactionOutputValue{
+				Amount: 5000000,
+				Assets: &testAssets,
+			},
+		},
+	)
+	// Assets sent to collateral return
+	t.Run(
+		"assets returned",
+		func(t *testing.T) {
+			testTx := testCollateralTx(testRedeemers, 1)
+			testTx.Body.TxCollateralReturn = &babbage.BabbageTransactionOutput{
+				OutputAmount: mary.MaryTransactionOutputValue{
+					Amount: 4000000,
+					Assets: &testAssets,
+				},
+			}
+			err := babbage.UtxoValidateCollateralContainsNonAda(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateCollateralContainsNonAda should succeed when all assets are sent to the collateral return\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// No collateral return
+	t.Run(
+		"no collateral return",
+		func(t *testing.T) {
+			testTx := testCollateralTx(testRedeemers, 1)
+			err := babbage.UtxoValidateCollateralContainsNonAda(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateCollateralContainsNonAda should fail when collateral assets are not returned",
+				)
+				return
+			}
+			testErrType := alonzo.CollateralContainsNonADAError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+	// Collateral return without assets
+	t.Run(
+		"assets not returned",
+		func(t *testing.T) {
+			testTx := testCollateralTx(testRedeemers, 1)
+			testTx.Body.TxCollateralReturn = &babbage.BabbageTransactionOutput{
+				OutputAmount: mary.MaryTransactionOutputValue{
+					Amount: 4000000,
+				},
+			}
+			err := babbage.UtxoValidateCollateralContainsNonAda(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateCollateralContainsNonAda should fail when collateral assets are not returned",
+				)
+				return
+			}
+			testErrType := alonzo.CollateralContainsNonADAError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateIncorrectTotalCollateralField(t *testing.T) {
+	testProtocolParams := &babbage.BabbageProtocolParameters{}
+	testSlot := uint64(0)
+	testLedgerState := testInputLedgerState(
+		babbage.BabbageTransactionOutput{
+			OutputAmount: mary.MaryTransactionOutputValue{
+				Amount: 5000000,
+			},
+		},
+	)
+	testRun := func(t *testing.T, name string, totalCollateral uint64, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testTx := testCollateralTx(testRedeemers, 1)
+				testTx.Body.TxCollateralReturn = &babbage.BabbageTransactionOutput{
+					OutputAmount: mary.MaryTransactionOutputValue{
+						Amount: 3000000,
+					},
+				}
+				testTx.Body.TxTotalCollateral = totalCollateral
+				err := babbage.UtxoValidateIncorrectTotalCollateralField(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	// Matching total collateral
+	testRun(
+		t,
+		"matching total collateral",
+		2000000,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateIncorrectTotalCollateralField should succeed when the total collateral matches\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// No total collateral
+	testRun(
+		t,
+		"no total collateral",
+		0,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateIncorrectTotalCollateralField should succeed when the total collateral is not specified\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Mismatched total collateral
+	testRun(
+		t,
+		"mismatched total collateral",
+		5000000,
+		func(t *testing.T, err error) {
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateIncorrectTotalCollateralField should fail when the total collateral does not match",
+				)
+				return
+			}
+			testErrType := babbage.IncorrectTotalCollateralFieldError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateBadInputsUtxo(t *testing.T) {
+	testProtocolParams := &babbage.BabbageProtocolParameters{}
+	testSlot := uint64(0)
+	testLedgerState := testInputLedgerState(
+		babbage.BabbageTransactionOutput{},
+		babbage.BabbageTransactionOutput{},
+	)
+	testTx := &babbage.BabbageTransaction{}
+	testTx.Body.TxInputs = shelley.NewShelleyTransactionInputSet(
+		[]shelley.ShelleyTransactionInput{
+			shelley.NewShelleyTransactionInput(testInputTxId, 0),
+		},
+	)
+	// Good reference input
+	t.Run(
+		"good reference input",
+		func(t *testing.T) {
+			testTx.Body.TxReferenceInputs = []shelley.ShelleyTransactionInput{
+				shelley.NewShelleyTransactionInput(testInputTxId, 1),
+			}
+			err := babbage.UtxoValidateBadInputsUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateBadInputsUtxo should succeed when provided a known reference input\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Bad reference input
+	t.Run(
+		"bad reference input",
+		func(t *testing.T) {
+			testTx.Body.TxReferenceInputs = []shelley.ShelleyTransactionInput{
+				shelley.NewShelleyTransactionInput(testInputTxId, 2),
+			}
+			err := babbage.UtxoValidateBadInputsUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateBadInputsUtxo should fail when provided an unknown reference input",
+				)
+				return
+			}
+			testErrType := shelley.BadInputsUtxoError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateNonDisjointRefInputs(t *testing.T) {
+	testProtocolParams := &babbage.BabbageProtocolParameters{}
+	testLedgerState := testLedgerState{}
+	testSlot := uint64(0)
+	testTx := &babbage.BabbageTransaction{}
+	testTx.Body.TxInputs = shelley.NewShelleyTransactionInputSet(
+		[]shelley.ShelleyTransactionInput{
+			shelley.NewShelleyTransactionInput(testInputTxId, 0),
+		},
+	)
+	// Disjoint reference inputs
+	t.Run(
+		"disjoint reference inputs",
+		func(t *testing.T) {
+			testTx.Body.TxReferenceInputs = []shelley.ShelleyTransactionInput{
+				shelley.NewShelleyTransactionInput(testInputTxId, 1),
+			}
+			err := babbage.UtxoValidateNonDisjointRefInputs(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateNonDisjointRefInputs should succeed when reference inputs are disjoint from inputs\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Overlapping reference inputs
+	t.Run(
+		"overlapping reference inputs",
+		func(t *testing.T) {
+			testTx.Body.TxReferenceInputs = []shelley.ShelleyTransactionInput{
+				shelley.NewShelleyTransactionInput(testInputTxId, 0),
+			}
+			err := babbage.UtxoValidateNonDisjointRefInputs(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateNonDisjointRefInputs should fail when a reference input is also a regular input",
+				)
+				return
+			}
+			testErrType := babbage.NonDisjointRefInputsError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateWrongNetworkInTxBody(t *testing.T) {
+	testLedgerState := testLedgerState{
+		networkId: common.AddressNetworkMainnet,
+	}
+	testProtocolParams := &babbage.BabbageProtocolParameters{}
+	testSlot := uint64(0)
+	testTx := &babbage.BabbageTransaction{}
+	// Correct network ID
+	t.Run(
+		"correct network ID",
+		func(t *testing.T) {
+			testNetworkId := uint8(common.AddressNetworkMainnet)
+			testTx.Body.NetworkId = &testNetworkId
+			err := babbage.UtxoValidateWrongNetworkInTxBody(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateWrongNetworkInTxBody should succeed when the correct network ID is specified\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Wrong network ID
+	t.Run(
+		"wrong network ID",
+		func(t *testing.T) {
+			testNetworkId := uint8(common.AddressNetworkTestnet)
+			testTx.Body.NetworkId = &testNetworkId
+			err := babbage.UtxoValidateWrongNetworkInTxBody(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateWrongNetworkInTxBody should fail when the wrong network ID is specified",
+				)
+				return
+			}
+			testErrType := alonzo.WrongNetworkInTxBodyError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateOutputTooSmallUtxo(t *testing.T) {
+	testProtocolParams := &babbage.BabbageProtocolParameters{
+		AdaPerUtxoByte: 4310,
+	}
+	testLedgerState := testLedgerState{}
+	testSlot := uint64(0)
+	testAddr := testAddress(t)
+	testRun := func(t *testing.T, name string, amount uint64, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testTx := &babbage.BabbageTransaction{
+					Body: babbage.BabbageTransactionBody{
+						TxOutputs: []babbage.BabbageTransactionOutput{
+							{
+								OutputAddress: testAddr,
+								OutputAmount: mary.MaryTransactionOutputValue{
+									Amount: amount,
+								},
+							},
+						},
+					},
+				}
+				err := babbage.UtxoValidateOutputTooSmallUtxo(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	// Calculate the expected minimum from the serialized output size
+	testOutput := babbage.BabbageTransactionOutput{
+		OutputAddress: testAddr,
+		OutputAmount: mary.MaryTransactionOutputValue{
+			Amount: 1000000,
+		},
+	}
+	testOutputCbor, err := cbor.Encode(&testOutput)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	minCoin, err := babbage.MinCoinTxOut(&testOutput, testProtocolParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedMinCoin := (160 + uint64(len(testOutputCbor))) * 4310
+	if minCoin != expectedMinCoin {
+		t.Fatalf("did not get expected min coin: got %d, wanted %d", minCoin, expectedMinCoin)
+	}
+	// Enough ADA
+	testRun(
+		t,
+		"enough ADA",
+		2000000,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateOutputTooSmallUtxo should succeed when outputs have enough ADA\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Not enough ADA
+	testRun(
+		t,
+		"not enough ADA",
+		500000,
+		func(t *testing.T, err error) {
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateOutputTooSmallUtxo should fail when outputs don't have enough ADA",
+				)
+				return
+			}
+			testErrType := shelley.OutputTooSmallUtxoError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateMalformedReferenceScripts(t *testing.T) {
+	testProtocolParams := &babbage.BabbageProtocolParameters{}
+	testLedgerState := testLedgerState{}
+	testSlot := uint64(0)
+	testRun := func(t *testing.T, name string, scriptRef *cbor.Tag, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testTx := &babbage.BabbageTransaction{
+					Body: babbage.BabbageTransactionBody{
+						TxOutputs: []babbage.BabbageTransactionOutput{
+							{
+								ScriptRef: scriptRef,
+							},
+						},
+					},
+				}
+				err := babbage.UtxoValidateMalformedReferenceScripts(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	validateSuccess := func(t *testing.T, err error) {
+		if err != nil {
+			t.Errorf(
+				"UtxoValidateMalformedReferenceScripts should succeed when reference scripts are well-formed\n  got error: %v",
+				err,
+			)
+		}
+	}
+	validateFailure := func(t *testing.T, err error) {
+		if err == nil {
+			t.Errorf(
+				"UtxoValidateMalformedReferenceScripts should fail when reference scripts are malformed",
+			)
+			return
+		}
+		testErrType := babbage.MalformedReferenceScriptsError{}
+		assert.IsType(
+			t,
+			testErrType,
+			err,
+			"did not get expected error type: got %T, wanted %T",
+			err,
+			testErrType,
+		)
+	}
+	testRun(t, "no reference script", nil, validateSuccess)
+	testRun(
+		t,
+		"Plutus V2 script",
+		testScriptRef(t, babbage.ScriptRefTypePlutusV2, []byte{0xde, 0xad}),
+		validateSuccess,
+	)
+	testRun(
+		t,
+		"native script",
+		// ScriptPubkey
+		testScriptRef(t, babbage.ScriptRefTypeNativeScript, []any{0, make([]byte, 28)}),
+		validateSuccess,
+	)
+	testRun(
+		t,
+		"Plutus V3 script",
+		testScriptRef(t, babbage.ScriptRefTypePlutusV3, []byte{0xde, 0xad}),
+		validateFailure,
+	)
+	testRun(
+		t,
+		"unknown script type",
+		testScriptRef(t, 99, []byte{0xde, 0xad}),
+		validateFailure,
+	)
+	testRun(
+		t,
+		"malformed native script",
+		testScriptRef(t, babbage.ScriptRefTypeNativeScript, []any{99}),
+		validateFailure,
+	)
+}
@@ -27,6 +27,9 @@ type ByronMainBlockHeader = byron.ByronMainBlockHeader
 type ByronTransaction = byron.ByronTransaction
 type ByronTransactionInput = byron.ByronTransactionInput
 type ByronTransactionOutput = byron.ByronTransactionOutput
+type ByronTransactionWitness = byron.ByronTransactionWitness
+type ByronTxPayload = byron.ByronTxPayload
+type ByronChainValidator = byron.ByronChainValidator
 
 // Byron constants
 const (
@@ -45,4 +48,6 @@ var (
 	NewByronMainBlockHeaderFromCbor          = byron.NewByronMainBlockHeaderFromCbor
 	NewByronTransactionInput                 = byron.NewByronTransactionInput
 	NewByronTransactionFromCbor              = byron.NewByronTransactionFromCbor
+	NewByronTransactionOutputFromCbor        = byron.NewByronTransactionOutputFromCbor
+	NewByronChainValidator                   = byron.NewByronChainValidator
 )
@@ -139,6 +139,8 @@ type ByronTransaction struct {
 	TxInputs   []ByronTransactionInput
 	TxOutputs  []ByronTransactionOutput
 	Attributes *cbor.LazyValue
+	// Witnesses are stored alongside the transaction rather than inside it
+	witnesses []ByronTransactionWitness
 }
 
 func (t *ByronTransaction) UnmarshalCBOR(data []byte) error {
@@ -289,12 +291,56 @@ func (t *ByronTransaction) Produced() []common.Utxo {
 }
 
 func (t ByronTransaction) Witnesses() common.TransactionWitnessSet {
-	// TODO: implement once we properly support decoding Byron TX witnesses (#853)
+	return NewByronTransactionWitnessSet(t.witnesses)
+}
+
+// VerifyWitnesses checks that there is a witness for each input and that the signature of each
+// witness is valid for the transaction on the network with the specified protocol magic
+func (t *ByronTransaction) VerifyWitnesses(protocolMagic uint32) error {
+	if len(t.witnesses) != len(t.TxInputs) {
+		return fmt.Errorf(
+			"witness count (%d) does not match input count (%d)",
+			len(t.witnesses),
+			len(t.TxInputs),
+		)
+	}
+	tmpHash, err := hex.DecodeString(t.Hash())
+	if err != nil {
+		return err
+	}
+	txHash := common.NewBlake2b256(tmpHash)
+	for idx, witness := range t.witnesses {
+		if err := witness.Verify(txHash, protocolMagic); err != nil {
+			return fmt.Errorf("witness %d: %w", idx, err)
+		}
+	}
 	return nil
 }
 
-func (t *ByronTransaction) Utxorpc() *utxorpc.Tx {
-	return &utxorpc.Tx{}
+func (t *ByronTransaction) Utxorpc() (*utxorpc.Tx, error) {
+	var txi []*utxorpc.TxInput
+	var txo []*utxorpc.TxOutput
+	for _, i := range t.Inputs() {
+		input := i.Utxorpc()
+		txi = append(txi, input)
+	}
+	for _, o := range t.Outputs() {
+		output, err := o.Utxorpc()
+		if err != nil {
+			return nil, err
+		}
+		txo = append(txo, output)
+	}
+	tmpHash, err := hex.DecodeString(t.Hash())
+	if err != nil {
+		return nil, err
+	}
+	tx := &utxorpc.Tx{
+		Inputs:  txi,
+		Outputs: txo,
+		Hash:    tmpHash,
+	}
+	return tx, nil
 }
 
 func (t *ByronTransaction) ProtocolParameterUpdates() (uint64, map[common.Blake2b224]common.ProtocolParameterUpdate) {
@@ -388,6 +434,12 @@ func (o *ByronTransactionOutput) UnmarshalCBOR(data []byte) error {
 	if _, err := cbor.Decode(data, &tmpData); err != nil {
 		return err
 	}
+	// Byron addresses are a CBOR array, which distinguishes a Byron output from a later era output
+	// that uses a Byron address as a bytestring
+	if len(tmpData.WrappedAddress) == 0 ||
+		tmpData.WrappedAddress[0]&cbor.CborTypeMask != cbor.CborTypeArray {
+		return fmt.Errorf("invalid Byron output address")
+	}
 	o.OutputAmount = tmpData.Amount
 	if _, err := cbor.Decode(tmpData.WrappedAddress, &o.OutputAddress); err != nil {
 		return err
@@ -415,11 +467,15 @@ func (o ByronTransactionOutput) Datum() *cbor.LazyValue {
 	return nil
 }
 
-func (o ByronTransactionOutput) Utxorpc() *utxorpc.TxOutput {
+func (o ByronTransactionOutput) Utxorpc() (*utxorpc.TxOutput, error) {
+	addressBytes, err := o.OutputAddress.Bytes()
+	if err != nil {
+		return nil, err
+	}
 	return &utxorpc.TxOutput{
-		Address: o.OutputAddress.Bytes(),
+		Address: addressBytes,
 		Coin:    o.Amount(),
-	}
+	}, nil
 }
 
 type ByronBlockVersion struct {
@@ -478,13 +534,7 @@ type ByronUpdateProposalBlockVersionMod struct {
 type ByronMainBlockBody struct {
 	cbor.StructAsArray
 	cbor.DecodeStoreCbor
-	// TODO: split this to its own type (#853)
-	TxPayload []struct {
-		cbor.StructAsArray
-		Transaction ByronTransaction
-		// TODO: properly decode TX witnesses (#853)
-		Twit []cbor.Value
-	}
+	TxPayload  []ByronTxPayload
 	SscPayload cbor.Value
 	DlgPayload []interface{}
 	UpdPayload ByronUpdatePayload
@@ -495,6 +545,29 @@ func (b *ByronMainBlockBody) UnmarshalCBOR(data []byte) error {
 	return b.UnmarshalCbor(data, b)
 }
 
+// ByronTxPayload is a transaction with its witnesses, as found in a block body or submitted to a node
+type ByronTxPayload struct {
+	cbor.StructAsArray
+	Transaction ByronTransaction
+	Witnesses   []ByronTransactionWitness
+}
+
+func (p *ByronTxPayload) UnmarshalCBOR(data []byte) error {
+	var tmpData struct {
+		cbor.StructAsArray
+		Transaction ByronTransaction
+		Witnesses   []ByronTransactionWitness
+	}
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	p.Transaction = tmpData.Transaction
+	p.Witnesses = tmpData.Witnesses
+	// Make the witnesses available from the transaction
+	p.Transaction.witnesses = tmpData.Witnesses
+	return nil
+}
+
 type ByronEpochBoundaryBlockHeader struct {
 	cbor.StructAsArray
 	cbor.DecodeStoreCbor
@@ -619,8 +692,8 @@ func (b *ByronMainBlock) Transactions() []common.Transaction {
 	return ret
 }
 
-func (b *ByronMainBlock) Utxorpc() *utxorpc.Block {
-	return &utxorpc.Block{}
+func (b *ByronMainBlock) Utxorpc() (*utxorpc.Block, error) {
+	return &utxorpc.Block{}, nil
 }
 
 type ByronEpochBoundaryBlock struct {
@@ -678,8 +751,8 @@ func (b *ByronEpochBoundaryBlock) Transactions() []common.Transaction {
 	return nil
 }
 
-func (b *ByronEpochBoundaryBlock) Utxorpc() *utxorpc.Block {
-	return &utxorpc.Block{}
+func (b *ByronEpochBoundaryBlock) Utxorpc() (*utxorpc.Block, error) {
+	return &utxorpc.Block{}, nil
 }
 
 func NewByronEpochBoundaryBlockFromCbor(
@@ -702,6 +775,14 @@ func NewByronEpochBoundaryBlockHeaderFromCbor(
 	return &byronEbbBlockHeader, nil
 }
 
+func NewByronTransactionOutputFromCbor(data []byte) (*ByronTransactionOutput, error) {
+	var byronTxOutput ByronTransactionOutput
+	if _, err := cbor.Decode(data, &byronTxOutput); err != nil {
+		return nil, fmt.Errorf("Byron transaction output decode error: %s", err)
+	}
+	return &byronTxOutput, nil
+}
+
 func NewByronMainBlockFromCbor(data []byte) (*ByronMainBlock, error) {
 	var byronMainBlock ByronMainBlock
 	if _, err := cbor.Decode(data, &byronMainBlock); err != nil {
@@ -720,7 +801,12 @@ func NewByronMainBlockHeaderFromCbor(
 	return &byronMainBlockHeader, nil
 }
 
+// NewByronTransactionFromCbor decodes a Byron transaction, either on its own or with its witnesses
 func NewByronTransactionFromCbor(data []byte) (*ByronTransaction, error) {
+	var byronTxPayload ByronTxPayload
+	if _, err := cbor.Decode(data, &byronTxPayload); err == nil {
+		return &byronTxPayload.Transaction, nil
+	}
 	var byronTx ByronTransaction
 	if _, err := cbor.Decode(data, &byronTx); err != nil {
 		return nil, fmt.Errorf("Byron transaction decode error: %s", err)
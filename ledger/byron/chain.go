@@ -0,0 +1,395 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package byron
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// ByronPbftSignatureThreshold is the default maximum fraction of the last k blocks that may be
+// signed by a single genesis key
+const ByronPbftSignatureThreshold = 0.22
+
+// ByronChainValidator validates a chain of Byron blocks using the Ouroboros BFT (PBFT) rules. Blocks
+// must be provided in order, starting with the first block after genesis
+type ByronChainValidator struct {
+	// SignatureThreshold is the maximum fraction of the last k blocks that may be signed by a single
+	// genesis key
+	SignatureThreshold float64
+	protocolMagic      uint32
+	securityParam      uint64
+	slotsPerEpoch      uint64
+	genesisKeys        map[common.Blake2b224]bool
+	// Current delegate key for each genesis key
+	delegations        map[common.Blake2b224][]byte
+	pendingDelegations []byronPendingDelegation
+	recentSigners      []common.Blake2b224
+	prevHash           common.Blake2b256
+	difficulty         uint64
+	epoch              uint64
+	lastSlot           uint64
+	hasMainBlock       bool
+}
+
+type byronPendingDelegation struct {
+	activationSlot uint64
+	genesisKey     common.Blake2b224
+	delegateKey    []byte
+}
+
+// NewByronChainValidator creates a chain validator from the Byron genesis config and the hash of the
+// genesis config, which is the previous hash of the first block
+func NewByronChainValidator(
+	genesis ByronGenesis,
+	genesisHash common.Blake2b256,
+) (*ByronChainValidator, error) {
+	if genesis.ProtocolConsts.K <= 0 {
+		return nil, fmt.Errorf("invalid security parameter: %d", genesis.ProtocolConsts.K)
+	}
+	if genesis.ProtocolConsts.ProtocolMagic < 0 ||
+		genesis.ProtocolConsts.ProtocolMagic > math.MaxUint32 {
+		return nil, fmt.Errorf("invalid protocol magic: %d", genesis.ProtocolConsts.ProtocolMagic)
+	}
+	v := &ByronChainValidator{
+		SignatureThreshold: ByronPbftSignatureThreshold,
+		protocolMagic:      uint32(genesis.ProtocolConsts.ProtocolMagic),
+		securityParam:      uint64(genesis.ProtocolConsts.K),
+		// Byron epochs are always 10k slots
+		slotsPerEpoch: uint64(genesis.ProtocolConsts.K) * 10,
+		genesisKeys:   make(map[common.Blake2b224]bool),
+		delegations:   make(map[common.Blake2b224][]byte),
+		prevHash:      genesisHash,
+	}
+	for stakeholderId := range genesis.BootStakeholders {
+		tmpId, err := hex.DecodeString(stakeholderId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis stakeholder ID %s: %w", stakeholderId, err)
+		}
+		v.genesisKeys[common.NewBlake2b224(tmpId)] = true
+	}
+	// Build the initial delegations from the genesis heavy delegation certificates
+	for stakeholderId, delegation := range genesis.HeavyDelegation {
+		cert, err := delegation.Certificate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis delegation for %s: %w", stakeholderId, err)
+		}
+		if err := cert.Verify(v.protocolMagic); err != nil {
+			return nil, fmt.Errorf("invalid genesis delegation for %s: %w", stakeholderId, err)
+		}
+		issuerId, err := ByronStakeholderId(cert.IssuerKey)
+		if err != nil {
+			return nil, err
+		}
+		if issuerId.String() != stakeholderId {
+			return nil, fmt.Errorf(
+				"genesis delegation issuer does not match stakeholder ID %s",
+				stakeholderId,
+			)
+		}
+		if !v.genesisKeys[issuerId] {
+			return nil, fmt.Errorf(
+				"genesis delegation issuer %s is not a genesis key",
+				stakeholderId,
+			)
+		}
+		if delegateInUse(cert.DelegateKey, issuerId, v.delegations, nil) {
+			return nil, fmt.Errorf(
+				"genesis delegation for %s uses a delegate key from another genesis key",
+				stakeholderId,
+			)
+		}
+		v.delegations[issuerId] = cert.DelegateKey
+	}
+	return v, nil
+}
+
+// SetChainTip sets the chain state to continue validation after the specified block, which must
+// already be known to be valid. The signature threshold only takes into account blocks validated
+// after this, and the slot of the next block is not checked against the tip
+func (v *ByronChainValidator) SetChainTip(hash common.Blake2b256, epoch uint64, difficulty uint64) {
+	v.prevHash = hash
+	v.epoch = epoch
+	v.difficulty = difficulty
+	v.recentSigners = nil
+	v.lastSlot = 0
+	v.hasMainBlock = false
+}
+
+// Certificate decodes the genesis heavy delegation certificate
+func (d ByronGenesisHeavyDelegation) Certificate() (ByronDelegationCertificate, error) {
+	var ret ByronDelegationCertificate
+	issuerKey, err := base64.StdEncoding.DecodeString(d.IssuerPk)
+	if err != nil {
+		return ret, err
+	}
+	delegateKey, err := base64.StdEncoding.DecodeString(d.DelegatePk)
+	if err != nil {
+		return ret, err
+	}
+	signature, err := hex.DecodeString(d.Cert)
+	if err != nil {
+		return ret, err
+	}
+	if d.Omega < 0 {
+		return ret, fmt.Errorf("invalid epoch: %d", d.Omega)
+	}
+	ret = ByronDelegationCertificate{
+		Epoch:       uint64(d.Omega),
+		IssuerKey:   issuerKey,
+		DelegateKey: delegateKey,
+		Signature:   signature,
+	}
+	return ret, nil
+}
+
+// ValidateBlock validates a Byron main or epoch boundary block against the current chain state,
+// and updates the chain state if it's valid
+func (v *ByronChainValidator) ValidateBlock(block common.Block) error {
+	switch b := block.(type) {
+	case *ByronEpochBoundaryBlock:
+		return v.validateEpochBoundaryBlock(b)
+	case *ByronMainBlock:
+		return v.validateMainBlock(b)
+	default:
+		return fmt.Errorf("unsupported block type: %T", block)
+	}
+}
+
+func (v *ByronChainValidator) validateEpochBoundaryBlock(block *ByronEpochBoundaryBlock) error {
+	header := block.BlockHeader
+	if header.ProtocolMagic != v.protocolMagic {
+		return fmt.Errorf(
+			"protocol magic mismatch: got %d, expected %d",
+			header.ProtocolMagic,
+			v.protocolMagic,
+		)
+	}
+	if header.PrevBlock != v.prevHash {
+		return fmt.Errorf(
+			"previous block hash mismatch: got %s, expected %s",
+			header.PrevBlock.String(),
+			v.prevHash.String(),
+		)
+	}
+	epoch := header.ConsensusData.Epoch
+	if v.hasMainBlock && epoch <= v.epoch {
+		return fmt.Errorf("epoch boundary block for epoch %d follows epoch %d", epoch, v.epoch)
+	}
+	// Epoch boundary blocks don't count towards the chain difficulty
+	if header.ConsensusData.Difficulty.Value != v.difficulty {
+		return fmt.Errorf(
+			"difficulty mismatch: got %d, expected %d",
+			header.ConsensusData.Difficulty.Value,
+			v.difficulty,
+		)
+	}
+	if err := block.VerifyBodyProof(); err != nil {
+		return err
+	}
+	tmpHash, err := hex.DecodeString(block.Hash())
+	if err != nil {
+		return err
+	}
+	v.prevHash = common.NewBlake2b256(tmpHash)
+	v.epoch = epoch
+	return nil
+}
+
+func (v *ByronChainValidator) validateMainBlock(block *ByronMainBlock) error {
+	header := block.BlockHeader
+	if header.ProtocolMagic != v.protocolMagic {
+		return fmt.Errorf(
+			"protocol magic mismatch: got %d, expected %d",
+			header.ProtocolMagic,
+			v.protocolMagic,
+		)
+	}
+	if header.PrevBlock != v.prevHash {
+		return fmt.Errorf(
+			"previous block hash mismatch: got %s, expected %s",
+			header.PrevBlock.String(),
+			v.prevHash.String(),
+		)
+	}
+	// Slot
+	slotId := header.ConsensusData.SlotId
+	if uint64(slotId.Slot) >= v.slotsPerEpoch {
+		return fmt.Errorf("slot %d is outside of epoch with %d slots", slotId.Slot, v.slotsPerEpoch)
+	}
+	if slotId.Epoch < v.epoch {
+		return fmt.Errorf("block epoch %d is before current epoch %d", slotId.Epoch, v.epoch)
+	}
+	slot := slotId.Epoch*v.slotsPerEpoch + uint64(slotId.Slot)
+	if v.hasMainBlock && slot <= v.lastSlot {
+		return fmt.Errorf("block slot %d is not after previous block slot %d", slot, v.lastSlot)
+	}
+	if header.ConsensusData.Difficulty.Unknown != v.difficulty+1 {
+		return fmt.Errorf(
+			"difficulty mismatch: got %d, expected %d",
+			header.ConsensusData.Difficulty.Unknown,
+			v.difficulty+1,
+		)
+	}
+	// Signature
+	if err := header.VerifySignature(); err != nil {
+		return err
+	}
+	sig, err := header.Signature()
+	if err != nil {
+		return err
+	}
+	genesisKey, err := ByronStakeholderId(sig.Certificate.IssuerKey)
+	if err != nil {
+		return err
+	}
+	if !v.genesisKeys[genesisKey] {
+		return fmt.Errorf("block issuer %s is not a genesis key", genesisKey.String())
+	}
+	// Apply delegations that have become active by this slot
+	delegations := maps.Clone(v.delegations)
+	var pendingDelegations []byronPendingDelegation
+	for _, pending := range v.pendingDelegations {
+		if pending.activationSlot <= slot {
+			delegations[pending.genesisKey] = pending.delegateKey
+			continue
+		}
+		pendingDelegations = append(pendingDelegations, pending)
+	}
+	if !bytes.Equal(delegations[genesisKey], sig.Certificate.DelegateKey) {
+		return fmt.Errorf(
+			"block signer is not the current delegate for genesis key %s",
+			genesisKey.String(),
+		)
+	}
+	// Check the number of blocks signed by this genesis key in the last k blocks
+	recentSigners := append(slices.Clone(v.recentSigners), genesisKey)
+	if uint64(len(recentSigners)) > v.securityParam {
+		recentSigners = recentSigners[uint64(len(recentSigners))-v.securityParam:]
+	}
+	signedCount := 0
+	for _, signer := range recentSigners {
+		if signer == genesisKey {
+			signedCount++
+		}
+	}
+	maxSigned := int(math.Floor(v.SignatureThreshold * float64(v.securityParam)))
+	if signedCount > maxSigned {
+		return fmt.Errorf(
+			"genesis key %s signed %d of the last %d blocks, which exceeds the limit of %d",
+			genesisKey.String(),
+			signedCount,
+			len(recentSigners),
+			maxSigned,
+		)
+	}
+	// Body
+	if err := block.VerifyBodyProof(); err != nil {
+		return err
+	}
+	certs, err := block.Body.DelegationCertificates()
+	if err != nil {
+		return err
+	}
+	for _, cert := range certs {
+		if err := cert.Verify(v.protocolMagic); err != nil {
+			return err
+		}
+		issuerId, err := ByronStakeholderId(cert.IssuerKey)
+		if err != nil {
+			return err
+		}
+		if !v.genesisKeys[issuerId] {
+			return fmt.Errorf("delegation certificate issuer %s is not a genesis key", issuerId.String())
+		}
+		if cert.Epoch != slotId.Epoch {
+			return fmt.Errorf(
+				"delegation certificate for epoch %d is not for the current epoch %d",
+				cert.Epoch,
+				slotId.Epoch,
+			)
+		}
+		if delegateInUse(cert.DelegateKey, issuerId, delegations, pendingDelegations) {
+			return fmt.Errorf(
+				"delegation certificate from %s uses a delegate key from another genesis key",
+				issuerId.String(),
+			)
+		}
+		// Delegations become active after 2k slots
+		pendingDelegations = append(
+			pendingDelegations,
+			byronPendingDelegation{
+				activationSlot: slot + 2*v.securityParam,
+				genesisKey:     issuerId,
+				delegateKey:    cert.DelegateKey,
+			},
+		)
+	}
+	// Update chain state
+	tmpHash, err := hex.DecodeString(block.Hash())
+	if err != nil {
+		return err
+	}
+	v.prevHash = common.NewBlake2b256(tmpHash)
+	v.delegations = delegations
+	v.pendingDelegations = pendingDelegations
+	v.recentSigners = recentSigners
+	v.difficulty++
+	v.epoch = slotId.Epoch
+	v.lastSlot = slot
+	v.hasMainBlock = true
+	return nil
+}
+
+// delegateInUse returns whether the delegate key is used by a genesis key other than the specified
+// one, once the pending delegations are active
+func delegateInUse(
+	delegateKey []byte,
+	genesisKey common.Blake2b224,
+	delegations map[common.Blake2b224][]byte,
+	pendingDelegations []byronPendingDelegation,
+) bool {
+	tmpDelegations := maps.Clone(delegations)
+	for _, pending := range pendingDelegations {
+		tmpDelegations[pending.genesisKey] = pending.delegateKey
+	}
+	for tmpGenesisKey, tmpDelegateKey := range tmpDelegations {
+		if tmpGenesisKey != genesisKey && bytes.Equal(tmpDelegateKey, delegateKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// DelegationCertificates decodes the heavy delegation certificates from the delegation payload
+func (b *ByronMainBlockBody) DelegationCertificates() ([]ByronDelegationCertificate, error) {
+	var rawBody byronMainBlockBodyRaw
+	if _, err := cbor.Decode(b.Cbor(), &rawBody); err != nil {
+		return nil, err
+	}
+	var ret []ByronDelegationCertificate
+	if _, err := cbor.Decode(rawBody.DlgPayload, &ret); err != nil {
+		return nil, fmt.Errorf("invalid Byron delegation payload: %w", err)
+	}
+	return ret, nil
+}
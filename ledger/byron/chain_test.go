@@ -0,0 +1,473 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package byron_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/byron"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const (
+	testChainProtocolMagic = 42
+	testChainSecurityParam = 10
+)
+
+type testByronKey struct {
+	privKey ed25519.PrivateKey
+	xpub    []byte
+}
+
+func newTestByronKey(seed byte) testByronKey {
+	privKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, 32))
+	return testByronKey{
+		privKey: privKey,
+		xpub: slices.Concat(
+			[]byte(privKey.Public().(ed25519.PublicKey)),
+			bytes.Repeat([]byte{seed}, 32),
+		),
+	}
+}
+
+// testSignTag returns the signing tag followed by the CBOR-encoded protocol magic
+func testSignTag(t *testing.T, tag byte) []byte {
+	magicCbor, err := cbor.Encode(uint32(testChainProtocolMagic))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return slices.Concat([]byte{tag}, magicCbor)
+}
+
+func newTestDelegationCertificate(
+	t *testing.T,
+	issuer testByronKey,
+	delegate testByronKey,
+	epoch uint64,
+) byron.ByronDelegationCertificate {
+	epochCbor, err := cbor.Encode(epoch)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	certData, err := cbor.Encode(slices.Concat([]byte("00"), delegate.xpub, epochCbor))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return byron.ByronDelegationCertificate{
+		Epoch:       epoch,
+		IssuerKey:   issuer.xpub,
+		DelegateKey: delegate.xpub,
+		Signature:   ed25519.Sign(issuer.privKey, slices.Concat(testSignTag(t, 0x0a), certData)),
+	}
+}
+
+func newTestChainGenesis(t *testing.T, issuers []testByronKey, delegates []testByronKey) byron.ByronGenesis {
+	genesis := byron.ByronGenesis{
+		ProtocolConsts: byron.ByronGenesisProtocolConsts{
+			K:             testChainSecurityParam,
+			ProtocolMagic: testChainProtocolMagic,
+		},
+		BootStakeholders: make(map[string]int),
+		HeavyDelegation:  make(map[string]byron.ByronGenesisHeavyDelegation),
+	}
+	for idx, issuer := range issuers {
+		stakeholderId, err := byron.ByronStakeholderId(issuer.xpub)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		cert := newTestDelegationCertificate(t, issuer, delegates[idx], 0)
+		genesis.BootStakeholders[stakeholderId.String()] = 1
+		genesis.HeavyDelegation[stakeholderId.String()] = byron.ByronGenesisHeavyDelegation{
+			Cert:       hex.EncodeToString(cert.Signature),
+			DelegatePk: base64.StdEncoding.EncodeToString(delegates[idx].xpub),
+			IssuerPk:   base64.StdEncoding.EncodeToString(issuer.xpub),
+		}
+	}
+	return genesis
+}
+
+func mustEncode(t *testing.T, v any) []byte {
+	ret, err := cbor.Encode(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return ret
+}
+
+func newTestEpochBoundaryBlock(
+	t *testing.T,
+	prevHash common.Blake2b256,
+	epoch uint64,
+	difficulty uint64,
+) *byron.ByronEpochBoundaryBlock {
+	bodyCbor := mustEncode(t, []any{})
+	bodyHash := common.Blake2b256Hash(bodyCbor)
+	blockCbor := mustEncode(
+		t,
+		[]any{
+			[]any{
+				uint32(testChainProtocolMagic),
+				prevHash.Bytes(),
+				bodyHash.Bytes(),
+				[]any{epoch, []any{difficulty}},
+				[]any{map[any]any{}},
+			},
+			cbor.RawMessage(bodyCbor),
+			[]any{map[any]any{}},
+		},
+	)
+	block, err := byron.NewByronEpochBoundaryBlockFromCbor(blockCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return block
+}
+
+func newTestMainBlock(
+	t *testing.T,
+	prevHash common.Blake2b256,
+	epoch uint64,
+	slot uint16,
+	difficulty uint64,
+	issuer testByronKey,
+	delegate testByronKey,
+	dlgCerts []byron.ByronDelegationCertificate,
+) *byron.ByronMainBlock {
+	// Build the body and calculate its proof
+	if dlgCerts == nil {
+		dlgCerts = []byron.ByronDelegationCertificate{}
+	}
+	bodyCbor := mustEncode(
+		t,
+		[]any{
+			[]any{},
+			[]any{3, cbor.RawMessage{0xd9, 0x01, 0x02, 0x80}},
+			dlgCerts,
+			[]any{[]any{}, []any{}},
+		},
+	)
+	var body byron.ByronMainBlockBody
+	if _, err := cbor.Decode(bodyCbor, &body); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	proof, err := body.Proof()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	proofCbor := mustEncode(
+		t,
+		[]any{
+			[]any{
+				proof.TxProof.TxCount,
+				proof.TxProof.MerkleRoot.Bytes(),
+				proof.TxProof.WitnessesHash.Bytes(),
+			},
+			[]any{3, proof.SscProof.CertificatesHash.Bytes()},
+			proof.DlgProof.Bytes(),
+			proof.UpdProof.Bytes(),
+		},
+	)
+	// Build and sign the header
+	prevHashCbor := mustEncode(t, prevHash.Bytes())
+	slotIdCbor := mustEncode(t, []any{epoch, slot})
+	difficultyCbor := mustEncode(t, []any{difficulty})
+	extraCbor := mustEncode(
+		t,
+		[]any{
+			[]any{0, 0, 0},
+			[]any{"cardano-sl", 1},
+			map[any]any{},
+			make([]byte, 32),
+		},
+	)
+	sigData := slices.Concat(
+		[]byte("01"),
+		issuer.xpub,
+		testSignTag(t, 0x09),
+		[]byte{0x85},
+		prevHashCbor,
+		proofCbor,
+		slotIdCbor,
+		difficultyCbor,
+		extraCbor,
+	)
+	cert := newTestDelegationCertificate(t, issuer, delegate, epoch)
+	blockSig := []any{
+		2,
+		[]any{cert, ed25519.Sign(delegate.privKey, sigData)},
+	}
+	blockCbor := mustEncode(
+		t,
+		[]any{
+			[]any{
+				uint32(testChainProtocolMagic),
+				cbor.RawMessage(prevHashCbor),
+				cbor.RawMessage(proofCbor),
+				[]any{
+					cbor.RawMessage(slotIdCbor),
+					issuer.xpub,
+					cbor.RawMessage(difficultyCbor),
+					blockSig,
+				},
+				cbor.RawMessage(extraCbor),
+			},
+			cbor.RawMessage(bodyCbor),
+			[]any{map[any]any{}},
+		},
+	)
+	block, err := byron.NewByronMainBlockFromCbor(blockCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return block
+}
+
+func blockHash(t *testing.T, block common.Block) common.Blake2b256 {
+	tmpHash, err := hex.DecodeString(block.Hash())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return common.NewBlake2b256(tmpHash)
+}
+
+func TestByronChainValidatorMainnetGenesis(t *testing.T) {
+	genesis, err := byron.NewByronGenesisFromReader(strings.NewReader(byronGenesisConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// This verifies the genesis delegation certificates
+	if _, err := byron.NewByronChainValidator(genesis, common.Blake2b256{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Certificates are only valid for the network they were issued on
+	genesis.ProtocolConsts.ProtocolMagic = 1097911063
+	if _, err := byron.NewByronChainValidator(genesis, common.Blake2b256{}); err == nil {
+		t.Fatalf("did not get expected error for wrong protocol magic")
+	}
+}
+
+func TestByronChainValidator(t *testing.T) {
+	issuers := []testByronKey{newTestByronKey(1), newTestByronKey(2)}
+	delegates := []testByronKey{newTestByronKey(11), newTestByronKey(12)}
+	genesisHash := common.Blake2b256Hash([]byte("genesis"))
+	genesis := newTestChainGenesis(t, issuers, delegates)
+	validator, err := byron.NewByronChainValidator(genesis, genesisHash)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ebb := newTestEpochBoundaryBlock(t, genesisHash, 0, 0)
+	if err := validator.ValidateBlock(ebb); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	prevHash := blockHash(t, ebb)
+	// Wrong delegate for genesis key
+	block := newTestMainBlock(t, prevHash, 0, 0, 1, issuers[0], delegates[1], nil)
+	if err := validator.ValidateBlock(block); err == nil {
+		t.Fatalf("did not get expected error for wrong delegate")
+	}
+	// Issuer is not a genesis key
+	block = newTestMainBlock(t, prevHash, 0, 0, 1, newTestByronKey(3), delegates[0], nil)
+	if err := validator.ValidateBlock(block); err == nil {
+		t.Fatalf("did not get expected error for non-genesis issuer")
+	}
+	// Wrong previous hash
+	block = newTestMainBlock(t, genesisHash, 0, 0, 1, issuers[0], delegates[0], nil)
+	if err := validator.ValidateBlock(block); err == nil {
+		t.Fatalf("did not get expected error for wrong previous hash")
+	}
+	// Each genesis key can sign 2 of the last 10 blocks
+	for slot, signer := range []int{0, 0, 1, 1} {
+		block = newTestMainBlock(
+			t,
+			prevHash,
+			0,
+			uint16(slot),
+			uint64(slot+1),
+			issuers[signer],
+			delegates[signer],
+			nil,
+		)
+		if err := validator.ValidateBlock(block); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		prevHash = blockHash(t, block)
+	}
+	block = newTestMainBlock(t, prevHash, 0, 4, 5, issuers[0], delegates[0], nil)
+	if err := validator.ValidateBlock(block); err == nil {
+		t.Fatalf("did not get expected error for exceeding signature threshold")
+	}
+	// Slot must increase
+	block = newTestMainBlock(t, prevHash, 0, 3, 5, issuers[0], delegates[0], nil)
+	validator.SignatureThreshold = 1
+	if err := validator.ValidateBlock(block); err == nil {
+		t.Fatalf("did not get expected error for non-increasing slot")
+	}
+	// Re-delegate the first genesis key, which becomes active after 2k slots
+	newDelegate := newTestByronKey(13)
+	block = newTestMainBlock(
+		t,
+		prevHash,
+		0,
+		4,
+		5,
+		issuers[0],
+		delegates[0],
+		[]byron.ByronDelegationCertificate{
+			newTestDelegationCertificate(t, issuers[0], newDelegate, 0),
+		},
+	)
+	if err := validator.ValidateBlock(block); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	prevHash = blockHash(t, block)
+	block = newTestMainBlock(t, prevHash, 0, 5, 6, issuers[0], newDelegate, nil)
+	if err := validator.ValidateBlock(block); err == nil {
+		t.Fatalf("did not get expected error for delegation that is not yet active")
+	}
+	block = newTestMainBlock(t, prevHash, 0, 24, 6, issuers[0], newDelegate, nil)
+	if err := validator.ValidateBlock(block); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	prevHash = blockHash(t, block)
+	// Delegation certificates must be for the current epoch
+	block = newTestMainBlock(
+		t,
+		prevHash,
+		0,
+		25,
+		7,
+		issuers[1],
+		delegates[1],
+		[]byron.ByronDelegationCertificate{
+			newTestDelegationCertificate(t, issuers[1], newTestByronKey(14), 1),
+		},
+	)
+	if err := validator.ValidateBlock(block); err == nil {
+		t.Fatalf("did not get expected error for delegation certificate from another epoch")
+	}
+	// A delegate key can't be used by more than one genesis key
+	block = newTestMainBlock(
+		t,
+		prevHash,
+		0,
+		25,
+		7,
+		issuers[1],
+		delegates[1],
+		[]byron.ByronDelegationCertificate{
+			newTestDelegationCertificate(t, issuers[1], newDelegate, 0),
+		},
+	)
+	if err := validator.ValidateBlock(block); err == nil {
+		t.Fatalf("did not get expected error for delegate key used by another genesis key")
+	}
+	block = newTestMainBlock(
+		t,
+		prevHash,
+		0,
+		25,
+		7,
+		issuers[1],
+		delegates[1],
+		[]byron.ByronDelegationCertificate{
+			newTestDelegationCertificate(t, issuers[1], newTestByronKey(14), 0),
+			newTestDelegationCertificate(t, issuers[0], newTestByronKey(14), 0),
+		},
+	)
+	if err := validator.ValidateBlock(block); err == nil {
+		t.Fatalf("did not get expected error for delegate key used twice in a block")
+	}
+	// Epoch boundary block for the next epoch
+	ebb = newTestEpochBoundaryBlock(t, prevHash, 1, 6)
+	if err := validator.ValidateBlock(ebb); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestByronChainValidatorTestnet(t *testing.T) {
+	mainBlockCbor, err := hex.DecodeString(testByronMainBlockHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	mainBlock, err := byron.NewByronMainBlockFromCbor(mainBlockCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Build the genesis from the block issuer and its delegation certificate, which was issued in
+	// the genesis for the legacy testnet
+	sig, err := mainBlock.BlockHeader.Signature()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	stakeholderId, err := byron.ByronStakeholderId(sig.Certificate.IssuerKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	genesis := byron.ByronGenesis{
+		ProtocolConsts: byron.ByronGenesisProtocolConsts{
+			K:             2160,
+			ProtocolMagic: 1097911063,
+		},
+		BootStakeholders: map[string]int{
+			stakeholderId.String(): 1,
+		},
+		HeavyDelegation: map[string]byron.ByronGenesisHeavyDelegation{
+			stakeholderId.String(): {
+				Cert:       hex.EncodeToString(sig.Certificate.Signature),
+				DelegatePk: base64.StdEncoding.EncodeToString(sig.Certificate.DelegateKey),
+				IssuerPk:   base64.StdEncoding.EncodeToString(sig.Certificate.IssuerKey),
+			},
+		},
+	}
+	genesisHash, err := hex.DecodeString("96fceff972c2c06bd3bb5243c39215333be6d56aaf4823073dca31afe5038471")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	validator, err := byron.NewByronChainValidator(genesis, common.NewBlake2b256(genesisHash))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The first epoch boundary block follows the genesis
+	ebbHex, err := os.ReadFile(
+		"../../protocol/chainsync/testdata/byron_ebb_testnet_8f8602837f7c6f8b8867dd1cbc1842cf51a27eaed2c70ef48325d00f8efb320f.hex",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ebbCbor, err := hex.DecodeString(strings.TrimSpace(string(ebbHex)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ebb, err := byron.NewByronEpochBoundaryBlockFromCbor(ebbCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := validator.ValidateBlock(ebb); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The main block is the 4th block in the epoch, so continue from the block before it
+	validator.SetChainTip(mainBlock.BlockHeader.PrevBlock, 0, 3)
+	if err := validator.ValidateBlock(mainBlock); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,474 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package byron
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// Signing tags, which are followed by the CBOR-encoded protocol magic
+	byronSignTagBlock       = 0x09
+	byronSignTagCertificate = 0x0a
+
+	ByronBlockSignatureTypeSignature      = 0
+	ByronBlockSignatureTypeLightDelegated = 1
+	ByronBlockSignatureTypeHeavyDelegated = 2
+
+	ByronSscPayloadTypeCommitments  = 0
+	ByronSscPayloadTypeOpenings     = 1
+	ByronSscPayloadTypeShares       = 2
+	ByronSscPayloadTypeCertificates = 3
+)
+
+// ByronDelegationCertificate is a heavyweight delegation certificate, which allows the delegate key
+// to sign blocks on behalf of the issuer key
+type ByronDelegationCertificate struct {
+	cbor.StructAsArray
+	Epoch       uint64
+	IssuerKey   []byte
+	DelegateKey []byte
+	Signature   []byte
+}
+
+// Verify checks the signature of the certificate by the issuer key
+func (c ByronDelegationCertificate) Verify(protocolMagic uint32) error {
+	if len(c.IssuerKey) != ByronExtendedPublicKeySize {
+		return fmt.Errorf("invalid issuer key length: %d", len(c.IssuerKey))
+	}
+	if len(c.DelegateKey) != ByronExtendedPublicKeySize {
+		return fmt.Errorf("invalid delegate key length: %d", len(c.DelegateKey))
+	}
+	if len(c.Signature) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid signature length: %d", len(c.Signature))
+	}
+	epochCbor, err := cbor.Encode(c.Epoch)
+	if err != nil {
+		return err
+	}
+	// The issuer signs a CBOR bytestring containing the delegate key and epoch
+	certData, err := cbor.Encode(slices.Concat([]byte("00"), c.DelegateKey, epochCbor))
+	if err != nil {
+		return err
+	}
+	sigData, err := byronSignTagData(byronSignTagCertificate, protocolMagic)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(
+		c.IssuerKey[:ed25519.PublicKeySize],
+		slices.Concat(sigData, certData),
+		c.Signature,
+	) {
+		return errors.New("invalid delegation certificate signature")
+	}
+	return nil
+}
+
+// ByronStakeholderId returns the stakeholder ID for a public key, which is used to identify genesis
+// keys in the genesis config
+func ByronStakeholderId(pubKey []byte) (common.Blake2b224, error) {
+	keyCbor, err := cbor.Encode(pubKey)
+	if err != nil {
+		return common.Blake2b224{}, err
+	}
+	sha3Sum := sha3.Sum256(keyCbor)
+	return common.Blake2b224Hash(sha3Sum[:]), nil
+}
+
+// ByronBlockSignature is the signature for a main block header, which is made by a delegate key on
+// behalf of a genesis key
+type ByronBlockSignature struct {
+	Certificate ByronDelegationCertificate
+	Signature   []byte
+}
+
+func (s *ByronBlockSignature) UnmarshalCBOR(data []byte) error {
+	id, err := cbor.DecodeIdFromList(data)
+	if err != nil {
+		return err
+	}
+	switch id {
+	case ByronBlockSignatureTypeHeavyDelegated:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type      uint
+			Signature struct {
+				cbor.StructAsArray
+				Certificate ByronDelegationCertificate
+				Signature   []byte
+			}
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		s.Certificate = tmpData.Signature.Certificate
+		s.Signature = tmpData.Signature.Signature
+	default:
+		return fmt.Errorf("unsupported Byron block signature type: %d", id)
+	}
+	return nil
+}
+
+// byronMainBlockHeaderRaw provides access to the original CBOR of the header fields, which are
+// needed to rebuild the signed data
+type byronMainBlockHeaderRaw struct {
+	cbor.StructAsArray
+	ProtocolMagic cbor.RawMessage
+	PrevBlock     cbor.RawMessage
+	BodyProof     cbor.RawMessage
+	ConsensusData struct {
+		cbor.StructAsArray
+		SlotId     cbor.RawMessage
+		PubKey     cbor.RawMessage
+		Difficulty cbor.RawMessage
+		BlockSig   cbor.RawMessage
+	}
+	ExtraData cbor.RawMessage
+}
+
+// Signature returns the block signature and the delegation certificate of the signer
+func (h *ByronMainBlockHeader) Signature() (*ByronBlockSignature, error) {
+	var rawHeader byronMainBlockHeaderRaw
+	if _, err := cbor.Decode(h.Cbor(), &rawHeader); err != nil {
+		return nil, err
+	}
+	var ret ByronBlockSignature
+	if _, err := cbor.Decode(rawHeader.ConsensusData.BlockSig, &ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// VerifySignature checks that the header was signed by the delegate key from the delegation
+// certificate, and that the certificate was issued by the block issuer. It does not check that the
+// issuer is a genesis key
+func (h *ByronMainBlockHeader) VerifySignature() error {
+	var rawHeader byronMainBlockHeaderRaw
+	if _, err := cbor.Decode(h.Cbor(), &rawHeader); err != nil {
+		return err
+	}
+	var sig ByronBlockSignature
+	if _, err := cbor.Decode(rawHeader.ConsensusData.BlockSig, &sig); err != nil {
+		return err
+	}
+	cert := sig.Certificate
+	if !bytes.Equal(cert.IssuerKey, h.ConsensusData.PubKey) {
+		return errors.New("delegation certificate issuer does not match block issuer")
+	}
+	if err := cert.Verify(h.ProtocolMagic); err != nil {
+		return err
+	}
+	if len(sig.Signature) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid block signature length: %d", len(sig.Signature))
+	}
+	tagData, err := byronSignTagData(byronSignTagBlock, h.ProtocolMagic)
+	if err != nil {
+		return err
+	}
+	// The delegate signs the issuer key and the header without the protocol magic and signature
+	sigData := slices.Concat(
+		[]byte("01"),
+		cert.IssuerKey,
+		tagData,
+		// Header list of 5 items
+		[]byte{0x85},
+		rawHeader.PrevBlock,
+		rawHeader.BodyProof,
+		rawHeader.ConsensusData.SlotId,
+		rawHeader.ConsensusData.Difficulty,
+		rawHeader.ExtraData,
+	)
+	if !ed25519.Verify(cert.DelegateKey[:ed25519.PublicKeySize], sigData, sig.Signature) {
+		return errors.New("invalid block signature")
+	}
+	return nil
+}
+
+// ByronBlockProof contains the hashes of the main block body payloads
+type ByronBlockProof struct {
+	cbor.StructAsArray
+	TxProof  ByronTxProof
+	SscProof ByronSscProof
+	DlgProof common.Blake2b256
+	UpdProof common.Blake2b256
+}
+
+type ByronTxProof struct {
+	cbor.StructAsArray
+	TxCount       uint32
+	MerkleRoot    common.Blake2b256
+	WitnessesHash common.Blake2b256
+}
+
+// ByronSscProof contains the hashes of the shared seed computation payload. The payload hash is not
+// present for a certificates payload
+type ByronSscProof struct {
+	Type             uint
+	PayloadHash      common.Blake2b256
+	CertificatesHash common.Blake2b256
+}
+
+func (p *ByronSscProof) UnmarshalCBOR(data []byte) error {
+	id, err := cbor.DecodeIdFromList(data)
+	if err != nil {
+		return err
+	}
+	switch id {
+	case ByronSscPayloadTypeCommitments, ByronSscPayloadTypeOpenings, ByronSscPayloadTypeShares:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type             uint
+			PayloadHash      common.Blake2b256
+			CertificatesHash common.Blake2b256
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		p.Type = tmpData.Type
+		p.PayloadHash = tmpData.PayloadHash
+		p.CertificatesHash = tmpData.CertificatesHash
+	case ByronSscPayloadTypeCertificates:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type             uint
+			CertificatesHash common.Blake2b256
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		p.Type = tmpData.Type
+		p.CertificatesHash = tmpData.CertificatesHash
+	default:
+		return fmt.Errorf("unknown Byron SSC proof type: %d", id)
+	}
+	return nil
+}
+
+// Proof decodes the body proof from the header
+func (h *ByronMainBlockHeader) Proof() (*ByronBlockProof, error) {
+	var rawHeader byronMainBlockHeaderRaw
+	if _, err := cbor.Decode(h.Cbor(), &rawHeader); err != nil {
+		return nil, err
+	}
+	var ret ByronBlockProof
+	if _, err := cbor.Decode(rawHeader.BodyProof, &ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// byronMainBlockBodyRaw provides access to the original CBOR of the body payloads, which is what
+// the body proof hashes are calculated from
+type byronMainBlockBodyRaw struct {
+	cbor.StructAsArray
+	TxPayload []struct {
+		cbor.StructAsArray
+		Transaction cbor.RawMessage
+		Witnesses   cbor.RawMessage
+	}
+	SscPayload cbor.RawMessage
+	DlgPayload cbor.RawMessage
+	UpdPayload cbor.RawMessage
+}
+
+// Proof calculates the body proof for the block body
+func (b *ByronMainBlockBody) Proof() (*ByronBlockProof, error) {
+	var rawBody byronMainBlockBodyRaw
+	if _, err := cbor.Decode(b.Cbor(), &rawBody); err != nil {
+		return nil, err
+	}
+	// Transactions
+	txs := make([][]byte, len(rawBody.TxPayload))
+	// The witnesses are hashed as an indefinite-length list of the witness list for each TX
+	witnesses := []byte{0x9f}
+	for idx, payload := range rawBody.TxPayload {
+		txs[idx] = payload.Transaction
+		witnesses = append(witnesses, payload.Witnesses...)
+	}
+	witnesses = append(witnesses, 0xff)
+	ret := &ByronBlockProof{
+		TxProof: ByronTxProof{
+			TxCount:       uint32(len(txs)),
+			MerkleRoot:    byronMerkleRoot(txs),
+			WitnessesHash: common.Blake2b256Hash(witnesses),
+		},
+		DlgProof: common.Blake2b256Hash(rawBody.DlgPayload),
+		UpdProof: common.Blake2b256Hash(rawBody.UpdPayload),
+	}
+	// Shared seed computation
+	var sscPayload []cbor.RawMessage
+	if _, err := cbor.Decode(rawBody.SscPayload, &sscPayload); err != nil {
+		return nil, err
+	}
+	if len(sscPayload) < 2 {
+		return nil, errors.New("invalid Byron SSC payload")
+	}
+	if _, err := cbor.Decode(sscPayload[0], &ret.SscProof.Type); err != nil {
+		return nil, err
+	}
+	certsRaw := sscPayload[len(sscPayload)-1]
+	if ret.SscProof.Type != ByronSscPayloadTypeCertificates {
+		if len(sscPayload) != 3 {
+			return nil, errors.New("invalid Byron SSC payload")
+		}
+		ret.SscProof.PayloadHash = common.Blake2b256Hash(sscPayload[1])
+	}
+	certsHash, err := byronVssCertificatesHash(certsRaw)
+	if err != nil {
+		return nil, err
+	}
+	ret.SscProof.CertificatesHash = certsHash
+	return ret, nil
+}
+
+// byronMerkleRoot calculates the root of the Merkle tree for the transactions in a block. Leaves are
+// prefixed with 0x00 and branches with 0x01 before hashing, and the tree is split at the largest
+// power of two smaller than the number of items
+func byronMerkleRoot(items [][]byte) common.Blake2b256 {
+	if len(items) == 0 {
+		return common.Blake2b256Hash(nil)
+	}
+	if len(items) == 1 {
+		return common.Blake2b256Hash(slices.Concat([]byte{0x00}, items[0]))
+	}
+	split := 1
+	for split*2 < len(items) {
+		split *= 2
+	}
+	left := byronMerkleRoot(items[:split])
+	right := byronMerkleRoot(items[split:])
+	return common.Blake2b256Hash(slices.Concat([]byte{0x01}, left.Bytes(), right.Bytes()))
+}
+
+// byronVssCertificatesHash calculates the hash for the VSS certificates in an SSC payload. The
+// certificates are sent as a set, but they are hashed as a map keyed by the stakeholder ID of the
+// signing key
+func byronVssCertificatesHash(data []byte) (common.Blake2b256, error) {
+	var certSet cbor.RawTag
+	var certsRaw []cbor.RawMessage
+	if _, err := cbor.Decode(data, &certSet); err == nil {
+		data = certSet.Content
+	}
+	if _, err := cbor.Decode(data, &certsRaw); err != nil {
+		return common.Blake2b256{}, err
+	}
+	type certEntry struct {
+		stakeholderId common.Blake2b224
+		cert          []byte
+	}
+	entries := make([]certEntry, 0, len(certsRaw))
+	for _, certRaw := range certsRaw {
+		var cert struct {
+			cbor.StructAsArray
+			VssKey      cbor.RawMessage
+			ExpiryEpoch uint64
+			Signature   []byte
+			SigningKey  []byte
+		}
+		if _, err := cbor.Decode(certRaw, &cert); err != nil {
+			return common.Blake2b256{}, err
+		}
+		stakeholderId, err := ByronStakeholderId(cert.SigningKey)
+		if err != nil {
+			return common.Blake2b256{}, err
+		}
+		entries = append(entries, certEntry{stakeholderId: stakeholderId, cert: certRaw})
+	}
+	slices.SortFunc(entries, func(a, b certEntry) int {
+		return bytes.Compare(a.stakeholderId.Bytes(), b.stakeholderId.Bytes())
+	})
+	// The map header uses the same encoding as an unsigned integer with a different major type
+	ret, err := cbor.Encode(uint64(len(entries)))
+	if err != nil {
+		return common.Blake2b256{}, err
+	}
+	ret[0] |= cbor.CborTypeMap
+	for _, entry := range entries {
+		keyCbor, err := cbor.Encode(entry.stakeholderId.Bytes())
+		if err != nil {
+			return common.Blake2b256{}, err
+		}
+		ret = slices.Concat(ret, keyCbor, entry.cert)
+	}
+	return common.Blake2b256Hash(ret), nil
+}
+
+// VerifyBodyProof checks that the body proof in the header matches the block body
+func (b *ByronMainBlock) VerifyBodyProof() error {
+	headerProof, err := b.BlockHeader.Proof()
+	if err != nil {
+		return err
+	}
+	bodyProof, err := b.Body.Proof()
+	if err != nil {
+		return err
+	}
+	if headerProof.TxProof != bodyProof.TxProof {
+		return errors.New("TX proof does not match block body")
+	}
+	if headerProof.SscProof != bodyProof.SscProof {
+		return errors.New("SSC proof does not match block body")
+	}
+	if headerProof.DlgProof != bodyProof.DlgProof {
+		return errors.New("delegation proof does not match block body")
+	}
+	if headerProof.UpdProof != bodyProof.UpdProof {
+		return errors.New("update proof does not match block body")
+	}
+	return nil
+}
+
+// VerifyBodyProof checks that the body proof in the header matches the hash of the block body
+func (b *ByronEpochBoundaryBlock) VerifyBodyProof() error {
+	var rawBlock struct {
+		cbor.StructAsArray
+		Header struct {
+			cbor.StructAsArray
+			ProtocolMagic cbor.RawMessage
+			PrevBlock     cbor.RawMessage
+			BodyProof     common.Blake2b256
+			ConsensusData cbor.RawMessage
+			ExtraData     cbor.RawMessage
+		}
+		Body  cbor.RawMessage
+		Extra cbor.RawMessage
+	}
+	if _, err := cbor.Decode(b.Cbor(), &rawBlock); err != nil {
+		return err
+	}
+	headerProof := rawBlock.Header.BodyProof
+	if headerProof != common.Blake2b256Hash(rawBlock.Body) {
+		return errors.New("body proof does not match block body")
+	}
+	return nil
+}
+
+// byronSignTagData returns the signing tag followed by the CBOR-encoded protocol magic
+func byronSignTagData(signTag byte, protocolMagic uint32) ([]byte, error) {
+	magicCbor, err := cbor.Encode(protocolMagic)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat([]byte{signTag}, magicCbor), nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package byron_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/byron"
+)
+
+// Byron main block f38aa5e8cf0b47d1ffa8b2385aa2d43882282db2ffd5ac0e3dadec1a6f2ecf08 from the legacy testnet
+const testByronMainBlockHex = "83851A4170CB175820067E773E6FFD66EA06F7F1C967E18A1EE0916797F6A1C1ABDF410379EB8B1DBE84830058200E5751C026E543B2E8AB2EB06099DAA1D1E5DF47778F7787FAAB45CDF12FE3A85820AFC0DA64183BF2664F3D4EEC7238D524BA607FAEEAB24FC100EB861DBA69971B8300582025777ACA9E4A73D48FC73B4F961D345B06D4A6F349CB7916570D35537D53479F5820D36A2619A672494604E11BB447CBCF5231E9F2BA25C2169177EDC941BD50AD6C5820AFC0DA64183BF2664F3D4EEC7238D524BA607FAEEAB24FC100EB861DBA69971B58204E66280CD94D591072349BEC0A3090A53AA945562EFB6D08D56E53654B0E409884820019040A5840CB51D29AB94E50D9A144D4F426564CEC700DEE4D9E857AACF91D3B689374D81F742A452818CF2489C16DFC186F6E9C76B7DF40845B7C450785F02D8809767575810482028284005840CB51D29AB94E50D9A144D4F426564CEC700DEE4D9E857AACF91D3B689374D81F742A452818CF2489C16DFC186F6E9C76B7DF40845B7C450785F02D880976757558407EC249D890D0AAF9A81207960C163AE2D6AC5E715CA6B96D5860E50D9F2B2B2A1D568FAA87C9CC8BFD433A3224A96EC5D101B4B6E9DB008DB8857F49BAE294B25840A304BF45B44FBCCC78F54B9014A6B2D4354631EBFF235AEBB2E71A15BDD582BE3794384C1BA713B99EF05766E92B8F438B2FC5AF349F2BB16E85E3780AA84C07584017A846A92477D3468690D97E28A44811BE4F8E3FDE79E478E4DCC432B13370C669C124BE03015EF2B1F121B807FFE74B1A92A4247EA8A22F9EA30D5DF671CB068483000000826A63617264616E6F2D736C00A058204BA92AA320C60ACC9AD7B9A64F2EDA55C4D2EC28E604FAF186708B4F0C4E8EDF849FFF8300D9010280D90102809FFF82809FFF81A0"
+
+func TestByronMainBlockVerify(t *testing.T) {
+	blockCbor, err := hex.DecodeString(testByronMainBlockHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	block, err := byron.NewByronMainBlockFromCbor(blockCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := block.BlockHeader.VerifySignature(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := block.VerifyBodyProof(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	sig, err := block.BlockHeader.Signature()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := sig.Certificate.Verify(block.BlockHeader.ProtocolMagic); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The certificate is only valid for the network it was issued on
+	if err := sig.Certificate.Verify(764824073); err == nil {
+		t.Fatalf("did not get expected error for wrong protocol magic")
+	}
+	// Modifying the header invalidates the signature
+	modifiedCbor, err := hex.DecodeString(testByronMainBlockHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Last byte of the header extra proof
+	modifiedCbor[0x285] ^= 0xff
+	modifiedBlock, err := byron.NewByronMainBlockFromCbor(modifiedCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := modifiedBlock.BlockHeader.VerifySignature(); err == nil {
+		t.Fatalf("did not get expected error for modified header")
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package byron
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const (
+	ByronTransactionWitnessTypePubKey = 0
+	ByronTransactionWitnessTypeScript = 1
+	ByronTransactionWitnessTypeRedeem = 2
+)
+
+const (
+	// Signing tags, which are followed by the CBOR-encoded protocol magic
+	byronSignTagTx       = 0x01
+	byronSignTagRedeemTx = 0x02
+)
+
+// ByronExtendedPublicKeySize is the size of an extended public key, which is an Ed25519 public key
+// followed by a 32 byte chain code
+const ByronExtendedPublicKeySize = 64
+
+// ByronTransactionWitness is a witness for a single input of a Byron transaction. The fields that
+// are populated depend on the witness type
+type ByronTransactionWitness struct {
+	WitnessType uint
+	// PublicKey is an extended public key for a pubkey witness, or an Ed25519 public key for a
+	// redeem witness
+	PublicKey []byte
+	Signature []byte
+	Validator ByronScript
+	Redeemer  ByronScript
+	data      []byte
+}
+
+// ByronScript is a versioned script used by a Byron script witness
+type ByronScript struct {
+	cbor.StructAsArray
+	Version uint16
+	Script  []byte
+}
+
+func (w *ByronTransactionWitness) UnmarshalCBOR(data []byte) error {
+	// [type, #6.24(bytes)]
+	var tmpData struct {
+		cbor.StructAsArray
+		Type uint
+		Data cbor.WrappedCbor
+	}
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	w.WitnessType = tmpData.Type
+	w.data = tmpData.Data.Bytes()
+	switch tmpData.Type {
+	case ByronTransactionWitnessTypePubKey, ByronTransactionWitnessTypeRedeem:
+		var keySig struct {
+			cbor.StructAsArray
+			PublicKey []byte
+			Signature []byte
+		}
+		if _, err := cbor.Decode(w.data, &keySig); err != nil {
+			return err
+		}
+		w.PublicKey = keySig.PublicKey
+		w.Signature = keySig.Signature
+	case ByronTransactionWitnessTypeScript:
+		var scripts struct {
+			cbor.StructAsArray
+			Validator ByronScript
+			Redeemer  ByronScript
+		}
+		if _, err := cbor.Decode(w.data, &scripts); err != nil {
+			return err
+		}
+		w.Validator = scripts.Validator
+		w.Redeemer = scripts.Redeemer
+	default:
+		return fmt.Errorf("unknown Byron transaction witness type: %d", tmpData.Type)
+	}
+	return nil
+}
+
+func (w *ByronTransactionWitness) MarshalCBOR() ([]byte, error) {
+	data := w.data
+	if data == nil {
+		var tmpData any
+		switch w.WitnessType {
+		case ByronTransactionWitnessTypePubKey, ByronTransactionWitnessTypeRedeem:
+			tmpData = []any{w.PublicKey, w.Signature}
+		case ByronTransactionWitnessTypeScript:
+			tmpData = []any{w.Validator, w.Redeemer}
+		default:
+			return nil, fmt.Errorf("unknown Byron transaction witness type: %d", w.WitnessType)
+		}
+		tmpCbor, err := cbor.Encode(tmpData)
+		if err != nil {
+			return nil, err
+		}
+		data = tmpCbor
+	}
+	return cbor.Encode(
+		[]any{
+			w.WitnessType,
+			cbor.WrappedCbor(data),
+		},
+	)
+}
+
+// Verify checks the signature of a pubkey or redeem witness against the transaction hash and the
+// protocol magic of the network. Script witnesses cannot be verified
+func (w ByronTransactionWitness) Verify(txHash common.Blake2b256, protocolMagic uint32) error {
+	var signTag byte
+	var pubKey []byte
+	switch w.WitnessType {
+	case ByronTransactionWitnessTypePubKey:
+		if len(w.PublicKey) != ByronExtendedPublicKeySize {
+			return fmt.Errorf("invalid extended public key length: %d", len(w.PublicKey))
+		}
+		signTag = byronSignTagTx
+		// An extended key signature verifies against the Ed25519 public key without the chain code
+		pubKey = w.PublicKey[:ed25519.PublicKeySize]
+	case ByronTransactionWitnessTypeRedeem:
+		if len(w.PublicKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid redeem public key length: %d", len(w.PublicKey))
+		}
+		signTag = byronSignTagRedeemTx
+		pubKey = w.PublicKey
+	case ByronTransactionWitnessTypeScript:
+		return errors.New("Byron script witnesses are not supported")
+	default:
+		return fmt.Errorf("unknown Byron transaction witness type: %d", w.WitnessType)
+	}
+	if len(w.Signature) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid signature length: %d", len(w.Signature))
+	}
+	sigData, err := byronSigData(signTag, txHash, protocolMagic)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pubKey, sigData, w.Signature) {
+		return errors.New("invalid Byron transaction witness signature")
+	}
+	return nil
+}
+
+// byronSigData builds the data signed by a Byron transaction witness, which is the signing tag
+// and CBOR-encoded protocol magic followed by the CBOR-encoded transaction hash
+func byronSigData(signTag byte, txHash common.Blake2b256, protocolMagic uint32) ([]byte, error) {
+	tagData, err := byronSignTagData(signTag, protocolMagic)
+	if err != nil {
+		return nil, err
+	}
+	hashCbor, err := cbor.Encode(txHash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(tagData, hashCbor), nil
+}
+
+// ByronTransactionWitnessSet provides the witnesses for a Byron transaction through the generic
+// witness set interface
+type ByronTransactionWitnessSet struct {
+	witnesses []ByronTransactionWitness
+}
+
+func NewByronTransactionWitnessSet(witnesses []ByronTransactionWitness) ByronTransactionWitnessSet {
+	return ByronTransactionWitnessSet{
+		witnesses: witnesses,
+	}
+}
+
+// Items returns the witnesses in the same order as the transaction inputs
+func (w ByronTransactionWitnessSet) Items() []ByronTransactionWitness {
+	return w.witnesses
+}
+
+func (w ByronTransactionWitnessSet) Vkey() []common.VkeyWitness {
+	// No vkey witnesses in Byron
+	return nil
+}
+
+func (w ByronTransactionWitnessSet) NativeScripts() []common.NativeScript {
+	// No native scripts in Byron
+	return nil
+}
+
+// Bootstrap returns the pubkey witnesses, with the extended public key split into the public key
+// and chain code. The address attributes are not available from a Byron witness
+func (w ByronTransactionWitnessSet) Bootstrap() []common.BootstrapWitness {
+	var ret []common.BootstrapWitness
+	for _, witness := range w.witnesses {
+		if witness.WitnessType != ByronTransactionWitnessTypePubKey ||
+			len(witness.PublicKey) != ByronExtendedPublicKeySize {
+			continue
+		}
+		ret = append(
+			ret,
+			common.BootstrapWitness{
+				PublicKey: witness.PublicKey[:ed25519.PublicKeySize],
+				Signature: witness.Signature,
+				ChainCode: witness.PublicKey[ed25519.PublicKeySize:],
+			},
+		)
+	}
+	return ret
+}
+
+func (w ByronTransactionWitnessSet) PlutusData() []cbor.Value {
+	// No plutus data in Byron
+	return nil
+}
+
+func (w ByronTransactionWitnessSet) PlutusV1Scripts() [][]byte {
+	// No plutus scripts in Byron
+	return nil
+}
+
+func (w ByronTransactionWitnessSet) PlutusV2Scripts() [][]byte {
+	// No plutus scripts in Byron
+	return nil
+}
+
+func (w ByronTransactionWitnessSet) PlutusV3Scripts() [][]byte {
+	// No plutus scripts in Byron
+	return nil
+}
+
+func (w ByronTransactionWitnessSet) Redeemers() common.TransactionWitnessRedeemers {
+	// No redeemers in Byron
+	return nil
+}
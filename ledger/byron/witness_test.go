@@ -0,0 +1,258 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package byron_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/byron"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const (
+	testByronProtocolMagic = 1097911063
+	testByronAddress       = "Ae2tdPwUPEYwFx4dmJheyNPPYXtvHbJLeCaA96o6Y2iiUL18cAt7AizN2zG"
+)
+
+// buildTestByronTx returns the CBOR for a Byron transaction with a single input and output
+func buildTestByronTx(t *testing.T) []byte {
+	inputCbor, err := cbor.Encode(
+		[]any{
+			bytes.Repeat([]byte{0xab}, 32),
+			uint32(1),
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	addr, err := common.NewAddress(testByronAddress)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	addrCbor, err := addr.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	txCbor, err := cbor.Encode(
+		[]any{
+			[]any{
+				[]any{0, cbor.WrappedCbor(inputCbor)},
+			},
+			[]any{
+				[]any{cbor.RawMessage(addrCbor), uint64(1_000_000)},
+			},
+			map[any]any{},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return txCbor
+}
+
+// signTestByronTx builds the CBOR for a Byron transaction with its witnesses, signing it with the
+// provided key using the specified witness type and signing tag
+func signTestByronTx(
+	t *testing.T,
+	txCbor []byte,
+	witnessType uint,
+	signTag byte,
+	privKey ed25519.PrivateKey,
+	pubKey []byte,
+) []byte {
+	txHash := common.Blake2b256Hash(txCbor)
+	// Signed data is the signing tag, protocol magic (as CBOR) and TX hash (as a CBOR bytestring)
+	sigData := slices.Concat(
+		[]byte{signTag, 0x1a, 0x41, 0x70, 0xcb, 0x17, 0x58, 0x20},
+		txHash.Bytes(),
+	)
+	witness := byron.ByronTransactionWitness{
+		WitnessType: witnessType,
+		PublicKey:   pubKey,
+		Signature:   ed25519.Sign(privKey, sigData),
+	}
+	txAuxCbor, err := cbor.Encode(
+		[]any{
+			cbor.RawMessage(txCbor),
+			[]any{&witness},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return txAuxCbor
+}
+
+func TestByronTransactionWitnessPubKey(t *testing.T) {
+	privKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x01}, 32))
+	chainCode := bytes.Repeat([]byte{0x02}, 32)
+	xpub := slices.Concat([]byte(privKey.Public().(ed25519.PublicKey)), chainCode)
+	txCbor := buildTestByronTx(t)
+	txAuxCbor := signTestByronTx(
+		t,
+		txCbor,
+		byron.ByronTransactionWitnessTypePubKey,
+		0x01,
+		privKey,
+		xpub,
+	)
+	tx, err := byron.NewByronTransactionFromCbor(txAuxCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The TX hash only covers the transaction and not the witnesses
+	expectedHash := common.Blake2b256Hash(txCbor)
+	if tx.Hash() != expectedHash.String() {
+		t.Fatalf("did not get expected TX hash: got %s, wanted %s", tx.Hash(), expectedHash.String())
+	}
+	witnessSet, ok := tx.Witnesses().(byron.ByronTransactionWitnessSet)
+	if !ok {
+		t.Fatalf("unexpected witness set type: %T", tx.Witnesses())
+	}
+	if len(witnessSet.Items()) != 1 {
+		t.Fatalf("did not get expected witness count: got %d, wanted 1", len(witnessSet.Items()))
+	}
+	bootstrap := tx.Witnesses().Bootstrap()
+	if len(bootstrap) != 1 {
+		t.Fatalf("did not get expected bootstrap witness count: got %d, wanted 1", len(bootstrap))
+	}
+	if !bytes.Equal(bootstrap[0].PublicKey, xpub[:32]) ||
+		!bytes.Equal(bootstrap[0].ChainCode, chainCode) {
+		t.Fatalf("did not get expected bootstrap witness: %x", bootstrap[0].PublicKey)
+	}
+	if err := tx.VerifyWitnesses(testByronProtocolMagic); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tx.VerifyWitnesses(764824073); err == nil {
+		t.Fatalf("did not get expected error for wrong protocol magic")
+	}
+	// Round-trip the witness
+	witnessCbor, err := cbor.Encode(&witnessSet.Items()[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Contains(txAuxCbor, witnessCbor) {
+		t.Fatalf("witness CBOR did not round-trip: %x", witnessCbor)
+	}
+}
+
+func TestByronTransactionWitnessRedeem(t *testing.T) {
+	privKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x03}, 32))
+	pubKey := []byte(privKey.Public().(ed25519.PublicKey))
+	txCbor := buildTestByronTx(t)
+	txAuxCbor := signTestByronTx(
+		t,
+		txCbor,
+		byron.ByronTransactionWitnessTypeRedeem,
+		0x02,
+		privKey,
+		pubKey,
+	)
+	tx, err := byron.NewByronTransactionFromCbor(txAuxCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tx.VerifyWitnesses(testByronProtocolMagic); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Redeem witnesses are not bootstrap witnesses
+	if len(tx.Witnesses().Bootstrap()) != 0 {
+		t.Fatalf("did not expect bootstrap witnesses")
+	}
+	// A redeem signature is not valid for a pubkey witness
+	txAuxCbor = signTestByronTx(
+		t,
+		txCbor,
+		byron.ByronTransactionWitnessTypeRedeem,
+		0x01,
+		privKey,
+		pubKey,
+	)
+	tx, err = byron.NewByronTransactionFromCbor(txAuxCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tx.VerifyWitnesses(testByronProtocolMagic); err == nil {
+		t.Fatalf("did not get expected error for wrong signing tag")
+	}
+}
+
+// Fixed witnessed transaction for the legacy testnet, so that the signed data is checked against stored
+// bytes rather than rebuilt alongside the implementation
+const (
+	testByronTxAuxHex = "8283818200d8185824825820abababababababababababababababababababababababababababababababab01818282d818582183581c04865e42d2373addbebd5d2acf81c760c848970142889f7ee763091ba0001af01916d51a000f4240a0818200d81858858258406e7a1cdd29b0b78fd13af4c5598feff4ef2a97166e3ca6f2e4fbfccd80505bf10606060606060606060606060606060606060606060606060606060606060606584059277bfb5c15379dcee5c2a98ea21445b4ed50e31cbf05bd2708f370916e762ed32f34d9a914977a1511e27bd5a70c1f9871d71eeb27f8544df7507013fafc01"
+	testByronTxHash   = "f84c67350c963b62450df9a785852e0c44f0a1b52c7c381c326642f95bf92dc7"
+)
+
+func TestByronTransactionWitnessFixed(t *testing.T) {
+	txAuxCbor, err := hex.DecodeString(testByronTxAuxHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tx, err := byron.NewByronTransactionFromCbor(txAuxCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tx.Hash() != testByronTxHash {
+		t.Fatalf("did not get expected TX hash: got %s, wanted %s", tx.Hash(), testByronTxHash)
+	}
+	if err := tx.VerifyWitnesses(testByronProtocolMagic); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tx.VerifyWitnesses(764824073); err == nil {
+		t.Fatalf("did not get expected error for wrong protocol magic")
+	}
+	// Flipping the last byte of the signature invalidates the witness
+	txAuxCbor[len(txAuxCbor)-1] ^= 0xff
+	tx, err = byron.NewByronTransactionFromCbor(txAuxCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tx.VerifyWitnesses(testByronProtocolMagic); err == nil {
+		t.Fatalf("did not get expected error for modified signature")
+	}
+}
+
+func TestByronTransactionWithoutWitnesses(t *testing.T) {
+	txCbor := buildTestByronTx(t)
+	tx, err := byron.NewByronTransactionFromCbor(txCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tx.Inputs()) != 1 || len(tx.Outputs()) != 1 {
+		t.Fatalf("did not get expected inputs/outputs")
+	}
+	if err := tx.VerifyWitnesses(testByronProtocolMagic); err == nil {
+		t.Fatalf("did not get expected error for missing witness")
+	}
+	utxorpcTx, err := tx.Utxorpc()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if hex.EncodeToString(utxorpcTx.Hash) != tx.Hash() {
+		t.Fatalf("did not get expected utxorpc TX hash: got %x, wanted %s", utxorpcTx.Hash, tx.Hash())
+	}
+	if len(utxorpcTx.Inputs) != 1 || len(utxorpcTx.Outputs) != 1 {
+		t.Fatalf("did not get expected utxorpc inputs/outputs")
+	}
+	if utxorpcTx.Outputs[0].Coin != 1_000_000 {
+		t.Fatalf("did not get expected utxorpc output amount: %d", utxorpcTx.Outputs[0].Coin)
+	}
+}
@@ -58,6 +58,7 @@ type Address struct {
 	networkId        uint8
 	paymentAddress   []byte
 	stakingAddress   []byte
+	pointer          *AddressPointer
 	extraData        []byte
 	byronAddressType uint64
 	byronAddressAttr ByronAddressAttributes
@@ -110,6 +111,21 @@ func NewAddressFromParts(
 			len(stakingAddr),
 		)
 	}
+	if addrType == AddressTypeKeyPointer ||
+		addrType == AddressTypeScriptPointer {
+		return Address{}, fmt.Errorf(
+			"pointer addresses must be created with NewPointerAddress",
+		)
+	}
+	// The hash for stake addresses is the staking part of the address
+	if addrType == AddressTypeNoneKey || addrType == AddressTypeNoneScript {
+		return Address{
+			addressType:    addrType,
+			networkId:      networkId,
+			paymentAddress: make([]byte, 0),
+			stakingAddress: paymentAddr[:],
+		}, nil
+	}
 	return Address{
 		addressType:    addrType,
 		networkId:      networkId,
@@ -118,6 +134,116 @@ func NewAddressFromParts(
 	}, nil
 }
 
+// NewBaseAddress returns a base address with the provided payment and stake credentials
+func NewBaseAddress(
+	networkId uint8,
+	payment StakeCredential,
+	stake StakeCredential,
+) (Address, error) {
+	addrType := uint8(AddressTypeKeyKey)
+	if payment.CredType == StakeCredentialTypeScriptHash {
+		addrType |= 0b0001
+	}
+	if stake.CredType == StakeCredentialTypeScriptHash {
+		addrType |= 0b0010
+	}
+	if err := validateAddressParts(networkId, payment, stake); err != nil {
+		return Address{}, err
+	}
+	return NewAddressFromParts(
+		addrType,
+		networkId,
+		payment.Credential,
+		stake.Credential,
+	)
+}
+
+// NewPointerAddress returns a pointer address with the provided payment credential and pointer to the stake
+// registration certificate
+func NewPointerAddress(
+	networkId uint8,
+	payment StakeCredential,
+	pointer AddressPointer,
+) (Address, error) {
+	addrType := uint8(AddressTypeKeyPointer)
+	if payment.CredType == StakeCredentialTypeScriptHash {
+		addrType = AddressTypeScriptPointer
+	}
+	if err := validateAddressParts(networkId, payment); err != nil {
+		return Address{}, err
+	}
+	return Address{
+		addressType:    addrType,
+		networkId:      networkId,
+		paymentAddress: payment.Credential[:],
+		pointer:        &pointer,
+	}, nil
+}
+
+// NewEnterpriseAddress returns an enterprise address, which has a payment credential and no stake credential
+func NewEnterpriseAddress(
+	networkId uint8,
+	payment StakeCredential,
+) (Address, error) {
+	addrType := uint8(AddressTypeKeyNone)
+	if payment.CredType == StakeCredentialTypeScriptHash {
+		addrType = AddressTypeScriptNone
+	}
+	if err := validateAddressParts(networkId, payment); err != nil {
+		return Address{}, err
+	}
+	return NewAddressFromParts(addrType, networkId, payment.Credential, nil)
+}
+
+// NewRewardAddress returns a reward (stake) address for the provided stake credential
+func NewRewardAddress(
+	networkId uint8,
+	stake StakeCredential,
+) (Address, error) {
+	addrType := uint8(AddressTypeNoneKey)
+	if stake.CredType == StakeCredentialTypeScriptHash {
+		addrType = AddressTypeNoneScript
+	}
+	if err := validateAddressParts(networkId, stake); err != nil {
+		return Address{}, err
+	}
+	return NewAddressFromParts(addrType, networkId, stake.Credential, nil)
+}
+
+// NewScriptAddress returns an address that is locked by the script with the provided hash, such as the result of
+// NativeScript.Hash or ScriptHash for a Plutus script. A base address is returned if a stake credential is
+// provided, and an enterprise address otherwise
+func NewScriptAddress(
+	networkId uint8,
+	scriptHash Blake2b224,
+	stake *StakeCredential,
+) (Address, error) {
+	payment := NewScriptCredential(scriptHash)
+	if stake == nil {
+		return NewEnterpriseAddress(networkId, payment)
+	}
+	return NewBaseAddress(networkId, payment, *stake)
+}
+
+func validateAddressParts(networkId uint8, creds ...StakeCredential) error {
+	if networkId > AddressHeaderNetworkMask {
+		return fmt.Errorf("invalid network ID: %d", networkId)
+	}
+	for _, cred := range creds {
+		if cred.CredType != StakeCredentialTypeAddrKeyHash &&
+			cred.CredType != StakeCredentialTypeScriptHash {
+			return fmt.Errorf("invalid credential type: %d", cred.CredType)
+		}
+		if len(cred.Credential) != AddressHashSize {
+			return fmt.Errorf(
+				"invalid credential hash length: %d",
+				len(cred.Credential),
+			)
+		}
+	}
+	return nil
+}
+
 func NewByronAddressFromParts(
 	byronAddrType uint64,
 	paymentAddr []byte,
@@ -207,21 +333,20 @@ func (a *Address) populateFromBytes(data []byte) error {
 		return nil
 	}
 	// Check length
-	// We exclude a few address types without fixed sizes that we don't properly support yet
-	if a.addressType != AddressTypeKeyPointer &&
+	dataLen := len(data)
+	// Addresses must be at least the address hash size plus header byte
+	if dataLen < (AddressHashSize + 1) {
+		return fmt.Errorf("invalid address length: %d", dataLen)
+	}
+	// Check bounds of second part if the address type is supposed to have one
+	// Pointer addresses have a variable length pointer instead
+	if a.addressType != AddressTypeKeyNone &&
+		a.addressType != AddressTypeScriptNone &&
+		a.addressType != AddressTypeKeyPointer &&
 		a.addressType != AddressTypeScriptPointer {
-		dataLen := len(data)
-		// Addresses must be at least the address hash size plus header byte
-		if dataLen < (AddressHashSize + 1) {
-			return fmt.Errorf("invalid address length: %d", dataLen)
-		}
-		// Check bounds of second part if the address type is supposed to have one
-		if a.addressType != AddressTypeKeyNone &&
-			a.addressType != AddressTypeScriptNone {
-			if dataLen > (AddressHashSize + 1) {
-				if dataLen < (AddressHashSize + AddressHashSize + 1) {
-					return fmt.Errorf("invalid address length: %d", dataLen)
-				}
+		if dataLen > (AddressHashSize + 1) {
+			if dataLen < (AddressHashSize + AddressHashSize + 1) {
+				return fmt.Errorf("invalid address length: %d", dataLen)
 			}
 		}
 	}
@@ -229,8 +354,16 @@ func (a *Address) populateFromBytes(data []byte) error {
 	payload := data[1:]
 	a.paymentAddress = payload[:AddressHashSize]
 	payload = payload[AddressHashSize:]
-	if a.addressType != AddressTypeKeyNone &&
-		a.addressType != AddressTypeScriptNone {
+	switch a.addressType {
+	case AddressTypeKeyNone, AddressTypeScriptNone:
+	case AddressTypeKeyPointer, AddressTypeScriptPointer:
+		// Pointers that can't be decoded are kept as extra data, since they're still allowed on chain
+		pointer, pointerLen, err := decodeAddressPointer(payload)
+		if err == nil {
+			a.pointer = &pointer
+			payload = payload[pointerLen:]
+		}
+	default:
 		if len(payload) >= AddressHashSize {
 			a.stakingAddress = payload[:AddressHashSize]
 			payload = payload[AddressHashSize:]
@@ -269,7 +402,10 @@ func (a *Address) UnmarshalCBOR(data []byte) error {
 }
 
 func (a *Address) MarshalCBOR() ([]byte, error) {
-	addrBytes := a.Bytes()
+	addrBytes, err := a.Bytes()
+	if err != nil {
+		return nil, err
+	}
 	if a.addressType == AddressTypeByron {
 		return addrBytes, nil
 	}
@@ -356,6 +492,49 @@ func (a *Address) StakeKeyHash() Blake2b224 {
 	return Blake2b224(a.stakingAddress[:])
 }
 
+// PaymentCredential returns the payment credential of the address, or nil if the address does not have one
+func (a Address) PaymentCredential() *StakeCredential {
+	if a.addressType > AddressTypeScriptNone ||
+		len(a.paymentAddress) != AddressHashSize {
+		return nil
+	}
+	credType := uint(StakeCredentialTypeAddrKeyHash)
+	// The lowest bit of the address type indicates a script payment credential
+	if a.addressType&0b0001 != 0 {
+		credType = StakeCredentialTypeScriptHash
+	}
+	return &StakeCredential{
+		CredType:   credType,
+		Credential: a.paymentAddress[:],
+	}
+}
+
+// StakeCredential returns the stake credential of base and reward addresses, or nil if the address does not have
+// one. The stake credential of pointer addresses is only available from the referenced certificate
+func (a Address) StakeCredential() *StakeCredential {
+	if len(a.stakingAddress) != AddressHashSize {
+		return nil
+	}
+	var credType uint
+	switch a.addressType {
+	case AddressTypeKeyKey, AddressTypeScriptKey, AddressTypeNoneKey:
+		credType = StakeCredentialTypeAddrKeyHash
+	case AddressTypeKeyScript, AddressTypeScriptScript, AddressTypeNoneScript:
+		credType = StakeCredentialTypeScriptHash
+	default:
+		return nil
+	}
+	return &StakeCredential{
+		CredType:   credType,
+		Credential: a.stakingAddress[:],
+	}
+}
+
+// Pointer returns the stake pointer for pointer addresses, or nil for other address types
+func (a Address) Pointer() *AddressPointer {
+	return a.pointer
+}
+
 func (a *Address) ByronAttr() ByronAddressAttributes {
 	return a.byronAddressAttr
 }
@@ -376,7 +555,7 @@ func (a Address) generateHRP() string {
 }
 
 // Bytes returns the underlying bytes for the address
-func (a Address) Bytes() []byte {
+func (a Address) Bytes() ([]byte, error) {
 	if a.addressType == AddressTypeByron {
 		tmpPayload := []any{
 			a.paymentAddress,
@@ -385,8 +564,7 @@ func (a Address) Bytes() []byte {
 		}
 		rawPayload, err := cbor.Encode(tmpPayload)
 		if err != nil {
-			// TODO: handle error (#851)
-			return nil
+			return nil, fmt.Errorf("encode Byron address payload: %w", err)
 		}
 		tmpData := []any{
 			cbor.Tag{
@@ -397,10 +575,9 @@ func (a Address) Bytes() []byte {
 		}
 		ret, err := cbor.Encode(tmpData)
 		if err != nil {
-			// TODO: handle error (#851)
-			return nil
+			return nil, fmt.Errorf("encode Byron address: %w", err)
 		}
-		return ret
+		return ret, nil
 	}
 	ret := []byte{}
 	ret = append(
@@ -409,35 +586,112 @@ func (a Address) Bytes() []byte {
 	)
 	ret = append(ret, a.paymentAddress...)
 	ret = append(ret, a.stakingAddress...)
+	if a.pointer != nil {
+		ret = append(ret, a.pointer.Bytes()...)
+	}
 	ret = append(ret, a.extraData...)
-	return ret
+	return ret, nil
 }
 
-// String returns the bech32-encoded version of the address
+// String returns the bech32-encoded version of the address, or an empty string if the address cannot be encoded
 func (a Address) String() string {
-	data := a.Bytes()
+	encoded, err := a.encode()
+	if err != nil {
+		return ""
+	}
+	return encoded
+}
+
+// encode returns the bech32 (or base58 for Byron) encoding of the address
+func (a Address) encode() (string, error) {
+	data, err := a.Bytes()
+	if err != nil {
+		return "", err
+	}
 	if a.addressType == AddressTypeByron {
 		// Encode data to base58
-		encoded := base58.Encode(data)
-		return encoded
-	} else {
-		// Convert data to base32 and encode as bech32
-		convData, err := bech32.ConvertBits(data, 8, 5, true)
-		if err != nil {
-			panic(fmt.Sprintf("unexpected error converting data to base32: %s", err))
-		}
-		// Generate human readable part of address for output
-		hrp := a.generateHRP()
-		encoded, err := bech32.Encode(hrp, convData)
+		return base58.Encode(data), nil
+	}
+	// Convert data to base32 and encode as bech32
+	convData, err := bech32.ConvertBits(data, 8, 5, true)
+	if err != nil {
+		return "", fmt.Errorf("convert address data to base32: %w", err)
+	}
+	// Generate human readable part of address for output
+	hrp := a.generateHRP()
+	encoded, err := bech32.Encode(hrp, convData)
+	if err != nil {
+		return "", fmt.Errorf("encode address as bech32: %w", err)
+	}
+	return encoded, nil
+}
+
+func (a Address) MarshalJSON() ([]byte, error) {
+	encoded, err := a.encode()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(`"` + encoded + `"`), nil
+}
+
+// AddressPointer identifies a stake registration certificate by its location on chain
+type AddressPointer struct {
+	Slot      uint64
+	TxIndex   uint64
+	CertIndex uint64
+}
+
+// Bytes returns the pointer encoded as a sequence of variable-length natural numbers
+func (p AddressPointer) Bytes() []byte {
+	ret := []byte{}
+	for _, val := range []uint64{p.Slot, p.TxIndex, p.CertIndex} {
+		ret = append(ret, encodeVariableNat(val)...)
+	}
+	return ret
+}
+
+func decodeAddressPointer(data []byte) (AddressPointer, int, error) {
+	var vals [3]uint64
+	offset := 0
+	for idx := range vals {
+		val, valLen, err := decodeVariableNat(data[offset:])
 		if err != nil {
-			panic(fmt.Sprintf("unexpected error encoding data as bech32: %s", err))
+			return AddressPointer{}, 0, err
 		}
-		return encoded
+		vals[idx] = val
+		offset += valLen
 	}
+	return AddressPointer{
+		Slot:      vals[0],
+		TxIndex:   vals[1],
+		CertIndex: vals[2],
+	}, offset, nil
 }
 
-func (a Address) MarshalJSON() ([]byte, error) {
-	return []byte(`"` + a.String() + `"`), nil
+// encodeVariableNat encodes a natural number as big-endian groups of 7 bits, with the high bit set on all but
+// the last byte
+func encodeVariableNat(val uint64) []byte {
+	ret := []byte{byte(val & 0x7f)}
+	val >>= 7
+	for val > 0 {
+		ret = append([]byte{byte(val&0x7f) | 0x80}, ret...)
+		val >>= 7
+	}
+	return ret
+}
+
+func decodeVariableNat(data []byte) (uint64, int, error) {
+	var ret uint64
+	for idx, b := range data {
+		if ret > (^uint64(0) >> 7) {
+			return 0, 0, fmt.Errorf("pointer value overflows uint64")
+		}
+		ret = (ret << 7) | uint64(b&0x7f)
+		if b&0x80 == 0 {
+			return ret, idx + 1, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("unexpected end of pointer data")
 }
 
 type byronAddress struct {
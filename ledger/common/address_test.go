@@ -291,3 +291,166 @@ func TestAddressPaymentAddress_MixedCase(t *testing.T) {
 	assert.Nil(t, err, "Expected no error when decoding a mixed-case address")
 	assert.NotNil(t, addr, "Expected a valid address object after decoding")
 }
+
+func TestAddressCip19(t *testing.T) {
+	// Test vectors from CIP-19
+	paymentCred := NewKeyCredential(
+		NewBlake2b224(test.DecodeHexString("9493315cd92eb5d8c4304e67b7e16ae36d61d34502694657811a2c8e")),
+	)
+	stakeCred := NewKeyCredential(
+		NewBlake2b224(test.DecodeHexString("337b62cfff6403a06a3acbc34f8c46003c69fe79a3628cefa9c47251")),
+	)
+	scriptCred := NewScriptCredential(
+		NewBlake2b224(test.DecodeHexString("c37b1b5dc0669f1d3c61a6fddb2e8fde96be87b881c60bce8e8d542f")),
+	)
+	pointer := AddressPointer{Slot: 2498243, TxIndex: 27, CertIndex: 3}
+	testDefs := []struct {
+		name            string
+		addrFunc        func() (Address, error)
+		addressType     uint8
+		paymentCred     *StakeCredential
+		stakeCred       *StakeCredential
+		pointer         *AddressPointer
+		expectedAddress string
+	}{
+		{
+			name:            "type 0",
+			addrFunc:        func() (Address, error) { return NewBaseAddress(AddressNetworkMainnet, paymentCred, stakeCred) },
+			addressType:     AddressTypeKeyKey,
+			paymentCred:     &paymentCred,
+			stakeCred:       &stakeCred,
+			expectedAddress: "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x",
+		},
+		{
+			name: "type 1",
+			addrFunc: func() (Address, error) {
+				return NewScriptAddress(AddressNetworkMainnet, NewBlake2b224(scriptCred.Credential), &stakeCred)
+			},
+			addressType:     AddressTypeScriptKey,
+			paymentCred:     &scriptCred,
+			stakeCred:       &stakeCred,
+			expectedAddress: "addr1z8phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gten0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgs9yc0hh",
+		},
+		{
+			name:            "type 2",
+			addrFunc:        func() (Address, error) { return NewBaseAddress(AddressNetworkMainnet, paymentCred, scriptCred) },
+			addressType:     AddressTypeKeyScript,
+			paymentCred:     &paymentCred,
+			stakeCred:       &scriptCred,
+			expectedAddress: "addr1yx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzerkr0vd4msrxnuwnccdxlhdjar77j6lg0wypcc9uar5d2shs2z78ve",
+		},
+		{
+			name:            "type 3",
+			addrFunc:        func() (Address, error) { return NewBaseAddress(AddressNetworkMainnet, scriptCred, scriptCred) },
+			addressType:     AddressTypeScriptScript,
+			paymentCred:     &scriptCred,
+			stakeCred:       &scriptCred,
+			expectedAddress: "addr1x8phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gt7r0vd4msrxnuwnccdxlhdjar77j6lg0wypcc9uar5d2shskhj42g",
+		},
+		{
+			name:            "type 4",
+			addrFunc:        func() (Address, error) { return NewPointerAddress(AddressNetworkMainnet, paymentCred, pointer) },
+			addressType:     AddressTypeKeyPointer,
+			paymentCred:     &paymentCred,
+			pointer:         &pointer,
+			expectedAddress: "addr1gx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer5pnz75xxcrzqf96k",
+		},
+		{
+			name:            "type 5",
+			addrFunc:        func() (Address, error) { return NewPointerAddress(AddressNetworkMainnet, scriptCred, pointer) },
+			addressType:     AddressTypeScriptPointer,
+			paymentCred:     &scriptCred,
+			pointer:         &pointer,
+			expectedAddress: "addr128phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gtupnz75xxcrtw79hu",
+		},
+		{
+			name:            "type 6",
+			addrFunc:        func() (Address, error) { return NewEnterpriseAddress(AddressNetworkMainnet, paymentCred) },
+			addressType:     AddressTypeKeyNone,
+			paymentCred:     &paymentCred,
+			expectedAddress: "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8",
+		},
+		{
+			name: "type 7",
+			addrFunc: func() (Address, error) {
+				return NewScriptAddress(AddressNetworkMainnet, NewBlake2b224(scriptCred.Credential), nil)
+			},
+			addressType:     AddressTypeScriptNone,
+			paymentCred:     &scriptCred,
+			expectedAddress: "addr1w8phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gtcyjy7wx",
+		},
+		{
+			name:            "type 14",
+			addrFunc:        func() (Address, error) { return NewRewardAddress(AddressNetworkMainnet, stakeCred) },
+			addressType:     AddressTypeNoneKey,
+			stakeCred:       &stakeCred,
+			expectedAddress: "stake1uyehkck0lajq8gr28t9uxnuvgcqrc6070x3k9r8048z8y5gh6ffgw",
+		},
+		{
+			name:            "type 15",
+			addrFunc:        func() (Address, error) { return NewRewardAddress(AddressNetworkMainnet, scriptCred) },
+			addressType:     AddressTypeNoneScript,
+			stakeCred:       &scriptCred,
+			expectedAddress: "stake178phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gtcccycj5",
+		},
+		{
+			name:            "type 4 testnet",
+			addrFunc:        func() (Address, error) { return NewPointerAddress(AddressNetworkTestnet, paymentCred, pointer) },
+			addressType:     AddressTypeKeyPointer,
+			paymentCred:     &paymentCred,
+			pointer:         &pointer,
+			expectedAddress: "addr_test1gz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer5pnz75xxcrdw5vky",
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			addr, err := testDef.addrFunc()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if addr.String() != testDef.expectedAddress {
+				t.Errorf("address did not match expected value, got: %s, wanted: %s", addr.String(), testDef.expectedAddress)
+			}
+			decodedAddr, err := NewAddress(testDef.expectedAddress)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			assert.Equal(t, testDef.addressType, decodedAddr.Type())
+			assert.Equal(t, testDef.expectedAddress, decodedAddr.String())
+			assert.Equal(t, testDef.paymentCred, decodedAddr.PaymentCredential())
+			assert.Equal(t, testDef.stakeCred, decodedAddr.StakeCredential())
+			assert.Equal(t, testDef.pointer, decodedAddr.Pointer())
+		})
+	}
+}
+
+func TestAddressFromPartsReward(t *testing.T) {
+	stakeHash := test.DecodeHexString("337b62cfff6403a06a3acbc34f8c46003c69fe79a3628cefa9c47251")
+	addr, err := NewAddressFromParts(AddressTypeNoneKey, AddressNetworkMainnet, stakeHash, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if addr.StakeKeyHash() != NewBlake2b224(stakeHash) {
+		t.Errorf("did not get expected stake key hash: got %s", addr.StakeKeyHash().String())
+	}
+	if addr.String() != "stake1uyehkck0lajq8gr28t9uxnuvgcqrc6070x3k9r8048z8y5gh6ffgw" {
+		t.Errorf("did not get expected address: got %s", addr.String())
+	}
+}
+
+func TestAddressPointerLarge(t *testing.T) {
+	pointer := AddressPointer{Slot: ^uint64(0), TxIndex: 0, CertIndex: 128}
+	addr, err := NewPointerAddress(
+		AddressNetworkMainnet,
+		NewKeyCredential(Blake2b224{}),
+		pointer,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	decodedAddr, err := NewAddress(addr.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, &pointer, decodedAddr.Pointer())
+}
@@ -0,0 +1,317 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+// TxCertState tracks the changes made by a transaction's withdrawals and certificates on top of the ledger
+// certificate state. This allows validating each certificate against the state left by the certificates before it,
+// which is how the ledger processes them
+type TxCertState struct {
+	certState          CertState
+	keyDeposit         uint64
+	poolDeposit        uint64
+	stakeRegistrations map[Blake2b224]*StakeRegistration
+	poolRegistrations  map[PoolKeyHash]*PoolRegistration
+	drepRegistrations  map[Blake2b224]*DrepRegistration
+	committeeMembers   map[Blake2b224]*CommitteeMember
+}
+
+// NewTxCertState returns a new TxCertState on top of the provided certificate state. The key and pool deposits
+// are recorded for certificates that don't specify an explicit deposit amount
+func NewTxCertState(certState CertState, keyDeposit uint64, poolDeposit uint64) *TxCertState {
+	return &TxCertState{
+		certState:          certState,
+		keyDeposit:         keyDeposit,
+		poolDeposit:        poolDeposit,
+		stakeRegistrations: make(map[Blake2b224]*StakeRegistration),
+		poolRegistrations:  make(map[PoolKeyHash]*PoolRegistration),
+		drepRegistrations:  make(map[Blake2b224]*DrepRegistration),
+		committeeMembers:   make(map[Blake2b224]*CommitteeMember),
+	}
+}
+
+func (s *TxCertState) StakeRegistration(cred Blake2b224) (*StakeRegistration, error) {
+	if reg, ok := s.stakeRegistrations[cred]; ok {
+		return reg, nil
+	}
+	return s.certState.StakeRegistration(cred)
+}
+
+func (s *TxCertState) PoolRegistration(pool PoolKeyHash) (*PoolRegistration, error) {
+	if reg, ok := s.poolRegistrations[pool]; ok {
+		return reg, nil
+	}
+	return s.certState.PoolRegistration(pool)
+}
+
+func (s *TxCertState) DrepRegistration(cred Blake2b224) (*DrepRegistration, error) {
+	if reg, ok := s.drepRegistrations[cred]; ok {
+		return reg, nil
+	}
+	return s.certState.DrepRegistration(cred)
+}
+
+func (s *TxCertState) CommitteeMember(cred Blake2b224) (*CommitteeMember, error) {
+	if member, ok := s.committeeMembers[cred]; ok {
+		return member, nil
+	}
+	return s.certState.CommitteeMember(cred)
+}
+
+// ApplyWithdrawals deducts the provided withdrawals from the reward account balances
+func (s *TxCertState) ApplyWithdrawals(withdrawals map[*Address]uint64) error {
+	for addr, amount := range withdrawals {
+		cred := addr.StakeKeyHash()
+		reg, err := s.StakeRegistration(cred)
+		if err != nil {
+			return err
+		}
+		if reg == nil {
+			continue
+		}
+		tmpReg := *reg
+		if amount > tmpReg.Reward {
+			tmpReg.Reward = 0
+		} else {
+			tmpReg.Reward -= amount
+		}
+		s.stakeRegistrations[cred] = &tmpReg
+	}
+	return nil
+}
+
+// ApplyCertificate updates the state with the changes made by the provided certificate. No validation is performed
+func (s *TxCertState) ApplyCertificate(cert Certificate) error {
+	switch c := cert.(type) {
+	case *StakeRegistrationCertificate:
+		s.registerStake(c.StakeRegistration, s.keyDeposit)
+	case *RegistrationCertificate:
+		s.registerStake(c.StakeCredential, uint64(c.Amount))
+	case *StakeDeregistrationCertificate:
+		s.stakeRegistrations[NewBlake2b224(c.StakeDeregistration.Credential)] = nil
+	case *DeregistrationCertificate:
+		s.stakeRegistrations[NewBlake2b224(c.StakeCredential.Credential)] = nil
+	case *StakeDelegationCertificate:
+		if c.StakeCredential == nil {
+			return nil
+		}
+		pool := c.PoolKeyHash
+		return s.delegateStake(*c.StakeCredential, &pool, nil)
+	case *VoteDelegationCertificate:
+		drep := c.Drep
+		return s.delegateStake(c.StakeCredential, nil, &drep)
+	case *StakeVoteDelegationCertificate:
+		pool := PoolKeyHash(NewBlake2b224(c.PoolKeyHash))
+		drep := c.Drep
+		return s.delegateStake(c.StakeCredential, &pool, &drep)
+	case *StakeRegistrationDelegationCertificate:
+		s.registerStake(c.StakeCredential, uint64(c.Amount))
+		pool := PoolKeyHash(NewBlake2b224(c.PoolKeyHash))
+		return s.delegateStake(c.StakeCredential, &pool, nil)
+	case *VoteRegistrationDelegationCertificate:
+		s.registerStake(c.StakeCredential, uint64(c.Amount))
+		drep := c.Drep
+		return s.delegateStake(c.StakeCredential, nil, &drep)
+	case *StakeVoteRegistrationDelegationCertificate:
+		s.registerStake(c.StakeCredential, uint64(c.Amount))
+		pool := PoolKeyHash(NewBlake2b224(c.PoolKeyHash))
+		drep := c.Drep
+		return s.delegateStake(c.StakeCredential, &pool, &drep)
+	case *PoolRegistrationCertificate:
+		reg, err := s.PoolRegistration(c.Operator)
+		if err != nil {
+			return err
+		}
+		// Re-registering an existing pool updates its parameters and cancels any pending retirement, but the
+		// original deposit is kept
+		tmpReg := PoolRegistration{
+			Deposit: s.poolDeposit,
+			Cost:    c.Cost,
+		}
+		if reg != nil {
+			tmpReg.Deposit = reg.Deposit
+		}
+		s.poolRegistrations[c.Operator] = &tmpReg
+	case *PoolRetirementCertificate:
+		reg, err := s.PoolRegistration(c.PoolKeyHash)
+		if err != nil {
+			return err
+		}
+		if reg == nil {
+			return nil
+		}
+		tmpReg := *reg
+		tmpReg.RetiringEpoch = c.Epoch
+		s.poolRegistrations[c.PoolKeyHash] = &tmpReg
+	case *RegistrationDrepCertificate:
+		s.drepRegistrations[NewBlake2b224(c.DrepCredential.Credential)] = &DrepRegistration{
+			Deposit: uint64(c.Amount),
+			Anchor:  c.Anchor,
+		}
+	case *DeregistrationDrepCertificate:
+		s.drepRegistrations[NewBlake2b224(c.DrepCredential.Credential)] = nil
+	case *UpdateDrepCertificate:
+		cred := NewBlake2b224(c.DrepCredential.Credential)
+		reg, err := s.DrepRegistration(cred)
+		if err != nil {
+			return err
+		}
+		if reg == nil {
+			return nil
+		}
+		tmpReg := *reg
+		tmpReg.Anchor = c.Anchor
+		s.drepRegistrations[cred] = &tmpReg
+	case *AuthCommitteeHotCertificate:
+		cred := NewBlake2b224(c.ColdCredential.Credential)
+		member, err := s.CommitteeMember(cred)
+		if err != nil {
+			return err
+		}
+		tmpMember := CommitteeMember{}
+		if member != nil {
+			tmpMember = *member
+		}
+		hotCred := c.HostCredential
+		tmpMember.HotCredential = &hotCred
+		s.committeeMembers[cred] = &tmpMember
+	case *ResignCommitteeColdCertificate:
+		cred := NewBlake2b224(c.ColdCredential.Credential)
+		member, err := s.CommitteeMember(cred)
+		if err != nil {
+			return err
+		}
+		tmpMember := CommitteeMember{}
+		if member != nil {
+			tmpMember = *member
+		}
+		tmpMember.HotCredential = nil
+		tmpMember.Resigned = true
+		s.committeeMembers[cred] = &tmpMember
+	}
+	return nil
+}
+
+func (s *TxCertState) registerStake(cred StakeCredential, deposit uint64) {
+	s.stakeRegistrations[NewBlake2b224(cred.Credential)] = &StakeRegistration{
+		Deposit: deposit,
+	}
+}
+
+func (s *TxCertState) delegateStake(cred StakeCredential, pool *PoolKeyHash, drep *Drep) error {
+	credHash := NewBlake2b224(cred.Credential)
+	reg, err := s.StakeRegistration(credHash)
+	if err != nil {
+		return err
+	}
+	if reg == nil {
+		return nil
+	}
+	tmpReg := *reg
+	if pool != nil {
+		tmpReg.Pool = pool
+	}
+	if drep != nil {
+		tmpReg.Drep = drep
+	}
+	s.stakeRegistrations[credHash] = &tmpReg
+	return nil
+}
+
+// ProcessTx applies the transaction withdrawals followed by each of its certificates in order. The provided function
+// is called for each certificate before it is applied, which allows checking it against the current state.
+// Processing stops at the first error
+func (s *TxCertState) ProcessTx(tx Transaction, certFunc func(Certificate) error) error {
+	if err := s.ApplyWithdrawals(tx.Withdrawals()); err != nil {
+		return err
+	}
+	for _, cert := range tx.Certificates() {
+		if certFunc != nil {
+			if err := certFunc(cert); err != nil {
+				return err
+			}
+		}
+		if err := s.ApplyCertificate(cert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TxCertDeposits returns the total deposits paid and refunds claimed by the transaction's certificates. Refunds are
+// based on the deposit that was recorded at registration, and re-registering an existing pool requires no deposit
+func TxCertDeposits(tx Transaction, certState CertState, keyDeposit uint64, poolDeposit uint64) (uint64, uint64, error) {
+	var deposits, refunds uint64
+	txCertState := NewTxCertState(certState, keyDeposit, poolDeposit)
+	err := txCertState.ProcessTx(
+		tx,
+		func(cert Certificate) error {
+			switch c := cert.(type) {
+			case *StakeRegistrationCertificate:
+				deposits += keyDeposit
+			case *RegistrationCertificate:
+				deposits += uint64(c.Amount)
+			case *StakeRegistrationDelegationCertificate:
+				deposits += uint64(c.Amount)
+			case *VoteRegistrationDelegationCertificate:
+				deposits += uint64(c.Amount)
+			case *StakeVoteRegistrationDelegationCertificate:
+				deposits += uint64(c.Amount)
+			case *RegistrationDrepCertificate:
+				deposits += uint64(c.Amount)
+			case *PoolRegistrationCertificate:
+				reg, err := txCertState.PoolRegistration(c.Operator)
+				if err != nil {
+					return err
+				}
+				if reg == nil {
+					deposits += poolDeposit
+				}
+			case *StakeDeregistrationCertificate:
+				reg, err := txCertState.StakeRegistration(NewBlake2b224(c.StakeDeregistration.Credential))
+				if err != nil {
+					return err
+				}
+				if reg != nil {
+					refunds += reg.Deposit
+				}
+			case *DeregistrationCertificate:
+				reg, err := txCertState.StakeRegistration(NewBlake2b224(c.StakeCredential.Credential))
+				if err != nil {
+					return err
+				}
+				if reg != nil {
+					refunds += reg.Deposit
+				} else {
+					refunds += uint64(c.Amount)
+				}
+			case *DeregistrationDrepCertificate:
+				reg, err := txCertState.DrepRegistration(NewBlake2b224(c.DrepCredential.Credential))
+				if err != nil {
+					return err
+				}
+				if reg != nil {
+					refunds += reg.Deposit
+				} else {
+					refunds += uint64(c.Amount)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+	return deposits, refunds, nil
+}
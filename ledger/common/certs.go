@@ -15,6 +15,7 @@
 package common
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 
@@ -124,11 +125,61 @@ const (
 	DrepTypeNoConfidence = 3
 )
 
+const (
+	drepAlwaysAbstain      = "drep_always_abstain"
+	drepAlwaysNoConfidence = "drep_always_no_confidence"
+)
+
 type Drep struct {
 	Type       int
 	Credential []byte
 }
 
+// NewDrepFromBech32 returns a Drep from a CIP-129 DRep ID or a CIP-105 key or script hash. The predefined DReps
+// are also accepted as drep_always_abstain and drep_always_no_confidence
+func NewDrepFromBech32(data string) (Drep, error) {
+	switch data {
+	case drepAlwaysAbstain:
+		return Drep{Type: DrepTypeAbstain}, nil
+	case drepAlwaysNoConfidence:
+		return Drep{Type: DrepTypeNoConfidence}, nil
+	}
+	kind, cred, err := NewGovCredentialFromBech32(data)
+	if err != nil {
+		return Drep{}, err
+	}
+	if kind != GovCredentialKindDrep {
+		return Drep{}, fmt.Errorf("invalid DRep ID: %s", data)
+	}
+	ret := Drep{
+		Type:       DrepTypeAddrKeyHash,
+		Credential: cred.Credential,
+	}
+	if cred.CredType == StakeCredentialTypeScriptHash {
+		ret.Type = DrepTypeScriptHash
+	}
+	return ret, nil
+}
+
+// String returns the CIP-129 DRep ID, or drep_always_abstain and drep_always_no_confidence for the predefined DReps
+func (d Drep) String() string {
+	switch d.Type {
+	case DrepTypeAddrKeyHash:
+		return GovCredentialBech32(GovCredentialKindDrep, NewKeyCredential(NewBlake2b224(d.Credential)))
+	case DrepTypeScriptHash:
+		return GovCredentialBech32(GovCredentialKindDrep, NewScriptCredential(NewBlake2b224(d.Credential)))
+	case DrepTypeAbstain:
+		return drepAlwaysAbstain
+	case DrepTypeNoConfidence:
+		return drepAlwaysNoConfidence
+	}
+	return fmt.Sprintf("unknown drep type %d", d.Type)
+}
+
+func (d Drep) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
 func (d *Drep) UnmarshalCBOR(data []byte) error {
 	drepType, err := cbor.DecodeIdFromList(data)
 	if err != nil {
@@ -35,6 +35,22 @@ type StakeCredential struct {
 	Credential []byte
 }
 
+// NewKeyCredential returns a credential for the provided key hash
+func NewKeyCredential(keyHash Blake2b224) StakeCredential {
+	return StakeCredential{
+		CredType:   StakeCredentialTypeAddrKeyHash,
+		Credential: keyHash.Bytes(),
+	}
+}
+
+// NewScriptCredential returns a credential for the provided script hash
+func NewScriptCredential(scriptHash Blake2b224) StakeCredential {
+	return StakeCredential{
+		CredType:   StakeCredentialTypeScriptHash,
+		Credential: scriptHash.Bytes(),
+	}
+}
+
 func (c *StakeCredential) Hash() Blake2b224 {
 	hash, err := blake2b.New(28, nil)
 	if err != nil {
@@ -15,9 +15,13 @@
 package common
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/btcsuite/btcd/btcutil/bech32"
 )
 
 // VotingProcedures is a convenience type to avoid needing to duplicate the full type definition everywhere
@@ -37,6 +41,69 @@ type Voter struct {
 	Hash [28]byte
 }
 
+// NewVoterFromBech32 returns a Voter from a CIP-129 committee hot credential or DRep ID, a CIP-105 key or script
+// hash, or a pool ID
+func NewVoterFromBech32(data string) (Voter, error) {
+	prefix, _, err := decodeBech32(data)
+	if err != nil {
+		return Voter{}, err
+	}
+	if prefix == "pool" {
+		poolId, err := NewPoolIdFromBech32(data)
+		if err != nil {
+			return Voter{}, err
+		}
+		return Voter{Type: VoterTypeStakingPoolKeyHash, Hash: poolId}, nil
+	}
+	kind, cred, err := NewGovCredentialFromBech32(data)
+	if err != nil {
+		return Voter{}, err
+	}
+	ret := Voter{Hash: [28]byte(cred.Credential)}
+	switch kind {
+	case GovCredentialKindCommitteeHot:
+		ret.Type = VoterTypeConstitutionalCommitteeHotKeyHash
+	case GovCredentialKindDrep:
+		ret.Type = VoterTypeDRepKeyHash
+	default:
+		return Voter{}, fmt.Errorf("invalid voter credential: %s", data)
+	}
+	if cred.CredType == StakeCredentialTypeScriptHash {
+		ret.Type++
+	}
+	return ret, nil
+}
+
+// String returns the CIP-129 bech32 ID for committee and DRep voters, or the pool ID for stake pool voters
+func (v Voter) String() string {
+	cred := StakeCredential{
+		CredType:   StakeCredentialTypeAddrKeyHash,
+		Credential: v.Hash[:],
+	}
+	switch v.Type {
+	case VoterTypeConstitutionalCommitteeHotScriptHash, VoterTypeDRepScriptHash:
+		cred.CredType = StakeCredentialTypeScriptHash
+	}
+	switch v.Type {
+	case VoterTypeConstitutionalCommitteeHotKeyHash,
+		VoterTypeConstitutionalCommitteeHotScriptHash:
+		return GovCredentialBech32(GovCredentialKindCommitteeHot, cred)
+	case VoterTypeDRepKeyHash, VoterTypeDRepScriptHash:
+		return GovCredentialBech32(GovCredentialKindDrep, cred)
+	case VoterTypeStakingPoolKeyHash:
+		return PoolId(v.Hash).String()
+	}
+	return fmt.Sprintf("unknown voter type %d: %x", v.Type, v.Hash)
+}
+
+func (v Voter) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v Voter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
 const (
 	GovVoteNo      uint8 = 0
 	GovVoteYes     uint8 = 1
@@ -61,6 +128,42 @@ type GovActionId struct {
 	GovActionIdx  uint32
 }
 
+// NewGovActionIdFromBech32 returns a GovActionId from its CIP-129 bech32 encoding
+func NewGovActionIdFromBech32(data string) (GovActionId, error) {
+	prefix, decoded, err := decodeBech32(data)
+	if err != nil {
+		return GovActionId{}, err
+	}
+	if prefix != "gov_action" {
+		return GovActionId{}, fmt.Errorf("invalid governance action ID prefix: %s", prefix)
+	}
+	if len(decoded) <= 32 || len(decoded) > 36 {
+		return GovActionId{}, fmt.Errorf("invalid governance action ID length: %d", len(decoded))
+	}
+	return GovActionId{
+		TransactionId: [32]byte(decoded[:32]),
+		GovActionIdx:  uint32(new(big.Int).SetBytes(decoded[32:]).Uint64()),
+	}, nil
+}
+
+// String returns the CIP-129 bech32 encoding of the governance action ID, which is the transaction ID followed by
+// the big-endian action index
+func (id GovActionId) String() string {
+	idx := big.NewInt(int64(id.GovActionIdx)).Bytes()
+	if len(idx) == 0 {
+		idx = []byte{0}
+	}
+	return encodeBech32("gov_action", append(id.TransactionId[:], idx...))
+}
+
+func (id GovActionId) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id GovActionId) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
 type ProposalProcedure struct {
 	cbor.StructAsArray
 	Deposit       uint64
@@ -196,3 +299,126 @@ type InfoGovAction struct {
 }
 
 func (a InfoGovAction) isGovAction() {}
+
+// GovCredentialKind identifies the role of a governance credential, and is the upper half of the CIP-129 header byte
+type GovCredentialKind uint8
+
+const (
+	GovCredentialKindCommitteeHot  GovCredentialKind = 0
+	GovCredentialKindCommitteeCold GovCredentialKind = 1
+	GovCredentialKindDrep          GovCredentialKind = 2
+)
+
+// CIP-129 header byte values for the credential type
+const (
+	cip129CredentialTypeKeyHash    = 0b0010
+	cip129CredentialTypeScriptHash = 0b0011
+)
+
+var govCredentialPrefixes = map[GovCredentialKind]string{
+	GovCredentialKindCommitteeHot:  "cc_hot",
+	GovCredentialKindCommitteeCold: "cc_cold",
+	GovCredentialKindDrep:          "drep",
+}
+
+// GovCredentialBech32 returns the CIP-129 bech32 encoding of a governance credential, which includes a header byte
+// with the credential kind and whether it's a key or script hash
+func GovCredentialBech32(kind GovCredentialKind, cred StakeCredential) string {
+	header := byte(kind) << 4
+	if cred.CredType == StakeCredentialTypeScriptHash {
+		header |= cip129CredentialTypeScriptHash
+	} else {
+		header |= cip129CredentialTypeKeyHash
+	}
+	return encodeBech32(
+		govCredentialPrefixes[kind],
+		append([]byte{header}, cred.Credential...),
+	)
+}
+
+// GovCredentialCip105Bech32 returns the CIP-105 bech32 encoding of a governance credential, which uses separate
+// prefixes for key hashes and script hashes, such as drep_vkh and drep_script
+func GovCredentialCip105Bech32(kind GovCredentialKind, cred StakeCredential) string {
+	prefix := govCredentialPrefixes[kind]
+	if cred.CredType == StakeCredentialTypeScriptHash {
+		prefix += "_script"
+	} else {
+		prefix += "_vkh"
+	}
+	return encodeBech32(prefix, cred.Credential)
+}
+
+// NewGovCredentialFromBech32 decodes a CIP-129 or CIP-105 bech32 governance credential. Verification keys
+// (such as drep_vk) are hashed, and the legacy CIP-105 drep prefix for key hashes is also accepted
+func NewGovCredentialFromBech32(data string) (GovCredentialKind, StakeCredential, error) {
+	prefix, decoded, err := decodeBech32(data)
+	if err != nil {
+		return 0, StakeCredential{}, err
+	}
+	for kind, kindPrefix := range govCredentialPrefixes {
+		switch prefix {
+		case kindPrefix:
+			if kind == GovCredentialKindDrep && len(decoded) == AddressHashSize {
+				// Legacy CIP-105 DRep ID, which is a key hash without a header
+				return kind, NewKeyCredential(NewBlake2b224(decoded)), nil
+			}
+			if len(decoded) != AddressHashSize+1 {
+				return 0, StakeCredential{}, fmt.Errorf("invalid governance credential length: %d", len(decoded))
+			}
+			if GovCredentialKind(decoded[0]>>4) != kind {
+				return 0, StakeCredential{}, fmt.Errorf("governance credential header does not match prefix: %s", data)
+			}
+			switch decoded[0] & 0x0f {
+			case cip129CredentialTypeKeyHash:
+				return kind, NewKeyCredential(NewBlake2b224(decoded[1:])), nil
+			case cip129CredentialTypeScriptHash:
+				return kind, NewScriptCredential(NewBlake2b224(decoded[1:])), nil
+			}
+			return 0, StakeCredential{}, fmt.Errorf("invalid governance credential header: %x", decoded[0])
+		case kindPrefix + "_vkh", kindPrefix + "_script":
+			if len(decoded) != AddressHashSize {
+				return 0, StakeCredential{}, fmt.Errorf("invalid governance credential length: %d", len(decoded))
+			}
+			if prefix == kindPrefix+"_script" {
+				return kind, NewScriptCredential(NewBlake2b224(decoded)), nil
+			}
+			return kind, NewKeyCredential(NewBlake2b224(decoded)), nil
+		case kindPrefix + "_vk":
+			if len(decoded) != 32 {
+				return 0, StakeCredential{}, fmt.Errorf("invalid verification key length: %d", len(decoded))
+			}
+			return kind, NewKeyCredential(Blake2b224Hash(decoded)), nil
+		}
+	}
+	return 0, StakeCredential{}, fmt.Errorf("unknown governance credential prefix: %s", prefix)
+}
+
+func encodeBech32(prefix string, data []byte) string {
+	// Convert data to base32 and encode as bech32
+	convData, err := bech32.ConvertBits(data, 8, 5, true)
+	if err != nil {
+		panic(
+			fmt.Sprintf("unexpected error converting data to base32: %s", err),
+		)
+	}
+	encoded, err := bech32.Encode(prefix, convData)
+	if err != nil {
+		panic(fmt.Sprintf("unexpected error encoding data as bech32: %s", err))
+	}
+	return encoded
+}
+
+func decodeBech32(data string) (string, []byte, error) {
+	prefix, convData, err := bech32.DecodeNoLimit(data)
+	if err != nil {
+		return "", nil, err
+	}
+	decoded, err := bech32.ConvertBits(convData, 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(decoded) == 0 {
+		return "", nil, errors.New("empty bech32 data")
+	}
+	return prefix, decoded, nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGovActionIdBech32(t *testing.T) {
+	// Test vectors from CIP-129
+	testDefs := []struct {
+		txId     string
+		index    uint32
+		expected string
+	}{
+		{
+			txId:     strings.Repeat("00", 32),
+			index:    17,
+			expected: "gov_action1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqpzklpgpf",
+		},
+		{
+			txId:     strings.Repeat("11", 32),
+			index:    0,
+			expected: "gov_action1zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygsq6dmejn",
+		},
+	}
+	for _, testDef := range testDefs {
+		id := GovActionId{
+			TransactionId: [32]byte(test.DecodeHexString(testDef.txId)),
+			GovActionIdx:  testDef.index,
+		}
+		if id.String() != testDef.expected {
+			t.Errorf("did not get expected governance action ID: got %s, wanted %s", id.String(), testDef.expected)
+		}
+		decodedId, err := NewGovActionIdFromBech32(testDef.expected)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		assert.Equal(t, id, decodedId)
+	}
+	if _, err := NewGovActionIdFromBech32(PoolId{}.String()); err == nil {
+		t.Errorf("did not get expected error for wrong prefix")
+	}
+}
+
+func TestGovCredentialBech32(t *testing.T) {
+	hash := NewBlake2b224(test.DecodeHexString("00000000000000000000000000000000000000000000000000000000"))
+	testDefs := []struct {
+		kind           GovCredentialKind
+		cred           StakeCredential
+		cip129Prefix   string
+		cip129Header   byte
+		cip105Prefix   string
+		expectedVoter  uint8
+		expectedIsDrep bool
+	}{
+		{
+			kind:           GovCredentialKindDrep,
+			cred:           NewKeyCredential(hash),
+			cip129Prefix:   "drep1",
+			cip129Header:   0x22,
+			cip105Prefix:   "drep_vkh1",
+			expectedVoter:  VoterTypeDRepKeyHash,
+			expectedIsDrep: true,
+		},
+		{
+			kind:           GovCredentialKindDrep,
+			cred:           NewScriptCredential(hash),
+			cip129Prefix:   "drep1",
+			cip129Header:   0x23,
+			cip105Prefix:   "drep_script1",
+			expectedVoter:  VoterTypeDRepScriptHash,
+			expectedIsDrep: true,
+		},
+		{
+			kind:          GovCredentialKindCommitteeHot,
+			cred:          NewKeyCredential(hash),
+			cip129Prefix:  "cc_hot1",
+			cip129Header:  0x02,
+			cip105Prefix:  "cc_hot_vkh1",
+			expectedVoter: VoterTypeConstitutionalCommitteeHotKeyHash,
+		},
+		{
+			kind:          GovCredentialKindCommitteeHot,
+			cred:          NewScriptCredential(hash),
+			cip129Prefix:  "cc_hot1",
+			cip129Header:  0x03,
+			cip105Prefix:  "cc_hot_script1",
+			expectedVoter: VoterTypeConstitutionalCommitteeHotScriptHash,
+		},
+		{
+			kind:         GovCredentialKindCommitteeCold,
+			cred:         NewKeyCredential(hash),
+			cip129Prefix: "cc_cold1",
+			cip129Header: 0x12,
+			cip105Prefix: "cc_cold_vkh1",
+		},
+		{
+			kind:         GovCredentialKindCommitteeCold,
+			cred:         NewScriptCredential(hash),
+			cip129Prefix: "cc_cold1",
+			cip129Header: 0x13,
+			cip105Prefix: "cc_cold_script1",
+		},
+	}
+	for _, testDef := range testDefs {
+		encoded := GovCredentialBech32(testDef.kind, testDef.cred)
+		if !strings.HasPrefix(encoded, testDef.cip129Prefix) {
+			t.Errorf("did not get expected prefix: got %s, wanted %s", encoded, testDef.cip129Prefix)
+		}
+		_, decoded, err := decodeBech32(encoded)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if decoded[0] != testDef.cip129Header || !bytes.Equal(decoded[1:], testDef.cred.Credential) {
+			t.Errorf("did not get expected data: got %x", decoded)
+		}
+		cip105Encoded := GovCredentialCip105Bech32(testDef.kind, testDef.cred)
+		if !strings.HasPrefix(cip105Encoded, testDef.cip105Prefix) {
+			t.Errorf("did not get expected prefix: got %s, wanted %s", cip105Encoded, testDef.cip105Prefix)
+		}
+		for _, tmpEncoded := range []string{encoded, cip105Encoded} {
+			kind, cred, err := NewGovCredentialFromBech32(tmpEncoded)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			assert.Equal(t, testDef.kind, kind)
+			assert.Equal(t, testDef.cred, cred)
+		}
+		if testDef.kind == GovCredentialKindCommitteeCold {
+			if _, err := NewVoterFromBech32(encoded); err == nil {
+				t.Errorf("did not get expected error for committee cold voter")
+			}
+			continue
+		}
+		voter, err := NewVoterFromBech32(encoded)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if voter.Type != testDef.expectedVoter || voter.String() != encoded {
+			t.Errorf("did not get expected voter: got %#v", voter)
+		}
+		if testDef.expectedIsDrep {
+			drep, err := NewDrepFromBech32(cip105Encoded)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if drep.String() != encoded {
+				t.Errorf("did not get expected DRep ID: got %s, wanted %s", drep.String(), encoded)
+			}
+		}
+	}
+	t.Run("mismatched header", func(t *testing.T) {
+		encoded := encodeBech32("drep", append([]byte{0x02}, hash.Bytes()...))
+		if _, _, err := NewGovCredentialFromBech32(encoded); err == nil {
+			t.Errorf("did not get expected error")
+		}
+	})
+	t.Run("legacy DRep ID", func(t *testing.T) {
+		drep, err := NewDrepFromBech32(encodeBech32("drep", hash.Bytes()))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		assert.Equal(t, DrepTypeAddrKeyHash, drep.Type)
+	})
+}
+
+func TestDrepPredefined(t *testing.T) {
+	for _, drepType := range []int{DrepTypeAbstain, DrepTypeNoConfidence} {
+		drep := Drep{Type: drepType}
+		decodedDrep, err := NewDrepFromBech32(drep.String())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		assert.Equal(t, drep, decodedDrep)
+	}
+}
+
+func TestVotingProceduresJson(t *testing.T) {
+	voter := &Voter{Type: VoterTypeStakingPoolKeyHash}
+	govActionId := &GovActionId{GovActionIdx: 17}
+	procedures := VotingProcedures{
+		voter: {
+			govActionId: {Vote: GovVoteYes},
+		},
+	}
+	jsonData, err := json.Marshal(procedures)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := `{"` + PoolId{}.String() + `":{"gov_action1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqpzklpgpf":{"Vote":1,"Anchor":null}}}`
+	if string(jsonData) != expected {
+		t.Errorf("did not get expected JSON:\n  got: %s\n  wanted: %s", jsonData, expected)
+	}
+	// Text marshaling for JSON map keys should not affect the CBOR encoding
+	voterCbor, err := cbor.Encode(voter)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if voterCbor[0] != 0x82 {
+		t.Errorf("voter should be encoded as a CBOR array: got %x", voterCbor)
+	}
+}
@@ -0,0 +1,644 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+// TransactionMetadatumType identifies the kind of a TransactionMetadatum value
+type TransactionMetadatumType uint8
+
+const (
+	TransactionMetadatumTypeInt TransactionMetadatumType = iota
+	TransactionMetadatumTypeBytes
+	TransactionMetadatumTypeText
+	TransactionMetadatumTypeList
+	TransactionMetadatumTypeMap
+)
+
+// MetadatumMaxStringSize is the maximum size in bytes of a metadata bytestring or text string
+const MetadatumMaxStringSize = 64
+
+// TransactionMetadatum represents a single value in transaction metadata
+type TransactionMetadatum struct {
+	cbor.DecodeStoreCbor
+	item any
+}
+
+// TransactionMetadatumMapPair is a single key/value pair in a metadata map
+type TransactionMetadatumMapPair struct {
+	Key   TransactionMetadatum
+	Value TransactionMetadatum
+}
+
+// NewMetadatumInteger returns an integer value
+func NewMetadatumInteger(value *big.Int) TransactionMetadatum {
+	return TransactionMetadatum{
+		item: new(big.Int).Set(value),
+	}
+}
+
+// NewMetadatumInt returns an integer value from an int64
+func NewMetadatumInt(value int64) TransactionMetadatum {
+	return TransactionMetadatum{
+		item: big.NewInt(value),
+	}
+}
+
+// NewMetadatumBytes returns a bytestring value
+func NewMetadatumBytes(value []byte) TransactionMetadatum {
+	return TransactionMetadatum{
+		item: append([]byte{}, value...),
+	}
+}
+
+// NewMetadatumText returns a text value
+func NewMetadatumText(value string) TransactionMetadatum {
+	return TransactionMetadatum{
+		item: value,
+	}
+}
+
+// NewMetadatumList returns a list value containing the specified items
+func NewMetadatumList(items ...TransactionMetadatum) TransactionMetadatum {
+	return TransactionMetadatum{
+		item: append([]TransactionMetadatum{}, items...),
+	}
+}
+
+// NewMetadatumMap returns a map value containing the specified pairs in order
+func NewMetadatumMap(pairs ...TransactionMetadatumMapPair) TransactionMetadatum {
+	return TransactionMetadatum{
+		item: append([]TransactionMetadatumMapPair{}, pairs...),
+	}
+}
+
+// NewTransactionMetadatumFromCbor decodes a metadata value from CBOR
+func NewTransactionMetadatumFromCbor(data []byte) (TransactionMetadatum, error) {
+	var ret TransactionMetadatum
+	if _, err := cbor.Decode(data, &ret); err != nil {
+		return TransactionMetadatum{}, err
+	}
+	return ret, nil
+}
+
+// Type returns the kind of the value
+func (m TransactionMetadatum) Type() TransactionMetadatumType {
+	switch m.item.(type) {
+	case *big.Int:
+		return TransactionMetadatumTypeInt
+	case []byte:
+		return TransactionMetadatumTypeBytes
+	case string:
+		return TransactionMetadatumTypeText
+	case []TransactionMetadatum:
+		return TransactionMetadatumTypeList
+	default:
+		return TransactionMetadatumTypeMap
+	}
+}
+
+// Integer returns the integer value, or nil if the value is not an integer
+func (m TransactionMetadatum) Integer() *big.Int {
+	if v, ok := m.item.(*big.Int); ok {
+		return new(big.Int).Set(v)
+	}
+	return nil
+}
+
+// Bytes returns the bytestring value, or nil if the value is not a bytestring
+func (m TransactionMetadatum) Bytes() []byte {
+	if v, ok := m.item.([]byte); ok {
+		return v
+	}
+	return nil
+}
+
+// Text returns the text value, and whether the value is text
+func (m TransactionMetadatum) Text() (string, bool) {
+	v, ok := m.item.(string)
+	return v, ok
+}
+
+// List returns the list items, or nil if the value is not a list
+func (m TransactionMetadatum) List() []TransactionMetadatum {
+	if v, ok := m.item.([]TransactionMetadatum); ok {
+		return v
+	}
+	return nil
+}
+
+// Map returns the map pairs in their original order, or nil if the value is not a map
+func (m TransactionMetadatum) Map() []TransactionMetadatumMapPair {
+	if v, ok := m.item.([]TransactionMetadatumMapPair); ok {
+		return v
+	}
+	return nil
+}
+
+// MapValue returns the value for the first map key equal to the specified key, and whether the key
+// was found
+func (m TransactionMetadatum) MapValue(key TransactionMetadatum) (TransactionMetadatum, bool) {
+	for _, pair := range m.Map() {
+		if pair.Key.Equal(key) {
+			return pair.Value, true
+		}
+	}
+	return TransactionMetadatum{}, false
+}
+
+// Equal returns whether both values are the same, ignoring their CBOR encoding
+func (m TransactionMetadatum) Equal(other TransactionMetadatum) bool {
+	switch v := m.item.(type) {
+	case *big.Int:
+		o, ok := other.item.(*big.Int)
+		return ok && v.Cmp(o) == 0
+	case []byte:
+		o, ok := other.item.([]byte)
+		return ok && string(v) == string(o)
+	case string:
+		o, ok := other.item.(string)
+		return ok && v == o
+	case []TransactionMetadatum:
+		o, ok := other.item.([]TransactionMetadatum)
+		return ok && slices.EqualFunc(v, o, TransactionMetadatum.Equal)
+	case []TransactionMetadatumMapPair:
+		o, ok := other.item.([]TransactionMetadatumMapPair)
+		return ok && slices.EqualFunc(
+			v,
+			o,
+			func(a, b TransactionMetadatumMapPair) bool {
+				return a.Key.Equal(b.Key) && a.Value.Equal(b.Value)
+			},
+		)
+	}
+	return false
+}
+
+// Validate checks that all bytestrings and text strings in the value are within the size limit
+func (m TransactionMetadatum) Validate() error {
+	switch v := m.item.(type) {
+	case []byte:
+		if len(v) > MetadatumMaxStringSize {
+			return fmt.Errorf("metadata bytestring too long: %d bytes", len(v))
+		}
+	case string:
+		if len(v) > MetadatumMaxStringSize {
+			return fmt.Errorf("metadata text too long: %d bytes", len(v))
+		}
+	case []TransactionMetadatum:
+		for _, item := range v {
+			if err := item.Validate(); err != nil {
+				return err
+			}
+		}
+	case []TransactionMetadatumMapPair:
+		for _, pair := range v {
+			if err := pair.Key.Validate(); err != nil {
+				return err
+			}
+			if err := pair.Value.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *TransactionMetadatum) UnmarshalCBOR(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty metadata value")
+	}
+	switch data[0] & cbor.CborTypeMask {
+	case 0x00, 0x20:
+		// Unsigned and negative integers
+		var tmpInt big.Int
+		if _, err := cbor.Decode(data, &tmpInt); err != nil {
+			return err
+		}
+		m.item = &tmpInt
+	case cbor.CborTypeByteString:
+		var tmpBytes []byte
+		if _, err := cbor.Decode(data, &tmpBytes); err != nil {
+			return err
+		}
+		m.item = tmpBytes
+	case cbor.CborTypeTextString:
+		var tmpText string
+		if _, err := cbor.Decode(data, &tmpText); err != nil {
+			return err
+		}
+		m.item = tmpText
+	case cbor.CborTypeArray:
+		tmpItems := []TransactionMetadatum{}
+		if _, err := cbor.Decode(data, &tmpItems); err != nil {
+			return err
+		}
+		m.item = tmpItems
+	case cbor.CborTypeMap:
+		pairs := []TransactionMetadatumMapPair{}
+		err := decodeCborMap(
+			data,
+			func(key TransactionMetadatum, value TransactionMetadatum) {
+				pairs = append(pairs, TransactionMetadatumMapPair{Key: key, Value: value})
+			},
+		)
+		if err != nil {
+			return err
+		}
+		m.item = pairs
+	default:
+		return fmt.Errorf("unsupported metadata CBOR type: %#x", data[0]&cbor.CborTypeMask)
+	}
+	m.SetCbor(data)
+	return nil
+}
+
+func (m TransactionMetadatum) MarshalCBOR() ([]byte, error) {
+	// Return stored CBOR if we have any
+	if cborData := m.Cbor(); cborData != nil {
+		return cborData, nil
+	}
+	switch v := m.item.(type) {
+	case *big.Int:
+		if v.IsUint64() {
+			return cbor.Encode(v.Uint64())
+		}
+		if v.IsInt64() {
+			return cbor.Encode(v.Int64())
+		}
+		return nil, fmt.Errorf("metadata integer out of range: %s", v.String())
+	case []byte:
+		return cbor.Encode(v)
+	case string:
+		return cbor.Encode(v)
+	case []TransactionMetadatum:
+		return cbor.Encode(v)
+	case []TransactionMetadatumMapPair:
+		// Maps keep their pair order, so we build the encoding by hand
+		ret := encodeCborHead(cbor.CborTypeMap, uint64(len(v)))
+		for _, pair := range v {
+			keyCbor, err := pair.Key.MarshalCBOR()
+			if err != nil {
+				return nil, err
+			}
+			valueCbor, err := pair.Value.MarshalCBOR()
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, keyCbor...)
+			ret = append(ret, valueCbor...)
+		}
+		return ret, nil
+	default:
+		return nil, errors.New("empty metadata value")
+	}
+}
+
+// transactionMetadatumJson is the cardano-cli detailed schema JSON format
+type transactionMetadatumJson struct {
+	Int    *json.Number                   `json:"int,omitempty"`
+	Bytes  *string                        `json:"bytes,omitempty"`
+	String *string                        `json:"string,omitempty"`
+	List   *[]TransactionMetadatum        `json:"list,omitempty"`
+	Map    *[]transactionMetadatumJsonMap `json:"map,omitempty"`
+}
+
+type transactionMetadatumJsonMap struct {
+	Key   TransactionMetadatum `json:"k"`
+	Value TransactionMetadatum `json:"v"`
+}
+
+// MarshalJSON returns the value in the cardano-cli detailed schema JSON format
+func (m TransactionMetadatum) MarshalJSON() ([]byte, error) {
+	var tmpData transactionMetadatumJson
+	switch v := m.item.(type) {
+	case *big.Int:
+		tmpInt := json.Number(v.String())
+		tmpData.Int = &tmpInt
+	case []byte:
+		tmpBytes := hex.EncodeToString(v)
+		tmpData.Bytes = &tmpBytes
+	case string:
+		tmpData.String = &v
+	case []TransactionMetadatum:
+		items := append([]TransactionMetadatum{}, v...)
+		tmpData.List = &items
+	case []TransactionMetadatumMapPair:
+		pairs := make([]transactionMetadatumJsonMap, len(v))
+		for idx, pair := range v {
+			pairs[idx] = transactionMetadatumJsonMap(pair)
+		}
+		tmpData.Map = &pairs
+	default:
+		return nil, errors.New("empty metadata value")
+	}
+	return json.Marshal(tmpData)
+}
+
+// UnmarshalJSON decodes a value in the cardano-cli detailed schema JSON format
+func (m *TransactionMetadatum) UnmarshalJSON(data []byte) error {
+	var tmpData transactionMetadatumJson
+	if err := json.Unmarshal(data, &tmpData); err != nil {
+		return err
+	}
+	switch {
+	case tmpData.Int != nil:
+		tmpInt, ok := new(big.Int).SetString(tmpData.Int.String(), 10)
+		if !ok {
+			return fmt.Errorf("invalid metadata integer: %s", tmpData.Int.String())
+		}
+		*m = NewMetadatumInteger(tmpInt)
+	case tmpData.Bytes != nil:
+		tmpBytes, err := hex.DecodeString(*tmpData.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid metadata bytes: %w", err)
+		}
+		*m = NewMetadatumBytes(tmpBytes)
+	case tmpData.String != nil:
+		*m = NewMetadatumText(*tmpData.String)
+	case tmpData.List != nil:
+		*m = NewMetadatumList(*tmpData.List...)
+	case tmpData.Map != nil:
+		pairs := make([]TransactionMetadatumMapPair, len(*tmpData.Map))
+		for idx, pair := range *tmpData.Map {
+			pairs[idx] = TransactionMetadatumMapPair(pair)
+		}
+		*m = NewMetadatumMap(pairs...)
+	default:
+		return errors.New("unknown metadata JSON value")
+	}
+	return nil
+}
+
+// NoSchemaJSON returns the value in the cardano-cli "no schema" JSON format. Bytestrings are
+// represented as hex strings with a 0x prefix, and map keys which are not text are converted to
+// strings
+func (m TransactionMetadatum) NoSchemaJSON() ([]byte, error) {
+	tmpValue, err := m.noSchemaValue()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(tmpValue)
+}
+
+func (m TransactionMetadatum) noSchemaValue() (any, error) {
+	switch v := m.item.(type) {
+	case *big.Int:
+		return json.Number(v.String()), nil
+	case []byte:
+		return "0x" + hex.EncodeToString(v), nil
+	case string:
+		return v, nil
+	case []TransactionMetadatum:
+		ret := make([]any, len(v))
+		for idx, item := range v {
+			tmpItem, err := item.noSchemaValue()
+			if err != nil {
+				return nil, err
+			}
+			ret[idx] = tmpItem
+		}
+		return ret, nil
+	case []TransactionMetadatumMapPair:
+		ret := make(map[string]any, len(v))
+		for _, pair := range v {
+			var key string
+			switch k := pair.Key.item.(type) {
+			case string:
+				key = k
+			case *big.Int:
+				key = k.String()
+			case []byte:
+				key = "0x" + hex.EncodeToString(k)
+			default:
+				// Lists and maps are used as keys in their JSON form
+				tmpKey, err := pair.Key.NoSchemaJSON()
+				if err != nil {
+					return nil, err
+				}
+				key = string(tmpKey)
+			}
+			tmpValue, err := pair.Value.noSchemaValue()
+			if err != nil {
+				return nil, err
+			}
+			ret[key] = tmpValue
+		}
+		return ret, nil
+	default:
+		return nil, errors.New("empty metadata value")
+	}
+}
+
+// NewTransactionMetadatumFromNoSchemaJSON decodes a value in the cardano-cli "no schema" JSON format.
+// Strings which are hex with a 0x prefix are decoded as bytestrings, and map keys which are integers
+// or 0x-prefixed hex are decoded as integers or bytestrings. Since JSON objects are unordered, map
+// pairs are sorted by key
+func NewTransactionMetadatumFromNoSchemaJSON(data []byte) (TransactionMetadatum, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	var tmpValue any
+	if err := decoder.Decode(&tmpValue); err != nil {
+		return TransactionMetadatum{}, err
+	}
+	return metadatumFromNoSchemaValue(tmpValue)
+}
+
+func metadatumFromNoSchemaValue(value any) (TransactionMetadatum, error) {
+	switch v := value.(type) {
+	case json.Number:
+		tmpInt, ok := new(big.Int).SetString(v.String(), 10)
+		if !ok {
+			return TransactionMetadatum{}, fmt.Errorf("invalid metadata integer: %s", v.String())
+		}
+		return NewMetadatumInteger(tmpInt), nil
+	case string:
+		return metadatumFromNoSchemaString(v), nil
+	case []any:
+		items := make([]TransactionMetadatum, len(v))
+		for idx, item := range v {
+			tmpItem, err := metadatumFromNoSchemaValue(item)
+			if err != nil {
+				return TransactionMetadatum{}, err
+			}
+			items[idx] = tmpItem
+		}
+		return NewMetadatumList(items...), nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		pairs := make([]TransactionMetadatumMapPair, len(keys))
+		for idx, key := range keys {
+			tmpKey := metadatumFromNoSchemaString(key)
+			if tmpInt, ok := new(big.Int).SetString(key, 10); ok {
+				tmpKey = NewMetadatumInteger(tmpInt)
+			}
+			tmpValue, err := metadatumFromNoSchemaValue(v[key])
+			if err != nil {
+				return TransactionMetadatum{}, err
+			}
+			pairs[idx] = TransactionMetadatumMapPair{Key: tmpKey, Value: tmpValue}
+		}
+		return NewMetadatumMap(pairs...), nil
+	default:
+		return TransactionMetadatum{}, fmt.Errorf("unsupported metadata JSON value: %v", value)
+	}
+}
+
+func metadatumFromNoSchemaString(value string) TransactionMetadatum {
+	if hexData, ok := strings.CutPrefix(value, "0x"); ok {
+		if tmpBytes, err := hex.DecodeString(hexData); err == nil {
+			return NewMetadatumBytes(tmpBytes)
+		}
+	}
+	return NewMetadatumText(value)
+}
+
+// TransactionMetadata is the metadata from the auxiliary data of a transaction, keyed by label
+type TransactionMetadata map[uint64]TransactionMetadatum
+
+// NewTransactionMetadataFromAuxData decodes the metadata from transaction auxiliary data. This supports
+// the Shelley format (a plain metadata map), the Allegra format (an array of the metadata and native
+// scripts), and the Alonzo format (a tagged map with the metadata at key 0)
+func NewTransactionMetadataFromAuxData(data []byte) (TransactionMetadata, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty auxiliary data")
+	}
+	metadataCbor := data
+	switch data[0] & cbor.CborTypeMask {
+	case cbor.CborTypeMap:
+	case cbor.CborTypeArray:
+		var tmpAuxData []cbor.RawMessage
+		if _, err := cbor.Decode(data, &tmpAuxData); err != nil {
+			return nil, err
+		}
+		if len(tmpAuxData) == 0 {
+			return nil, errors.New("invalid auxiliary data: empty array")
+		}
+		metadataCbor = tmpAuxData[0]
+	case cbor.CborTypeTag:
+		var tmpTag cbor.RawTag
+		if _, err := cbor.Decode(data, &tmpTag); err != nil {
+			return nil, err
+		}
+		if tmpTag.Number != cbor.CborTagMap {
+			return nil, fmt.Errorf("invalid auxiliary data tag: %d", tmpTag.Number)
+		}
+		var tmpAuxData map[uint]cbor.RawMessage
+		if _, err := cbor.Decode(tmpTag.Content, &tmpAuxData); err != nil {
+			return nil, err
+		}
+		var ok bool
+		if metadataCbor, ok = tmpAuxData[0]; !ok {
+			return TransactionMetadata{}, nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid auxiliary data CBOR type: %#x", data[0]&cbor.CborTypeMask)
+	}
+	var ret TransactionMetadata
+	if _, err := cbor.Decode(metadataCbor, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// TxMetadata returns the decoded metadata for a transaction, or nil if it has no auxiliary data
+func TxMetadata(tx Transaction) (TransactionMetadata, error) {
+	auxData := tx.Metadata()
+	if auxData == nil {
+		return nil, nil
+	}
+	return NewTransactionMetadataFromAuxData(auxData.Cbor())
+}
+
+// MarshalJSON returns the metadata in the cardano-cli detailed schema JSON format, keyed by label
+func (m TransactionMetadata) MarshalJSON() ([]byte, error) {
+	tmpData := make(map[string]TransactionMetadatum, len(m))
+	for label, value := range m {
+		tmpData[strconv.FormatUint(label, 10)] = value
+	}
+	return json.Marshal(tmpData)
+}
+
+// UnmarshalJSON decodes metadata in the cardano-cli detailed schema JSON format, keyed by label
+func (m *TransactionMetadata) UnmarshalJSON(data []byte) error {
+	var tmpData map[string]TransactionMetadatum
+	if err := json.Unmarshal(data, &tmpData); err != nil {
+		return err
+	}
+	ret, err := metadataFromLabels(tmpData)
+	if err != nil {
+		return err
+	}
+	*m = ret
+	return nil
+}
+
+// NoSchemaJSON returns the metadata in the cardano-cli "no schema" JSON format, keyed by label
+func (m TransactionMetadata) NoSchemaJSON() ([]byte, error) {
+	tmpData := make(map[string]json.RawMessage, len(m))
+	for label, value := range m {
+		tmpValue, err := value.NoSchemaJSON()
+		if err != nil {
+			return nil, err
+		}
+		tmpData[strconv.FormatUint(label, 10)] = tmpValue
+	}
+	return json.Marshal(tmpData)
+}
+
+// NewTransactionMetadataFromNoSchemaJSON decodes metadata in the cardano-cli "no schema" JSON format,
+// keyed by label
+func NewTransactionMetadataFromNoSchemaJSON(data []byte) (TransactionMetadata, error) {
+	var tmpData map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tmpData); err != nil {
+		return nil, err
+	}
+	tmpValues := make(map[string]TransactionMetadatum, len(tmpData))
+	for label, value := range tmpData {
+		tmpValue, err := NewTransactionMetadatumFromNoSchemaJSON(value)
+		if err != nil {
+			return nil, fmt.Errorf("metadata label %s: %w", label, err)
+		}
+		tmpValues[label] = tmpValue
+	}
+	return metadataFromLabels(tmpValues)
+}
+
+func metadataFromLabels(values map[string]TransactionMetadatum) (TransactionMetadata, error) {
+	ret := make(TransactionMetadata, len(values))
+	for label, value := range values {
+		tmpLabel, err := strconv.ParseUint(label, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata label: %s", label)
+		}
+		ret[tmpLabel] = value
+	}
+	return ret, nil
+}
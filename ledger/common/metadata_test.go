@@ -0,0 +1,199 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+func TestTransactionMetadatumCborRoundTrip(t *testing.T) {
+	testDefs := []struct {
+		name    string
+		cborHex string
+	}{
+		{
+			name:    "integer",
+			cborHex: "1903e8",
+		},
+		{
+			name:    "negative integer",
+			cborHex: "3863",
+		},
+		{
+			name:    "bytes",
+			cborHex: "43010203",
+		},
+		{
+			name:    "text",
+			cborHex: "6568656c6c6f",
+		},
+		{
+			name:    "indefinite list",
+			cborHex: "9f0102ff",
+		},
+		{
+			name:    "unsorted map",
+			cborHex: "a2026162016161",
+		},
+		{
+			name:    "indefinite-length unsorted map",
+			cborHex: "bf026162016161ff",
+		},
+		{
+			name:    "nested",
+			cborHex: "a16161a1624b31820142abcd",
+		},
+	}
+	for _, testDef := range testDefs {
+		cborData, err := hex.DecodeString(testDef.cborHex)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		md, err := NewTransactionMetadatumFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		// Re-encode from stored CBOR
+		encoded, err := cbor.Encode(md)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if hex.EncodeToString(encoded) != testDef.cborHex {
+			t.Errorf("%s: did not get expected CBOR\n  got:    %x\n  wanted: %s", testDef.name, encoded, testDef.cborHex)
+		}
+		// Re-encode from the detailed schema JSON, which doesn't preserve indefinite lengths
+		jsonData, err := json.Marshal(md)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		var tmpMd TransactionMetadatum
+		if err := json.Unmarshal(jsonData, &tmpMd); err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if !tmpMd.Equal(md) {
+			t.Errorf("%s: value does not match after JSON round-trip: %s", testDef.name, jsonData)
+		}
+	}
+}
+
+func TestTransactionMetadatumJson(t *testing.T) {
+	md := NewMetadatumMap(
+		TransactionMetadatumMapPair{
+			Key:   NewMetadatumInt(1),
+			Value: NewMetadatumBytes([]byte{0xab}),
+		},
+		TransactionMetadatumMapPair{
+			Key:   NewMetadatumText("x"),
+			Value: NewMetadatumList(NewMetadatumInt(-5), NewMetadatumText("y")),
+		},
+	)
+	expectedDetailed := `{"map":[{"k":{"int":1},"v":{"bytes":"ab"}},{"k":{"string":"x"},"v":{"list":[{"int":-5},{"string":"y"}]}}]}`
+	expectedNoSchema := `{"1":"0xab","x":[-5,"y"]}`
+	jsonData, err := json.Marshal(md)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(jsonData) != expectedDetailed {
+		t.Errorf("did not get expected detailed JSON\n  got:    %s\n  wanted: %s", jsonData, expectedDetailed)
+	}
+	var tmpMd TransactionMetadatum
+	if err := json.Unmarshal([]byte(expectedDetailed), &tmpMd); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !tmpMd.Equal(md) {
+		t.Errorf("value does not match after decoding detailed JSON")
+	}
+	jsonData, err = md.NoSchemaJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(jsonData) != expectedNoSchema {
+		t.Errorf("did not get expected no schema JSON\n  got:    %s\n  wanted: %s", jsonData, expectedNoSchema)
+	}
+	tmpMd, err = NewTransactionMetadatumFromNoSchemaJSON([]byte(expectedNoSchema))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !tmpMd.Equal(md) {
+		t.Errorf("value does not match after decoding no schema JSON")
+	}
+}
+
+func TestTransactionMetadatumValidate(t *testing.T) {
+	if err := NewMetadatumText(strings.Repeat("a", 64)).Validate(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	md := NewMetadatumList(NewMetadatumText(strings.Repeat("a", 65)))
+	if err := md.Validate(); err == nil {
+		t.Fatalf("did not get expected error for long text")
+	}
+	md = NewMetadatumMap(
+		TransactionMetadatumMapPair{
+			Key:   NewMetadatumBytes(make([]byte, 65)),
+			Value: NewMetadatumInt(0),
+		},
+	)
+	if err := md.Validate(); err == nil {
+		t.Fatalf("did not get expected error for long bytes")
+	}
+}
+
+func TestTransactionMetadataFromAuxData(t *testing.T) {
+	testDefs := []struct {
+		name    string
+		cborHex string
+	}{
+		{
+			name:    "Shelley",
+			cborHex: "a1186462616161",
+		},
+		{
+			name:    "Allegra",
+			cborHex: "82a118646261616180",
+		},
+		{
+			name:    "Alonzo",
+			cborHex: "d90103a100a1186462616161",
+		},
+	}
+	for _, testDef := range testDefs {
+		cborData, err := hex.DecodeString(testDef.cborHex)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		metadata, err := NewTransactionMetadataFromAuxData(cborData)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if len(metadata) != 1 {
+			t.Fatalf("%s: did not get expected number of labels: %d", testDef.name, len(metadata))
+		}
+		if text, ok := metadata[100].Text(); !ok || text != "aa" {
+			t.Errorf("%s: did not get expected value for label 100", testDef.name)
+		}
+		jsonData, err := metadata.NoSchemaJSON()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if string(jsonData) != `{"100":"aa"}` {
+			t.Errorf("%s: did not get expected no schema JSON: %s", testDef.name, jsonData)
+		}
+	}
+}
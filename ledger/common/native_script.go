@@ -15,15 +15,145 @@
 package common
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 
 	"github.com/blinklabs-io/gouroboros/cbor"
 )
 
+const (
+	NativeScriptTypePubkey           = 0
+	NativeScriptTypeAll              = 1
+	NativeScriptTypeAny              = 2
+	NativeScriptTypeNofK             = 3
+	NativeScriptTypeInvalidBefore    = 4
+	NativeScriptTypeInvalidHereafter = 5
+)
+
+const (
+	ScriptTypeNative   = 0
+	ScriptTypePlutusV1 = 1
+	ScriptTypePlutusV2 = 2
+	ScriptTypePlutusV3 = 3
+)
+
+// ScriptHash returns the hash of a script with the specified type. The script type is used as a prefix
+// byte when hashing to prevent the same bytes producing the same hash for different script languages
+func ScriptHash(scriptType uint8, script []byte) Blake2b224 {
+	return Blake2b224Hash(append([]byte{scriptType}, script...))
+}
+
 type NativeScript struct {
+	cbor.DecodeStoreCbor
 	item any
 }
 
+// NewNativeScriptPubkey returns a native script that requires a signature from the key with the provided hash
+func NewNativeScriptPubkey(keyHash Blake2b224) NativeScript {
+	return newNativeScriptLeaf(
+		&NativeScriptPubkey{
+			Type: NativeScriptTypePubkey,
+			Hash: keyHash.Bytes(),
+		},
+		NativeScriptTypePubkey,
+		append(
+			encodeCborHead(cbor.CborTypeByteString, uint64(len(keyHash.Bytes()))),
+			keyHash.Bytes()...,
+		),
+	)
+}
+
+// NewNativeScriptAll returns a native script that requires all of the provided scripts to be satisfied
+func NewNativeScriptAll(scripts ...NativeScript) (NativeScript, error) {
+	return newNativeScript(
+		&NativeScriptAll{
+			Type:    NativeScriptTypeAll,
+			Scripts: append([]NativeScript{}, scripts...),
+		},
+		scripts,
+	)
+}
+
+// NewNativeScriptAny returns a native script that requires any of the provided scripts to be satisfied
+func NewNativeScriptAny(scripts ...NativeScript) (NativeScript, error) {
+	return newNativeScript(
+		&NativeScriptAny{
+			Type:    NativeScriptTypeAny,
+			Scripts: append([]NativeScript{}, scripts...),
+		},
+		scripts,
+	)
+}
+
+// NewNativeScriptNofK returns a native script that requires at least n of the provided scripts to be satisfied
+func NewNativeScriptNofK(n uint, scripts ...NativeScript) (NativeScript, error) {
+	return newNativeScript(
+		&NativeScriptNofK{
+			Type:    NativeScriptTypeNofK,
+			N:       n,
+			Scripts: append([]NativeScript{}, scripts...),
+		},
+		scripts,
+	)
+}
+
+// NewNativeScriptInvalidBefore returns a native script that is satisfied from the provided slot onward
+func NewNativeScriptInvalidBefore(slot uint64) NativeScript {
+	return newNativeScriptLeaf(
+		&NativeScriptInvalidBefore{
+			Type: NativeScriptTypeInvalidBefore,
+			Slot: slot,
+		},
+		NativeScriptTypeInvalidBefore,
+		encodeCborHead(cbor.CborTypeUnsignedInt, slot),
+	)
+}
+
+// NewNativeScriptInvalidHereafter returns a native script that is satisfied before the provided slot
+func NewNativeScriptInvalidHereafter(slot uint64) NativeScript {
+	return newNativeScriptLeaf(
+		&NativeScriptInvalidHereafter{
+			Type: NativeScriptTypeInvalidHereafter,
+			Slot: slot,
+		},
+		NativeScriptTypeInvalidHereafter,
+		encodeCborHead(cbor.CborTypeUnsignedInt, slot),
+	)
+}
+
+// newNativeScript returns a native script with child scripts. An error is returned if any of the child scripts
+// is empty
+func newNativeScript(item any, scripts []NativeScript) (NativeScript, error) {
+	for idx, script := range scripts {
+		if script.item == nil && script.Cbor() == nil {
+			return NativeScript{}, fmt.Errorf("native script %d is empty", idx)
+		}
+	}
+	cborData, err := cbor.Encode(item)
+	if err != nil {
+		return NativeScript{}, err
+	}
+	ret := NativeScript{
+		item: item,
+	}
+	ret.SetCbor(cborData)
+	return ret, nil
+}
+
+// newNativeScriptLeaf returns a native script without child scripts, which is encoded as a list of the script
+// type and the provided value CBOR
+func newNativeScriptLeaf(item any, scriptType uint64, valueCbor []byte) NativeScript {
+	cborData := encodeCborHead(cbor.CborTypeArray, 2)
+	cborData = append(cborData, encodeCborHead(cbor.CborTypeUnsignedInt, scriptType)...)
+	cborData = append(cborData, valueCbor...)
+	ret := NativeScript{
+		item: item,
+	}
+	ret.SetCbor(cborData)
+	return ret
+}
+
 func (n *NativeScript) Item() any {
 	return n.item
 }
@@ -35,17 +165,17 @@ func (n *NativeScript) UnmarshalCBOR(data []byte) error {
 	}
 	var tmpData any
 	switch id {
-	case 0:
+	case NativeScriptTypePubkey:
 		tmpData = &NativeScriptPubkey{}
-	case 1:
+	case NativeScriptTypeAll:
 		tmpData = &NativeScriptAll{}
-	case 2:
+	case NativeScriptTypeAny:
 		tmpData = &NativeScriptAny{}
-	case 3:
+	case NativeScriptTypeNofK:
 		tmpData = &NativeScriptNofK{}
-	case 4:
+	case NativeScriptTypeInvalidBefore:
 		tmpData = &NativeScriptInvalidBefore{}
-	case 5:
+	case NativeScriptTypeInvalidHereafter:
 		tmpData = &NativeScriptInvalidHereafter{}
 	default:
 		return fmt.Errorf("unknown native script type %d", id)
@@ -53,9 +183,168 @@ func (n *NativeScript) UnmarshalCBOR(data []byte) error {
 	if _, err := cbor.Decode(data, tmpData); err != nil {
 		return err
 	}
+	n.SetCbor(data)
+	n.item = tmpData
 	return nil
 }
 
+func (n NativeScript) MarshalCBOR() ([]byte, error) {
+	// Return stored CBOR if we have any
+	if cborData := n.Cbor(); cborData != nil {
+		return cborData, nil
+	}
+	if n.item == nil {
+		return nil, fmt.Errorf("native script is empty")
+	}
+	return cbor.Encode(n.item)
+}
+
+// nativeScriptJson is the cardano-cli simple script JSON format
+type nativeScriptJson struct {
+	Type     string          `json:"type"`
+	KeyHash  string          `json:"keyHash,omitempty"`
+	Required *uint           `json:"required,omitempty"`
+	Slot     *uint64         `json:"slot,omitempty"`
+	Scripts  *[]NativeScript `json:"scripts,omitempty"`
+}
+
+// MarshalJSON returns the script in the cardano-cli simple script JSON format
+func (n NativeScript) MarshalJSON() ([]byte, error) {
+	var tmpData nativeScriptJson
+	switch item := n.item.(type) {
+	case *NativeScriptPubkey:
+		tmpData.Type = "sig"
+		tmpData.KeyHash = hex.EncodeToString(item.Hash)
+	case *NativeScriptAll:
+		tmpData.Type = "all"
+		tmpData.Scripts = nonNilScripts(item.Scripts)
+	case *NativeScriptAny:
+		tmpData.Type = "any"
+		tmpData.Scripts = nonNilScripts(item.Scripts)
+	case *NativeScriptNofK:
+		tmpData.Type = "atLeast"
+		tmpData.Required = &item.N
+		tmpData.Scripts = nonNilScripts(item.Scripts)
+	case *NativeScriptInvalidBefore:
+		tmpData.Type = "after"
+		tmpData.Slot = &item.Slot
+	case *NativeScriptInvalidHereafter:
+		tmpData.Type = "before"
+		tmpData.Slot = &item.Slot
+	default:
+		return nil, fmt.Errorf("native script is empty")
+	}
+	return json.Marshal(tmpData)
+}
+
+// UnmarshalJSON decodes a script in the cardano-cli simple script JSON format
+func (n *NativeScript) UnmarshalJSON(data []byte) error {
+	var tmpData nativeScriptJson
+	if err := json.Unmarshal(data, &tmpData); err != nil {
+		return err
+	}
+	var scripts []NativeScript
+	if tmpData.Scripts != nil {
+		scripts = *tmpData.Scripts
+	}
+	switch tmpData.Type {
+	case "sig":
+		keyHash, err := hex.DecodeString(tmpData.KeyHash)
+		if err != nil {
+			return fmt.Errorf("invalid native script key hash: %w", err)
+		}
+		if len(keyHash) != Blake2b224Size {
+			return fmt.Errorf("invalid native script key hash length: %d", len(keyHash))
+		}
+		*n = NewNativeScriptPubkey(NewBlake2b224(keyHash))
+	case "all":
+		tmpScript, err := NewNativeScriptAll(scripts...)
+		if err != nil {
+			return err
+		}
+		*n = tmpScript
+	case "any":
+		tmpScript, err := NewNativeScriptAny(scripts...)
+		if err != nil {
+			return err
+		}
+		*n = tmpScript
+	case "atLeast":
+		if tmpData.Required == nil {
+			return fmt.Errorf("native script missing required count")
+		}
+		tmpScript, err := NewNativeScriptNofK(*tmpData.Required, scripts...)
+		if err != nil {
+			return err
+		}
+		*n = tmpScript
+	case "after":
+		if tmpData.Slot == nil {
+			return fmt.Errorf("native script missing slot")
+		}
+		*n = NewNativeScriptInvalidBefore(*tmpData.Slot)
+	case "before":
+		if tmpData.Slot == nil {
+			return fmt.Errorf("native script missing slot")
+		}
+		*n = NewNativeScriptInvalidHereafter(*tmpData.Slot)
+	default:
+		return fmt.Errorf("unknown native script type: %s", tmpData.Type)
+	}
+	return nil
+}
+
+// nonNilScripts returns a pointer to the scripts list, which is always included in the JSON output
+func nonNilScripts(scripts []NativeScript) *[]NativeScript {
+	if scripts == nil {
+		scripts = []NativeScript{}
+	}
+	return &scripts
+}
+
+// Hash returns the script hash, which is calculated over the script CBOR with a language prefix
+func (n *NativeScript) Hash() Blake2b224 {
+	return ScriptHash(ScriptTypeNative, n.Cbor())
+}
+
+// Evaluate returns whether the native script is satisfied by the provided key hashes and validity interval.
+// A validity interval bound of 0 means that the bound is not set
+func (n *NativeScript) Evaluate(keyHashes map[Blake2b224]bool, validityStart uint64, validityEnd uint64) bool {
+	switch item := n.item.(type) {
+	case *NativeScriptPubkey:
+		return keyHashes[NewBlake2b224(item.Hash)]
+	case *NativeScriptAll:
+		for _, script := range item.Scripts {
+			if !script.Evaluate(keyHashes, validityStart, validityEnd) {
+				return false
+			}
+		}
+		return true
+	case *NativeScriptAny:
+		for _, script := range item.Scripts {
+			if script.Evaluate(keyHashes, validityStart, validityEnd) {
+				return true
+			}
+		}
+		return false
+	case *NativeScriptNofK:
+		var satisfied uint
+		for _, script := range item.Scripts {
+			if script.Evaluate(keyHashes, validityStart, validityEnd) {
+				satisfied++
+			}
+		}
+		return satisfied >= item.N
+	case *NativeScriptInvalidBefore:
+		// The transaction must not be valid before the specified slot
+		return validityStart != 0 && item.Slot <= validityStart
+	case *NativeScriptInvalidHereafter:
+		// The transaction must not be valid at or after the specified slot
+		return validityEnd != 0 && validityEnd <= item.Slot
+	}
+	return false
+}
+
 type NativeScriptPubkey struct {
 	cbor.StructAsArray
 	Type uint
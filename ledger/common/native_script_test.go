@@ -0,0 +1,360 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+func TestNativeScriptEvaluate(t *testing.T) {
+	keyHash1 := Blake2b224Hash([]byte("key1"))
+	keyHash2 := Blake2b224Hash([]byte("key2"))
+	keyHash3 := Blake2b224Hash([]byte("key3"))
+	testDefs := []struct {
+		name          string
+		script        any
+		keyHashes     map[Blake2b224]bool
+		validityStart uint64
+		validityEnd   uint64
+		expected      bool
+	}{
+		{
+			name:      "pubkey present",
+			script:    []any{0, keyHash1.Bytes()},
+			keyHashes: map[Blake2b224]bool{keyHash1: true},
+			expected:  true,
+		},
+		{
+			name:      "pubkey missing",
+			script:    []any{0, keyHash1.Bytes()},
+			keyHashes: map[Blake2b224]bool{keyHash2: true},
+			expected:  false,
+		},
+		{
+			name: "all partially satisfied",
+			script: []any{
+				1,
+				[]any{
+					[]any{0, keyHash1.Bytes()},
+					[]any{0, keyHash2.Bytes()},
+				},
+			},
+			keyHashes: map[Blake2b224]bool{keyHash1: true},
+			expected:  false,
+		},
+		{
+			name: "any partially satisfied",
+			script: []any{
+				2,
+				[]any{
+					[]any{0, keyHash1.Bytes()},
+					[]any{0, keyHash2.Bytes()},
+				},
+			},
+			keyHashes: map[Blake2b224]bool{keyHash2: true},
+			expected:  true,
+		},
+		{
+			name: "2-of-3 satisfied",
+			script: []any{
+				3,
+				2,
+				[]any{
+					[]any{0, keyHash1.Bytes()},
+					[]any{0, keyHash2.Bytes()},
+					[]any{0, keyHash3.Bytes()},
+				},
+			},
+			keyHashes: map[Blake2b224]bool{keyHash1: true, keyHash3: true},
+			expected:  true,
+		},
+		{
+			name: "2-of-3 not satisfied",
+			script: []any{
+				3,
+				2,
+				[]any{
+					[]any{0, keyHash1.Bytes()},
+					[]any{0, keyHash2.Bytes()},
+					[]any{0, keyHash3.Bytes()},
+				},
+			},
+			keyHashes: map[Blake2b224]bool{keyHash3: true},
+			expected:  false,
+		},
+		{
+			name:          "invalid before satisfied",
+			script:        []any{4, 1000},
+			validityStart: 1000,
+			expected:      true,
+		},
+		{
+			name:          "invalid before too early",
+			script:        []any{4, 1000},
+			validityStart: 999,
+			expected:      false,
+		},
+		{
+			name:     "invalid before unbounded",
+			script:   []any{4, 1000},
+			expected: false,
+		},
+		{
+			name:        "invalid hereafter satisfied",
+			script:      []any{5, 1000},
+			validityEnd: 1000,
+			expected:    true,
+		},
+		{
+			name:        "invalid hereafter too late",
+			script:      []any{5, 1000},
+			validityEnd: 1001,
+			expected:    false,
+		},
+	}
+	for _, testDef := range testDefs {
+		scriptCbor, err := cbor.Encode(testDef.script)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var script NativeScript
+		if _, err := cbor.Decode(scriptCbor, &script); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		result := script.Evaluate(
+			testDef.keyHashes,
+			testDef.validityStart,
+			testDef.validityEnd,
+		)
+		if result != testDef.expected {
+			t.Errorf(
+				"%s: did not get expected result: got %v, wanted %v",
+				testDef.name,
+				result,
+				testDef.expected,
+			)
+		}
+	}
+}
+
+func TestNativeScriptHash(t *testing.T) {
+	// Single pubkey script for an all-zero key hash
+	scriptCbor := []byte{0x82, 0x00, 0x58, 0x1c}
+	scriptCbor = append(scriptCbor, make([]byte, 28)...)
+	var script NativeScript
+	if _, err := cbor.Decode(scriptCbor, &script); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedHash := Blake2b224Hash(append([]byte{ScriptTypeNative}, scriptCbor...))
+	if script.Hash() != expectedHash {
+		t.Errorf(
+			"did not get expected script hash: got %s, wanted %s",
+			script.Hash().String(),
+			expectedHash.String(),
+		)
+	}
+}
+
+func TestNativeScriptConstructors(t *testing.T) {
+	keyHash := Blake2b224Hash([]byte("key1"))
+	script, err := NewNativeScriptAll(
+		NewNativeScriptPubkey(keyHash),
+		NewNativeScriptInvalidHereafter(1000),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedCbor, err := cbor.Encode(
+		[]any{
+			1,
+			[]any{
+				[]any{0, keyHash.Bytes()},
+				[]any{5, 1000},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	scriptCbor, err := cbor.Encode(script)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(scriptCbor, expectedCbor) {
+		t.Fatalf(
+			"did not get expected CBOR: got %x, wanted %x",
+			scriptCbor,
+			expectedCbor,
+		)
+	}
+	var decoded NativeScript
+	if _, err := cbor.Decode(scriptCbor, &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if decoded.Hash() != script.Hash() {
+		t.Errorf(
+			"did not get expected script hash: got %s, wanted %s",
+			decoded.Hash().String(),
+			script.Hash().String(),
+		)
+	}
+	if !script.Evaluate(map[Blake2b224]bool{keyHash: true}, 0, 1000) {
+		t.Errorf("expected script to validate")
+	}
+	if script.Evaluate(map[Blake2b224]bool{keyHash: true}, 0, 1001) {
+		t.Errorf("expected script to fail after validity end")
+	}
+}
+
+func TestNativeScriptConstructorsEmptyScript(t *testing.T) {
+	keyHash := Blake2b224Hash([]byte("key1"))
+	if _, err := NewNativeScriptAll(NewNativeScriptPubkey(keyHash), NativeScript{}); err == nil {
+		t.Errorf("did not get expected error for empty script in all")
+	}
+	if _, err := NewNativeScriptAny(NativeScript{}); err == nil {
+		t.Errorf("did not get expected error for empty script in any")
+	}
+	if _, err := NewNativeScriptNofK(1, NativeScript{}); err == nil {
+		t.Errorf("did not get expected error for empty script in atLeast")
+	}
+}
+
+func TestNativeScriptLeafCbor(t *testing.T) {
+	keyHash := Blake2b224Hash([]byte("key1"))
+	testDefs := []struct {
+		script   NativeScript
+		expected []any
+	}{
+		{script: NewNativeScriptPubkey(keyHash), expected: []any{0, keyHash.Bytes()}},
+		{script: NewNativeScriptInvalidBefore(0), expected: []any{4, 0}},
+		{script: NewNativeScriptInvalidBefore(24), expected: []any{4, 24}},
+		{script: NewNativeScriptInvalidHereafter(1 << 40), expected: []any{5, uint64(1 << 40)}},
+		{script: NewNativeScriptInvalidHereafter(math.MaxUint64), expected: []any{5, uint64(math.MaxUint64)}},
+	}
+	for _, testDef := range testDefs {
+		expectedCbor, err := cbor.Encode(testDef.expected)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		scriptCbor, err := cbor.Encode(testDef.script)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(scriptCbor, expectedCbor) {
+			t.Errorf("did not get expected CBOR: got %x, wanted %x", scriptCbor, expectedCbor)
+		}
+		var decoded NativeScript
+		if _, err := cbor.Decode(scriptCbor, &decoded); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if decoded.Hash() != testDef.script.Hash() {
+			t.Errorf("did not get expected script hash for %x", scriptCbor)
+		}
+	}
+}
+
+func TestNativeScriptJson(t *testing.T) {
+	keyHash := Blake2b224Hash([]byte("key1"))
+	allScript, err := NewNativeScriptAll(
+		NewNativeScriptPubkey(keyHash),
+		NewNativeScriptInvalidHereafter(1000),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	anyScript, err := NewNativeScriptAny()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	nofkScript, err := NewNativeScriptNofK(
+		1,
+		NewNativeScriptInvalidBefore(500),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testDefs := []struct {
+		name     string
+		script   NativeScript
+		jsonData string
+	}{
+		{
+			name:     "sig",
+			script:   NewNativeScriptPubkey(keyHash),
+			jsonData: `{"type":"sig","keyHash":"` + keyHash.String() + `"}`,
+		},
+		{
+			name:     "all",
+			script:   allScript,
+			jsonData: `{"type":"all","scripts":[{"type":"sig","keyHash":"` + keyHash.String() + `"},{"type":"before","slot":1000}]}`,
+		},
+		{
+			name:     "any empty",
+			script:   anyScript,
+			jsonData: `{"type":"any","scripts":[]}`,
+		},
+		{
+			name:     "atLeast",
+			script:   nofkScript,
+			jsonData: `{"type":"atLeast","required":1,"scripts":[{"type":"after","slot":500}]}`,
+		},
+	}
+	for _, testDef := range testDefs {
+		jsonData, err := json.Marshal(testDef.script)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if string(jsonData) != testDef.jsonData {
+			t.Errorf(
+				"%s: did not get expected JSON: got %s, wanted %s",
+				testDef.name,
+				jsonData,
+				testDef.jsonData,
+			)
+		}
+		var decoded NativeScript
+		if err := json.Unmarshal([]byte(testDef.jsonData), &decoded); err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if decoded.Hash() != testDef.script.Hash() {
+			t.Errorf(
+				"%s: did not get expected script hash: got %s, wanted %s",
+				testDef.name,
+				decoded.Hash().String(),
+				testDef.script.Hash().String(),
+			)
+		}
+	}
+}
+
+func TestNativeScriptJsonInvalid(t *testing.T) {
+	testDefs := []string{
+		`{"type":"foo"}`,
+		`{"type":"sig","keyHash":"abcd"}`,
+		`{"type":"atLeast","scripts":[]}`,
+		`{"type":"before"}`,
+	}
+	for _, testDef := range testDefs {
+		var script NativeScript
+		if err := json.Unmarshal([]byte(testDef), &script); err == nil {
+			t.Errorf("did not get expected error for JSON: %s", testDef)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+// OperationalCertificate delegates block production from a pool's cold key to a KES (hot) key,
+// starting from the specified KES period
+type OperationalCertificate struct {
+	cbor.StructAsArray
+	HotVkey        []byte
+	SequenceNumber uint32
+	KesPeriod      uint32
+	Signature      []byte
+}
+
+// SignedData returns the data signed by the cold key, which is the hot vkey followed by the sequence
+// number and KES period as big-endian 64-bit integers
+func (c *OperationalCertificate) SignedData() []byte {
+	return slices.Concat(
+		c.HotVkey,
+		binary.BigEndian.AppendUint64(nil, uint64(c.SequenceNumber)),
+		binary.BigEndian.AppendUint64(nil, uint64(c.KesPeriod)),
+	)
+}
+
+// Verify checks the signature of the certificate against the specified cold vkey
+func (c *OperationalCertificate) Verify(coldVkey []byte) error {
+	if len(coldVkey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid opcert cold vkey length: %d", len(coldVkey))
+	}
+	if len(c.Signature) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid opcert signature length: %d", len(c.Signature))
+	}
+	if !ed25519.Verify(coldVkey, c.SignedData(), c.Signature) {
+		return errors.New("invalid opcert signature")
+	}
+	return nil
+}
+
+// KesPeriodValid checks that the specified KES period is within the validity window of the
+// certificate, which starts at the certificate KES period and lasts for maxKesEvolutions periods
+func (c *OperationalCertificate) KesPeriodValid(kesPeriod uint64, maxKesEvolutions uint64) error {
+	if kesPeriod < uint64(c.KesPeriod) {
+		return fmt.Errorf(
+			"KES period %d is before opcert KES period %d",
+			kesPeriod,
+			c.KesPeriod,
+		)
+	}
+	if kesPeriod >= uint64(c.KesPeriod)+maxKesEvolutions {
+		return fmt.Errorf(
+			"KES period %d is after the end of the opcert validity (KES period %d + %d evolutions)",
+			kesPeriod,
+			c.KesPeriod,
+			maxKesEvolutions,
+		)
+	}
+	return nil
+}
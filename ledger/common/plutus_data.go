@@ -0,0 +1,600 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+// PlutusDataType identifies the kind of a PlutusData value
+type PlutusDataType uint8
+
+const (
+	PlutusDataTypeConstr PlutusDataType = iota
+	PlutusDataTypeMap
+	PlutusDataTypeList
+	PlutusDataTypeInteger
+	PlutusDataTypeBytes
+)
+
+// Plutus bytestrings longer than this are encoded as indefinite-length chunked bytestrings
+const plutusDataBytesChunkSize = 64
+
+const (
+	cborTagPositiveBignum = 2
+	cborTagNegativeBignum = 3
+)
+
+// PlutusData represents a Plutus data value, as used for datums and redeemers
+type PlutusData struct {
+	cbor.DecodeStoreCbor
+	item any
+}
+
+// PlutusConstr is a Plutus data constructor application
+type PlutusConstr struct {
+	Constructor uint64
+	Fields      []PlutusData
+}
+
+// PlutusMapPair is a single key/value pair in a Plutus data map
+type PlutusMapPair struct {
+	Key   PlutusData
+	Value PlutusData
+}
+
+// NewPlutusConstr returns a constructor value with the specified alternative and fields
+func NewPlutusConstr(constructor uint64, fields ...PlutusData) PlutusData {
+	return PlutusData{
+		item: PlutusConstr{
+			Constructor: constructor,
+			Fields:      append([]PlutusData{}, fields...),
+		},
+	}
+}
+
+// NewPlutusMap returns a map value containing the specified pairs in order
+func NewPlutusMap(pairs ...PlutusMapPair) PlutusData {
+	return PlutusData{
+		item: append([]PlutusMapPair{}, pairs...),
+	}
+}
+
+// NewPlutusList returns a list value containing the specified items
+func NewPlutusList(items ...PlutusData) PlutusData {
+	return PlutusData{
+		item: append([]PlutusData{}, items...),
+	}
+}
+
+// NewPlutusInteger returns an integer value
+func NewPlutusInteger(value *big.Int) PlutusData {
+	return PlutusData{
+		item: new(big.Int).Set(value),
+	}
+}
+
+// NewPlutusInt returns an integer value from an int64
+func NewPlutusInt(value int64) PlutusData {
+	return PlutusData{
+		item: big.NewInt(value),
+	}
+}
+
+// NewPlutusBytes returns a bytestring value
+func NewPlutusBytes(value []byte) PlutusData {
+	return PlutusData{
+		item: append([]byte{}, value...),
+	}
+}
+
+// NewPlutusDataFromCbor decodes Plutus data from CBOR
+func NewPlutusDataFromCbor(data []byte) (PlutusData, error) {
+	var ret PlutusData
+	if _, err := cbor.Decode(data, &ret); err != nil {
+		return PlutusData{}, err
+	}
+	return ret, nil
+}
+
+// Type returns the kind of the value
+func (d PlutusData) Type() PlutusDataType {
+	switch d.item.(type) {
+	case PlutusConstr:
+		return PlutusDataTypeConstr
+	case []PlutusMapPair:
+		return PlutusDataTypeMap
+	case []PlutusData:
+		return PlutusDataTypeList
+	case *big.Int:
+		return PlutusDataTypeInteger
+	default:
+		return PlutusDataTypeBytes
+	}
+}
+
+// Constr returns the constructor application, or nil if the value is not a constructor
+func (d PlutusData) Constr() *PlutusConstr {
+	if v, ok := d.item.(PlutusConstr); ok {
+		return &v
+	}
+	return nil
+}
+
+// Map returns the map pairs, or nil if the value is not a map
+func (d PlutusData) Map() []PlutusMapPair {
+	if v, ok := d.item.([]PlutusMapPair); ok {
+		return v
+	}
+	return nil
+}
+
+// List returns the list items, or nil if the value is not a list
+func (d PlutusData) List() []PlutusData {
+	if v, ok := d.item.([]PlutusData); ok {
+		return v
+	}
+	return nil
+}
+
+// Integer returns the integer value, or nil if the value is not an integer
+func (d PlutusData) Integer() *big.Int {
+	if v, ok := d.item.(*big.Int); ok {
+		return new(big.Int).Set(v)
+	}
+	return nil
+}
+
+// Bytes returns the bytestring value, or nil if the value is not a bytestring
+func (d PlutusData) Bytes() []byte {
+	if v, ok := d.item.([]byte); ok {
+		return v
+	}
+	return nil
+}
+
+// Hash returns the datum hash of the value
+func (d PlutusData) Hash() Blake2b256 {
+	cborData, err := d.MarshalCBOR()
+	if err != nil {
+		// This should never happen, since an invalid value cannot be constructed
+		panic(fmt.Sprintf("unexpected error encoding Plutus data: %s", err))
+	}
+	return Blake2b256Hash(cborData)
+}
+
+// Equal returns whether both values are structurally equal, ignoring their encoding
+func (d PlutusData) Equal(other PlutusData) bool {
+	switch v := d.item.(type) {
+	case PlutusConstr:
+		o := other.Constr()
+		if o == nil || o.Constructor != v.Constructor ||
+			len(o.Fields) != len(v.Fields) {
+			return false
+		}
+		for idx := range v.Fields {
+			if !v.Fields[idx].Equal(o.Fields[idx]) {
+				return false
+			}
+		}
+		return true
+	case []PlutusMapPair:
+		o, ok := other.item.([]PlutusMapPair)
+		if !ok || len(o) != len(v) {
+			return false
+		}
+		for idx := range v {
+			if !v[idx].Key.Equal(o[idx].Key) ||
+				!v[idx].Value.Equal(o[idx].Value) {
+				return false
+			}
+		}
+		return true
+	case []PlutusData:
+		o, ok := other.item.([]PlutusData)
+		if !ok || len(o) != len(v) {
+			return false
+		}
+		for idx := range v {
+			if !v[idx].Equal(o[idx]) {
+				return false
+			}
+		}
+		return true
+	case *big.Int:
+		o, ok := other.item.(*big.Int)
+		return ok && v.Cmp(o) == 0
+	case []byte:
+		o, ok := other.item.([]byte)
+		return ok && bytes.Equal(v, o)
+	}
+	return false
+}
+
+func (d *PlutusData) UnmarshalCBOR(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty Plutus data")
+	}
+	switch data[0] & cbor.CborTypeMask {
+	case cbor.CborTypeTag:
+		var tmpTag cbor.RawTag
+		if _, err := cbor.Decode(data, &tmpTag); err != nil {
+			return err
+		}
+		switch {
+		case tmpTag.Number >= cbor.CborTagAlternative1Min && tmpTag.Number <= cbor.CborTagAlternative1Max:
+			fields, err := decodePlutusDataList(tmpTag.Content)
+			if err != nil {
+				return err
+			}
+			d.item = PlutusConstr{
+				Constructor: tmpTag.Number - cbor.CborTagAlternative1Min,
+				Fields:      fields,
+			}
+		case tmpTag.Number >= cbor.CborTagAlternative2Min && tmpTag.Number <= cbor.CborTagAlternative2Max:
+			fields, err := decodePlutusDataList(tmpTag.Content)
+			if err != nil {
+				return err
+			}
+			d.item = PlutusConstr{
+				Constructor: tmpTag.Number - cbor.CborTagAlternative2Min + 7,
+				Fields:      fields,
+			}
+		case tmpTag.Number == cbor.CborTagAlternative3:
+			var tmpConstr struct {
+				cbor.StructAsArray
+				Constructor uint64
+				Fields      cbor.RawMessage
+			}
+			if _, err := cbor.Decode(tmpTag.Content, &tmpConstr); err != nil {
+				return err
+			}
+			fields, err := decodePlutusDataList(tmpConstr.Fields)
+			if err != nil {
+				return err
+			}
+			d.item = PlutusConstr{
+				Constructor: tmpConstr.Constructor,
+				Fields:      fields,
+			}
+		case tmpTag.Number == cborTagPositiveBignum || tmpTag.Number == cborTagNegativeBignum:
+			var tmpBytes []byte
+			if _, err := cbor.Decode(tmpTag.Content, &tmpBytes); err != nil {
+				return err
+			}
+			tmpInt := new(big.Int).SetBytes(tmpBytes)
+			if tmpTag.Number == cborTagNegativeBignum {
+				// Negative bignums encode -1 - n
+				tmpInt.Neg(tmpInt).Sub(tmpInt, big.NewInt(1))
+			}
+			d.item = tmpInt
+		default:
+			return fmt.Errorf("unsupported Plutus data tag: %d", tmpTag.Number)
+		}
+	case cbor.CborTypeMap:
+		pairs := []PlutusMapPair{}
+		err := decodeCborMap(
+			data,
+			func(key PlutusData, value PlutusData) {
+				pairs = append(pairs, PlutusMapPair{Key: key, Value: value})
+			},
+		)
+		if err != nil {
+			return err
+		}
+		d.item = pairs
+	case cbor.CborTypeArray:
+		items, err := decodePlutusDataList(data)
+		if err != nil {
+			return err
+		}
+		d.item = items
+	case cbor.CborTypeByteString:
+		var tmpBytes []byte
+		if _, err := cbor.Decode(data, &tmpBytes); err != nil {
+			return err
+		}
+		d.item = tmpBytes
+	case cbor.CborTypeUnsignedInt, cbor.CborTypeNegativeInt:
+		var tmpInt big.Int
+		if _, err := cbor.Decode(data, &tmpInt); err != nil {
+			return err
+		}
+		d.item = &tmpInt
+	default:
+		return fmt.Errorf("unsupported Plutus data CBOR type: %#x", data[0]&cbor.CborTypeMask)
+	}
+	d.SetCbor(data)
+	return nil
+}
+
+func (d PlutusData) MarshalCBOR() ([]byte, error) {
+	// Return stored CBOR if we have any
+	if cborData := d.Cbor(); cborData != nil {
+		return cborData, nil
+	}
+	switch v := d.item.(type) {
+	case PlutusConstr:
+		fields := encodePlutusDataList(v.Fields)
+		var tmpTag cbor.Tag
+		switch {
+		case v.Constructor <= 6:
+			tmpTag.Number = v.Constructor + cbor.CborTagAlternative1Min
+			tmpTag.Content = fields
+		case v.Constructor <= 127:
+			tmpTag.Number = v.Constructor - 7 + cbor.CborTagAlternative2Min
+			tmpTag.Content = fields
+		default:
+			tmpTag.Number = cbor.CborTagAlternative3
+			tmpTag.Content = []any{v.Constructor, fields}
+		}
+		return cbor.Encode(&tmpTag)
+	case []PlutusMapPair:
+		// Maps keep their pair order, so we build the encoding by hand
+		ret := encodeCborHead(cbor.CborTypeMap, uint64(len(v)))
+		for _, pair := range v {
+			keyCbor, err := pair.Key.MarshalCBOR()
+			if err != nil {
+				return nil, err
+			}
+			valueCbor, err := pair.Value.MarshalCBOR()
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, keyCbor...)
+			ret = append(ret, valueCbor...)
+		}
+		return ret, nil
+	case []PlutusData:
+		return cbor.Encode(encodePlutusDataList(v))
+	case *big.Int:
+		if v.IsUint64() {
+			return cbor.Encode(v.Uint64())
+		}
+		if v.IsInt64() {
+			return cbor.Encode(v.Int64())
+		}
+		// Negative integers down to -2^64 are encoded as -1 - n without a bignum tag
+		if v.Sign() < 0 {
+			tmpInt := new(big.Int).Neg(v)
+			tmpInt.Sub(tmpInt, big.NewInt(1))
+			if tmpInt.IsUint64() {
+				return encodeCborHead(cbor.CborTypeNegativeInt, tmpInt.Uint64()), nil
+			}
+		}
+		tmpTag := cbor.Tag{
+			Number: cborTagPositiveBignum,
+		}
+		tmpInt := new(big.Int).Set(v)
+		if v.Sign() < 0 {
+			tmpTag.Number = cborTagNegativeBignum
+			tmpInt.Neg(tmpInt).Sub(tmpInt, big.NewInt(1))
+		}
+		tmpTag.Content = encodePlutusDataBytes(tmpInt.Bytes())
+		return cbor.Encode(&tmpTag)
+	case []byte:
+		return cbor.Encode(encodePlutusDataBytes(v))
+	default:
+		return nil, errors.New("empty Plutus data value")
+	}
+}
+
+// encodePlutusDataList matches the reference implementation, which uses indefinite-length
+// lists for anything other than an empty list
+func encodePlutusDataList(items []PlutusData) any {
+	if len(items) == 0 {
+		return []any{}
+	}
+	tmpItems := make(cbor.IndefLengthList, len(items))
+	for idx, item := range items {
+		tmpItems[idx] = item
+	}
+	return tmpItems
+}
+
+// encodePlutusDataBytes splits long bytestrings into chunks, since Plutus data limits
+// bytestrings to 64 bytes
+func encodePlutusDataBytes(data []byte) any {
+	if len(data) <= plutusDataBytesChunkSize {
+		return data
+	}
+	var chunks cbor.IndefLengthByteString
+	for len(data) > 0 {
+		chunkLen := min(len(data), plutusDataBytesChunkSize)
+		chunks = append(chunks, data[:chunkLen])
+		data = data[chunkLen:]
+	}
+	return chunks
+}
+
+func decodePlutusDataList(data []byte) ([]PlutusData, error) {
+	ret := []PlutusData{}
+	if _, err := cbor.Decode(data, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// decodeCborMap decodes a map while preserving the order of its pairs, calling the provided function
+// with the key and value of each pair
+func decodeCborMap[T any](data []byte, pairFunc func(T, T)) error {
+	count, offset, err := decodeCborHead(data)
+	if err != nil {
+		return err
+	}
+	for i := 0; count < 0 || i < count; i++ {
+		if offset >= len(data) {
+			return errors.New("unexpected end of CBOR map")
+		}
+		if count < 0 && data[offset] == 0xff {
+			break
+		}
+		var key, value T
+		n, err := cbor.Decode(data[offset:], &key)
+		if err != nil {
+			return err
+		}
+		offset += n
+		n, err = cbor.Decode(data[offset:], &value)
+		if err != nil {
+			return err
+		}
+		offset += n
+		pairFunc(key, value)
+	}
+	return nil
+}
+
+// decodeCborHead returns the item count and header length for a CBOR map or array. The
+// returned count is -1 for indefinite-length items
+func decodeCborHead(data []byte) (int, int, error) {
+	if len(data) == 0 {
+		return 0, 0, errors.New("unexpected end of CBOR data")
+	}
+	info := data[0] & ^cbor.CborTypeMask
+	switch {
+	case info <= cbor.CborMaxUintSimple:
+		return int(info), 1, nil
+	case info == 0x1f:
+		return -1, 1, nil
+	case info >= 0x18 && info <= 0x1b:
+		size := 1 << (info - 0x18)
+		if len(data) < 1+size {
+			return 0, 0, errors.New("unexpected end of CBOR data")
+		}
+		var count uint64
+		for _, b := range data[1 : 1+size] {
+			count = count<<8 | uint64(b)
+		}
+		if count > uint64(len(data)) {
+			return 0, 0, fmt.Errorf("invalid CBOR item count: %d", count)
+		}
+		return int(count), 1 + size, nil
+	default:
+		return 0, 0, fmt.Errorf("invalid CBOR additional info: %d", info)
+	}
+}
+
+// encodeCborHead returns the header for a CBOR item of the specified major type
+func encodeCborHead(majorType uint8, count uint64) []byte {
+	switch {
+	case count <= uint64(cbor.CborMaxUintSimple):
+		return []byte{majorType | uint8(count)}
+	case count <= 0xff:
+		return []byte{majorType | 0x18, uint8(count)}
+	case count <= 0xffff:
+		return []byte{majorType | 0x19, uint8(count >> 8), uint8(count)}
+	case count <= 0xffffffff:
+		return []byte{
+			majorType | 0x1a,
+			uint8(count >> 24), uint8(count >> 16), uint8(count >> 8), uint8(count),
+		}
+	default:
+		ret := []byte{majorType | 0x1b}
+		for i := 7; i >= 0; i-- {
+			ret = append(ret, uint8(count>>(8*i)))
+		}
+		return ret
+	}
+}
+
+// plutusDataJson is the cardano-cli detailed schema JSON format
+type plutusDataJson struct {
+	Constructor *uint64              `json:"constructor,omitempty"`
+	Fields      *[]PlutusData        `json:"fields,omitempty"`
+	Map         *[]plutusDataJsonMap `json:"map,omitempty"`
+	List        *[]PlutusData        `json:"list,omitempty"`
+	Int         *json.Number         `json:"int,omitempty"`
+	Bytes       *string              `json:"bytes,omitempty"`
+}
+
+type plutusDataJsonMap struct {
+	Key   PlutusData `json:"k"`
+	Value PlutusData `json:"v"`
+}
+
+// MarshalJSON returns the value in the cardano-cli detailed schema JSON format
+func (d PlutusData) MarshalJSON() ([]byte, error) {
+	var tmpData plutusDataJson
+	switch v := d.item.(type) {
+	case PlutusConstr:
+		tmpData.Constructor = &v.Constructor
+		fields := append([]PlutusData{}, v.Fields...)
+		tmpData.Fields = &fields
+	case []PlutusMapPair:
+		pairs := make([]plutusDataJsonMap, len(v))
+		for idx, pair := range v {
+			pairs[idx] = plutusDataJsonMap(pair)
+		}
+		tmpData.Map = &pairs
+	case []PlutusData:
+		items := append([]PlutusData{}, v...)
+		tmpData.List = &items
+	case *big.Int:
+		tmpInt := json.Number(v.String())
+		tmpData.Int = &tmpInt
+	case []byte:
+		tmpBytes := hex.EncodeToString(v)
+		tmpData.Bytes = &tmpBytes
+	default:
+		return nil, errors.New("empty Plutus data value")
+	}
+	return json.Marshal(tmpData)
+}
+
+// UnmarshalJSON decodes a value in the cardano-cli detailed schema JSON format
+func (d *PlutusData) UnmarshalJSON(data []byte) error {
+	var tmpData plutusDataJson
+	if err := json.Unmarshal(data, &tmpData); err != nil {
+		return err
+	}
+	switch {
+	case tmpData.Constructor != nil:
+		if tmpData.Fields == nil {
+			return errors.New("missing fields for Plutus data constructor")
+		}
+		*d = NewPlutusConstr(*tmpData.Constructor, *tmpData.Fields...)
+	case tmpData.Map != nil:
+		pairs := make([]PlutusMapPair, len(*tmpData.Map))
+		for idx, pair := range *tmpData.Map {
+			pairs[idx] = PlutusMapPair(pair)
+		}
+		*d = NewPlutusMap(pairs...)
+	case tmpData.List != nil:
+		*d = NewPlutusList(*tmpData.List...)
+	case tmpData.Int != nil:
+		tmpInt, ok := new(big.Int).SetString(tmpData.Int.String(), 10)
+		if !ok {
+			return fmt.Errorf("invalid Plutus data integer: %s", tmpData.Int.String())
+		}
+		*d = NewPlutusInteger(tmpInt)
+	case tmpData.Bytes != nil:
+		tmpBytes, err := hex.DecodeString(*tmpData.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid Plutus data bytes: %w", err)
+		}
+		*d = NewPlutusBytes(tmpBytes)
+	default:
+		return errors.New("unknown Plutus data JSON value")
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/internal/test"
+)
+
+func TestPlutusDataCborRoundTrip(t *testing.T) {
+	testDefs := []struct {
+		name    string
+		cborHex string
+	}{
+		{
+			name:    "unit",
+			cborHex: "d87980",
+		},
+		{
+			name:    "constructor with indefinite fields",
+			cborHex: "d8799f41ab9f0102ffa203040102ff",
+		},
+		{
+			name:    "constructor with definite fields",
+			cborHex: "d8798241ab820102",
+		},
+		{
+			name:    "constructor 7",
+			cborHex: "d9050080",
+		},
+		{
+			name:    "constructor 200",
+			cborHex: "d8668218c880",
+		},
+		{
+			name:    "positive bignum",
+			cborHex: "c249010000000000000000",
+		},
+		{
+			name:    "negative bignum",
+			cborHex: "c349010000000000000000",
+		},
+		{
+			name:    "negative integer",
+			cborHex: "3863",
+		},
+		{
+			name:    "indefinite map",
+			cborHex: "bf0102ff",
+		},
+	}
+	for _, testDef := range testDefs {
+		cborData := test.DecodeHexString(testDef.cborHex)
+		data, err := NewPlutusDataFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		encoded, err := data.MarshalCBOR()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if !bytes.Equal(encoded, cborData) {
+			t.Errorf(
+				"%s: did not get expected CBOR: got %x, wanted %x",
+				testDef.name,
+				encoded,
+				cborData,
+			)
+		}
+	}
+}
+
+func TestPlutusDataEncode(t *testing.T) {
+	bignum, _ := new(big.Int).SetString("18446744073709551616", 10)
+	longBytes := bytes.Repeat([]byte{0xab}, 65)
+	testDefs := []struct {
+		name    string
+		data    PlutusData
+		cborHex string
+	}{
+		{
+			name:    "unit",
+			data:    NewPlutusConstr(0),
+			cborHex: "d87980",
+		},
+		{
+			name: "constructor with fields",
+			data: NewPlutusConstr(
+				1,
+				NewPlutusBytes([]byte{0xab}),
+				NewPlutusList(NewPlutusInt(1), NewPlutusInt(2)),
+			),
+			cborHex: "d87a9f41ab9f0102ffff",
+		},
+		{
+			name:    "constructor 7",
+			data:    NewPlutusConstr(7),
+			cborHex: "d9050080",
+		},
+		{
+			name:    "constructor 200",
+			data:    NewPlutusConstr(200),
+			cborHex: "d8668218c880",
+		},
+		{
+			name: "map keeps order",
+			data: NewPlutusMap(
+				PlutusMapPair{Key: NewPlutusInt(3), Value: NewPlutusInt(4)},
+				PlutusMapPair{Key: NewPlutusInt(1), Value: NewPlutusInt(2)},
+			),
+			cborHex: "a203040102",
+		},
+		{
+			name:    "positive bignum",
+			data:    NewPlutusInteger(bignum),
+			cborHex: "c249010000000000000000",
+		},
+		{
+			name:    "negative bignum",
+			data:    NewPlutusInteger(new(big.Int).Neg(new(big.Int).Add(bignum, big.NewInt(1)))),
+			cborHex: "c349010000000000000000",
+		},
+		{
+			name:    "negative integer -2^63-1",
+			data:    NewPlutusInteger(new(big.Int).Sub(big.NewInt(math.MinInt64), big.NewInt(1))),
+			cborHex: "3b8000000000000000",
+		},
+		{
+			name:    "negative integer -2^64",
+			data:    NewPlutusInteger(new(big.Int).Neg(bignum)),
+			cborHex: "3bffffffffffffffff",
+		},
+		{
+			name:    "long bytes",
+			data:    NewPlutusBytes(longBytes),
+			cborHex: "5f5840" + strings.Repeat("ab", 64) + "41abff",
+		},
+	}
+	for _, testDef := range testDefs {
+		encoded, err := testDef.data.MarshalCBOR()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if hex.EncodeToString(encoded) != testDef.cborHex {
+			t.Errorf(
+				"%s: did not get expected CBOR: got %x, wanted %s",
+				testDef.name,
+				encoded,
+				testDef.cborHex,
+			)
+		}
+		decoded, err := NewPlutusDataFromCbor(encoded)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if !decoded.Equal(testDef.data) {
+			t.Errorf("%s: decoded value does not match original", testDef.name)
+		}
+	}
+}
+
+func TestPlutusDataHash(t *testing.T) {
+	expectedHash := "923918e403bf43c34b4ef6b48eb2ee04babed17320d8d1b9ff9ad086e86f44ec"
+	if hash := NewPlutusConstr(0).Hash(); hash.String() != expectedHash {
+		t.Errorf(
+			"did not get expected datum hash: got %s, wanted %s",
+			hash.String(),
+			expectedHash,
+		)
+	}
+}
+
+func TestPlutusDataJson(t *testing.T) {
+	jsonData := `{"constructor":0,"fields":[{"bytes":"abcd"},{"int":-340282366920938463463374607431768211456},{"list":[{"int":1}]},{"map":[{"k":{"int":1},"v":{"constructor":1,"fields":[]}}]}]}`
+	var data PlutusData
+	if err := json.Unmarshal([]byte(jsonData), &data); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	constr := data.Constr()
+	if constr == nil || len(constr.Fields) != 4 {
+		t.Fatalf("did not get expected constructor: %#v", data)
+	}
+	if constr.Fields[1].Integer().String() != "-340282366920938463463374607431768211456" {
+		t.Errorf(
+			"did not get expected integer: %s",
+			constr.Fields[1].Integer().String(),
+		)
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(encoded) != jsonData {
+		t.Errorf(
+			"did not get expected JSON: got %s, wanted %s",
+			encoded,
+			jsonData,
+		)
+	}
+	// Decoded CBOR should convert to JSON
+	cborData, err := data.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	decoded, err := NewPlutusDataFromCbor(cborData)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	encoded, err = json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(encoded) != jsonData {
+		t.Errorf(
+			"did not get expected JSON: got %s, wanted %s",
+			encoded,
+			jsonData,
+		)
+	}
+}
+
+func TestPlutusDataJsonInvalid(t *testing.T) {
+	testDefs := []string{
+		`{}`,
+		`{"constructor":0}`,
+		`{"bytes":"xyz"}`,
+		`{"int":1.5}`,
+	}
+	for _, testDef := range testDefs {
+		var data PlutusData
+		if err := json.Unmarshal([]byte(testDef), &data); err == nil {
+			t.Errorf("did not get expected error for JSON: %s", testDef)
+		}
+	}
+}
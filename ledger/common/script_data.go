@@ -0,0 +1,142 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+// CostModelKey returns the key in the protocol parameter cost models for a Plutus script type
+func CostModelKey(scriptType uint8) (uint, error) {
+	switch scriptType {
+	case ScriptTypePlutusV1, ScriptTypePlutusV2, ScriptTypePlutusV3:
+		return uint(scriptType) - 1, nil
+	default:
+		return 0, fmt.Errorf("not a Plutus script type: %d", scriptType)
+	}
+}
+
+// EncodeLanguageViews returns the CBOR encoding of the language views for the specified Plutus script
+// types, which is a map of the language to its cost model. The PlutusV1 entry is encoded differently
+// for compatibility with the original Alonzo implementation: the language and the cost model are both
+// wrapped in a bytestring, and the cost model uses an indefinite-length list
+func EncodeLanguageViews(costModels map[uint][]int64, scriptTypes []uint8) ([]byte, error) {
+	type languageView struct {
+		key   []byte
+		value []byte
+	}
+	var views []languageView
+	for _, scriptType := range scriptTypes {
+		lang, err := CostModelKey(scriptType)
+		if err != nil {
+			return nil, err
+		}
+		costModel, hasCostModel := costModels[lang]
+		var view languageView
+		if scriptType == ScriptTypePlutusV1 {
+			var costModelCbor []byte
+			if hasCostModel {
+				costModelCbor = []byte{0x9f}
+				for _, cost := range costModel {
+					tmpCost, err := cbor.Encode(cost)
+					if err != nil {
+						return nil, err
+					}
+					costModelCbor = append(costModelCbor, tmpCost...)
+				}
+				costModelCbor = append(costModelCbor, 0xff)
+			} else {
+				costModelCbor, err = cbor.Encode(nil)
+				if err != nil {
+					return nil, err
+				}
+			}
+			langCbor, err := cbor.Encode(lang)
+			if err != nil {
+				return nil, err
+			}
+			if view.key, err = cbor.Encode(langCbor); err != nil {
+				return nil, err
+			}
+			if view.value, err = cbor.Encode(costModelCbor); err != nil {
+				return nil, err
+			}
+		} else {
+			if view.key, err = cbor.Encode(lang); err != nil {
+				return nil, err
+			}
+			var tmpValue any
+			if hasCostModel {
+				// Make sure an empty cost model is encoded as an empty list rather than null
+				if costModel == nil {
+					costModel = []int64{}
+				}
+				tmpValue = costModel
+			}
+			if view.value, err = cbor.Encode(tmpValue); err != nil {
+				return nil, err
+			}
+		}
+		if !slices.ContainsFunc(views, func(v languageView) bool { return bytes.Equal(v.key, view.key) }) {
+			views = append(views, view)
+		}
+	}
+	// Map keys use the canonical CBOR ordering, with shorter keys first
+	sort.Slice(views, func(i, j int) bool {
+		if len(views[i].key) != len(views[j].key) {
+			return len(views[i].key) < len(views[j].key)
+		}
+		return bytes.Compare(views[i].key, views[j].key) < 0
+	})
+	// There are at most 3 languages, so the map header is always a single byte
+	ret := []byte{0xa0 | byte(len(views))} // #nosec G115
+	for _, view := range views {
+		ret = append(ret, view.key...)
+		ret = append(ret, view.value...)
+	}
+	return ret, nil
+}
+
+// CalculateScriptDataHash returns the script data hash (also known as the script integrity hash) for a
+// transaction. The redeemers and datums must be the CBOR encoding exactly as it appears in the transaction
+// witness set, and datums should be empty if there are none. If the transaction has datums but no
+// redeemers, the redeemers should be the empty list or map encoding used by the era. The script types
+// are the Plutus languages of the scripts run by the transaction. It returns nil if there are no
+// redeemers, datums or languages
+func CalculateScriptDataHash(
+	costModels map[uint][]int64,
+	scriptTypes []uint8,
+	redeemers []byte,
+	datums []byte,
+) (*Blake2b256, error) {
+	emptyRedeemers := len(redeemers) == 0 ||
+		bytes.Equal(redeemers, []byte{0x80}) ||
+		bytes.Equal(redeemers, []byte{0xa0})
+	if emptyRedeemers && len(datums) == 0 && len(scriptTypes) == 0 {
+		return nil, nil
+	}
+	languageViews, err := EncodeLanguageViews(costModels, scriptTypes)
+	if err != nil {
+		return nil, err
+	}
+	data := slices.Concat(redeemers, datums, languageViews)
+	ret := Blake2b256Hash(data)
+	return &ret, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestEncodeLanguageViews(t *testing.T) {
+	testCostModels := map[uint][]int64{
+		0: {1, 2, 300},
+		1: {4, -5},
+		2: {},
+	}
+	testDefs := []struct {
+		name        string
+		scriptTypes []uint8
+		expectedHex string
+	}{
+		{
+			name:        "none",
+			expectedHex: "a0",
+		},
+		{
+			// The language and the indefinite-length cost model are each wrapped in a bytestring
+			name:        "PlutusV1",
+			scriptTypes: []uint8{ScriptTypePlutusV1},
+			expectedHex: "a14100479f010219012cff",
+		},
+		{
+			name:        "PlutusV2",
+			scriptTypes: []uint8{ScriptTypePlutusV2},
+			expectedHex: "a101820424",
+		},
+		{
+			// Shorter keys sort first, so PlutusV1 comes last
+			name:        "all languages",
+			scriptTypes: []uint8{ScriptTypePlutusV1, ScriptTypePlutusV3, ScriptTypePlutusV2, ScriptTypePlutusV1},
+			expectedHex: "a3018204240280" + "4100479f010219012cff",
+		},
+	}
+	for _, testDef := range testDefs {
+		languageViews, err := EncodeLanguageViews(testCostModels, testDef.scriptTypes)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if hex.EncodeToString(languageViews) != testDef.expectedHex {
+			t.Errorf(
+				"%s: did not get expected language views: got %x, wanted %s",
+				testDef.name,
+				languageViews,
+				testDef.expectedHex,
+			)
+		}
+	}
+	// Missing cost models are encoded as null
+	languageViews, err := EncodeLanguageViews(nil, []uint8{ScriptTypePlutusV1, ScriptTypePlutusV2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if hex.EncodeToString(languageViews) != "a201f6410041f6" {
+		t.Errorf("did not get expected language views for missing cost models: got %x", languageViews)
+	}
+	if _, err := EncodeLanguageViews(testCostModels, []uint8{ScriptTypeNative}); err == nil {
+		t.Errorf("did not get expected error for native script type")
+	}
+}
+
+func TestCalculateScriptDataHash(t *testing.T) {
+	testCostModels := map[uint][]int64{1: {4, -5}}
+	// Nothing to hash
+	scriptDataHash, err := CalculateScriptDataHash(testCostModels, nil, []byte{0xa0}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if scriptDataHash != nil {
+		t.Errorf("did not get expected nil hash: got %s", scriptDataHash.String())
+	}
+	// Mainnet Conway transactions with witness datums but no redeemers or scripts, which use an empty map
+	// for the redeemers
+	testDefs := []struct {
+		name         string
+		datumsHex    string
+		expectedHash string
+	}{
+		{
+			name:         "block 10882991 tx 21",
+			datumsHex:    "9fd8799fd8799fd8799f581c5a559504984048e663389bceb76c7896e5b118c1466c23a2a4e31a24ffd8799fd8799fd8799f581c208cb95a62356b77160360a5beca2d895ff308f7dc157e6fda23b8d5ffffffffd8799fd8799f581c5a559504984048e663389bceb76c7896e5b118c1466c23a2a4e31a24ffd8799fd8799fd8799f581c208cb95a62356b77160360a5beca2d895ff308f7dc157e6fda23b8d5ffffffffd87a80d8799fd8799f4040ff1a38991ce6ff1a001e84801a001e8480ffff",
+			expectedHash: "debc6b6664fcfaab3819fe51585893be0ab52a5f20718895cd3f32ee4e7ab8b4",
+		},
+		{
+			name:         "block 10882991 tx 32",
+			datumsHex:    "9fd8799fd8799fd8799fd8799f581cc19fdcc1408973627eb054337c98989e2af48522725787c9adf4e016ffd8799fd8799fd8799f581c52a83eaf59105c79dc7791db4f38b8223ac9c67c472bcfb511455cf0ffffffff4040581ca0028f350aaabe0545fdcb56b039bfb08e4bb4d8c4d7c3c7d481c23545484f534b591a2bed6370d879801a00286f90ffffff",
+			expectedHash: "da9983f5220976c0a1cfef46055c86edb985d5b5403c5e8e1603964027af5a28",
+		},
+		{
+			name:         "block 10873394 tx 50",
+			datumsHex:    "9fd8799fd8799fd8799fd8799f581c1f93215c69fde05d0bd89d5c98703b18403463d8a15012b1a1398248ffd8799fd8799fd8799f581c14d9afe58d99b43fcff3165f01f66b671aa4747a0bfd915612d89349ffffffff4040581ca0028f350aaabe0545fdcb56b039bfb08e4bb4d8c4d7c3c7d481c23545484f534b591a0dbbfe51d879801a00286f90ffffff",
+			expectedHash: "e08fb89a8aef0382bb364f0eefb6f0b0fdeb978d6d7a548f50d7248dddc8dbf9",
+		},
+	}
+	for _, testDef := range testDefs {
+		datums, err := hex.DecodeString(testDef.datumsHex)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		scriptDataHash, err := CalculateScriptDataHash(testCostModels, nil, []byte{0xa0}, datums)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if scriptDataHash == nil || scriptDataHash.String() != testDef.expectedHash {
+			t.Errorf("%s: did not get expected hash: got %v, wanted %s", testDef.name, scriptDataHash, testDef.expectedHash)
+		}
+	}
+}
@@ -23,8 +23,51 @@ type UtxoState interface {
 	UtxoById(TransactionInput) (Utxo, error)
 }
 
-// CertState defines the interface for querying the certificate state
-type CertState interface{}
+// StakeRegistration represents a registered stake credential
+type StakeRegistration struct {
+	// Deposit is the deposit paid when registering the stake credential, which is returned on deregistration
+	Deposit uint64
+	// Reward is the reward account balance
+	Reward uint64
+	// Pool is the pool that the stake credential is delegated to, if any
+	Pool *PoolKeyHash
+	// Drep is the DRep that the stake credential is delegated to, if any
+	Drep *Drep
+}
+
+// PoolRegistration represents a registered stake pool
+type PoolRegistration struct {
+	// Deposit is the deposit paid when the pool was first registered
+	Deposit uint64
+	// Cost is the fixed cost of the current pool parameters
+	Cost uint64
+	// RetiringEpoch is the epoch in which the pool is scheduled to retire, or 0 if it is not retiring
+	RetiringEpoch uint64
+}
+
+// DrepRegistration represents a registered DRep
+type DrepRegistration struct {
+	// Deposit is the deposit paid when registering the DRep, which is returned on deregistration
+	Deposit uint64
+	Anchor  *GovAnchor
+}
+
+// CommitteeMember represents a constitutional committee member, identified by its cold credential
+type CommitteeMember struct {
+	// HotCredential is the currently authorized hot credential, if any
+	HotCredential *StakeCredential
+	// Resigned indicates that the member has resigned
+	Resigned bool
+}
+
+// CertState defines the interface for querying the certificate state. Credentials are identified by their key
+// or script hash. Each function returns nil with no error when the credential is not registered
+type CertState interface {
+	StakeRegistration(Blake2b224) (*StakeRegistration, error)
+	PoolRegistration(PoolKeyHash) (*PoolRegistration, error)
+	DrepRegistration(Blake2b224) (*DrepRegistration, error)
+	CommitteeMember(Blake2b224) (*CommitteeMember, error)
+}
 
 // LedgerState defines the interface for querying the ledger
 type LedgerState interface {
@@ -33,6 +76,16 @@ type LedgerState interface {
 	NetworkId() uint
 }
 
+// TreasuryState defines the interface for querying the treasury
+type TreasuryState interface {
+	Treasury() (uint64, error)
+}
+
+// EpochState defines the interface for querying the current epoch
+type EpochState interface {
+	CurrentEpoch() (uint64, error)
+}
+
 // TipState defines the interface for querying the current tip
 type TipState interface {
 	Tip() (pcommon.Tip, error)
@@ -54,7 +54,7 @@ type TransactionBody interface {
 	ProposalProcedures() []ProposalProcedure
 	CurrentTreasuryValue() int64
 	Donation() uint64
-	Utxorpc() *utxorpc.Tx
+	Utxorpc() (*utxorpc.Tx, error)
 }
 
 type TransactionInput interface {
@@ -71,7 +71,7 @@ type TransactionOutput interface {
 	Datum() *cbor.LazyValue
 	DatumHash() *Blake2b256
 	Cbor() []byte
-	Utxorpc() *utxorpc.TxOutput
+	Utxorpc() (*utxorpc.TxOutput, error)
 }
 
 type TransactionWitnessSet interface {
@@ -0,0 +1,313 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+// Value is an amount of lovelace and native assets, as found in transaction outputs. A nil Assets
+// represents no native assets
+type Value struct {
+	Coin   uint64
+	Assets *MultiAsset[MultiAssetTypeOutput]
+}
+
+// NewValue creates a Value with the specified lovelace and assets
+func NewValue(coin uint64, assets *MultiAsset[MultiAssetTypeOutput]) Value {
+	return Value{
+		Coin:   coin,
+		Assets: assets,
+	}
+}
+
+// NewValueFromOutput returns the value of a transaction output
+func NewValueFromOutput(output TransactionOutput) Value {
+	return Value{
+		Coin:   output.Amount(),
+		Assets: output.Assets(),
+	}
+}
+
+// NewValueFromMint converts the signed quantities of a mint field into the value minted and the value
+// burned, both of which have unsigned quantities and no lovelace
+func NewValueFromMint(mint *MultiAsset[MultiAssetTypeMint]) (Value, Value) {
+	if mint == nil {
+		return Value{}, Value{}
+	}
+	minted := make(valueAssets)
+	burned := make(valueAssets)
+	for policyId, assets := range mint.data {
+		for assetName, amount := range assets {
+			if amount > 0 {
+				minted.set(policyId, assetName, uint64(amount))
+			} else if amount < 0 {
+				// Negate in unsigned arithmetic to handle the minimum int64
+				burned.set(policyId, assetName, -uint64(amount))
+			}
+		}
+	}
+	return Value{Assets: minted.multiAsset()}, Value{Assets: burned.multiAsset()}
+}
+
+// ValueUnderflowError is returned when subtracting a value which isn't covered by the other value
+type ValueUnderflowError struct {
+	Value      Value
+	Subtracted Value
+}
+
+func (e ValueUnderflowError) Error() string {
+	return fmt.Sprintf(
+		"value underflow: cannot subtract %s from %s",
+		e.Subtracted,
+		e.Value,
+	)
+}
+
+// ErrValueOverflow is returned when adding values results in a quantity which doesn't fit in a uint64
+var ErrValueOverflow = errors.New("value overflow")
+
+// Add returns the sum of both values. An error is returned if any quantity overflows
+func (v Value) Add(other Value) (Value, error) {
+	coin, carry := bits.Add64(v.Coin, other.Coin, 0)
+	if carry != 0 {
+		return Value{}, fmt.Errorf("%w: lovelace", ErrValueOverflow)
+	}
+	assets := newValueAssets(v.Assets)
+	for policyId, otherAssets := range other.assetData() {
+		for assetName, amount := range otherAssets {
+			sum, carry := bits.Add64(assets.get(policyId, assetName), amount, 0)
+			if carry != 0 {
+				return Value{}, fmt.Errorf(
+					"%w: asset %s.%x",
+					ErrValueOverflow,
+					policyId.String(),
+					assetName.Bytes(),
+				)
+			}
+			assets.set(policyId, assetName, sum)
+		}
+	}
+	return Value{Coin: coin, Assets: assets.multiAsset()}, nil
+}
+
+// Sub returns the value less the other value. A ValueUnderflowError is returned if any quantity in the
+// other value is greater than in this value
+func (v Value) Sub(other Value) (Value, error) {
+	surplus, deficit := v.Diff(other)
+	if !deficit.IsZero() {
+		return Value{}, ValueUnderflowError{
+			Value:      v,
+			Subtracted: other,
+		}
+	}
+	return surplus, nil
+}
+
+// Diff compares the value with the other value, returning the quantities by which this value exceeds
+// the other value and the quantities by which it falls short. Both results are normalized
+func (v Value) Diff(other Value) (Value, Value) {
+	var surplus, deficit Value
+	if v.Coin >= other.Coin {
+		surplus.Coin = v.Coin - other.Coin
+	} else {
+		deficit.Coin = other.Coin - v.Coin
+	}
+	surplusAssets := make(valueAssets)
+	deficitAssets := make(valueAssets)
+	otherAssets := newValueAssets(other.Assets)
+	for policyId, assets := range v.assetData() {
+		for assetName, amount := range assets {
+			otherAmount := otherAssets.get(policyId, assetName)
+			if amount >= otherAmount {
+				surplusAssets.set(policyId, assetName, amount-otherAmount)
+			} else {
+				deficitAssets.set(policyId, assetName, otherAmount-amount)
+			}
+			otherAssets.set(policyId, assetName, 0)
+		}
+	}
+	// Remaining assets are only present in the other value
+	for policyId, assets := range otherAssets {
+		for assetName, amount := range assets {
+			deficitAssets.set(policyId, assetName, amount)
+		}
+	}
+	surplus.Assets = surplusAssets.multiAsset()
+	deficit.Assets = deficitAssets.multiAsset()
+	return surplus, deficit
+}
+
+// Equal returns whether both values have the same quantities, ignoring zero quantities
+func (v Value) Equal(other Value) bool {
+	surplus, deficit := v.Diff(other)
+	return surplus.IsZero() && deficit.IsZero()
+}
+
+// GreaterOrEqual returns whether every quantity in the value is at least the quantity in the other value
+func (v Value) GreaterOrEqual(other Value) bool {
+	_, deficit := v.Diff(other)
+	return deficit.IsZero()
+}
+
+// IsZero returns whether the value has no lovelace and no non-zero asset quantities
+func (v Value) IsZero() bool {
+	if v.Coin != 0 {
+		return false
+	}
+	for _, assets := range v.assetData() {
+		for _, amount := range assets {
+			if amount != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Normalize returns a copy of the value without zero asset quantities or empty policies. Assets is
+// nil if no assets remain
+func (v Value) Normalize() Value {
+	return Value{
+		Coin:   v.Coin,
+		Assets: newValueAssets(v.Assets).multiAsset(),
+	}
+}
+
+func (v *Value) UnmarshalCBOR(data []byte) error {
+	var tmpValue struct {
+		cbor.StructAsArray
+		Coin   uint64
+		Assets *MultiAsset[MultiAssetTypeOutput]
+	}
+	if _, err := cbor.Decode(data, &(v.Coin)); err == nil {
+		v.Assets = nil
+		return nil
+	}
+	if _, err := cbor.Decode(data, &tmpValue); err != nil {
+		return err
+	}
+	v.Coin = tmpValue.Coin
+	v.Assets = tmpValue.Assets
+	return nil
+}
+
+// MarshalCBOR encodes the normalized value. A value without assets is encoded as the lovelace
+// amount, and otherwise as an array of the lovelace amount and the assets. Map keys are sorted
+// for a deterministic encoding
+func (v Value) MarshalCBOR() ([]byte, error) {
+	tmpValue := v.Normalize()
+	if tmpValue.Assets == nil {
+		return cbor.Encode(tmpValue.Coin)
+	}
+	return cbor.Encode([]any{tmpValue.Coin, tmpValue.Assets.data})
+}
+
+// String returns the value in the format used by cardano-cli, such as "1000000 lovelace + 5 <policy ID>.<asset name hex>"
+func (v Value) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d lovelace", v.Coin)
+	assets := v.assetData()
+	policyIds := make([]Blake2b224, 0, len(assets))
+	for policyId := range assets {
+		policyIds = append(policyIds, policyId)
+	}
+	slices.SortFunc(
+		policyIds,
+		func(a, b Blake2b224) int {
+			return bytes.Compare(a[:], b[:])
+		},
+	)
+	for _, policyId := range policyIds {
+		assetNames := make([][]byte, 0, len(assets[policyId]))
+		for assetName := range assets[policyId] {
+			assetNames = append(assetNames, assetName.Bytes())
+		}
+		slices.SortFunc(assetNames, bytes.Compare)
+		for _, assetName := range assetNames {
+			fmt.Fprintf(
+				&sb,
+				" + %d %s",
+				assets[policyId][cbor.NewByteString(assetName)],
+				policyId.String(),
+			)
+			if len(assetName) > 0 {
+				sb.WriteString("." + hex.EncodeToString(assetName))
+			}
+		}
+	}
+	return sb.String()
+}
+
+func (v Value) assetData() map[Blake2b224]map[cbor.ByteString]MultiAssetTypeOutput {
+	if v.Assets == nil {
+		return nil
+	}
+	return v.Assets.data
+}
+
+// valueAssets is a mutable copy of asset quantities used for value arithmetic
+type valueAssets map[Blake2b224]map[cbor.ByteString]MultiAssetTypeOutput
+
+func newValueAssets(assets *MultiAsset[MultiAssetTypeOutput]) valueAssets {
+	ret := make(valueAssets)
+	if assets == nil {
+		return ret
+	}
+	for policyId, policyAssets := range assets.data {
+		for assetName, amount := range policyAssets {
+			ret.set(policyId, assetName, amount)
+		}
+	}
+	return ret
+}
+
+func (a valueAssets) get(policyId Blake2b224, assetName cbor.ByteString) uint64 {
+	return a[policyId][assetName]
+}
+
+// set sets the quantity of an asset, removing the asset when the quantity is zero
+func (a valueAssets) set(policyId Blake2b224, assetName cbor.ByteString, amount uint64) {
+	if amount == 0 {
+		if a[policyId] != nil {
+			delete(a[policyId], assetName)
+			if len(a[policyId]) == 0 {
+				delete(a, policyId)
+			}
+		}
+		return
+	}
+	if a[policyId] == nil {
+		a[policyId] = make(map[cbor.ByteString]MultiAssetTypeOutput)
+	}
+	a[policyId][assetName] = amount
+}
+
+// multiAsset returns the assets as a MultiAsset, or nil if there are none
+func (a valueAssets) multiAsset() *MultiAsset[MultiAssetTypeOutput] {
+	if len(a) == 0 {
+		return nil
+	}
+	ret := NewMultiAsset[MultiAssetTypeOutput](a)
+	return &ret
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+func testValue(coin uint64, assets map[string]uint64) Value {
+	policyId := NewBlake2b224(make([]byte, Blake2b224Size))
+	data := map[Blake2b224]map[cbor.ByteString]MultiAssetTypeOutput{}
+	for assetName, amount := range assets {
+		if data[policyId] == nil {
+			data[policyId] = map[cbor.ByteString]MultiAssetTypeOutput{}
+		}
+		data[policyId][cbor.NewByteString([]byte(assetName))] = amount
+	}
+	tmpAssets := NewMultiAsset(data)
+	return NewValue(coin, &tmpAssets)
+}
+
+func TestValueAddSub(t *testing.T) {
+	a := testValue(100, map[string]uint64{"a": 5, "b": 1})
+	b := testValue(50, map[string]uint64{"a": 5, "c": 2})
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !sum.Equal(testValue(150, map[string]uint64{"a": 10, "b": 1, "c": 2})) {
+		t.Errorf("did not get expected sum: %s", sum)
+	}
+	diff, err := sum.Sub(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !diff.Equal(a) {
+		t.Errorf("did not get expected difference: %s", diff)
+	}
+	// Subtracting the value from itself leaves no assets
+	diff, err = a.Sub(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !diff.IsZero() || diff.Assets != nil {
+		t.Errorf("did not get expected zero value: %s", diff)
+	}
+	// Underflow
+	_, err = a.Sub(b)
+	var underflowErr ValueUnderflowError
+	if !errors.As(err, &underflowErr) {
+		t.Fatalf("did not get expected underflow error: %v", err)
+	}
+	if _, err := a.Sub(NewValue(101, nil)); err == nil {
+		t.Errorf("did not get expected underflow error for lovelace")
+	}
+	// Overflow
+	if _, err := a.Add(NewValue(math.MaxUint64, nil)); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("did not get expected overflow error: %v", err)
+	}
+	if _, err := a.Add(testValue(0, map[string]uint64{"a": math.MaxUint64})); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("did not get expected overflow error: %v", err)
+	}
+}
+
+func TestValueCompare(t *testing.T) {
+	a := testValue(100, map[string]uint64{"a": 5})
+	testDefs := []struct {
+		name           string
+		other          Value
+		equal          bool
+		greaterOrEqual bool
+	}{
+		{
+			name:           "same",
+			other:          testValue(100, map[string]uint64{"a": 5}),
+			equal:          true,
+			greaterOrEqual: true,
+		},
+		{
+			name:           "zero quantities",
+			other:          testValue(100, map[string]uint64{"a": 5, "b": 0}),
+			equal:          true,
+			greaterOrEqual: true,
+		},
+		{
+			name:           "less lovelace",
+			other:          testValue(99, map[string]uint64{"a": 5}),
+			greaterOrEqual: true,
+		},
+		{
+			name:           "no assets",
+			other:          NewValue(100, nil),
+			greaterOrEqual: true,
+		},
+		{
+			name:  "more assets",
+			other: testValue(50, map[string]uint64{"a": 6}),
+		},
+		{
+			name:  "other asset",
+			other: testValue(50, map[string]uint64{"b": 1}),
+		},
+	}
+	for _, testDef := range testDefs {
+		if a.Equal(testDef.other) != testDef.equal {
+			t.Errorf("%s: did not get expected result from Equal", testDef.name)
+		}
+		if a.GreaterOrEqual(testDef.other) != testDef.greaterOrEqual {
+			t.Errorf("%s: did not get expected result from GreaterOrEqual", testDef.name)
+		}
+	}
+	if !testValue(0, map[string]uint64{"a": 0}).IsZero() {
+		t.Errorf("value with zero quantities should be zero")
+	}
+	if testValue(0, map[string]uint64{"a": 1}).IsZero() {
+		t.Errorf("value with assets should not be zero")
+	}
+}
+
+func TestValueFromMint(t *testing.T) {
+	policyId := NewBlake2b224(make([]byte, Blake2b224Size))
+	mint := NewMultiAsset(
+		map[Blake2b224]map[cbor.ByteString]MultiAssetTypeMint{
+			policyId: {
+				cbor.NewByteString([]byte("a")): 10,
+				cbor.NewByteString([]byte("b")): -3,
+				cbor.NewByteString([]byte("c")): math.MinInt64,
+			},
+		},
+	)
+	minted, burned := NewValueFromMint(&mint)
+	if !minted.Equal(testValue(0, map[string]uint64{"a": 10})) {
+		t.Errorf("did not get expected minted value: %s", minted)
+	}
+	if !burned.Equal(testValue(0, map[string]uint64{"b": 3, "c": 1 << 63})) {
+		t.Errorf("did not get expected burned value: %s", burned)
+	}
+}
+
+func TestValueCbor(t *testing.T) {
+	testDefs := []struct {
+		name    string
+		value   Value
+		cborHex string
+	}{
+		{
+			name:    "lovelace only",
+			value:   NewValue(1000000, nil),
+			cborHex: "1a000f4240",
+		},
+		{
+			name:    "zero assets dropped",
+			value:   testValue(1000000, map[string]uint64{"a": 0}),
+			cborHex: "1a000f4240",
+		},
+		{
+			name:    "assets sorted",
+			value:   testValue(5, map[string]uint64{"bb": 2, "c": 3, "a": 1}),
+			cborHex: "8205a1581c00000000000000000000000000000000000000000000000000000000a341610141630342626202",
+		},
+	}
+	for _, testDef := range testDefs {
+		cborData, err := cbor.Encode(testDef.value)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if hex.EncodeToString(cborData) != testDef.cborHex {
+			t.Errorf("%s: did not get expected CBOR\n  got:    %x\n  wanted: %s", testDef.name, cborData, testDef.cborHex)
+		}
+		var tmpValue Value
+		if _, err := cbor.Decode(cborData, &tmpValue); err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if !tmpValue.Equal(testDef.value) {
+			t.Errorf("%s: value does not match after round-trip: %s", testDef.name, tmpValue)
+		}
+	}
+}
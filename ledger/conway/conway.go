@@ -15,8 +15,10 @@
 package conway
 
 import (
+	"cmp"
 	"encoding/hex"
 	"fmt"
+	"slices"
 
 	"github.com/blinklabs-io/gouroboros/cbor"
 	"github.com/blinklabs-io/gouroboros/ledger/alonzo"
@@ -117,11 +119,14 @@ func (b *ConwayBlock) Transactions() []common.Transaction {
 	return ret
 }
 
-func (b *ConwayBlock) Utxorpc() *utxorpc.Block {
+func (b *ConwayBlock) Utxorpc() (*utxorpc.Block, error) {
 	var txs []*utxorpc.Tx
 	tmpHash, _ := hex.DecodeString(b.Hash())
 	for _, t := range b.Transactions() {
-		tx := t.Utxorpc()
+		tx, err := t.Utxorpc()
+		if err != nil {
+			return nil, err
+		}
 		txs = append(txs, tx)
 	}
 	body := &utxorpc.BlockBody{
@@ -136,7 +141,7 @@ func (b *ConwayBlock) Utxorpc() *utxorpc.Block {
 		Body:   body,
 		Header: header,
 	}
-	return block
+	return block, nil
 }
 
 type ConwayBlockHeader struct {
@@ -189,6 +194,35 @@ func (r *ConwayRedeemers) UnmarshalCBOR(cborData []byte) error {
 	return nil
 }
 
+func (r ConwayRedeemers) MarshalCBOR() ([]byte, error) {
+	if r.legacy {
+		tmpRedeemers := make([]alonzo.AlonzoRedeemer, 0, len(r.Redeemers))
+		for key, val := range r.Redeemers {
+			tmpRedeemers = append(
+				tmpRedeemers,
+				alonzo.AlonzoRedeemer{
+					Tag:     key.Tag,
+					Index:   key.Index,
+					Data:    val.Data,
+					ExUnits: val.ExUnits,
+				},
+			)
+		}
+		slices.SortFunc(tmpRedeemers, func(a, b alonzo.AlonzoRedeemer) int {
+			if a.Tag != b.Tag {
+				return cmp.Compare(a.Tag, b.Tag)
+			}
+			return cmp.Compare(a.Index, b.Index)
+		})
+		return cbor.Encode(tmpRedeemers)
+	}
+	// Make sure empty redeemers are encoded as an empty map rather than null
+	if len(r.Redeemers) == 0 {
+		return cbor.Encode(map[ConwayRedeemerKey]ConwayRedeemerValue{})
+	}
+	return cbor.Encode(r.Redeemers)
+}
+
 func (r ConwayRedeemers) Indexes(tag common.RedeemerTag) []uint {
 	ret := []uint{}
 	for key := range r.Redeemers {
@@ -232,6 +266,13 @@ type ConwayTransactionInputSet struct {
 	items []shelley.ShelleyTransactionInput
 }
 
+func NewConwayTransactionInputSet(items []shelley.ShelleyTransactionInput) ConwayTransactionInputSet {
+	s := ConwayTransactionInputSet{
+		items: items,
+	}
+	return s
+}
+
 func (s *ConwayTransactionInputSet) UnmarshalCBOR(data []byte) error {
 	// This overrides the Shelley behavior that explicitly disallowed tag-wrapped sets
 	var tmpData []shelley.ShelleyTransactionInput
@@ -372,6 +413,11 @@ func (t ConwayTransaction) ScriptDataHash() *common.Blake2b256 {
 	return t.Body.ScriptDataHash()
 }
 
+// NetworkId returns the network ID specified in the transaction body, if any
+func (t ConwayTransaction) NetworkId() *uint8 {
+	return t.Body.NetworkId
+}
+
 func (t ConwayTransaction) VotingProcedures() common.VotingProcedures {
 	return t.Body.VotingProcedures()
 }
@@ -449,7 +495,7 @@ func (t *ConwayTransaction) Cbor() []byte {
 	tmpObj := []any{
 		cbor.RawMessage(t.Body.Cbor()),
 		cbor.RawMessage(t.WitnessSet.Cbor()),
-		t.IsValid,
+		t.IsValid(),
 	}
 	if t.TxMetadata != nil {
 		tmpObj = append(tmpObj, cbor.RawMessage(t.TxMetadata.Cbor()))
@@ -461,7 +507,7 @@ func (t *ConwayTransaction) Cbor() []byte {
 	return cborData
 }
 
-func (t *ConwayTransaction) Utxorpc() *utxorpc.Tx {
+func (t *ConwayTransaction) Utxorpc() (*utxorpc.Tx, error) {
 	return t.Body.Utxorpc()
 }
 
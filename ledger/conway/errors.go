@@ -0,0 +1,162 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conway
+
+import (
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+type TreasuryValueMismatchError struct {
+	Actual   uint64
+	Provided int64
+}
+
+func (e TreasuryValueMismatchError) Error() string {
+	return fmt.Sprintf(
+		"treasury value mismatch: actual %d, provided %d",
+		e.Actual,
+		e.Provided,
+	)
+}
+
+type TxRefScriptsSizeTooBigError struct {
+	Size uint64
+	Max  uint64
+}
+
+func (e TxRefScriptsSizeTooBigError) Error() string {
+	return fmt.Sprintf(
+		"reference scripts size too big: size %d, maximum %d",
+		e.Size,
+		e.Max,
+	)
+}
+
+type IncorrectDepositError struct {
+	Credential common.Blake2b224
+	Provided   int64
+	Expected   uint64
+}
+
+func (e IncorrectDepositError) Error() string {
+	return fmt.Sprintf(
+		"incorrect stake key deposit: %s, provided %d, expected %d",
+		e.Credential.String(),
+		e.Provided,
+		e.Expected,
+	)
+}
+
+type IncorrectKeyDepositRefundError struct {
+	Credential common.Blake2b224
+	Provided   int64
+	Expected   uint64
+}
+
+func (e IncorrectKeyDepositRefundError) Error() string {
+	return fmt.Sprintf(
+		"incorrect stake key deposit refund: %s, provided %d, expected %d",
+		e.Credential.String(),
+		e.Provided,
+		e.Expected,
+	)
+}
+
+type DelegateeDrepNotRegisteredError struct {
+	Credential common.Blake2b224
+}
+
+func (e DelegateeDrepNotRegisteredError) Error() string {
+	return fmt.Sprintf(
+		"delegatee DRep not registered: %s",
+		e.Credential.String(),
+	)
+}
+
+type DrepAlreadyRegisteredError struct {
+	Credential common.Blake2b224
+}
+
+func (e DrepAlreadyRegisteredError) Error() string {
+	return fmt.Sprintf(
+		"DRep already registered: %s",
+		e.Credential.String(),
+	)
+}
+
+type DrepNotRegisteredError struct {
+	Credential common.Blake2b224
+}
+
+func (e DrepNotRegisteredError) Error() string {
+	return fmt.Sprintf(
+		"DRep not registered: %s",
+		e.Credential.String(),
+	)
+}
+
+type DrepIncorrectDepositError struct {
+	Credential common.Blake2b224
+	Provided   int64
+	Expected   uint64
+}
+
+func (e DrepIncorrectDepositError) Error() string {
+	return fmt.Sprintf(
+		"incorrect DRep deposit: %s, provided %d, expected %d",
+		e.Credential.String(),
+		e.Provided,
+		e.Expected,
+	)
+}
+
+type DrepIncorrectRefundError struct {
+	Credential common.Blake2b224
+	Provided   int64
+	Expected   uint64
+}
+
+func (e DrepIncorrectRefundError) Error() string {
+	return fmt.Sprintf(
+		"incorrect DRep refund: %s, provided %d, expected %d",
+		e.Credential.String(),
+		e.Provided,
+		e.Expected,
+	)
+}
+
+type CommitteeIsUnknownError struct {
+	Credential common.Blake2b224
+}
+
+func (e CommitteeIsUnknownError) Error() string {
+	return fmt.Sprintf(
+		"committee member is unknown: %s",
+		e.Credential.String(),
+	)
+}
+
+type CommitteeHasPreviouslyResignedError struct {
+	Credential common.Blake2b224
+}
+
+func (e CommitteeHasPreviouslyResignedError) Error() string {
+	return fmt.Sprintf(
+		"committee member has previously resigned: %s",
+		e.Credential.String(),
+	)
+}
@@ -0,0 +1,790 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conway
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/ledger/alonzo"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+const (
+	// Size (in bytes) of each reference script fee tier
+	refScriptCostStride = 25600
+	// Maximum total size (in bytes) of reference scripts used by a transaction
+	maxRefScriptSizePerTx = 200 * 1024
+)
+
+// Price multiplier for each successive reference script fee tier
+var refScriptCostMultiplier = big.NewRat(6, 5)
+
+var UtxoValidationRules = []common.UtxoValidationRuleFunc{
+	UtxoValidateOutsideValidityIntervalUtxo,
+	UtxoValidateInputSetEmptyUtxo,
+	UtxoValidateFeeTooSmallUtxo,
+	UtxoValidateInsufficientCollateral,
+	UtxoValidateCollateralContainsNonAda,
+	UtxoValidateScriptsNotPaidUtxo,
+	UtxoValidateNoCollateralInputs,
+	UtxoValidateIncorrectTotalCollateralField,
+	UtxoValidateBadInputsUtxo,
+	UtxoValidateNonDisjointRefInputs,
+	UtxoValidateValueNotConservedUtxo,
+	UtxoValidateOutputTooSmallUtxo,
+	UtxoValidateOutputTooBigUtxo,
+	UtxoValidateOutputBootAddrAttrsTooBig,
+	UtxoValidateWrongNetwork,
+	UtxoValidateWrongNetworkWithdrawal,
+	UtxoValidateWrongNetworkInTxBody,
+	UtxoValidateMaxTxSizeUtxo,
+	UtxoValidateExUnitsTooBigUtxo,
+	UtxoValidateTooManyCollateralInputs,
+	UtxoValidateScriptDataHash,
+	UtxoValidateMalformedReferenceScripts,
+	UtxoValidateTxRefScriptsSizeTooBig,
+	UtxoValidateCurrentTreasuryValue,
+}
+
+var UtxowValidationRules = []common.UtxoValidationRuleFunc{
+	UtxowValidateInvalidWitnesses,
+	UtxowValidateMissingVKeyWitnesses,
+	UtxowValidateMissingScriptWitnesses,
+	UtxowValidateScriptWitnessNotValidating,
+	UtxowValidateMetadataHash,
+}
+
+var CertValidationRules = []common.UtxoValidationRuleFunc{
+	DelegValidateWithdrawalsNotInRewards,
+	DelegValidateStakeKeyAlreadyRegistered,
+	DelegValidateStakeKeyNotRegistered,
+	DelegValidateStakeKeyNonZeroAccountBalance,
+	DelegValidateDelegateeNotRegistered,
+	DelegValidateIncorrectDeposit,
+	DelegValidateIncorrectKeyDepositRefund,
+	DelegValidateDelegateeDrepNotRegistered,
+	PoolValidateStakePoolNotRegisteredOnKey,
+	PoolValidateStakePoolRetirementWrongEpoch,
+	PoolValidateStakePoolCostTooLow,
+	GovCertValidateDrepAlreadyRegistered,
+	GovCertValidateDrepNotRegistered,
+	GovCertValidateDrepIncorrectDeposit,
+	GovCertValidateDrepIncorrectRefund,
+	GovCertValidateCommitteeIsUnknown,
+	GovCertValidateCommitteeHasPreviouslyResigned,
+}
+
+// UtxoValidateFeeTooSmallUtxo ensures that the fee is at least the calculated minimum, including the reference script fee
+func UtxoValidateFeeTooSmallUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	minFee, err := MinFeeTx(tx, ls, pp)
+	if err != nil {
+		return err
+	}
+	if tx.Fee() >= minFee {
+		return nil
+	}
+	return shelley.FeeTooSmallUtxoError{
+		Provided: tx.Fee(),
+		Min:      minFee,
+	}
+}
+
+// UtxoValidateValueNotConservedUtxo ensures that the consumed value equals the produced value, accounting
+// for deposits, refunds and treasury donations
+func UtxoValidateValueNotConservedUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	consumedValue, producedValue, err := shelley.TxConsumedProducedValue(
+		tx,
+		ls,
+		uint64(tmpPparams.KeyDeposit),
+		uint64(tmpPparams.PoolDeposit),
+	)
+	if err != nil {
+		return err
+	}
+	// produced also includes proposal deposits and the treasury donation
+	for _, proposal := range tx.ProposalProcedures() {
+		producedValue, err = producedValue.Add(common.Value{Coin: proposal.Deposit})
+		if err != nil {
+			return err
+		}
+	}
+	producedValue, err = producedValue.Add(common.Value{Coin: tx.Donation()})
+	if err != nil {
+		return err
+	}
+	if consumedValue.Equal(producedValue) {
+		return nil
+	}
+	return shelley.ValueNotConservedUtxoError{
+		Consumed: consumedValue,
+		Produced: producedValue,
+	}
+}
+
+// UtxoValidateWrongNetworkInTxBody ensures that the network ID in the transaction body, if specified, is correct
+func UtxoValidateWrongNetworkInTxBody(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return alonzo.UtxoValidateWrongNetworkInTxBody(tx, slot, ls, pp)
+}
+
+// UtxoValidateMalformedReferenceScripts ensures that all reference scripts in outputs (including the collateral return) are well-formed
+func UtxoValidateMalformedReferenceScripts(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	outputs := tx.Outputs()
+	if collateralReturn := tx.CollateralReturn(); collateralReturn != nil {
+		outputs = append(outputs, collateralReturn)
+	}
+	var badOutputs []common.TransactionOutput
+	for _, txOutput := range outputs {
+		tmpOutput, ok := txOutput.(*babbage.BabbageTransactionOutput)
+		if !ok {
+			return fmt.Errorf("transaction output is not expected type")
+		}
+		if _, _, err := tmpOutput.ReferenceScript(); err == nil {
+			continue
+		}
+		badOutputs = append(badOutputs, tmpOutput)
+	}
+	if len(badOutputs) == 0 {
+		return nil
+	}
+	return babbage.MalformedReferenceScriptsError{
+		Outputs: badOutputs,
+	}
+}
+
+// UtxoValidateTxRefScriptsSizeTooBig ensures that the total size of the reference scripts used by the transaction doesn't exceed the maximum
+func UtxoValidateTxRefScriptsSizeTooBig(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	refScriptsSize := RefScriptsSize(tx, ls)
+	if refScriptsSize <= maxRefScriptSizePerTx {
+		return nil
+	}
+	return TxRefScriptsSizeTooBigError{
+		Size: refScriptsSize,
+		Max:  maxRefScriptSizePerTx,
+	}
+}
+
+// UtxoValidateCurrentTreasuryValue ensures that the current treasury value, if specified, matches the ledger state.
+// This check is skipped if the ledger state does not provide the treasury value
+func UtxoValidateCurrentTreasuryValue(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	currentTreasuryValue := tx.CurrentTreasuryValue()
+	if currentTreasuryValue == 0 {
+		return nil
+	}
+	treasuryState, ok := ls.(common.TreasuryState)
+	if !ok {
+		return nil
+	}
+	treasury, err := treasuryState.Treasury()
+	if err != nil {
+		return err
+	}
+	if currentTreasuryValue > 0 && uint64(currentTreasuryValue) == treasury {
+		return nil
+	}
+	return TreasuryValueMismatchError{
+		Actual:   treasury,
+		Provided: currentTreasuryValue,
+	}
+}
+
+func UtxoValidateOutsideValidityIntervalUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateOutsideValidityIntervalUtxo(tx, slot, ls, pp)
+}
+
+func UtxoValidateInputSetEmptyUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateInputSetEmptyUtxo(tx, slot, ls, pp)
+}
+
+func UtxoValidateInsufficientCollateral(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return babbage.UtxoValidateInsufficientCollateral(tx, slot, ls, babbagePparams(tmpPparams))
+}
+
+func UtxoValidateCollateralContainsNonAda(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateCollateralContainsNonAda(tx, slot, ls, pp)
+}
+
+func UtxoValidateScriptsNotPaidUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateScriptsNotPaidUtxo(tx, slot, ls, pp)
+}
+
+func UtxoValidateNoCollateralInputs(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateNoCollateralInputs(tx, slot, ls, pp)
+}
+
+func UtxoValidateIncorrectTotalCollateralField(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateIncorrectTotalCollateralField(tx, slot, ls, pp)
+}
+
+func UtxoValidateBadInputsUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateBadInputsUtxo(tx, slot, ls, pp)
+}
+
+func UtxoValidateNonDisjointRefInputs(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateNonDisjointRefInputs(tx, slot, ls, pp)
+}
+
+func UtxoValidateOutputTooSmallUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return babbage.UtxoValidateOutputTooSmallUtxo(tx, slot, ls, babbagePparams(tmpPparams))
+}
+
+func UtxoValidateOutputTooBigUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return babbage.UtxoValidateOutputTooBigUtxo(tx, slot, ls, babbagePparams(tmpPparams))
+}
+
+func UtxoValidateOutputBootAddrAttrsTooBig(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateOutputBootAddrAttrsTooBig(tx, slot, ls, pp)
+}
+
+func UtxoValidateWrongNetwork(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateWrongNetwork(tx, slot, ls, pp)
+}
+
+func UtxoValidateWrongNetworkWithdrawal(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxoValidateWrongNetworkWithdrawal(tx, slot, ls, pp)
+}
+
+func UtxoValidateMaxTxSizeUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return babbage.UtxoValidateMaxTxSizeUtxo(tx, slot, ls, babbagePparams(tmpPparams))
+}
+
+func UtxoValidateExUnitsTooBigUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return babbage.UtxoValidateExUnitsTooBigUtxo(tx, slot, ls, babbagePparams(tmpPparams))
+}
+
+func UtxoValidateTooManyCollateralInputs(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return babbage.UtxoValidateTooManyCollateralInputs(tx, slot, ls, babbagePparams(tmpPparams))
+}
+
+func UtxoValidateScriptDataHash(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return babbage.UtxoValidateScriptDataHash(tx, slot, ls, babbagePparams(tmpPparams))
+}
+
+// MinFeeTx calculates the minimum required fee for a transaction based on protocol parameters,
+// including the cost of script execution and the reference scripts used by the transaction
+func MinFeeTx(tx common.Transaction, ls common.UtxoState, pparams common.ProtocolParameters) (uint64, error) {
+	tmpPparams, ok := pparams.(*ConwayProtocolParameters)
+	if !ok {
+		return 0, fmt.Errorf("pparams are not expected type")
+	}
+	minFee, err := babbage.MinFeeTx(tx, babbagePparams(tmpPparams))
+	if err != nil {
+		return 0, err
+	}
+	if tmpPparams.MinFeeRefScriptCostPerByte != nil &&
+		tmpPparams.MinFeeRefScriptCostPerByte.Rat != nil {
+		minFee += RefScriptFee(
+			RefScriptsSize(tx, ls),
+			tmpPparams.MinFeeRefScriptCostPerByte.Rat,
+		)
+	}
+	return minFee, nil
+}
+
+// MinCoinTxOut calculates the minimum coin for a transaction output based on protocol parameters
+func MinCoinTxOut(txOut common.TransactionOutput, pparams common.ProtocolParameters) (uint64, error) {
+	tmpPparams, ok := pparams.(*ConwayProtocolParameters)
+	if !ok {
+		return 0, fmt.Errorf("pparams are not expected type")
+	}
+	return babbage.MinCoinTxOut(txOut, babbagePparams(tmpPparams))
+}
+
+// RefScriptFee calculates the fee for the specified total size of reference scripts. The price per byte
+// increases by a fixed multiplier for each tier of 25KiB, and the total is rounded down
+func RefScriptFee(refScriptsSize uint64, costPerByte *big.Rat) uint64 {
+	fee := new(big.Rat)
+	tierPrice := new(big.Rat).Set(costPerByte)
+	remaining := refScriptsSize
+	for remaining >= refScriptCostStride {
+		fee.Add(
+			fee,
+			new(big.Rat).Mul(tierPrice, new(big.Rat).SetUint64(refScriptCostStride)),
+		)
+		tierPrice.Mul(tierPrice, refScriptCostMultiplier)
+		remaining -= refScriptCostStride
+	}
+	fee.Add(
+		fee,
+		new(big.Rat).Mul(tierPrice, new(big.Rat).SetUint64(remaining)),
+	)
+	return new(big.Int).Quo(fee.Num(), fee.Denom()).Uint64()
+}
+
+// RefScriptsSize returns the total size of the reference scripts in the UTxOs spent or referenced by the transaction.
+// Scripts are counted once for each input that provides them, and inputs that cannot be found in the ledger
+// state are ignored
+func RefScriptsSize(tx common.Transaction, ls common.UtxoState) uint64 {
+	var ret uint64
+	for _, tmpInput := range slices.Concat(tx.Inputs(), tx.ReferenceInputs()) {
+		utxo, err := ls.UtxoById(tmpInput)
+		if err != nil {
+			continue
+		}
+		tmpOutput, ok := utxo.Output.(*babbage.BabbageTransactionOutput)
+		if !ok {
+			continue
+		}
+		_, script, err := tmpOutput.ReferenceScript()
+		if err != nil {
+			continue
+		}
+		ret += uint64(len(script))
+	}
+	return ret
+}
+
+// DelegValidateIncorrectDeposit ensures that stake registration certificates specify a deposit matching KeyDeposit
+func DelegValidateIncorrectDeposit(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	for _, cert := range tx.Certificates() {
+		var cred common.StakeCredential
+		var amount int64
+		switch c := cert.(type) {
+		case *common.RegistrationCertificate:
+			cred, amount = c.StakeCredential, c.Amount
+		case *common.StakeRegistrationDelegationCertificate:
+			cred, amount = c.StakeCredential, c.Amount
+		case *common.VoteRegistrationDelegationCertificate:
+			cred, amount = c.StakeCredential, c.Amount
+		case *common.StakeVoteRegistrationDelegationCertificate:
+			cred, amount = c.StakeCredential, c.Amount
+		default:
+			continue
+		}
+		if amount < 0 || uint64(amount) != uint64(tmpPparams.KeyDeposit) {
+			return IncorrectDepositError{
+				Credential: common.NewBlake2b224(cred.Credential),
+				Provided:   amount,
+				Expected:   uint64(tmpPparams.KeyDeposit),
+			}
+		}
+	}
+	return nil
+}
+
+// DelegValidateIncorrectKeyDepositRefund ensures that stake deregistration certificates specify a refund matching
+// the deposit paid at registration
+func DelegValidateIncorrectKeyDepositRefund(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return processCertificates(
+		tx,
+		ls,
+		tmpPparams,
+		func(cert common.Certificate, certState common.CertState) error {
+			c, ok := cert.(*common.DeregistrationCertificate)
+			if !ok {
+				return nil
+			}
+			credHash := common.NewBlake2b224(c.StakeCredential.Credential)
+			reg, err := certState.StakeRegistration(credHash)
+			if err != nil {
+				return err
+			}
+			// Unregistered stake credentials are handled by DelegValidateStakeKeyNotRegistered
+			if reg == nil {
+				return nil
+			}
+			if c.Amount < 0 || uint64(c.Amount) != reg.Deposit {
+				return IncorrectKeyDepositRefundError{
+					Credential: credHash,
+					Provided:   c.Amount,
+					Expected:   reg.Deposit,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// DelegValidateDelegateeDrepNotRegistered ensures that votes are only delegated to registered DReps. Delegating to
+// the predefined abstain and no confidence DReps is always allowed
+func DelegValidateDelegateeDrepNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return processCertificates(
+		tx,
+		ls,
+		tmpPparams,
+		func(cert common.Certificate, certState common.CertState) error {
+			var drep common.Drep
+			switch c := cert.(type) {
+			case *common.VoteDelegationCertificate:
+				drep = c.Drep
+			case *common.StakeVoteDelegationCertificate:
+				drep = c.Drep
+			case *common.VoteRegistrationDelegationCertificate:
+				drep = c.Drep
+			case *common.StakeVoteRegistrationDelegationCertificate:
+				drep = c.Drep
+			default:
+				return nil
+			}
+			if drep.Type != common.DrepTypeAddrKeyHash &&
+				drep.Type != common.DrepTypeScriptHash {
+				return nil
+			}
+			credHash := common.NewBlake2b224(drep.Credential)
+			reg, err := certState.DrepRegistration(credHash)
+			if err != nil {
+				return err
+			}
+			if reg == nil {
+				return DelegateeDrepNotRegisteredError{
+					Credential: credHash,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// GovCertValidateDrepAlreadyRegistered ensures that certificates don't register an already registered DRep
+func GovCertValidateDrepAlreadyRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return processCertificates(
+		tx,
+		ls,
+		tmpPparams,
+		func(cert common.Certificate, certState common.CertState) error {
+			c, ok := cert.(*common.RegistrationDrepCertificate)
+			if !ok {
+				return nil
+			}
+			credHash := common.NewBlake2b224(c.DrepCredential.Credential)
+			reg, err := certState.DrepRegistration(credHash)
+			if err != nil {
+				return err
+			}
+			if reg != nil {
+				return DrepAlreadyRegisteredError{
+					Credential: credHash,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// GovCertValidateDrepNotRegistered ensures that certificates only deregister or update registered DReps
+func GovCertValidateDrepNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return processCertificates(
+		tx,
+		ls,
+		tmpPparams,
+		func(cert common.Certificate, certState common.CertState) error {
+			var cred common.StakeCredential
+			switch c := cert.(type) {
+			case *common.DeregistrationDrepCertificate:
+				cred = c.DrepCredential
+			case *common.UpdateDrepCertificate:
+				cred = c.DrepCredential
+			default:
+				return nil
+			}
+			credHash := common.NewBlake2b224(cred.Credential)
+			reg, err := certState.DrepRegistration(credHash)
+			if err != nil {
+				return err
+			}
+			if reg == nil {
+				return DrepNotRegisteredError{
+					Credential: credHash,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// GovCertValidateDrepIncorrectDeposit ensures that DRep registration certificates specify a deposit matching DRepDeposit
+func GovCertValidateDrepIncorrectDeposit(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	for _, cert := range tx.Certificates() {
+		c, ok := cert.(*common.RegistrationDrepCertificate)
+		if !ok {
+			continue
+		}
+		if c.Amount < 0 || uint64(c.Amount) != tmpPparams.DRepDeposit {
+			return DrepIncorrectDepositError{
+				Credential: common.NewBlake2b224(c.DrepCredential.Credential),
+				Provided:   c.Amount,
+				Expected:   tmpPparams.DRepDeposit,
+			}
+		}
+	}
+	return nil
+}
+
+// GovCertValidateDrepIncorrectRefund ensures that DRep deregistration certificates specify a refund matching the
+// deposit paid at registration
+func GovCertValidateDrepIncorrectRefund(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return processCertificates(
+		tx,
+		ls,
+		tmpPparams,
+		func(cert common.Certificate, certState common.CertState) error {
+			c, ok := cert.(*common.DeregistrationDrepCertificate)
+			if !ok {
+				return nil
+			}
+			credHash := common.NewBlake2b224(c.DrepCredential.Credential)
+			reg, err := certState.DrepRegistration(credHash)
+			if err != nil {
+				return err
+			}
+			// Unregistered DReps are handled by GovCertValidateDrepNotRegistered
+			if reg == nil {
+				return nil
+			}
+			if c.Amount < 0 || uint64(c.Amount) != reg.Deposit {
+				return DrepIncorrectRefundError{
+					Credential: credHash,
+					Provided:   c.Amount,
+					Expected:   reg.Deposit,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// GovCertValidateCommitteeIsUnknown ensures that committee certificates are only issued for known committee members
+func GovCertValidateCommitteeIsUnknown(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return processCertificates(
+		tx,
+		ls,
+		tmpPparams,
+		func(cert common.Certificate, certState common.CertState) error {
+			var cred common.StakeCredential
+			switch c := cert.(type) {
+			case *common.AuthCommitteeHotCertificate:
+				cred = c.ColdCredential
+			case *common.ResignCommitteeColdCertificate:
+				cred = c.ColdCredential
+			default:
+				return nil
+			}
+			credHash := common.NewBlake2b224(cred.Credential)
+			member, err := certState.CommitteeMember(credHash)
+			if err != nil {
+				return err
+			}
+			if member == nil {
+				return CommitteeIsUnknownError{
+					Credential: credHash,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// GovCertValidateCommitteeHasPreviouslyResigned ensures that resigned committee members don't authorize a new hot key
+func GovCertValidateCommitteeHasPreviouslyResigned(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return processCertificates(
+		tx,
+		ls,
+		tmpPparams,
+		func(cert common.Certificate, certState common.CertState) error {
+			c, ok := cert.(*common.AuthCommitteeHotCertificate)
+			if !ok {
+				return nil
+			}
+			credHash := common.NewBlake2b224(c.ColdCredential.Credential)
+			member, err := certState.CommitteeMember(credHash)
+			if err != nil {
+				return err
+			}
+			if member != nil && member.Resigned {
+				return CommitteeHasPreviouslyResignedError{
+					Credential: credHash,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// processCertificates calls the provided function for each certificate in the transaction, along with the
+// certificate state resulting from the withdrawals and the certificates before it
+func processCertificates(tx common.Transaction, ls common.LedgerState, pparams *ConwayProtocolParameters, certFunc func(common.Certificate, common.CertState) error) error {
+	certState := common.NewTxCertState(
+		ls,
+		uint64(pparams.KeyDeposit),
+		uint64(pparams.PoolDeposit),
+	)
+	return certState.ProcessTx(
+		tx,
+		func(cert common.Certificate) error {
+			return certFunc(cert, certState)
+		},
+	)
+}
+
+// babbagePparams returns the Babbage equivalent of the provided protocol parameters, which allows
+// reusing the Babbage rules that only depend on parameters common to both eras
+func babbagePparams(pparams *ConwayProtocolParameters) *babbage.BabbageProtocolParameters {
+	return &babbage.BabbageProtocolParameters{
+		MinFeeA:              pparams.MinFeeA,
+		MinFeeB:              pparams.MinFeeB,
+		MaxBlockBodySize:     pparams.MaxBlockBodySize,
+		MaxTxSize:            pparams.MaxTxSize,
+		MaxBlockHeaderSize:   pparams.MaxBlockHeaderSize,
+		KeyDeposit:           pparams.KeyDeposit,
+		PoolDeposit:          pparams.PoolDeposit,
+		MaxEpoch:             pparams.MaxEpoch,
+		NOpt:                 pparams.NOpt,
+		A0:                   pparams.A0,
+		Rho:                  pparams.Rho,
+		Tau:                  pparams.Tau,
+		ProtocolMajor:        pparams.ProtocolVersion.Major,
+		ProtocolMinor:        pparams.ProtocolVersion.Minor,
+		MinPoolCost:          pparams.MinPoolCost,
+		AdaPerUtxoByte:       pparams.AdaPerUtxoByte,
+		CostModels:           pparams.CostModels,
+		ExecutionCosts:       pparams.ExecutionCosts,
+		MaxTxExUnits:         pparams.MaxTxExUnits,
+		MaxBlockExUnits:      pparams.MaxBlockExUnits,
+		MaxValueSize:         pparams.MaxValueSize,
+		CollateralPercentage: pparams.CollateralPercentage,
+		MaxCollateralInputs:  pparams.MaxCollateralInputs,
+	}
+}
+
+func UtxowValidateInvalidWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxowValidateInvalidWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateMissingVKeyWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxowValidateMissingVKeyWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateMissingScriptWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxowValidateMissingScriptWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateScriptWitnessNotValidating(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxowValidateScriptWitnessNotValidating(tx, slot, ls, pp)
+}
+
+func UtxowValidateMetadataHash(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.UtxowValidateMetadataHash(tx, slot, ls, pp)
+}
+
+func DelegValidateWithdrawalsNotInRewards(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.DelegValidateWithdrawalsNotInRewards(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyAlreadyRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.DelegValidateStakeKeyAlreadyRegistered(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.DelegValidateStakeKeyNotRegistered(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyNonZeroAccountBalance(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.DelegValidateStakeKeyNonZeroAccountBalance(tx, slot, ls, pp)
+}
+
+func DelegValidateDelegateeNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.DelegValidateDelegateeNotRegistered(tx, slot, ls, pp)
+}
+
+func PoolValidateStakePoolNotRegisteredOnKey(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return babbage.PoolValidateStakePoolNotRegisteredOnKey(tx, slot, ls, pp)
+}
+
+func PoolValidateStakePoolRetirementWrongEpoch(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return babbage.PoolValidateStakePoolRetirementWrongEpoch(tx, slot, ls, babbagePparams(tmpPparams))
+}
+
+func PoolValidateStakePoolCostTooLow(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ConwayProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return babbage.PoolValidateStakePoolCostTooLow(tx, slot, ls, babbagePparams(tmpPparams))
+}
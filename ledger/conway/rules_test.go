@@ -0,0 +1,686 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conway_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/conway"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLedgerState struct {
+	networkId          uint
+	utxos              []common.Utxo
+	stakeRegistrations map[common.Blake2b224]*common.StakeRegistration
+	poolRegistrations  map[common.PoolKeyHash]*common.PoolRegistration
+	drepRegistrations  map[common.Blake2b224]*common.DrepRegistration
+	committeeMembers   map[common.Blake2b224]*common.CommitteeMember
+}
+
+func (ls testLedgerState) NetworkId() uint {
+	return ls.networkId
+}
+
+func (ls testLedgerState) UtxoById(id common.TransactionInput) (common.Utxo, error) {
+	for _, tmpUtxo := range ls.utxos {
+		if id.Index() != tmpUtxo.Id.Index() {
+			continue
+		}
+		if string(id.Id().Bytes()) != string(tmpUtxo.Id.Id().Bytes()) {
+			continue
+		}
+		return tmpUtxo, nil
+	}
+	return common.Utxo{}, fmt.Errorf("not found")
+}
+
+func (ls testLedgerState) StakeRegistration(cred common.Blake2b224) (*common.StakeRegistration, error) {
+	return ls.stakeRegistrations[cred], nil
+}
+
+func (ls testLedgerState) PoolRegistration(pool common.PoolKeyHash) (*common.PoolRegistration, error) {
+	return ls.poolRegistrations[pool], nil
+}
+
+func (ls testLedgerState) DrepRegistration(cred common.Blake2b224) (*common.DrepRegistration, error) {
+	return ls.drepRegistrations[cred], nil
+}
+
+func (ls testLedgerState) CommitteeMember(cred common.Blake2b224) (*common.CommitteeMember, error) {
+	return ls.committeeMembers[cred], nil
+}
+
+type testTreasuryLedgerState struct {
+	testLedgerState
+	treasury uint64
+}
+
+func (ls testTreasuryLedgerState) Treasury() (uint64, error) {
+	return ls.treasury, nil
+}
+
+const testInputTxId = "d228b482a1aae768e4a796380f49e021d9c21f70d3c12cb186b188dedfc0ee22"
+
+func testRefScriptLedgerState(t *testing.T, scriptSize int) testLedgerState {
+	scriptRefCbor, err := cbor.Encode(
+		[]any{babbage.ScriptRefTypePlutusV3, make([]byte, scriptSize)},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return testLedgerState{
+		utxos: []common.Utxo{
+			{
+				Id: shelley.NewShelleyTransactionInput(testInputTxId, 0),
+				Output: &babbage.BabbageTransactionOutput{
+					ScriptRef: &cbor.Tag{
+						Number:  24,
+						Content: scriptRefCbor,
+					},
+				},
+			},
+		},
+	}
+}
+
+func testRefScriptTx() *conway.ConwayTransaction {
+	testTx := &conway.ConwayTransaction{}
+	testTx.Body.TxReferenceInputs = []shelley.ShelleyTransactionInput{
+		shelley.NewShelleyTransactionInput(testInputTxId, 0),
+	}
+	return testTx
+}
+
+func TestRefScriptFee(t *testing.T) {
+	testCostPerByte := big.NewRat(15, 1)
+	testDefs := []struct {
+		size uint64
+		fee  uint64
+	}{
+		{size: 0, fee: 0},
+		{size: 100, fee: 1500},
+		// Exactly one tier
+		{size: 25600, fee: 384000},
+		// 25600*15 + 4400*18
+		{size: 30000, fee: 463200},
+		// 25600*15 + 25600*18 + 1*21.6 (rounded down)
+		{size: 51201, fee: 844821},
+	}
+	for _, testDef := range testDefs {
+		fee := conway.RefScriptFee(testDef.size, testCostPerByte)
+		if fee != testDef.fee {
+			t.Errorf(
+				"did not get expected fee for size %d: got %d, wanted %d",
+				testDef.size,
+				fee,
+				testDef.fee,
+			)
+		}
+	}
+}
+
+func TestUtxoValidateFeeTooSmallUtxo(t *testing.T) {
+	// 7*3 + 53 + 100*15
+	var testExactFee uint64 = 1574
+	var testBelowFee uint64 = 1573
+	testTxCbor, _ := hex.DecodeString("abcdef")
+	testTx := testRefScriptTx()
+	testTx.SetCbor(testTxCbor)
+	testProtocolParams := &conway.ConwayProtocolParameters{
+		MinFeeA:                    7,
+		MinFeeB:                    53,
+		MinFeeRefScriptCostPerByte: &cbor.Rat{Rat: big.NewRat(15, 1)},
+	}
+	testLedgerState := testRefScriptLedgerState(t, 100)
+	testSlot := uint64(0)
+	// Exact fee
+	t.Run(
+		"exact fee",
+		func(t *testing.T) {
+			testTx.Body.TxFee = testExactFee
+			err := conway.UtxoValidateFeeTooSmallUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateFeeTooSmallUtxo should succeed when provided the exact fee including reference script costs\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Fee too low
+	t.Run(
+		"fee too low",
+		func(t *testing.T) {
+			testTx.Body.TxFee = testBelowFee
+			err := conway.UtxoValidateFeeTooSmallUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateFeeTooSmallUtxo should fail when provided too low of a fee",
+				)
+				return
+			}
+			testErrType := shelley.FeeTooSmallUtxoError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateValueNotConservedUtxo(t *testing.T) {
+	var testInputAmount uint64 = 10000000
+	var testFee uint64 = 200000
+	var testKeyDeposit uint64 = 2000000
+	var testDrepRefund uint64 = 500000
+	var testDonation uint64 = 1000000
+	// input + refund - fee - (stake registration + registration cert) deposits - donation
+	var testExactOutputAmount = testInputAmount + testDrepRefund - testFee - (testKeyDeposit * 2) - testDonation
+	testProtocolParams := &conway.ConwayProtocolParameters{
+		KeyDeposit: uint(testKeyDeposit),
+	}
+	testLedgerState := testLedgerState{
+		utxos: []common.Utxo{
+			{
+				Id: shelley.NewShelleyTransactionInput(testInputTxId, 0),
+				Output: &babbage.BabbageTransactionOutput{
+					OutputAmount: mary.MaryTransactionOutputValue{
+						Amount: testInputAmount,
+					},
+				},
+			},
+		},
+	}
+	testSlot := uint64(0)
+	testRun := func(t *testing.T, name string, outputAmount uint64, donation uint64, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testTx := &conway.ConwayTransaction{}
+				testTx.Body.TxInputs = conway.NewConwayTransactionInputSet(
+					[]shelley.ShelleyTransactionInput{
+						shelley.NewShelleyTransactionInput(testInputTxId, 0),
+					},
+				)
+				testTx.Body.TxOutputs = []babbage.BabbageTransactionOutput{
+					{
+						OutputAmount: mary.MaryTransactionOutputValue{
+							Amount: outputAmount,
+						},
+					},
+				}
+				testTx.Body.TxFee = testFee
+				testTx.Body.TxDonation = donation
+				testTx.Body.TxCertificates = []common.CertificateWrapper{
+					{
+						Type:        common.CertificateTypeStakeRegistration,
+						Certificate: &common.StakeRegistrationCertificate{},
+					},
+					{
+						Type: common.CertificateTypeRegistration,
+						Certificate: &common.RegistrationCertificate{
+							Amount: int64(testKeyDeposit),
+						},
+					},
+					{
+						Type: common.CertificateTypeDeregistrationDrep,
+						Certificate: &common.DeregistrationDrepCertificate{
+							Amount: int64(testDrepRefund),
+						},
+					},
+				}
+				err := conway.UtxoValidateValueNotConservedUtxo(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	// Exact amount
+	testRun(
+		t,
+		"exact amount",
+		testExactOutputAmount,
+		testDonation,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateValueNotConservedUtxo should succeed when inputs and outputs are balanced\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Output too high
+	testRun(
+		t,
+		"output too high",
+		testExactOutputAmount+1,
+		testDonation,
+		func(t *testing.T, err error) {
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateValueNotConservedUtxo should fail when the outputs don't account for deposits and donations",
+				)
+				return
+			}
+			testErrType := shelley.ValueNotConservedUtxoError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+	// Donation that would wrap the produced value around to the consumed value
+	testRun(
+		t,
+		"donation overflow",
+		testExactOutputAmount+testDonation+1,
+		math.MaxUint64,
+		func(t *testing.T, err error) {
+			if !errors.Is(err, common.ErrValueOverflow) {
+				t.Errorf(
+					"UtxoValidateValueNotConservedUtxo should fail when the produced value overflows\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+}
+
+func TestUtxoValidateCurrentTreasuryValue(t *testing.T) {
+	var testTreasury uint64 = 1500000000000000
+	testProtocolParams := &conway.ConwayProtocolParameters{}
+	testSlot := uint64(0)
+	testRun := func(t *testing.T, name string, ls common.LedgerState, treasuryValue int64, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testTx := &conway.ConwayTransaction{}
+				testTx.Body.TxCurrentTreasuryValue = treasuryValue
+				err := conway.UtxoValidateCurrentTreasuryValue(
+					testTx,
+					testSlot,
+					ls,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	validateSuccess := func(t *testing.T, err error) {
+		if err != nil {
+			t.Errorf(
+				"UtxoValidateCurrentTreasuryValue should succeed\n  got error: %v",
+				err,
+			)
+		}
+	}
+	testTreasuryLedgerState := testTreasuryLedgerState{treasury: testTreasury}
+	testRun(t, "matching treasury value", testTreasuryLedgerState, int64(testTreasury), validateSuccess)
+	testRun(t, "no treasury value", testTreasuryLedgerState, 0, validateSuccess)
+	testRun(t, "no treasury state", testLedgerState{}, 1, validateSuccess)
+	testRun(
+		t,
+		"mismatched treasury value",
+		testTreasuryLedgerState,
+		int64(testTreasury)+1,
+		func(t *testing.T, err error) {
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateCurrentTreasuryValue should fail when the treasury value does not match",
+				)
+				return
+			}
+			testErrType := conway.TreasuryValueMismatchError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxoValidateTxRefScriptsSizeTooBig(t *testing.T) {
+	testProtocolParams := &conway.ConwayProtocolParameters{}
+	testSlot := uint64(0)
+	testTx := testRefScriptTx()
+	// Maximum size
+	t.Run(
+		"maximum size",
+		func(t *testing.T) {
+			err := conway.UtxoValidateTxRefScriptsSizeTooBig(
+				testTx,
+				testSlot,
+				testRefScriptLedgerState(t, 200*1024),
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateTxRefScriptsSizeTooBig should succeed when reference scripts are at the maximum size\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Too big
+	t.Run(
+		"too big",
+		func(t *testing.T) {
+			err := conway.UtxoValidateTxRefScriptsSizeTooBig(
+				testTx,
+				testSlot,
+				testRefScriptLedgerState(t, (200*1024)+1),
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateTxRefScriptsSizeTooBig should fail when reference scripts are too big",
+				)
+				return
+			}
+			testErrType := conway.TxRefScriptsSizeTooBigError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestCertValidationRules(t *testing.T) {
+	testCred := common.Blake2b224Hash([]byte("stake"))
+	testDrepCred := common.Blake2b224Hash([]byte("drep"))
+	testOtherCred := common.Blake2b224Hash([]byte("other"))
+	testColdCred := common.Blake2b224Hash([]byte("cold"))
+	testResignedColdCred := common.Blake2b224Hash([]byte("resigned cold"))
+	testStakeCredential := func(cred common.Blake2b224) common.StakeCredential {
+		return common.StakeCredential{
+			CredType:   common.StakeCredentialTypeAddrKeyHash,
+			Credential: cred.Bytes(),
+		}
+	}
+	testProtocolParams := &conway.ConwayProtocolParameters{
+		KeyDeposit:  2_000_000,
+		DRepDeposit: 500_000_000,
+	}
+	testLedgerState := testLedgerState{
+		stakeRegistrations: map[common.Blake2b224]*common.StakeRegistration{
+			// Registered when the key deposit was lower
+			testCred: {Deposit: 1_000_000},
+		},
+		drepRegistrations: map[common.Blake2b224]*common.DrepRegistration{
+			testDrepCred: {Deposit: 500_000_000},
+		},
+		committeeMembers: map[common.Blake2b224]*common.CommitteeMember{
+			testColdCred:         {},
+			testResignedColdCred: {Resigned: true},
+		},
+	}
+	testSlot := uint64(0)
+	testDefs := []struct {
+		name         string
+		cert         common.CertificateWrapper
+		validateFunc common.UtxoValidationRuleFunc
+		expectedErr  error
+	}{
+		{
+			name: "registration with correct deposit",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeRegistration,
+				Certificate: &common.RegistrationCertificate{
+					StakeCredential: testStakeCredential(testOtherCred),
+					Amount:          2_000_000,
+				},
+			},
+			validateFunc: conway.DelegValidateIncorrectDeposit,
+		},
+		{
+			name: "registration with incorrect deposit",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeRegistration,
+				Certificate: &common.RegistrationCertificate{
+					StakeCredential: testStakeCredential(testOtherCred),
+					Amount:          1_000_000,
+				},
+			},
+			validateFunc: conway.DelegValidateIncorrectDeposit,
+			expectedErr:  conway.IncorrectDepositError{},
+		},
+		{
+			name: "deregistration with original deposit",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeDeregistration,
+				Certificate: &common.DeregistrationCertificate{
+					StakeCredential: testStakeCredential(testCred),
+					Amount:          1_000_000,
+				},
+			},
+			validateFunc: conway.DelegValidateIncorrectKeyDepositRefund,
+		},
+		{
+			name: "deregistration with current deposit",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeDeregistration,
+				Certificate: &common.DeregistrationCertificate{
+					StakeCredential: testStakeCredential(testCred),
+					Amount:          2_000_000,
+				},
+			},
+			validateFunc: conway.DelegValidateIncorrectKeyDepositRefund,
+			expectedErr:  conway.IncorrectKeyDepositRefundError{},
+		},
+		{
+			name: "vote delegation to registered DRep",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeVoteDelegation,
+				Certificate: &common.VoteDelegationCertificate{
+					StakeCredential: testStakeCredential(testCred),
+					Drep: common.Drep{
+						Type:       common.DrepTypeAddrKeyHash,
+						Credential: testDrepCred.Bytes(),
+					},
+				},
+			},
+			validateFunc: conway.DelegValidateDelegateeDrepNotRegistered,
+		},
+		{
+			name: "vote delegation to abstain",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeVoteDelegation,
+				Certificate: &common.VoteDelegationCertificate{
+					StakeCredential: testStakeCredential(testCred),
+					Drep: common.Drep{
+						Type: common.DrepTypeAbstain,
+					},
+				},
+			},
+			validateFunc: conway.DelegValidateDelegateeDrepNotRegistered,
+		},
+		{
+			name: "vote delegation to unregistered DRep",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeVoteDelegation,
+				Certificate: &common.VoteDelegationCertificate{
+					StakeCredential: testStakeCredential(testCred),
+					Drep: common.Drep{
+						Type:       common.DrepTypeAddrKeyHash,
+						Credential: testOtherCred.Bytes(),
+					},
+				},
+			},
+			validateFunc: conway.DelegValidateDelegateeDrepNotRegistered,
+			expectedErr:  conway.DelegateeDrepNotRegisteredError{},
+		},
+		{
+			name: "DRep registration of registered DRep",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeRegistrationDrep,
+				Certificate: &common.RegistrationDrepCertificate{
+					DrepCredential: testStakeCredential(testDrepCred),
+					Amount:         500_000_000,
+				},
+			},
+			validateFunc: conway.GovCertValidateDrepAlreadyRegistered,
+			expectedErr:  conway.DrepAlreadyRegisteredError{},
+		},
+		{
+			name: "DRep registration with incorrect deposit",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeRegistrationDrep,
+				Certificate: &common.RegistrationDrepCertificate{
+					DrepCredential: testStakeCredential(testOtherCred),
+					Amount:         1_000_000,
+				},
+			},
+			validateFunc: conway.GovCertValidateDrepIncorrectDeposit,
+			expectedErr:  conway.DrepIncorrectDepositError{},
+		},
+		{
+			name: "DRep update of unregistered DRep",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeUpdateDrep,
+				Certificate: &common.UpdateDrepCertificate{
+					DrepCredential: testStakeCredential(testOtherCred),
+				},
+			},
+			validateFunc: conway.GovCertValidateDrepNotRegistered,
+			expectedErr:  conway.DrepNotRegisteredError{},
+		},
+		{
+			name: "DRep deregistration with incorrect refund",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeDeregistrationDrep,
+				Certificate: &common.DeregistrationDrepCertificate{
+					DrepCredential: testStakeCredential(testDrepCred),
+					Amount:         1_000_000,
+				},
+			},
+			validateFunc: conway.GovCertValidateDrepIncorrectRefund,
+			expectedErr:  conway.DrepIncorrectRefundError{},
+		},
+		{
+			name: "hot key authorization for committee member",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeAuthCommitteeHot,
+				Certificate: &common.AuthCommitteeHotCertificate{
+					ColdCredential: testStakeCredential(testColdCred),
+					HostCredential: testStakeCredential(testOtherCred),
+				},
+			},
+			validateFunc: conway.GovCertValidateCommitteeIsUnknown,
+		},
+		{
+			name: "hot key authorization for unknown committee member",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeAuthCommitteeHot,
+				Certificate: &common.AuthCommitteeHotCertificate{
+					ColdCredential: testStakeCredential(testOtherCred),
+					HostCredential: testStakeCredential(testOtherCred),
+				},
+			},
+			validateFunc: conway.GovCertValidateCommitteeIsUnknown,
+			expectedErr:  conway.CommitteeIsUnknownError{},
+		},
+		{
+			name: "hot key authorization for resigned committee member",
+			cert: common.CertificateWrapper{
+				Type: common.CertificateTypeAuthCommitteeHot,
+				Certificate: &common.AuthCommitteeHotCertificate{
+					ColdCredential: testStakeCredential(testResignedColdCred),
+					HostCredential: testStakeCredential(testOtherCred),
+				},
+			},
+			validateFunc: conway.GovCertValidateCommitteeHasPreviouslyResigned,
+			expectedErr:  conway.CommitteeHasPreviouslyResignedError{},
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(
+			testDef.name,
+			func(t *testing.T) {
+				testTx := &conway.ConwayTransaction{}
+				testTx.Body.TxCertificates = []common.CertificateWrapper{
+					testDef.cert,
+				}
+				err := testDef.validateFunc(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				if testDef.expectedErr == nil {
+					if err != nil {
+						t.Errorf("validation should succeed\n  got error: %v", err)
+					}
+					return
+				}
+				if err == nil {
+					t.Errorf("validation should fail")
+					return
+				}
+				assert.IsType(
+					t,
+					testDef.expectedErr,
+					err,
+					"did not get expected error type: got %T, wanted %T",
+					err,
+					testDef.expectedErr,
+				)
+			},
+		)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cose
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/keys"
+)
+
+var (
+	// ErrAddressMismatch is returned when the address in the protected headers doesn't match the expected address
+	ErrAddressMismatch = errors.New("signed address does not match")
+	// ErrKeyMismatch is returned when the key hash doesn't match the payment or stake credential of the address
+	ErrKeyMismatch = errors.New("key does not match address credentials")
+	// ErrPayloadMismatch is returned when the signed payload doesn't match the expected message
+	ErrPayloadMismatch = errors.New("signed payload does not match")
+	// ErrInvalidSignature is returned when the signature is not valid for the key
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// DataSignature is the result of the CIP-30 signData wallet API, which contains the CBOR encodings of the
+// COSE_Sign1 message and the COSE_Key for the signing key
+type DataSignature struct {
+	Signature []byte
+	Key       []byte
+}
+
+type dataSignatureJson struct {
+	Signature string `json:"signature"`
+	Key       string `json:"key"`
+}
+
+// MarshalJSON encodes the signature and key as hex strings, as returned by CIP-30 wallets
+func (d DataSignature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		dataSignatureJson{
+			Signature: hex.EncodeToString(d.Signature),
+			Key:       hex.EncodeToString(d.Key),
+		},
+	)
+}
+
+func (d *DataSignature) UnmarshalJSON(data []byte) error {
+	var tmpData dataSignatureJson
+	if err := json.Unmarshal(data, &tmpData); err != nil {
+		return err
+	}
+	signature, err := hex.DecodeString(tmpData.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	key, err := hex.DecodeString(tmpData.Key)
+	if err != nil {
+		return fmt.Errorf("decode key: %w", err)
+	}
+	d.Signature = signature
+	d.Key = key
+	return nil
+}
+
+// SignData signs a message for an address as described in CIP-8, returning the same result as the CIP-30
+// signData wallet API. When hashed is true, the signed payload is the Blake2b-224 hash of the message
+func SignData(key *keys.SigningKey, address common.Address, message []byte, hashed bool) (*DataSignature, error) {
+	addrBytes, err := address.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	payload := message
+	if hashed {
+		payload = common.Blake2b224Hash(message).Bytes()
+	}
+	alg := int64(AlgorithmEdDSA)
+	msg := &Sign1Message{
+		Protected: Headers{
+			Algorithm: &alg,
+			Address:   addrBytes,
+		},
+		Unprotected: Headers{
+			Hashed: &hashed,
+		},
+		Payload: payload,
+	}
+	sigStructure, err := msg.SigStructure(nil)
+	if err != nil {
+		return nil, err
+	}
+	msg.Signature = key.Sign(sigStructure)
+	msgCbor, err := cbor.Encode(msg)
+	if err != nil {
+		return nil, err
+	}
+	keyCbor, err := cbor.Encode(
+		&Key{
+			PublicKey: key.VerificationKey().Bytes(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &DataSignature{
+		Signature: msgCbor,
+		Key:       keyCbor,
+	}, nil
+}
+
+// VerifyData verifies a CIP-30 signData result for an address and message, returning the signed message.
+// The key hash must match the payment or stake key credential of the address, and the address in the
+// protected headers must match the address. If message is nil, any payload is accepted
+func VerifyData(sig DataSignature, address common.Address, message []byte) (*Sign1Message, error) {
+	key, err := NewKeyFromCbor(sig.Key)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := NewSign1MessageFromCbor(sig.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Protected.Algorithm == nil || *msg.Protected.Algorithm != AlgorithmEdDSA {
+		return nil, errors.New("unsupported signature algorithm")
+	}
+	// Address
+	addrBytes, err := address.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(msg.Protected.Address, addrBytes) {
+		return nil, ErrAddressMismatch
+	}
+	// Key
+	keyHash := key.Hash()
+	keyMatches := false
+	for _, cred := range []*common.StakeCredential{address.PaymentCredential(), address.StakeCredential()} {
+		if cred != nil && cred.CredType == common.StakeCredentialTypeAddrKeyHash &&
+			bytes.Equal(cred.Credential, keyHash.Bytes()) {
+			keyMatches = true
+			break
+		}
+	}
+	if !keyMatches {
+		return nil, ErrKeyMismatch
+	}
+	// Payload
+	var externalPayload []byte
+	if message != nil {
+		expectedPayload := message
+		if msg.IsHashed() {
+			expectedPayload = common.Blake2b224Hash(message).Bytes()
+		}
+		if msg.Payload == nil {
+			externalPayload = expectedPayload
+		} else if !bytes.Equal(msg.Payload, expectedPayload) {
+			return nil, ErrPayloadMismatch
+		}
+	}
+	// Signature
+	sigStructure, err := msg.SigStructure(externalPayload)
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(key.PublicKey, sigStructure, msg.Signature) {
+		return nil, ErrInvalidSignature
+	}
+	return msg, nil
+}
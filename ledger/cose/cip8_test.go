@@ -0,0 +1,218 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cose_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/cose"
+	"github.com/blinklabs-io/gouroboros/ledger/keys"
+)
+
+func testKeys(t *testing.T) (*keys.SigningKey, *keys.SigningKey, common.Address, common.Address) {
+	t.Helper()
+	paymentKey, err := keys.NewSigningKey(keys.KeyRolePayment, bytes.Repeat([]byte{0x01}, 32))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	stakeKey, err := keys.NewSigningKey(keys.KeyRoleStake, bytes.Repeat([]byte{0x02}, 32))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	baseAddr, err := common.NewAddressFromParts(
+		common.AddressTypeKeyKey,
+		common.AddressNetworkTestnet,
+		paymentKey.VerificationKey().Hash().Bytes(),
+		stakeKey.VerificationKey().Hash().Bytes(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rewardAddr, err := common.NewAddressFromParts(
+		common.AddressTypeNoneKey,
+		common.AddressNetworkTestnet,
+		stakeKey.VerificationKey().Hash().Bytes(),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return paymentKey, stakeKey, baseAddr, rewardAddr
+}
+
+func TestSignDataEncoding(t *testing.T) {
+	paymentKey, _, baseAddr, _ := testKeys(t)
+	sig, err := cose.SignData(paymentKey, baseAddr, []byte("hello"), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	addrBytes, _ := baseAddr.Bytes()
+	// COSE_Sign1 with protected headers {1: -8, "address": <addr>}, unprotected headers {"hashed": false},
+	// the payload, and the 64-byte signature
+	expectedPrefix := "845846a201276761646472657373" + "5839" + hex.EncodeToString(addrBytes) +
+		"a166686173686564f4" + "4568656c6c6f" + "5840"
+	if !bytes.HasPrefix(sig.Signature, mustDecodeHex(t, expectedPrefix)) || len(sig.Signature) != len(expectedPrefix)/2+64 {
+		t.Errorf("did not get expected COSE_Sign1\n  got:    %x\n  wanted: %s...", sig.Signature, expectedPrefix)
+	}
+	expectedKey := "a4010103272006215820" + hex.EncodeToString(paymentKey.VerificationKey().Bytes())
+	if hex.EncodeToString(sig.Key) != expectedKey {
+		t.Errorf("did not get expected COSE_Key\n  got:    %x\n  wanted: %s", sig.Key, expectedKey)
+	}
+	// CIP-30 JSON
+	jsonData, err := json.Marshal(sig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var tmpSig cose.DataSignature
+	if err := json.Unmarshal(jsonData, &tmpSig); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(tmpSig.Signature, sig.Signature) || !bytes.Equal(tmpSig.Key, sig.Key) {
+		t.Errorf("signature does not match after JSON round-trip")
+	}
+}
+
+func TestVerifyData(t *testing.T) {
+	paymentKey, stakeKey, baseAddr, rewardAddr := testKeys(t)
+	message := []byte("Sign in to example.com")
+	testDefs := []struct {
+		name    string
+		key     *keys.SigningKey
+		address common.Address
+		hashed  bool
+	}{
+		{
+			name:    "payment key",
+			key:     paymentKey,
+			address: baseAddr,
+		},
+		{
+			name:    "stake key with base address",
+			key:     stakeKey,
+			address: baseAddr,
+		},
+		{
+			name:    "stake key with reward address",
+			key:     stakeKey,
+			address: rewardAddr,
+		},
+		{
+			name:    "hashed payload",
+			key:     paymentKey,
+			address: baseAddr,
+			hashed:  true,
+		},
+	}
+	for _, testDef := range testDefs {
+		sig, err := cose.SignData(testDef.key, testDef.address, message, testDef.hashed)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		msg, err := cose.VerifyData(*sig, testDef.address, message)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if msg.IsHashed() != testDef.hashed {
+			t.Errorf("%s: did not get expected hashed flag", testDef.name)
+		}
+		if _, err := cose.VerifyData(*sig, testDef.address, nil); err != nil {
+			t.Errorf("%s: unexpected error without message: %s", testDef.name, err)
+		}
+		if _, err := cose.VerifyData(*sig, testDef.address, []byte("other")); !errors.Is(err, cose.ErrPayloadMismatch) {
+			t.Errorf("%s: did not get expected error for other message: %v", testDef.name, err)
+		}
+	}
+}
+
+func TestVerifyDataErrors(t *testing.T) {
+	paymentKey, stakeKey, baseAddr, rewardAddr := testKeys(t)
+	message := []byte("hello")
+	sig, err := cose.SignData(paymentKey, baseAddr, message, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Different address
+	if _, err := cose.VerifyData(*sig, rewardAddr, message); !errors.Is(err, cose.ErrAddressMismatch) {
+		t.Errorf("did not get expected error for different address: %v", err)
+	}
+	// Key which doesn't match the address credentials
+	otherKey, err := keys.NewSigningKey(keys.KeyRolePayment, bytes.Repeat([]byte{0x03}, 32))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	otherSig, err := cose.SignData(otherKey, baseAddr, message, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := cose.VerifyData(*otherSig, baseAddr, message); !errors.Is(err, cose.ErrKeyMismatch) {
+		t.Errorf("did not get expected error for other key: %v", err)
+	}
+	// Key from another signature
+	stakeSig, err := cose.SignData(stakeKey, baseAddr, message, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	badSig := cose.DataSignature{
+		Signature: sig.Signature,
+		Key:       stakeSig.Key,
+	}
+	if _, err := cose.VerifyData(badSig, baseAddr, message); !errors.Is(err, cose.ErrInvalidSignature) {
+		t.Errorf("did not get expected error for mismatched key: %v", err)
+	}
+}
+
+func TestSign1MessageDetachedAndTagged(t *testing.T) {
+	paymentKey, _, baseAddr, _ := testKeys(t)
+	message := []byte("detached")
+	sig, err := cose.SignData(paymentKey, baseAddr, message, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	msg, err := cose.NewSign1MessageFromCbor(sig.Signature)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Detach the payload and add the COSE_Sign1 tag
+	msg.Payload = nil
+	msgCbor, err := cbor.Encode(cbor.Tag{Number: 18, Content: msg})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	detachedSig := cose.DataSignature{
+		Signature: msgCbor,
+		Key:       sig.Key,
+	}
+	if _, err := cose.VerifyData(detachedSig, baseAddr, message); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if _, err := cose.VerifyData(detachedSig, baseAddr, nil); err == nil {
+		t.Errorf("did not get expected error without detached payload")
+	}
+}
+
+func mustDecodeHex(t *testing.T, data string) []byte {
+	t.Helper()
+	ret, err := hex.DecodeString(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return ret
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cose implements the subset of COSE (RFC 9052) used for Cardano message signing, as described in
+// CIP-8 and used by the CIP-30 signData wallet API
+package cose
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+// Header labels
+const (
+	HeaderLabelAlgorithm = 1
+	HeaderLabelKeyId     = 4
+	// HeaderLabelAddress is the CIP-8 header containing the raw bytes of the address used for signing
+	HeaderLabelAddress = "address"
+	// HeaderLabelHashed is the CIP-8 header indicating that the payload is the Blake2b-224 hash of the message
+	HeaderLabelHashed = "hashed"
+)
+
+// AlgorithmEdDSA is the COSE algorithm identifier for EdDSA signatures
+const AlgorithmEdDSA = -8
+
+// cborTagSign1 is the optional CBOR tag for a COSE_Sign1 message
+const cborTagSign1 = 18
+
+// sigStructureContextSign1 is the context string of the Sig_structure for a COSE_Sign1 message
+const sigStructureContextSign1 = "Signature1"
+
+// Headers contains the COSE headers used by CIP-8. Other headers are preserved when decoding
+type Headers struct {
+	Algorithm *int64
+	KeyId     []byte
+	Address   []byte
+	// Hashed is nil if the header is not present
+	Hashed *bool
+	// Other contains any other headers, keyed by their integer or text label
+	Other map[any]any
+}
+
+func (h *Headers) UnmarshalCBOR(data []byte) error {
+	var tmpHeaders map[any]any
+	if _, err := cbor.Decode(data, &tmpHeaders); err != nil {
+		return err
+	}
+	*h = Headers{}
+	for label, value := range tmpHeaders {
+		// Positive integer labels decode as uint64, so they are normalized to int64
+		if tmpLabel, ok := label.(uint64); ok {
+			label = int64(tmpLabel)
+		}
+		var ok bool
+		switch label {
+		case int64(HeaderLabelAlgorithm):
+			var alg int64
+			alg, ok = headerInt(value)
+			h.Algorithm = &alg
+		case int64(HeaderLabelKeyId):
+			h.KeyId, ok = value.([]byte)
+		case HeaderLabelAddress:
+			h.Address, ok = value.([]byte)
+		case HeaderLabelHashed:
+			var hashed bool
+			hashed, ok = value.(bool)
+			h.Hashed = &hashed
+		default:
+			if h.Other == nil {
+				h.Other = make(map[any]any)
+			}
+			h.Other[label] = value
+			ok = true
+		}
+		if !ok {
+			return fmt.Errorf("invalid COSE header value for label %v", label)
+		}
+	}
+	return nil
+}
+
+func (h Headers) MarshalCBOR() ([]byte, error) {
+	tmpHeaders := make(map[any]any, len(h.Other)+4)
+	for label, value := range h.Other {
+		tmpHeaders[label] = value
+	}
+	if h.Algorithm != nil {
+		tmpHeaders[HeaderLabelAlgorithm] = *h.Algorithm
+	}
+	if h.KeyId != nil {
+		tmpHeaders[HeaderLabelKeyId] = h.KeyId
+	}
+	if h.Address != nil {
+		tmpHeaders[HeaderLabelAddress] = h.Address
+	}
+	if h.Hashed != nil {
+		tmpHeaders[HeaderLabelHashed] = *h.Hashed
+	}
+	return cbor.Encode(tmpHeaders)
+}
+
+func headerInt(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case uint64:
+		if v > 1<<63-1 {
+			return 0, false
+		}
+		return int64(v), true
+	}
+	return 0, false
+}
+
+// Sign1Message is a COSE_Sign1 message, which is a payload with a single signature
+type Sign1Message struct {
+	Protected   Headers
+	Unprotected Headers
+	// Payload is nil for a detached payload
+	Payload   []byte
+	Signature []byte
+	// protectedCbor is the original encoding of the protected headers, which is covered by the signature
+	protectedCbor []byte
+}
+
+type sign1MessageCbor struct {
+	cbor.StructAsArray
+	Protected   []byte
+	Unprotected cbor.RawMessage
+	Payload     []byte
+	Signature   []byte
+}
+
+// NewSign1MessageFromCbor decodes a COSE_Sign1 message, with or without the COSE_Sign1 tag
+func NewSign1MessageFromCbor(data []byte) (*Sign1Message, error) {
+	var ret Sign1Message
+	if _, err := cbor.Decode(data, &ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (m *Sign1Message) UnmarshalCBOR(data []byte) error {
+	if len(data) > 0 && data[0]&cbor.CborTypeMask == cbor.CborTypeTag {
+		var tmpTag cbor.RawTag
+		if _, err := cbor.Decode(data, &tmpTag); err != nil {
+			return err
+		}
+		if tmpTag.Number != cborTagSign1 {
+			return fmt.Errorf("unexpected CBOR tag for COSE_Sign1: %d", tmpTag.Number)
+		}
+		data = tmpTag.Content
+	}
+	var tmpMessage sign1MessageCbor
+	if _, err := cbor.Decode(data, &tmpMessage); err != nil {
+		return fmt.Errorf("decode COSE_Sign1: %w", err)
+	}
+	*m = Sign1Message{
+		Payload:       tmpMessage.Payload,
+		Signature:     tmpMessage.Signature,
+		protectedCbor: tmpMessage.Protected,
+	}
+	// An empty bytestring represents an empty map for protected headers
+	if len(tmpMessage.Protected) > 0 {
+		if _, err := cbor.Decode(tmpMessage.Protected, &m.Protected); err != nil {
+			return fmt.Errorf("decode protected headers: %w", err)
+		}
+	}
+	if _, err := cbor.Decode(tmpMessage.Unprotected, &m.Unprotected); err != nil {
+		return fmt.Errorf("decode unprotected headers: %w", err)
+	}
+	return nil
+}
+
+// MarshalCBOR encodes the message without the COSE_Sign1 tag, as returned by CIP-30 wallets
+func (m *Sign1Message) MarshalCBOR() ([]byte, error) {
+	protectedCbor, err := m.ProtectedCbor()
+	if err != nil {
+		return nil, err
+	}
+	unprotectedCbor, err := cbor.Encode(m.Unprotected)
+	if err != nil {
+		return nil, err
+	}
+	signature := m.Signature
+	if signature == nil {
+		signature = []byte{}
+	}
+	tmpMessage := sign1MessageCbor{
+		Protected:   protectedCbor,
+		Unprotected: unprotectedCbor,
+		Payload:     m.Payload,
+		Signature:   signature,
+	}
+	return cbor.Encode(&tmpMessage)
+}
+
+// ProtectedCbor returns the encoding of the protected headers. The original encoding is used for a decoded
+// message, since it's covered by the signature
+func (m *Sign1Message) ProtectedCbor() ([]byte, error) {
+	if m.protectedCbor != nil {
+		return m.protectedCbor, nil
+	}
+	return cbor.Encode(m.Protected)
+}
+
+// SigStructure returns the encoded Sig_structure, which is the data that is signed. A detached payload
+// must be provided as the external payload
+func (m *Sign1Message) SigStructure(externalPayload []byte) ([]byte, error) {
+	protectedCbor, err := m.ProtectedCbor()
+	if err != nil {
+		return nil, err
+	}
+	payload := m.Payload
+	if payload == nil {
+		if externalPayload == nil {
+			return nil, errors.New("detached payload not provided")
+		}
+		payload = externalPayload
+	}
+	sigStructure := []any{
+		sigStructureContextSign1,
+		protectedCbor,
+		// External AAD
+		[]byte{},
+		payload,
+	}
+	return cbor.Encode(sigStructure)
+}
+
+// IsHashed returns whether the payload is the Blake2b-224 hash of the message, as indicated by the CIP-8
+// hashed header in either the protected or unprotected headers
+func (m *Sign1Message) IsHashed() bool {
+	for _, headers := range []Headers{m.Protected, m.Unprotected} {
+		if headers.Hashed != nil && *headers.Hashed {
+			return true
+		}
+	}
+	return false
+}
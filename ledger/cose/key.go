@@ -0,0 +1,103 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cose
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// COSE_Key parameter labels and values for Ed25519 keys
+const (
+	KeyLabelKeyType   = 1
+	KeyLabelKeyId     = 2
+	KeyLabelAlgorithm = 3
+	KeyLabelCurve     = -1
+	KeyLabelX         = -2
+
+	KeyTypeOKP   = 1
+	CurveEd25519 = 6
+)
+
+// Key is a COSE_Key containing an Ed25519 public key
+type Key struct {
+	KeyId     []byte
+	PublicKey ed25519.PublicKey
+}
+
+// NewKeyFromCbor decodes a COSE_Key. Only Ed25519 keys are supported
+func NewKeyFromCbor(data []byte) (*Key, error) {
+	var ret Key
+	if _, err := cbor.Decode(data, &ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (k *Key) UnmarshalCBOR(data []byte) error {
+	var tmpKey map[int64]any
+	if _, err := cbor.Decode(data, &tmpKey); err != nil {
+		return fmt.Errorf("decode COSE_Key: %w", err)
+	}
+	if keyType, ok := headerInt(tmpKey[KeyLabelKeyType]); !ok || keyType != KeyTypeOKP {
+		return fmt.Errorf("unsupported COSE_Key type: %v", tmpKey[KeyLabelKeyType])
+	}
+	if alg, ok := tmpKey[KeyLabelAlgorithm]; ok {
+		if tmpAlg, ok := headerInt(alg); !ok || tmpAlg != AlgorithmEdDSA {
+			return fmt.Errorf("unsupported COSE_Key algorithm: %v", alg)
+		}
+	}
+	if curve, ok := headerInt(tmpKey[KeyLabelCurve]); !ok || curve != CurveEd25519 {
+		return fmt.Errorf("unsupported COSE_Key curve: %v", tmpKey[KeyLabelCurve])
+	}
+	publicKey, ok := tmpKey[KeyLabelX].([]byte)
+	if !ok || len(publicKey) != ed25519.PublicKeySize {
+		return errors.New("invalid COSE_Key public key")
+	}
+	*k = Key{
+		PublicKey: publicKey,
+	}
+	if keyId, ok := tmpKey[KeyLabelKeyId]; ok {
+		if k.KeyId, ok = keyId.([]byte); !ok {
+			return errors.New("invalid COSE_Key key ID")
+		}
+	}
+	return nil
+}
+
+func (k *Key) MarshalCBOR() ([]byte, error) {
+	if len(k.PublicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key size: %d", len(k.PublicKey))
+	}
+	tmpKey := map[int64]any{
+		KeyLabelKeyType:   KeyTypeOKP,
+		KeyLabelAlgorithm: AlgorithmEdDSA,
+		KeyLabelCurve:     CurveEd25519,
+		KeyLabelX:         []byte(k.PublicKey),
+	}
+	if k.KeyId != nil {
+		tmpKey[KeyLabelKeyId] = k.KeyId
+	}
+	return cbor.Encode(tmpKey)
+}
+
+// Hash returns the Blake2b-224 hash of the public key, as used for key hash credentials in addresses
+func (k *Key) Hash() common.Blake2b224 {
+	return common.Blake2b224Hash(k.PublicKey)
+}
@@ -0,0 +1,311 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/ledger/byron"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+// EraBound represents the start or end of an era. The time is relative to the system start
+type EraBound struct {
+	Time  time.Duration
+	Slot  uint64
+	Epoch uint64
+}
+
+// EraParams represents the slot/epoch parameters that are fixed for the duration of an era
+type EraParams struct {
+	EpochLength uint64
+	SlotLength  time.Duration
+	SafeZone    uint64
+}
+
+// EraSummary represents a single era in the era history. A nil End means that the era is unbounded
+type EraSummary struct {
+	Start  EraBound
+	End    *EraBound
+	Params EraParams
+}
+
+func (s EraSummary) containsSlot(slot uint64) bool {
+	if slot < s.Start.Slot {
+		return false
+	}
+	return s.End == nil || slot < s.End.Slot
+}
+
+func (s EraSummary) containsEpoch(epoch uint64) bool {
+	if epoch < s.Start.Epoch {
+		return false
+	}
+	return s.End == nil || epoch < s.End.Epoch
+}
+
+func (s EraSummary) containsTime(relTime time.Duration) bool {
+	if relTime < s.Start.Time {
+		return false
+	}
+	return s.End == nil || relTime < s.End.Time
+}
+
+// EraHistory converts between slots, epochs and wall clock time using the era history of a chain.
+// The eras are expected to be in chain order, such that the index of each era matches its era ID
+type EraHistory struct {
+	SystemStart time.Time
+	Eras        []EraSummary
+}
+
+// PastHorizonError is returned when a conversion is requested that falls outside of the
+// known era history, which usually means that it is past the forecast horizon
+type PastHorizonError struct {
+	Slot  *uint64
+	Epoch *uint64
+	Time  *time.Time
+}
+
+func (e PastHorizonError) Error() string {
+	switch {
+	case e.Slot != nil:
+		return fmt.Sprintf("slot %d is past the forecast horizon", *e.Slot)
+	case e.Epoch != nil:
+		return fmt.Sprintf("epoch %d is past the forecast horizon", *e.Epoch)
+	case e.Time != nil:
+		return fmt.Sprintf(
+			"time %s is past the forecast horizon",
+			e.Time.Format(time.RFC3339),
+		)
+	}
+	return "past the forecast horizon"
+}
+
+// NewEraHistory returns an EraHistory for the provided system start and era summaries
+func NewEraHistory(
+	systemStart time.Time,
+	eras []EraSummary,
+) (*EraHistory, error) {
+	if len(eras) == 0 {
+		return nil, errors.New("era history must contain at least one era")
+	}
+	for idx, era := range eras {
+		if era.Params.EpochLength == 0 {
+			return nil, fmt.Errorf("era %d: epoch length must not be zero", idx)
+		}
+		if era.Params.SlotLength <= 0 {
+			return nil, fmt.Errorf("era %d: slot length must be positive", idx)
+		}
+		if idx == 0 {
+			continue
+		}
+		prevEnd := eras[idx-1].End
+		if prevEnd == nil {
+			return nil, fmt.Errorf("era %d: previous era is unbounded", idx)
+		}
+		if *prevEnd != era.Start {
+			return nil, fmt.Errorf(
+				"era %d: start does not match end of previous era",
+				idx,
+			)
+		}
+	}
+	ret := &EraHistory{
+		SystemStart: systemStart.UTC(),
+		Eras:        eras,
+	}
+	return ret, nil
+}
+
+// NewEraHistoryFromGenesis returns an EraHistory built from the Byron and Shelley genesis configs.
+// The hard fork epochs specify the first epoch of each era after Byron (Shelley, Allegra, etc.),
+// which are not part of the genesis configs. All eras after Byron use the Shelley genesis slot
+// and epoch lengths, and the last era is unbounded
+func NewEraHistoryFromGenesis(
+	byronGenesis *byron.ByronGenesis,
+	shelleyGenesis *shelley.ShelleyGenesis,
+	hardForkEpochs ...uint64,
+) (*EraHistory, error) {
+	if byronGenesis == nil || shelleyGenesis == nil {
+		return nil, errors.New("both Byron and Shelley genesis are required")
+	}
+	if len(hardForkEpochs) == 0 {
+		return nil, errors.New("at least one hard fork epoch is required")
+	}
+	byronParams := EraParams{
+		EpochLength: uint64(byronGenesis.ProtocolConsts.K) * 10,
+		SlotLength: time.Duration(
+			byronGenesis.BlockVersionData.SlotDuration,
+		) * time.Millisecond,
+		SafeZone: uint64(byronGenesis.ProtocolConsts.K) * 2,
+	}
+	shelleyParams := EraParams{
+		EpochLength: uint64(shelleyGenesis.EpochLength),
+		SlotLength:  time.Duration(shelleyGenesis.SlotLength) * time.Second,
+	}
+	if shelleyGenesis.ActiveSlotsCoeff.Rat != nil &&
+		shelleyGenesis.ActiveSlotsCoeff.Sign() > 0 {
+		// The safe zone for Praos eras is 3k/f slots
+		safeZone := new(big.Rat).SetInt64(
+			int64(shelleyGenesis.SecurityParam) * 3,
+		)
+		safeZone.Quo(safeZone, shelleyGenesis.ActiveSlotsCoeff.Rat)
+		shelleyParams.SafeZone = new(big.Int).Quo(
+			safeZone.Num(),
+			safeZone.Denom(),
+		).Uint64()
+	}
+	var eras []EraSummary
+	start := EraBound{}
+	params := byronParams
+	for _, epoch := range hardForkEpochs {
+		if epoch < start.Epoch {
+			return nil, fmt.Errorf(
+				"hard fork epoch %d is before the start of the previous era",
+				epoch,
+			)
+		}
+		end := start.advanceEpochs(epoch-start.Epoch, params)
+		eras = append(
+			eras,
+			EraSummary{
+				Start:  start,
+				End:    &end,
+				Params: params,
+			},
+		)
+		start = end
+		params = shelleyParams
+	}
+	eras = append(
+		eras,
+		EraSummary{
+			Start:  start,
+			Params: params,
+		},
+	)
+	systemStart := time.Unix(int64(byronGenesis.StartTime), 0)
+	return NewEraHistory(systemStart, eras)
+}
+
+func (b EraBound) advanceEpochs(epochs uint64, params EraParams) EraBound {
+	slots := epochs * params.EpochLength
+	return EraBound{
+		Time:  b.Time + time.Duration(slots)*params.SlotLength,
+		Slot:  b.Slot + slots,
+		Epoch: b.Epoch + epochs,
+	}
+}
+
+// Horizon returns the last known bound of the era history, or nil if the last era is unbounded
+func (h *EraHistory) Horizon() *EraBound {
+	return h.Eras[len(h.Eras)-1].End
+}
+
+// EraSummaryForSlot returns the index and summary of the era containing the specified slot
+func (h *EraHistory) EraSummaryForSlot(slot uint64) (int, EraSummary, error) {
+	for idx, era := range h.Eras {
+		if era.containsSlot(slot) {
+			return idx, era, nil
+		}
+	}
+	return 0, EraSummary{}, PastHorizonError{Slot: &slot}
+}
+
+// EraForSlot returns the era containing the specified slot
+func (h *EraHistory) EraForSlot(slot uint64) (Era, error) {
+	idx, _, err := h.EraSummaryForSlot(slot)
+	if err != nil {
+		return EraInvalid, err
+	}
+	return GetEraById(uint8(idx)), nil // #nosec G115
+}
+
+// SlotToTime returns the wall clock time at the start of the specified slot
+func (h *EraHistory) SlotToTime(slot uint64) (time.Time, error) {
+	_, era, err := h.EraSummaryForSlot(slot)
+	if err != nil {
+		return time.Time{}, err
+	}
+	relTime := era.Start.Time +
+		time.Duration(slot-era.Start.Slot)*era.Params.SlotLength
+	return h.SystemStart.Add(relTime), nil
+}
+
+// SlotToPosixTime returns the POSIX time (in seconds) at the start of the specified slot
+func (h *EraHistory) SlotToPosixTime(slot uint64) (int64, error) {
+	slotTime, err := h.SlotToTime(slot)
+	if err != nil {
+		return 0, err
+	}
+	return slotTime.Unix(), nil
+}
+
+// TimeToSlot returns the slot containing the specified wall clock time
+func (h *EraHistory) TimeToSlot(t time.Time) (uint64, error) {
+	if t.Before(h.SystemStart) {
+		return 0, fmt.Errorf(
+			"time %s is before the system start",
+			t.UTC().Format(time.RFC3339),
+		)
+	}
+	relTime := t.Sub(h.SystemStart)
+	for _, era := range h.Eras {
+		if !era.containsTime(relTime) {
+			continue
+		}
+		slots := uint64((relTime - era.Start.Time) / era.Params.SlotLength)
+		return era.Start.Slot + slots, nil
+	}
+	return 0, PastHorizonError{Time: &t}
+}
+
+// PosixTimeToSlot returns the slot containing the specified POSIX time (in seconds)
+func (h *EraHistory) PosixTimeToSlot(posixTime int64) (uint64, error) {
+	return h.TimeToSlot(time.Unix(posixTime, 0))
+}
+
+// SlotToEpoch returns the epoch containing the specified slot
+func (h *EraHistory) SlotToEpoch(slot uint64) (uint64, error) {
+	_, era, err := h.EraSummaryForSlot(slot)
+	if err != nil {
+		return 0, err
+	}
+	return era.Start.Epoch + (slot-era.Start.Slot)/era.Params.EpochLength, nil
+}
+
+// EpochFirstSlot returns the first slot of the specified epoch
+func (h *EraHistory) EpochFirstSlot(epoch uint64) (uint64, error) {
+	for _, era := range h.Eras {
+		if !era.containsEpoch(epoch) {
+			continue
+		}
+		return era.Start.Slot + (epoch-era.Start.Epoch)*era.Params.EpochLength, nil
+	}
+	return 0, PastHorizonError{Epoch: &epoch}
+}
+
+// EpochLength returns the number of slots in the specified epoch
+func (h *EraHistory) EpochLength(epoch uint64) (uint64, error) {
+	for _, era := range h.Eras {
+		if era.containsEpoch(epoch) {
+			return era.Params.EpochLength, nil
+		}
+	}
+	return 0, PastHorizonError{Epoch: &epoch}
+}
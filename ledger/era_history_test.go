@@ -0,0 +1,300 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/allegra"
+	"github.com/blinklabs-io/gouroboros/ledger/byron"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+// Mainnet values
+const (
+	testMainnetSystemStart   = 1506203091
+	testMainnetShelleyEpoch  = 208
+	testMainnetShelleySlot   = 4492800
+	testMainnetShelleyPosix  = 1596059091
+	testMainnetAllegraEpoch  = 236
+	testMainnetAllegraSlot   = 16588800
+	testMainnetByronSlotLen  = 20 * time.Second
+	testMainnetByronEpochLen = 21600
+)
+
+func testMainnetEraHistory(t *testing.T) *ledger.EraHistory {
+	byronGenesis := &byron.ByronGenesis{
+		StartTime: testMainnetSystemStart,
+		ProtocolConsts: byron.ByronGenesisProtocolConsts{
+			K: 2160,
+		},
+		BlockVersionData: byron.ByronGenesisBlockVersionData{
+			SlotDuration: 20000,
+		},
+	}
+	shelleyGenesis := &shelley.ShelleyGenesis{
+		ActiveSlotsCoeff: common.GenesisRat{Rat: big.NewRat(1, 20)},
+		SecurityParam:    2160,
+		EpochLength:      432000,
+		SlotLength:       1,
+	}
+	eraHistory, err := ledger.NewEraHistoryFromGenesis(
+		byronGenesis,
+		shelleyGenesis,
+		testMainnetShelleyEpoch,
+		testMainnetAllegraEpoch,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return eraHistory
+}
+
+func TestEraHistoryFromGenesis(t *testing.T) {
+	eraHistory := testMainnetEraHistory(t)
+	if len(eraHistory.Eras) != 3 {
+		t.Fatalf("did not get expected number of eras: got %d, wanted %d", len(eraHistory.Eras), 3)
+	}
+	if eraHistory.Eras[0].Params.EpochLength != testMainnetByronEpochLen {
+		t.Errorf(
+			"did not get expected Byron epoch length: got %d, wanted %d",
+			eraHistory.Eras[0].Params.EpochLength,
+			testMainnetByronEpochLen,
+		)
+	}
+	if eraHistory.Eras[0].Params.SlotLength != testMainnetByronSlotLen {
+		t.Errorf(
+			"did not get expected Byron slot length: got %s, wanted %s",
+			eraHistory.Eras[0].Params.SlotLength,
+			testMainnetByronSlotLen,
+		)
+	}
+	if eraHistory.Eras[1].Params.SafeZone != 129600 {
+		t.Errorf(
+			"did not get expected Shelley safe zone: got %d, wanted %d",
+			eraHistory.Eras[1].Params.SafeZone,
+			129600,
+		)
+	}
+	if eraHistory.Eras[1].Start.Slot != testMainnetShelleySlot {
+		t.Errorf(
+			"did not get expected Shelley start slot: got %d, wanted %d",
+			eraHistory.Eras[1].Start.Slot,
+			testMainnetShelleySlot,
+		)
+	}
+	if eraHistory.Horizon() != nil {
+		t.Errorf("expected unbounded era history")
+	}
+}
+
+func TestEraHistorySlotToTime(t *testing.T) {
+	eraHistory := testMainnetEraHistory(t)
+	testDefs := []struct {
+		slot      uint64
+		posixTime int64
+	}{
+		{
+			slot:      0,
+			posixTime: testMainnetSystemStart,
+		},
+		{
+			slot:      1,
+			posixTime: testMainnetSystemStart + 20,
+		},
+		{
+			slot:      testMainnetShelleySlot - 1,
+			posixTime: testMainnetShelleyPosix - 20,
+		},
+		{
+			slot:      testMainnetShelleySlot,
+			posixTime: testMainnetShelleyPosix,
+		},
+		// Known mainnet block (slot 134000000)
+		{
+			slot:      134000000,
+			posixTime: 1725566291,
+		},
+	}
+	for _, testDef := range testDefs {
+		slotTime, err := eraHistory.SlotToTime(testDef.slot)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if slotTime.Unix() != testDef.posixTime {
+			t.Errorf(
+				"did not get expected time for slot %d: got %d, wanted %d",
+				testDef.slot,
+				slotTime.Unix(),
+				testDef.posixTime,
+			)
+		}
+		slot, err := eraHistory.TimeToSlot(slotTime)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if slot != testDef.slot {
+			t.Errorf(
+				"did not get expected slot for time %d: got %d, wanted %d",
+				testDef.posixTime,
+				slot,
+				testDef.slot,
+			)
+		}
+	}
+	// Time in the middle of a Byron slot
+	slot, err := eraHistory.PosixTimeToSlot(testMainnetSystemStart + 39)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if slot != 1 {
+		t.Errorf("did not get expected slot: got %d, wanted %d", slot, 1)
+	}
+	// Time before system start
+	if _, err := eraHistory.PosixTimeToSlot(testMainnetSystemStart - 1); err == nil {
+		t.Errorf("did not get expected error for time before system start")
+	}
+}
+
+func TestEraHistoryEpochs(t *testing.T) {
+	eraHistory := testMainnetEraHistory(t)
+	testDefs := []struct {
+		slot  uint64
+		epoch uint64
+	}{
+		{slot: 0, epoch: 0},
+		{slot: testMainnetByronEpochLen, epoch: 1},
+		{slot: testMainnetShelleySlot - 1, epoch: testMainnetShelleyEpoch - 1},
+		{slot: testMainnetShelleySlot, epoch: testMainnetShelleyEpoch},
+		{slot: testMainnetAllegraSlot, epoch: testMainnetAllegraEpoch},
+		{slot: 134000000, epoch: 507},
+	}
+	for _, testDef := range testDefs {
+		epoch, err := eraHistory.SlotToEpoch(testDef.slot)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if epoch != testDef.epoch {
+			t.Errorf(
+				"did not get expected epoch for slot %d: got %d, wanted %d",
+				testDef.slot,
+				epoch,
+				testDef.epoch,
+			)
+		}
+	}
+	firstSlot, err := eraHistory.EpochFirstSlot(testMainnetAllegraEpoch)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if firstSlot != testMainnetAllegraSlot {
+		t.Errorf(
+			"did not get expected first slot: got %d, wanted %d",
+			firstSlot,
+			testMainnetAllegraSlot,
+		)
+	}
+}
+
+func TestEraHistoryEraForSlot(t *testing.T) {
+	eraHistory := testMainnetEraHistory(t)
+	testDefs := []struct {
+		slot uint64
+		era  ledger.Era
+	}{
+		{slot: 0, era: byron.EraByron},
+		{slot: testMainnetShelleySlot - 1, era: byron.EraByron},
+		{slot: testMainnetShelleySlot, era: shelley.EraShelley},
+		{slot: testMainnetAllegraSlot, era: allegra.EraAllegra},
+	}
+	for _, testDef := range testDefs {
+		era, err := eraHistory.EraForSlot(testDef.slot)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if era != testDef.era {
+			t.Errorf(
+				"did not get expected era for slot %d: got %s, wanted %s",
+				testDef.slot,
+				era.Name,
+				testDef.era.Name,
+			)
+		}
+	}
+}
+
+func TestEraHistoryPastHorizon(t *testing.T) {
+	horizon := ledger.EraBound{
+		Time:  time.Duration(testMainnetShelleySlot) * testMainnetByronSlotLen,
+		Slot:  testMainnetShelleySlot,
+		Epoch: testMainnetShelleyEpoch,
+	}
+	eraHistory, err := ledger.NewEraHistory(
+		time.Unix(testMainnetSystemStart, 0),
+		[]ledger.EraSummary{
+			{
+				End: &horizon,
+				Params: ledger.EraParams{
+					EpochLength: testMainnetByronEpochLen,
+					SlotLength:  testMainnetByronSlotLen,
+				},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var pastHorizonErr ledger.PastHorizonError
+	if _, err := eraHistory.SlotToTime(testMainnetShelleySlot); !errors.As(err, &pastHorizonErr) {
+		t.Errorf("did not get expected error: got %v", err)
+	}
+	if _, err := eraHistory.EpochFirstSlot(testMainnetShelleyEpoch); !errors.As(err, &pastHorizonErr) {
+		t.Errorf("did not get expected error: got %v", err)
+	}
+	if _, err := eraHistory.PosixTimeToSlot(testMainnetShelleyPosix); !errors.As(err, &pastHorizonErr) {
+		t.Errorf("did not get expected error: got %v", err)
+	}
+	if _, err := eraHistory.SlotToTime(testMainnetShelleySlot - 1); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestNewEraHistoryMismatchedBounds(t *testing.T) {
+	params := ledger.EraParams{
+		EpochLength: 100,
+		SlotLength:  time.Second,
+	}
+	_, err := ledger.NewEraHistory(
+		time.Unix(0, 0),
+		[]ledger.EraSummary{
+			{
+				End:    &ledger.EraBound{Time: 100 * time.Second, Slot: 100, Epoch: 1},
+				Params: params,
+			},
+			{
+				Start:  ledger.EraBound{Time: 100 * time.Second, Slot: 101, Epoch: 1},
+				Params: params,
+			},
+		},
+	)
+	if err == nil {
+		t.Fatalf("did not get expected error")
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// KesSeedSize is the size of the seed for a KES key
+	KesSeedSize = 32
+	// Sum6KesDepth is the depth of the sum composition used for block header signatures, which
+	// allows for 64 KES periods
+	Sum6KesDepth = 6
+)
+
+// SumKesSigningKey is a key-evolving signature signing key using the sum composition over ed25519
+// with the specified depth, which is valid for 2^depth KES periods. The key uses the same raw
+// format as cardano-base, where each level consists of the signing key for the current half, the
+// seed for the right half and the verification keys for both halves. Secrets for past periods are
+// overwritten as the key is evolved
+type SumKesSigningKey struct {
+	depth  uint64
+	period uint64
+	key    []byte
+}
+
+// SumKesSigningKeySize returns the size of the raw signing key for the specified depth
+func SumKesSigningKeySize(depth uint64) int {
+	return KesSeedSize + int(depth)*(KesSeedSize+PUBLIC_KEY_SIZE*2)
+}
+
+// SumKesSignatureSize returns the size of a signature for the specified depth
+func SumKesSignatureSize(depth uint64) int {
+	return SIGMA_SIZE + int(depth)*PUBLIC_KEY_SIZE*2
+}
+
+// NewSumKesSigningKeyFromSeed returns a signing key at period 0 generated from the seed
+func NewSumKesSigningKeyFromSeed(depth uint64, seed []byte) (*SumKesSigningKey, error) {
+	if len(seed) != KesSeedSize {
+		return nil, fmt.Errorf("invalid KES seed length: %d", len(seed))
+	}
+	ret := &SumKesSigningKey{
+		depth: depth,
+		key:   make([]byte, SumKesSigningKeySize(depth)),
+	}
+	kesGenKey(ret.key, depth, seed)
+	return ret, nil
+}
+
+// GenerateSumKesSigningKey returns a new random signing key at period 0
+func GenerateSumKesSigningKey(depth uint64) (*SumKesSigningKey, error) {
+	seed := make([]byte, KesSeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+	return NewSumKesSigningKeyFromSeed(depth, seed)
+}
+
+// NewSumKesSigningKey returns a signing key from its raw format. The current period is determined
+// from the seeds that have been erased by evolving the key
+func NewSumKesSigningKey(depth uint64, data []byte) (*SumKesSigningKey, error) {
+	if len(data) != SumKesSigningKeySize(depth) {
+		return nil, fmt.Errorf("invalid KES signing key length: %d", len(data))
+	}
+	ret := &SumKesSigningKey{
+		depth: depth,
+		key:   append([]byte{}, data...),
+	}
+	ret.period = kesPeriod(ret.key, depth)
+	return ret, nil
+}
+
+// Depth returns the depth of the sum composition
+func (k *SumKesSigningKey) Depth() uint64 {
+	return k.depth
+}
+
+// Period returns the current KES period of the key, relative to the start of its validity
+func (k *SumKesSigningKey) Period() uint64 {
+	return k.period
+}
+
+// TotalPeriods returns the number of periods for which the key is valid
+func (k *SumKesSigningKey) TotalPeriods() uint64 {
+	return 1 << k.depth
+}
+
+// Bytes returns the raw signing key
+func (k *SumKesSigningKey) Bytes() []byte {
+	return append([]byte{}, k.key...)
+}
+
+// VerificationKey returns the verification key, which is the same for all periods
+func (k *SumKesSigningKey) VerificationKey() []byte {
+	return kesVerificationKey(k.key, k.depth)
+}
+
+// Sign returns the signature of the message for the specified period, which must be the current
+// period of the key
+func (k *SumKesSigningKey) Sign(period uint64, msg []byte) ([]byte, error) {
+	if k.key == nil {
+		return nil, errors.New("KES signing key has been erased")
+	}
+	if period != k.period {
+		return nil, fmt.Errorf(
+			"cannot sign for KES period %d with key at period %d",
+			period,
+			k.period,
+		)
+	}
+	return kesSign(k.key, k.depth, period, msg), nil
+}
+
+// Evolve updates the key to the next period, overwriting the secrets for the current period
+func (k *SumKesSigningKey) Evolve() error {
+	if k.key == nil {
+		return errors.New("KES signing key has been erased")
+	}
+	if k.period+1 >= k.TotalPeriods() {
+		return fmt.Errorf("KES signing key cannot be evolved past period %d", k.period)
+	}
+	kesUpdate(k.key, k.depth, k.period)
+	k.period++
+	return nil
+}
+
+// EvolveTo updates the key to the specified period, which must not be before the current period
+func (k *SumKesSigningKey) EvolveTo(period uint64) error {
+	if period < k.period {
+		return fmt.Errorf(
+			"cannot evolve KES signing key from period %d back to period %d",
+			k.period,
+			period,
+		)
+	}
+	for k.period < period {
+		if err := k.Evolve(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Erase overwrites the signing key. The key cannot be used afterward
+func (k *SumKesSigningKey) Erase() {
+	clear(k.key)
+	k.key = nil
+}
+
+// kesChildSize returns the size of the signing key for the child of a key with the specified depth
+func kesChildSize(depth uint64) int {
+	return SumKesSigningKeySize(depth - 1)
+}
+
+// kesExpandSeed returns the seeds for the left and right halves, which are the Blake2b-256 hashes of
+// the seed prefixed with 1 and 2 respectively
+func kesExpandSeed(seed []byte) ([]byte, []byte) {
+	left := blake2b.Sum256(append([]byte{1}, seed...))
+	right := blake2b.Sum256(append([]byte{2}, seed...))
+	return left[:], right[:]
+}
+
+// kesGenKey writes the signing key generated from the seed to dest
+func kesGenKey(dest []byte, depth uint64, seed []byte) {
+	if depth == 0 {
+		copy(dest, seed)
+		return
+	}
+	childSize := kesChildSize(depth)
+	leftSeed, rightSeed := kesExpandSeed(seed)
+	defer clear(leftSeed)
+	// The right half is generated to get its verification key, and then erased until it's needed
+	tmpRight := make([]byte, childSize)
+	defer clear(tmpRight)
+	kesGenKey(tmpRight, depth-1, rightSeed)
+	kesGenKey(dest[:childSize], depth-1, leftSeed)
+	copy(dest[childSize:], rightSeed)
+	copy(dest[childSize+KesSeedSize:], kesVerificationKey(dest[:childSize], depth-1))
+	copy(dest[childSize+KesSeedSize+PUBLIC_KEY_SIZE:], kesVerificationKey(tmpRight, depth-1))
+	clear(rightSeed)
+}
+
+// kesVerificationKey returns the verification key for a signing key
+func kesVerificationKey(key []byte, depth uint64) []byte {
+	if depth == 0 {
+		privKey := ed25519.NewKeyFromSeed(key)
+		defer clear(privKey)
+		return append([]byte{}, privKey.Public().(ed25519.PublicKey)...)
+	}
+	vkeys := key[kesChildSize(depth)+KesSeedSize:]
+	return HashPair(vkeys[:PUBLIC_KEY_SIZE], vkeys[PUBLIC_KEY_SIZE:])
+}
+
+// kesSign returns the signature of the message for the period, which consists of the signature
+// from the child key followed by the verification keys for both halves
+func kesSign(key []byte, depth uint64, period uint64, msg []byte) []byte {
+	if depth == 0 {
+		privKey := ed25519.NewKeyFromSeed(key)
+		defer clear(privKey)
+		return ed25519.Sign(privKey, msg)
+	}
+	childSize := kesChildSize(depth)
+	half := uint64(1) << (depth - 1)
+	if period >= half {
+		period -= half
+	}
+	return append(
+		kesSign(key[:childSize], depth-1, period, msg),
+		key[childSize+KesSeedSize:]...,
+	)
+}
+
+// kesUpdate evolves the signing key from the specified period to the next period. When moving to
+// the right half, the key for the left half is replaced with the key generated from the right seed,
+// and the right seed is erased
+func kesUpdate(key []byte, depth uint64, period uint64) {
+	childSize := kesChildSize(depth)
+	half := uint64(1) << (depth - 1)
+	switch {
+	case period+1 < half:
+		kesUpdate(key[:childSize], depth-1, period)
+	case period+1 == half:
+		rightSeed := key[childSize : childSize+KesSeedSize]
+		clear(key[:childSize])
+		kesGenKey(key[:childSize], depth-1, rightSeed)
+		clear(rightSeed)
+	default:
+		kesUpdate(key[:childSize], depth-1, period-half)
+	}
+}
+
+// kesPeriod returns the period of a signing key, based on which right seeds have been erased
+func kesPeriod(key []byte, depth uint64) uint64 {
+	if depth == 0 {
+		return 0
+	}
+	childSize := kesChildSize(depth)
+	ret := kesPeriod(key[:childSize], depth-1)
+	for _, b := range key[childSize : childSize+KesSeedSize] {
+		if b != 0 {
+			return ret
+		}
+	}
+	return ret + (uint64(1) << (depth - 1))
+}
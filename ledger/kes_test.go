@@ -0,0 +1,119 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+func TestSumKesSignAndVerify(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x42}, ledger.KesSeedSize)
+	skey, err := ledger.NewSumKesSigningKeyFromSeed(ledger.Sum6KesDepth, seed)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(skey.Bytes()) != 608 {
+		t.Fatalf("did not get expected signing key size: %d", len(skey.Bytes()))
+	}
+	vkey := skey.VerificationKey()
+	msg := []byte("test message")
+	for period := uint64(0); period < skey.TotalPeriods(); period++ {
+		if err := skey.EvolveTo(period); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(skey.VerificationKey(), vkey) {
+			t.Fatalf("verification key changed at period %d", period)
+		}
+		sig, err := skey.Sign(period, msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(sig) != ledger.Sum6KesSignatureSize {
+			t.Fatalf("did not get expected signature size: %d", len(sig))
+		}
+		if !ledger.NewSumKesFromByte(ledger.Sum6KesDepth, sig).Verify(period, vkey, msg) {
+			t.Fatalf("signature did not verify for period %d", period)
+		}
+		if ledger.NewSumKesFromByte(ledger.Sum6KesDepth, sig).Verify(period^1, vkey, msg) {
+			t.Fatalf("signature for period %d verified for period %d", period, period^1)
+		}
+		if ledger.NewSumKesFromByte(ledger.Sum6KesDepth, sig).Verify(period, vkey, msg[1:]) {
+			t.Fatalf("signature verified for a different message")
+		}
+	}
+	if err := skey.Evolve(); err == nil {
+		t.Fatalf("did not get expected error evolving past the last period")
+	}
+}
+
+func TestSumKesEvolve(t *testing.T) {
+	skey, err := ledger.GenerateSumKesSigningKey(ledger.Sum6KesDepth)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The ed25519 seed for period 0 is at the start of the key
+	period0Seed := skey.Bytes()[:ledger.KesSeedSize]
+	msg := []byte("test message")
+	for _, period := range []uint64{1, 2, 31, 32, 33, 63} {
+		if err := skey.EvolveTo(period); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		keyBytes := skey.Bytes()
+		if bytes.Contains(keyBytes, period0Seed) {
+			t.Fatalf("signing key at period %d still contains secret for period 0", period)
+		}
+		// The period is determined from the raw key
+		tmpSkey, err := ledger.NewSumKesSigningKey(ledger.Sum6KesDepth, keyBytes)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tmpSkey.Period() != period {
+			t.Fatalf("did not get expected period: got %d, wanted %d", tmpSkey.Period(), period)
+		}
+		sig, err := skey.Sign(period, msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		tmpSig, err := tmpSkey.Sign(period, msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(sig, tmpSig) {
+			t.Fatalf("did not get the same signature from the decoded key at period %d", period)
+		}
+	}
+	if _, err := skey.Sign(0, msg); err == nil {
+		t.Fatalf("did not get expected error signing for a past period")
+	}
+	if err := skey.EvolveTo(10); err == nil {
+		t.Fatalf("did not get expected error evolving to a past period")
+	}
+	skey.Erase()
+	if _, err := skey.Sign(63, msg); err == nil {
+		t.Fatalf("did not get expected error signing with an erased key")
+	}
+}
+
+func TestSumKesInvalid(t *testing.T) {
+	if _, err := ledger.NewSumKesSigningKeyFromSeed(ledger.Sum6KesDepth, make([]byte, 16)); err == nil {
+		t.Fatalf("did not get expected error for invalid seed")
+	}
+	if _, err := ledger.NewSumKesSigningKey(ledger.Sum6KesDepth, make([]byte, 612)); err == nil {
+		t.Fatalf("did not get expected error for invalid key length")
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+//go:embed bip39_english.txt
+var bip39EnglishWordlist string
+
+var (
+	bip39Words     = strings.Fields(bip39EnglishWordlist)
+	bip39WordIndex = func() map[string]int {
+		ret := make(map[string]int, len(bip39Words))
+		for idx, word := range bip39Words {
+			ret[word] = idx
+		}
+		return ret
+	}()
+)
+
+// NewMnemonic returns a new random BIP39 mnemonic with the specified entropy size in bits. The entropy size must
+// be a multiple of 32 between 128 and 256, which results in 12 to 24 words
+func NewMnemonic(entropyBits int) (string, error) {
+	if err := validateEntropySize(entropyBits / 8); err != nil || entropyBits%8 != 0 {
+		return "", fmt.Errorf("invalid entropy size: %d bits", entropyBits)
+	}
+	entropy := make([]byte, entropyBits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return EntropyToMnemonic(entropy)
+}
+
+// EntropyToMnemonic returns the BIP39 mnemonic for the provided entropy
+func EntropyToMnemonic(entropy []byte) (string, error) {
+	if err := validateEntropySize(len(entropy)); err != nil {
+		return "", err
+	}
+	// The mnemonic encodes the entropy followed by a checksum from the start of its SHA-256 hash, 11 bits per word
+	checksumBits := uint(len(entropy) / 4)
+	checksum := sha256.Sum256(entropy)
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, checksumBits)
+	data.Or(data, big.NewInt(int64(checksum[0]>>(8-checksumBits))))
+	wordCount := (len(entropy)*8 + int(checksumBits)) / 11
+	words := make([]string, wordCount)
+	mask := big.NewInt(2047)
+	for idx := wordCount - 1; idx >= 0; idx-- {
+		words[idx] = bip39Words[new(big.Int).And(data, mask).Int64()]
+		data.Rsh(data, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy returns the entropy encoded in the BIP39 mnemonic after validating its checksum
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return nil, fmt.Errorf("invalid mnemonic word count: %d", len(words))
+	}
+	data := new(big.Int)
+	for _, word := range words {
+		idx, ok := bip39WordIndex[strings.ToLower(word)]
+		if !ok {
+			return nil, fmt.Errorf("invalid mnemonic word: %s", word)
+		}
+		data.Lsh(data, 11)
+		data.Or(data, big.NewInt(int64(idx)))
+	}
+	checksumBits := uint(len(words) / 3)
+	entropySize := len(words) * 4 / 3
+	checksum := new(big.Int).And(data, big.NewInt(int64(1<<checksumBits)-1))
+	data.Rsh(data, checksumBits)
+	entropy := data.FillBytes(make([]byte, entropySize))
+	expectedChecksum := sha256.Sum256(entropy)
+	if checksum.Int64() != int64(expectedChecksum[0]>>(8-checksumBits)) {
+		return nil, errors.New("invalid mnemonic checksum")
+	}
+	return entropy, nil
+}
+
+func validateEntropySize(size int) error {
+	if size < 16 || size > 32 || size%4 != 0 {
+		return fmt.Errorf("invalid entropy size: %d bytes", size)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// HardenedIndex is the first child index that uses hardened derivation
+const HardenedIndex uint32 = 0x80000000
+
+// CIP-1852 derivation path components
+const (
+	Cip1852Purpose  uint32 = 1852
+	Cip1852CoinType uint32 = 1815
+
+	Cip1852RoleExternal      uint32 = 0
+	Cip1852RoleInternal      uint32 = 1
+	Cip1852RoleStaking       uint32 = 2
+	Cip1852RoleDrep          uint32 = 3
+	Cip1852RoleCommitteeCold uint32 = 4
+	Cip1852RoleCommitteeHot  uint32 = 5
+)
+
+// icarusPbkdf2Iterations is the number of PBKDF2 iterations used when generating an Icarus master key
+const icarusPbkdf2Iterations = 4096
+
+// Harden returns the hardened child index for the provided index
+func Harden(index uint32) uint32 {
+	return index | HardenedIndex
+}
+
+// NewRootKeyFromMnemonic returns the Icarus master key for a BIP39 mnemonic and optional passphrase, as
+// described in CIP-3. The passphrase is used as-is without Unicode normalization
+func NewRootKeyFromMnemonic(mnemonic string, passphrase string) (*SigningKey, error) {
+	entropy, err := MnemonicToEntropy(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return NewRootKeyFromEntropy(entropy, passphrase)
+}
+
+// NewRootKeyFromEntropy returns the Icarus master key for the BIP39 entropy and optional passphrase
+func NewRootKeyFromEntropy(entropy []byte, passphrase string) (*SigningKey, error) {
+	if err := validateEntropySize(len(entropy)); err != nil {
+		return nil, err
+	}
+	data := pbkdf2.Key(
+		[]byte(passphrase),
+		entropy,
+		icarusPbkdf2Iterations,
+		ExtendedSigningKeySize+ChainCodeSize,
+		sha512.New,
+	)
+	// Clamp the scalar
+	data[0] &= 0xf8
+	data[31] &= 0x1f
+	data[31] |= 0x40
+	return NewExtendedSigningKey(
+		KeyRolePayment,
+		data[:ExtendedSigningKeySize],
+		data[ExtendedSigningKeySize:],
+	)
+}
+
+// DeriveChild returns the child key at the specified index using BIP32-Ed25519 derivation. Indexes from
+// HardenedIndex use hardened derivation. The child key has the same role as the parent
+func (k *SigningKey) DeriveChild(index uint32) (*SigningKey, error) {
+	if !k.Extended() {
+		return nil, errors.New("child derivation requires an extended key")
+	}
+	indexBytes := binary.LittleEndian.AppendUint32(nil, index)
+	var zData, ccData []byte
+	if index >= HardenedIndex {
+		zData = append(append([]byte{0x00}, k.key...), indexBytes...)
+		ccData = append(append([]byte{0x01}, k.key...), indexBytes...)
+	} else {
+		publicKey := k.VerificationKey().Bytes()
+		zData = append(append([]byte{0x02}, publicKey...), indexBytes...)
+		ccData = append(append([]byte{0x03}, publicKey...), indexBytes...)
+	}
+	z := hmacSha512(k.chainCode, zData)
+	// kL = 8 * zL + parent kL, where zL is the first 28 bytes of Z
+	kL := new(big.Int).Lsh(leToInt(z[:28]), 3)
+	kL.Add(kL, leToInt(k.key[:32]))
+	// kR = zR + parent kR (mod 2^256)
+	kR := new(big.Int).Add(leToInt(z[32:]), leToInt(k.key[32:]))
+	childKey := append(intToLe(kL, 32), intToLe(kR, 32)...)
+	chainCode := hmacSha512(k.chainCode, ccData)[32:]
+	return NewExtendedSigningKey(k.Role, childKey, chainCode)
+}
+
+// DeriveChild returns the child verification key at the specified index. Only non-hardened derivation is
+// possible without the signing key
+func (k *VerificationKey) DeriveChild(index uint32) (*VerificationKey, error) {
+	if !k.Extended() {
+		return nil, errors.New("child derivation requires an extended key")
+	}
+	if index >= HardenedIndex {
+		return nil, errors.New("hardened derivation requires a signing key")
+	}
+	indexBytes := binary.LittleEndian.AppendUint32(nil, index)
+	z := hmacSha512(k.chainCode, append(append([]byte{0x02}, k.key...), indexBytes...))
+	// A = parent A + 8 * zL * B
+	tmpBytes := make([]byte, 64)
+	copy(tmpBytes, intToLe(new(big.Int).Lsh(leToInt(z[:28]), 3), 32))
+	scalar, err := edwards25519.NewScalar().SetUniformBytes(tmpBytes)
+	if err != nil {
+		return nil, err
+	}
+	parentPoint, err := new(edwards25519.Point).SetBytes(k.key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verification key: %w", err)
+	}
+	childPoint := new(edwards25519.Point).ScalarBaseMult(scalar)
+	childPoint.Add(childPoint, parentPoint)
+	chainCode := hmacSha512(k.chainCode, append(append([]byte{0x03}, k.key...), indexBytes...))[32:]
+	return NewExtendedVerificationKey(k.Role, childPoint.Bytes(), chainCode)
+}
+
+// DerivePath returns the key derived using the specified path, such as m/1852'/1815'/0'/0/0. Hardened indexes
+// are marked with ' or h
+func (k *SigningKey) DerivePath(path string) (*SigningKey, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path: %s", path)
+	}
+	ret := k
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path: %s", path)
+		}
+		tmpIndex := uint32(index)
+		if hardened {
+			tmpIndex = Harden(tmpIndex)
+		}
+		ret, err = ret.DeriveChild(tmpIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// DeriveAccount returns the CIP-1852 account key at m/1852'/1815'/account'
+func (k *SigningKey) DeriveAccount(account uint32) (*SigningKey, error) {
+	ret := k
+	var err error
+	for _, index := range []uint32{Cip1852Purpose, Cip1852CoinType, account} {
+		ret, err = ret.DeriveChild(Harden(index))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// DeriveCip1852 returns the CIP-1852 key at m/1852'/1815'/account'/role/index from the root key. The role of the
+// returned key is set based on the CIP-1852 role
+func (k *SigningKey) DeriveCip1852(account uint32, role uint32, index uint32) (*SigningKey, error) {
+	var keyRole KeyRole
+	switch role {
+	case Cip1852RoleExternal, Cip1852RoleInternal:
+		keyRole = KeyRolePayment
+	case Cip1852RoleStaking:
+		keyRole = KeyRoleStake
+	case Cip1852RoleDrep:
+		keyRole = KeyRoleDrep
+	case Cip1852RoleCommitteeCold:
+		keyRole = KeyRoleCommitteeCold
+	case Cip1852RoleCommitteeHot:
+		keyRole = KeyRoleCommitteeHot
+	default:
+		return nil, fmt.Errorf("unknown CIP-1852 role: %d", role)
+	}
+	accountKey, err := k.DeriveAccount(account)
+	if err != nil {
+		return nil, err
+	}
+	roleKey, err := accountKey.DeriveChild(role)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := roleKey.DeriveChild(index)
+	if err != nil {
+		return nil, err
+	}
+	ret.Role = keyRole
+	return ret, nil
+}
+
+func hmacSha512(key []byte, data []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// leToInt returns the integer value of little-endian bytes
+func leToInt(data []byte) *big.Int {
+	tmpData := make([]byte, len(data))
+	for idx, b := range data {
+		tmpData[len(data)-1-idx] = b
+	}
+	return new(big.Int).SetBytes(tmpData)
+}
+
+// intToLe returns the little-endian bytes of an integer, truncated to the specified size
+func intToLe(value *big.Int, size int) []byte {
+	tmpValue := new(big.Int).Mod(value, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+	tmpData := tmpValue.FillBytes(make([]byte, size))
+	ret := make([]byte, size)
+	for idx, b := range tmpData {
+		ret[size-1-idx] = b
+	}
+	return ret
+}
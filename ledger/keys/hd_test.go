@@ -0,0 +1,241 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/keys"
+)
+
+// Test mnemonic and keys from CIP-19
+const (
+	testCip19Mnemonic    = "test walk nut penalty hip pave soap entry language right filter choice"
+	testCip19PaymentVkey = "addr_vk1w0l2sr2zgfm26ztc6nl9xy8ghsk5sh6ldwemlpmp9xylzy4dtf7st80zhd"
+	testCip19StakeVkey   = "stake_vk1px4j0r2fk7ux5p23shz8f3y5y2qam7s954rgf3lg5merqcj6aetsft99wu"
+	testCip19BaseAddr    = "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x"
+	testCip19EntAddr     = "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8"
+)
+
+// Test keys from CIP-105 for the CIP-19 mnemonic
+const (
+	testCip105DrepVkey        = "drep_vk17axh4sc9zwkpsft3tlgpjemfwc0u5mnld80r85zw7zdqcst6w54sdv4a4e"
+	testCip105CommitteeColdVk = "cc_cold_vk149up407pvp9p36lldlp4qckqqzn6vm7u5yerwy8d8rqalse3t04q7qsvwl"
+	testCip105CommitteeHotVk  = "cc_hot_vk10y48lq72hypxraew74lwjjn9e2dscuwphckglh2nrrpkgweqk5hschnzv5"
+)
+
+// Test mnemonic and root keys from CIP-3
+const (
+	testCip3Mnemonic              = "eight country switch draw meat scout mystery blade tip drift useless good keep usage title"
+	testCip3RootKey               = "c065afd2832cd8b087c4d9ab7011f481ee1e0721e78ea5dd609f3ab3f156d245d176bd8fd4ec60b4731c3918a2a72a0226c0cd119ec35b47e4d55884667f552a23f7fdcd4a10c6cd2c7393ac61d877873e248f417634aa3d812af327ffe9d620"
+	testCip3RootKeyWithPassphrase = "70531039904019351e1afb361cd1b312a4d0565d4ff9f8062d38acf4b15cce41d7b5738d9c893feea55512a3004acb0d222c35d3e3d5cde943a15a9824cbac59443cf67e589614076ba01e354b1a432e0e6db3b59e37fc56b5fb0222970a010e"
+	testCip3RootKeyPassphrase     = "foo"
+)
+
+func TestMnemonic(t *testing.T) {
+	testDefs := []struct {
+		entropy  string
+		mnemonic string
+	}{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		},
+		{
+			entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+		},
+	}
+	for _, testDef := range testDefs {
+		entropy := testHexBytes(t, testDef.entropy)
+		mnemonic, err := keys.EntropyToMnemonic(entropy)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if mnemonic != testDef.mnemonic {
+			t.Errorf("did not get expected mnemonic:\n  got: %s\n  wanted: %s", mnemonic, testDef.mnemonic)
+		}
+		tmpEntropy, err := keys.MnemonicToEntropy(testDef.mnemonic)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(tmpEntropy, entropy) {
+			t.Errorf("did not get expected entropy: got %x, wanted %x", tmpEntropy, entropy)
+		}
+	}
+	t.Run("bad checksum", func(t *testing.T) {
+		_, err := keys.MnemonicToEntropy(strings.Repeat("abandon ", 12))
+		if err == nil {
+			t.Errorf("did not get expected error")
+		}
+	})
+	t.Run("random", func(t *testing.T) {
+		mnemonic, err := keys.NewMnemonic(256)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(strings.Fields(mnemonic)) != 24 {
+			t.Errorf("did not get expected word count: %s", mnemonic)
+		}
+		if _, err := keys.MnemonicToEntropy(mnemonic); err != nil {
+			t.Errorf("generated mnemonic should be valid\n  got error: %v", err)
+		}
+	})
+}
+
+func TestRootKeyFromMnemonic(t *testing.T) {
+	testDefs := []struct {
+		name       string
+		passphrase string
+		rootKey    string
+	}{
+		{
+			name:    "no passphrase",
+			rootKey: testCip3RootKey,
+		},
+		{
+			name:       "passphrase",
+			passphrase: testCip3RootKeyPassphrase,
+			rootKey:    testCip3RootKeyWithPassphrase,
+		},
+	}
+	for _, testDef := range testDefs {
+		rootKey, err := keys.NewRootKeyFromMnemonic(testCip3Mnemonic, testDef.passphrase)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		// The root key is the extended signing key followed by the chain code
+		tmpRootKey := append(rootKey.Bytes(), rootKey.ChainCode()...)
+		if hex.EncodeToString(tmpRootKey) != testDef.rootKey {
+			t.Errorf("%s: did not get expected root key:\n  got: %x\n  wanted: %s", testDef.name, tmpRootKey, testDef.rootKey)
+		}
+	}
+}
+
+func TestDeriveCip1852(t *testing.T) {
+	rootKey, err := keys.NewRootKeyFromMnemonic(testCip19Mnemonic, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	paymentKey, err := rootKey.DeriveCip1852(0, keys.Cip1852RoleExternal, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	paymentVkey, _ := keys.NewVerificationKey(keys.KeyRolePayment, paymentKey.VerificationKey().Bytes())
+	if paymentVkey.Bech32() != testCip19PaymentVkey {
+		t.Errorf("did not get expected payment key: got %s, wanted %s", paymentVkey.Bech32(), testCip19PaymentVkey)
+	}
+	// CIP-19 lists the stake key for its base address separately, and it isn't the key at m/1852'/1815'/0'/2/0
+	// for the mnemonic. Derivation for the other roles is checked against the CIP-105 keys below
+	stakeVkey, err := keys.NewVerificationKeyFromBech32(testCip19StakeVkey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	baseAddr, err := common.NewAddressFromParts(
+		common.AddressTypeKeyKey,
+		common.AddressNetworkMainnet,
+		paymentVkey.Hash().Bytes(),
+		stakeVkey.Hash().Bytes(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if baseAddr.String() != testCip19BaseAddr {
+		t.Errorf("did not get expected base address: got %s, wanted %s", baseAddr.String(), testCip19BaseAddr)
+	}
+	entAddr, err := common.NewAddressFromParts(
+		common.AddressTypeKeyNone,
+		common.AddressNetworkMainnet,
+		paymentVkey.Hash().Bytes(),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if entAddr.String() != testCip19EntAddr {
+		t.Errorf("did not get expected enterprise address: got %s, wanted %s", entAddr.String(), testCip19EntAddr)
+	}
+	drepKey, err := rootKey.DerivePath("m/1852'/1815'/0'/3/0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tmpDrepKey, _ := rootKey.DeriveCip1852(0, keys.Cip1852RoleDrep, 0)
+	if tmpDrepKey.VerificationKey().Bech32()[:9] != "drep_xvk1" {
+		t.Errorf("did not get expected DRep key prefix: %s", tmpDrepKey.VerificationKey().Bech32())
+	}
+	cip105Defs := []struct {
+		role uint32
+		vkey string
+	}{
+		{role: keys.Cip1852RoleDrep, vkey: testCip105DrepVkey},
+		{role: keys.Cip1852RoleCommitteeCold, vkey: testCip105CommitteeColdVk},
+		{role: keys.Cip1852RoleCommitteeHot, vkey: testCip105CommitteeHotVk},
+	}
+	for _, cip105Def := range cip105Defs {
+		tmpKey, err := rootKey.DeriveCip1852(0, cip105Def.role, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		tmpVkey, _ := keys.NewVerificationKey(tmpKey.Role, tmpKey.VerificationKey().Bytes())
+		if tmpVkey.Bech32() != cip105Def.vkey {
+			t.Errorf("did not get expected key for role %d: got %s, wanted %s", cip105Def.role, tmpVkey.Bech32(), cip105Def.vkey)
+		}
+	}
+	stakeKey, _ := rootKey.DeriveCip1852(0, keys.Cip1852RoleStaking, 0)
+	if stakeKey.Role != keys.KeyRoleStake {
+		t.Errorf("did not get expected stake key role: %d", stakeKey.Role)
+	}
+	if _, err := rootKey.DeriveCip1852(0, 6, 0); err == nil {
+		t.Errorf("did not get expected error for unknown role")
+	}
+	if tmpDrepKey.Role != keys.KeyRoleDrep || !bytes.Equal(drepKey.Bytes(), tmpDrepKey.Bytes()) {
+		t.Errorf("did not get expected DRep key")
+	}
+}
+
+func TestDeriveChildVerificationKey(t *testing.T) {
+	rootKey, _ := keys.NewRootKeyFromMnemonic(testCip19Mnemonic, "")
+	accountKey, err := rootKey.DeriveAccount(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Soft derivation from the account verification key should match derivation from the signing key
+	for _, index := range []uint32{0, 1, 42} {
+		childKey, _ := accountKey.DeriveChild(index)
+		childVkey, err := accountKey.VerificationKey().DeriveChild(index)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(childVkey.Bytes(), childKey.VerificationKey().Bytes()) ||
+			!bytes.Equal(childVkey.ChainCode(), childKey.ChainCode()) {
+			t.Errorf("did not get expected child verification key for index %d: got %s, wanted %s",
+				index,
+				hex.EncodeToString(childVkey.Bytes()),
+				hex.EncodeToString(childKey.VerificationKey().Bytes()),
+			)
+		}
+	}
+	if _, err := accountKey.VerificationKey().DeriveChild(keys.Harden(0)); err == nil {
+		t.Errorf("did not get expected error for hardened derivation")
+	}
+}
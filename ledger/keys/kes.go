@@ -0,0 +1,150 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+const (
+	// KesSigningKeyEnvelopeType is the text envelope type for a Sum6KES signing key
+	KesSigningKeyEnvelopeType = "KesSigningKey_ed25519_kes_2^6"
+	// KesVerificationKeyEnvelopeType is the text envelope type for a Sum6KES verification key
+	KesVerificationKeyEnvelopeType = "KesVerificationKey_ed25519_kes_2^6"
+)
+
+// KesSigningKey is a Sum6KES signing key, which is used to sign block headers. It is evolved to each
+// KES period in turn, and the secrets for past periods are erased
+type KesSigningKey struct {
+	*ledger.SumKesSigningKey
+}
+
+// NewKesSigningKey returns a KES signing key from its raw format
+func NewKesSigningKey(key []byte) (*KesSigningKey, error) {
+	tmpKey, err := ledger.NewSumKesSigningKey(ledger.Sum6KesDepth, key)
+	if err != nil {
+		return nil, err
+	}
+	return &KesSigningKey{tmpKey}, nil
+}
+
+// NewKesSigningKeyFromSeed returns a KES signing key at period 0 from a 32-byte seed
+func NewKesSigningKeyFromSeed(seed []byte) (*KesSigningKey, error) {
+	tmpKey, err := ledger.NewSumKesSigningKeyFromSeed(ledger.Sum6KesDepth, seed)
+	if err != nil {
+		return nil, err
+	}
+	return &KesSigningKey{tmpKey}, nil
+}
+
+// GenerateKesSigningKey returns a new random KES signing key at period 0
+func GenerateKesSigningKey() (*KesSigningKey, error) {
+	tmpKey, err := ledger.GenerateSumKesSigningKey(ledger.Sum6KesDepth)
+	if err != nil {
+		return nil, err
+	}
+	return &KesSigningKey{tmpKey}, nil
+}
+
+// VerificationKey returns the verification key corresponding to the signing key
+func (k *KesSigningKey) VerificationKey() *KesVerificationKey {
+	return &KesVerificationKey{
+		key: k.SumKesSigningKey.VerificationKey(),
+	}
+}
+
+// TextEnvelope returns the signing key as a cardano-cli text envelope
+func (k *KesSigningKey) TextEnvelope() *TextEnvelope {
+	return newTextEnvelope(KesSigningKeyEnvelopeType, "KES Signing Key", k.Bytes())
+}
+
+// NewKesSigningKeyFromTextEnvelope returns the KES signing key from a cardano-cli text envelope
+func NewKesSigningKeyFromTextEnvelope(e *TextEnvelope) (*KesSigningKey, error) {
+	if e.Type != KesSigningKeyEnvelopeType {
+		return nil, fmt.Errorf("unexpected text envelope type for KES signing key: %s", e.Type)
+	}
+	keyBytes, err := e.keyBytes()
+	if err != nil {
+		return nil, err
+	}
+	return NewKesSigningKey(keyBytes)
+}
+
+// ReadKesSigningKeyFile returns the KES signing key from a cardano-cli text envelope file
+func ReadKesSigningKeyFile(path string) (*KesSigningKey, error) {
+	e, err := ReadTextEnvelopeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewKesSigningKeyFromTextEnvelope(e)
+}
+
+// KesVerificationKey is a Sum6KES verification key, which is the hot key in an operational
+// certificate
+type KesVerificationKey struct {
+	key []byte
+}
+
+// NewKesVerificationKey returns a KES verification key from its bytes
+func NewKesVerificationKey(key []byte) (*KesVerificationKey, error) {
+	if len(key) != ledger.PUBLIC_KEY_SIZE {
+		return nil, fmt.Errorf("invalid KES verification key size: %d", len(key))
+	}
+	return &KesVerificationKey{
+		key: append([]byte{}, key...),
+	}, nil
+}
+
+// Bytes returns the key bytes
+func (k *KesVerificationKey) Bytes() []byte {
+	return append([]byte{}, k.key...)
+}
+
+// Verify checks the signature of the message for the specified KES period
+func (k *KesVerificationKey) Verify(period uint64, message []byte, signature []byte) bool {
+	if len(signature) != ledger.Sum6KesSignatureSize {
+		return false
+	}
+	return ledger.NewSumKesFromByte(ledger.Sum6KesDepth, signature).Verify(period, k.key, message)
+}
+
+// TextEnvelope returns the verification key as a cardano-cli text envelope
+func (k *KesVerificationKey) TextEnvelope() *TextEnvelope {
+	return newTextEnvelope(KesVerificationKeyEnvelopeType, "KES Verification Key", k.key)
+}
+
+// NewKesVerificationKeyFromTextEnvelope returns the KES verification key from a cardano-cli text
+// envelope
+func NewKesVerificationKeyFromTextEnvelope(e *TextEnvelope) (*KesVerificationKey, error) {
+	if e.Type != KesVerificationKeyEnvelopeType {
+		return nil, fmt.Errorf("unexpected text envelope type for KES verification key: %s", e.Type)
+	}
+	keyBytes, err := e.keyBytes()
+	if err != nil {
+		return nil, err
+	}
+	return NewKesVerificationKey(keyBytes)
+}
+
+// ReadKesVerificationKeyFile returns the KES verification key from a cardano-cli text envelope file
+func ReadKesVerificationKeyFile(path string) (*KesVerificationKey, error) {
+	e, err := ReadTextEnvelopeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewKesVerificationKeyFromTextEnvelope(e)
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/keys"
+)
+
+// Sum6 KES reference values for the key generated from testSeedHex, computed with a separate
+// implementation of the cardano-base sum composition over the RFC 8032 reference ed25519 code
+const (
+	testKesVkeyHex = "a8db23c591536b9bef164fb7f3741131b66f0387fdb8f55fc7e26546abec2b5f"
+	// Signing key at period 5, as the CBOR from a KesSigningKey_ed25519_kes_2^6 text envelope
+	testKesSkeyPeriod5CborHex = "5902608bd7c7c45da0294ff63eccd825513dffdfc834d6f49cdf727c22f34dc88b7a9e0000000000000000000000000000000000000000000000000000000000000000c4e5d17b79bcc3bee47d899634563fee19d712f1e06df6e0d1e788a6ad13da03db2cddbb823f2b22ca19a90c8524998d144b3bc629a5125b10a36d6840142e657a63a9c0b84518ed125da4c0a0d397d48c6d1b74d04f7f63cf0312007e4ee8ed98c209992f4b305fa430ba3f856bede72907ed74628a2d5e44cbd7f2dc79c07d6720947cad1b202431e579ad683a8cf87c8bf3a37da4b31e68299e6f7ff29307000000000000000000000000000000000000000000000000000000000000000074cbfc2598ae0014f671d8f363d876c4e3f0c2b5f5b22a4a3c0ea6b26bf58b4b8a9c9af128fc2d9db9d1dbc7decfeee762dd0a560beaa06a693fe56717bfc5c99e9dbf1767396e6edfcb90f19fc60147a71f883134fe9958edec63b3be93ed195a3a8958c9cb47761db3fe4b92d573b963c7709865bd503e23b7ad49dac4a4ac4011d1956704d459bfa4133277d46b8107272ad99124bc8556ca79f1c4ff444792256d7415326895e4dcdf48fe9f061ec405d9c6eb481d213fd50361348f6f91ba695272bb3e60f31be4b243ee9411c70ed5f069231f5742f04a5e48d9a2de43d058386e2018ad1d7f5f5e706fbcc64267ed25a977fbf8e58b5e3a06588f35a2a84207353f9c36911aec21bdbdc4b3dd43f632d76669d11bead8f89eca3e8d54f5bc9aff24c459f5575bcd90f43e4980e7c4cb169c9f4c261d0461ccde7a82a5c3fb34067fadbb812b8f12569a4426c0351dc15fcd3f73b5c50db25382a71ca8"
+	// Signatures of "test message" at periods 5 and 37
+	testKesSigPeriod5Hex  = "58662bcbabdda695f4d6fad95c7f1b576c799146627db6e1c1a81a7dba3ae81f13afffab4dfbd407c032080d666cd79d0a53c7e9a9cb07abd70f3bdb34968702c4e5d17b79bcc3bee47d899634563fee19d712f1e06df6e0d1e788a6ad13da03db2cddbb823f2b22ca19a90c8524998d144b3bc629a5125b10a36d6840142e6598c209992f4b305fa430ba3f856bede72907ed74628a2d5e44cbd7f2dc79c07d6720947cad1b202431e579ad683a8cf87c8bf3a37da4b31e68299e6f7ff2930774cbfc2598ae0014f671d8f363d876c4e3f0c2b5f5b22a4a3c0ea6b26bf58b4b8a9c9af128fc2d9db9d1dbc7decfeee762dd0a560beaa06a693fe56717bfc5c95a3a8958c9cb47761db3fe4b92d573b963c7709865bd503e23b7ad49dac4a4ac4011d1956704d459bfa4133277d46b8107272ad99124bc8556ca79f1c4ff4447ba695272bb3e60f31be4b243ee9411c70ed5f069231f5742f04a5e48d9a2de43d058386e2018ad1d7f5f5e706fbcc64267ed25a977fbf8e58b5e3a06588f35a2f5bc9aff24c459f5575bcd90f43e4980e7c4cb169c9f4c261d0461ccde7a82a5c3fb34067fadbb812b8f12569a4426c0351dc15fcd3f73b5c50db25382a71ca8"
+	testKesSigPeriod37Hex = "214873b681672b7c048c5ddc91a229c80973808b5dba36deb82c624121ea1c5bb2cc6e8627bebfc48880278ab32a00652661635cc964e4dbb8df61ee0464d50c624bf5bffbf1c367f98c067231c7e85df4f9b4587aaf79e7fac7ca943bf7820f61cfde87f6d9cd583dd0a007020904499603ec38b73ed4e8a7c0735b4870de81267a6ece65de04626121f6dc01e41997c80b403ae5d2e5c3211db567f9c9fdc7297f196b748d60c71486063a049424a596b4dddb82f194e7e2ebe70336e3e4690983bf4d0f59698a8fc0c94548466ab77bf3703838f98df3be840a3aff662026317298f3b4027ac1f61d8f81221de3b6170a5469b89cc444d7b5ec26586a7c98163b81895d58543367f3f3d93fb72f6fbbe96034b3a63e78fe44a7fa1a2d03cce853f2f3e50eb3713424814a2a6c297ec1a2026cc32c33e3750fcb1386b30f904aa36fbd147a7c3b48206b855027e31cdb63fc7233b1b6bd24211b6480afc336739baa2a35abb7c0754e80d1897d1dd06392a867c8db7abb6a26e2f664001924f5bc9aff24c459f5575bcd90f43e4980e7c4cb169c9f4c261d0461ccde7a82a5c3fb34067fadbb812b8f12569a4426c0351dc15fcd3f73b5c50db25382a71ca8"
+)
+
+func TestKesKeyReference(t *testing.T) {
+	skey, err := keys.NewKesSigningKeyFromTextEnvelope(
+		&keys.TextEnvelope{
+			Type:    "KesSigningKey_ed25519_kes_2^6",
+			CborHex: testKesSkeyPeriod5CborHex,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if skey.Period() != 5 {
+		t.Fatalf("did not get expected period: got %d, wanted 5", skey.Period())
+	}
+	vkey := skey.VerificationKey()
+	if !bytes.Equal(vkey.Bytes(), testHexBytes(t, testKesVkeyHex)) {
+		t.Fatalf("did not get expected verification key: got %x, wanted %s", vkey.Bytes(), testKesVkeyHex)
+	}
+	// The key generated from the seed matches the decoded key once evolved
+	seedSkey, err := keys.NewKesSigningKeyFromSeed(testHexBytes(t, testSeedHex))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := seedSkey.EvolveTo(5); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(seedSkey.Bytes(), skey.Bytes()) {
+		t.Fatalf("did not get expected signing key at period 5")
+	}
+	msg := []byte("test message")
+	testDefs := []struct {
+		period uint64
+		sigHex string
+	}{
+		{period: 5, sigHex: testKesSigPeriod5Hex},
+		{period: 37, sigHex: testKesSigPeriod37Hex},
+	}
+	for _, testDef := range testDefs {
+		if err := skey.EvolveTo(testDef.period); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		sig, err := skey.Sign(testDef.period, msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expectedSig := testHexBytes(t, testDef.sigHex)
+		if !bytes.Equal(sig, expectedSig) {
+			t.Fatalf("did not get expected signature at period %d: got %x", testDef.period, sig)
+		}
+		if !vkey.Verify(testDef.period, msg, expectedSig) {
+			t.Fatalf("reference signature did not verify at period %d", testDef.period)
+		}
+	}
+}
+
+func TestKesKeyTextEnvelope(t *testing.T) {
+	skey, err := keys.NewKesSigningKeyFromSeed(testHexBytes(t, testSeedHex))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := skey.EvolveTo(5); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vkey := skey.VerificationKey()
+	skeyEnvelope := skey.TextEnvelope()
+	// 608 byte key
+	if skeyEnvelope.Type != "KesSigningKey_ed25519_kes_2^6" ||
+		skeyEnvelope.Description != "KES Signing Key" ||
+		!strings.HasPrefix(skeyEnvelope.CborHex, "590260") {
+		t.Fatalf("did not get expected signing key envelope: %s %s", skeyEnvelope.Type, skeyEnvelope.Description)
+	}
+	vkeyEnvelope := vkey.TextEnvelope()
+	if vkeyEnvelope.Type != "KesVerificationKey_ed25519_kes_2^6" ||
+		vkeyEnvelope.Description != "KES Verification Key" ||
+		!strings.HasPrefix(vkeyEnvelope.CborHex, "5820") {
+		t.Fatalf("did not get expected verification key envelope: %#v", vkeyEnvelope)
+	}
+	// Round trip through files
+	dir := t.TempDir()
+	skeyPath := filepath.Join(dir, "kes.skey")
+	vkeyPath := filepath.Join(dir, "kes.vkey")
+	if err := skeyEnvelope.WriteFile(skeyPath); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := vkeyEnvelope.WriteFile(vkeyPath); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tmpSkey, err := keys.ReadKesSigningKeyFile(skeyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tmpSkey.Period() != 5 || !bytes.Equal(tmpSkey.Bytes(), skey.Bytes()) {
+		t.Fatalf("did not get expected signing key at period %d", tmpSkey.Period())
+	}
+	tmpVkey, err := keys.ReadKesVerificationKeyFile(vkeyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	msg := []byte("test message")
+	sig, err := tmpSkey.Sign(5, msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !tmpVkey.Verify(5, msg, sig) {
+		t.Fatalf("signature did not verify")
+	}
+	if tmpVkey.Verify(4, msg, sig) {
+		t.Fatalf("signature verified for the wrong period")
+	}
+	t.Run("wrong type", func(t *testing.T) {
+		if _, err := keys.NewKesSigningKeyFromTextEnvelope(vkeyEnvelope); err == nil {
+			t.Errorf("did not get expected error")
+		}
+	})
+}
+
+func TestKesKeyWithOperationalCertificate(t *testing.T) {
+	coldKey, err := keys.GenerateSigningKey(keys.KeyRoleStakePool)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	kesKey, err := keys.GenerateKesSigningKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The opcert starts at KES period 100, so the key period is relative to that
+	opCert, err := keys.IssueOperationalCertificate(
+		coldKey,
+		kesKey.VerificationKey().Bytes(),
+		0,
+		100,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := opCert.Verify(coldKey.VerificationKey().Bytes()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	currentKesPeriod := uint64(110)
+	if err := opCert.KesPeriodValid(currentKesPeriod, kesKey.TotalPeriods()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	keyPeriod := currentKesPeriod - uint64(opCert.KesPeriod)
+	if err := kesKey.EvolveTo(keyPeriod); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	msg := []byte("header body")
+	sig, err := kesKey.Sign(keyPeriod, msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	hotVkey, err := keys.NewKesVerificationKey(opCert.HotVkey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !hotVkey.Verify(keyPeriod, msg, sig) {
+		t.Fatalf("signature did not verify against opcert hot vkey")
+	}
+}
@@ -0,0 +1,398 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keys provides ed25519 signing and verification keys in the formats used by cardano-cli, and
+// transaction signing
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+)
+
+const (
+	// SigningKeySize is the size of a non-extended signing key, which is an ed25519 seed
+	SigningKeySize = ed25519.SeedSize
+	// ExtendedSigningKeySize is the size of an extended (BIP32-Ed25519) signing key, not including the chain code
+	ExtendedSigningKeySize = 64
+	// VerificationKeySize is the size of a verification key, not including any chain code
+	VerificationKeySize = ed25519.PublicKeySize
+	// ChainCodeSize is the size of the chain code for extended keys
+	ChainCodeSize = 32
+)
+
+// KeyRole identifies what a key is used for, which determines its text envelope type and bech32 prefix
+type KeyRole uint8
+
+const (
+	KeyRolePayment KeyRole = iota
+	KeyRoleStake
+	KeyRoleDrep
+	KeyRoleCommitteeCold
+	KeyRoleCommitteeHot
+	// KeyRoleStakePool is a stake pool cold key, which signs operational certificates
+	KeyRoleStakePool
+)
+
+// keyRoleInfo contains the names used for a key role in text envelopes and bech32 encoding
+type keyRoleInfo struct {
+	envelopeName   string
+	envelopeSuffix string
+	description    string
+	bech32Prefix   string
+}
+
+var keyRoles = map[KeyRole]keyRoleInfo{
+	KeyRolePayment: {
+		envelopeName:   "Payment",
+		envelopeSuffix: "Shelley",
+		description:    "Payment",
+		bech32Prefix:   "addr",
+	},
+	KeyRoleStake: {
+		envelopeName:   "Stake",
+		envelopeSuffix: "Shelley",
+		description:    "Stake",
+		bech32Prefix:   "stake",
+	},
+	KeyRoleDrep: {
+		envelopeName: "DRep",
+		description:  "Delegate Representative",
+		bech32Prefix: "drep",
+	},
+	KeyRoleCommitteeCold: {
+		envelopeName: "ConstitutionalCommitteeCold",
+		description:  "Constitutional Committee Cold",
+		bech32Prefix: "cc_cold",
+	},
+	KeyRoleCommitteeHot: {
+		envelopeName: "ConstitutionalCommitteeHot",
+		description:  "Constitutional Committee Hot",
+		bech32Prefix: "cc_hot",
+	},
+	KeyRoleStakePool: {
+		envelopeName: "StakePool",
+		description:  "Stake Pool Operator",
+		bech32Prefix: "pool",
+	},
+}
+
+// envelopeType returns the text envelope type for a key with the specified role, e.g. PaymentSigningKeyShelley_ed25519
+func (r KeyRole) envelopeType(signing bool, extended bool) string {
+	info := keyRoles[r]
+	ret := info.envelopeName
+	if extended {
+		ret += "Extended"
+	}
+	if signing {
+		ret += "SigningKey"
+	} else {
+		ret += "VerificationKey"
+	}
+	ret += info.envelopeSuffix + "_ed25519"
+	if extended {
+		ret += "_bip32"
+	}
+	return ret
+}
+
+// envelopeDescription returns the text envelope description for a key with the specified role
+func (r KeyRole) envelopeDescription(signing bool) string {
+	if signing {
+		return keyRoles[r].description + " Signing Key"
+	}
+	return keyRoles[r].description + " Verification Key"
+}
+
+// bech32Prefix returns the CIP-5 bech32 prefix for a key with the specified role, e.g. addr_xsk
+func (r KeyRole) bech32Prefix(signing bool, extended bool) string {
+	ret := keyRoles[r].bech32Prefix + "_"
+	if extended {
+		ret += "x"
+	}
+	if signing {
+		return ret + "sk"
+	}
+	return ret + "vk"
+}
+
+// SigningKey is an ed25519 signing key. Extended keys use the BIP32-Ed25519 scheme used by HD wallets
+type SigningKey struct {
+	Role      KeyRole
+	key       []byte
+	chainCode []byte
+}
+
+// NewSigningKey returns a non-extended signing key from an ed25519 seed
+func NewSigningKey(role KeyRole, seed []byte) (*SigningKey, error) {
+	if len(seed) != SigningKeySize {
+		return nil, fmt.Errorf("invalid signing key size: %d", len(seed))
+	}
+	return &SigningKey{
+		Role: role,
+		key:  append([]byte{}, seed...),
+	}, nil
+}
+
+// NewExtendedSigningKey returns an extended signing key from the 64-byte extended private key and the chain code
+func NewExtendedSigningKey(role KeyRole, key []byte, chainCode []byte) (*SigningKey, error) {
+	if len(key) != ExtendedSigningKeySize {
+		return nil, fmt.Errorf("invalid extended signing key size: %d", len(key))
+	}
+	if len(chainCode) != ChainCodeSize {
+		return nil, fmt.Errorf("invalid chain code size: %d", len(chainCode))
+	}
+	return &SigningKey{
+		Role:      role,
+		key:       append([]byte{}, key...),
+		chainCode: append([]byte{}, chainCode...),
+	}, nil
+}
+
+// GenerateSigningKey returns a new random non-extended signing key
+func GenerateSigningKey(role KeyRole) (*SigningKey, error) {
+	seed := make([]byte, SigningKeySize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	return NewSigningKey(role, seed)
+}
+
+// Extended returns whether this is an extended signing key
+func (k *SigningKey) Extended() bool {
+	return len(k.key) == ExtendedSigningKeySize
+}
+
+// Bytes returns the key bytes, which is the seed for non-extended keys and the 64-byte extended private key
+// for extended keys
+func (k *SigningKey) Bytes() []byte {
+	return append([]byte{}, k.key...)
+}
+
+// ChainCode returns the chain code for extended keys
+func (k *SigningKey) ChainCode() []byte {
+	return append([]byte{}, k.chainCode...)
+}
+
+// VerificationKey returns the verification key corresponding to the signing key
+func (k *SigningKey) VerificationKey() *VerificationKey {
+	ret := &VerificationKey{
+		Role: k.Role,
+	}
+	if k.Extended() {
+		ret.key = extendedScalar(k.key).publicKey()
+		ret.chainCode = append([]byte{}, k.chainCode...)
+	} else {
+		privKey := ed25519.NewKeyFromSeed(k.key)
+		ret.key = append([]byte{}, privKey.Public().(ed25519.PublicKey)...)
+	}
+	return ret
+}
+
+// Sign returns the ed25519 signature of the message
+func (k *SigningKey) Sign(message []byte) []byte {
+	if !k.Extended() {
+		return ed25519.Sign(ed25519.NewKeyFromSeed(k.key), message)
+	}
+	// Extended keys are already expanded, so the scalar and nonce prefix are used directly instead of being
+	// derived by hashing a seed
+	scalar := extendedScalar(k.key)
+	publicKey := scalar.publicKey()
+	h := sha512.New()
+	h.Write(k.key[32:])
+	h.Write(message)
+	r, _ := edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	R := new(edwards25519.Point).ScalarBaseMult(r).Bytes()
+	h.Reset()
+	h.Write(R)
+	h.Write(publicKey)
+	h.Write(message)
+	hram, _ := edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	s := edwards25519.NewScalar().MultiplyAdd(hram, scalar.scalar, r)
+	return append(R, s.Bytes()...)
+}
+
+// Bech32 returns the CIP-5 bech32 encoding of the signing key. Extended keys include the chain code
+func (k *SigningKey) Bech32() string {
+	return encodeBech32(
+		k.Role.bech32Prefix(true, k.Extended()),
+		append(k.Bytes(), k.chainCode...),
+	)
+}
+
+// NewSigningKeyFromBech32 returns the signing key from its CIP-5 bech32 encoding
+func NewSigningKeyFromBech32(data string) (*SigningKey, error) {
+	prefix, keyBytes, err := decodeBech32(data)
+	if err != nil {
+		return nil, err
+	}
+	for role := range keyRoles {
+		switch prefix {
+		case role.bech32Prefix(true, false):
+			return NewSigningKey(role, keyBytes)
+		case role.bech32Prefix(true, true):
+			if len(keyBytes) != ExtendedSigningKeySize+ChainCodeSize {
+				return nil, fmt.Errorf("invalid extended signing key size: %d", len(keyBytes))
+			}
+			return NewExtendedSigningKey(
+				role,
+				keyBytes[:ExtendedSigningKeySize],
+				keyBytes[ExtendedSigningKeySize:],
+			)
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key bech32 prefix: %s", prefix)
+}
+
+// VerificationKey is an ed25519 verification key. Extended keys also contain a chain code
+type VerificationKey struct {
+	Role      KeyRole
+	key       []byte
+	chainCode []byte
+}
+
+// NewVerificationKey returns a non-extended verification key
+func NewVerificationKey(role KeyRole, key []byte) (*VerificationKey, error) {
+	if len(key) != VerificationKeySize {
+		return nil, fmt.Errorf("invalid verification key size: %d", len(key))
+	}
+	return &VerificationKey{
+		Role: role,
+		key:  append([]byte{}, key...),
+	}, nil
+}
+
+// NewExtendedVerificationKey returns an extended verification key
+func NewExtendedVerificationKey(role KeyRole, key []byte, chainCode []byte) (*VerificationKey, error) {
+	if len(key) != VerificationKeySize {
+		return nil, fmt.Errorf("invalid verification key size: %d", len(key))
+	}
+	if len(chainCode) != ChainCodeSize {
+		return nil, fmt.Errorf("invalid chain code size: %d", len(chainCode))
+	}
+	return &VerificationKey{
+		Role:      role,
+		key:       append([]byte{}, key...),
+		chainCode: append([]byte{}, chainCode...),
+	}, nil
+}
+
+// Extended returns whether this is an extended verification key
+func (k *VerificationKey) Extended() bool {
+	return k.chainCode != nil
+}
+
+// Bytes returns the ed25519 public key
+func (k *VerificationKey) Bytes() []byte {
+	return append([]byte{}, k.key...)
+}
+
+// ChainCode returns the chain code for extended keys
+func (k *VerificationKey) ChainCode() []byte {
+	return append([]byte{}, k.chainCode...)
+}
+
+// Hash returns the key hash, which is used in addresses, credentials and required signers
+func (k *VerificationKey) Hash() common.Blake2b224 {
+	return common.Blake2b224Hash(k.key)
+}
+
+// Verify returns whether the signature of the message is valid for this key
+func (k *VerificationKey) Verify(message []byte, signature []byte) bool {
+	return ed25519.Verify(k.key, message, signature)
+}
+
+// Bech32 returns the CIP-5 bech32 encoding of the verification key. Extended keys include the chain code
+func (k *VerificationKey) Bech32() string {
+	return encodeBech32(
+		k.Role.bech32Prefix(false, k.Extended()),
+		append(k.Bytes(), k.chainCode...),
+	)
+}
+
+// NewVerificationKeyFromBech32 returns the verification key from its CIP-5 bech32 encoding
+func NewVerificationKeyFromBech32(data string) (*VerificationKey, error) {
+	prefix, keyBytes, err := decodeBech32(data)
+	if err != nil {
+		return nil, err
+	}
+	for role := range keyRoles {
+		switch prefix {
+		case role.bech32Prefix(false, false):
+			return NewVerificationKey(role, keyBytes)
+		case role.bech32Prefix(false, true):
+			if len(keyBytes) != VerificationKeySize+ChainCodeSize {
+				return nil, fmt.Errorf("invalid extended verification key size: %d", len(keyBytes))
+			}
+			return NewExtendedVerificationKey(
+				role,
+				keyBytes[:VerificationKeySize],
+				keyBytes[VerificationKeySize:],
+			)
+		}
+	}
+	return nil, fmt.Errorf("unknown verification key bech32 prefix: %s", prefix)
+}
+
+// scalarKey is the scalar half of an extended private key
+type scalarKey struct {
+	scalar *edwards25519.Scalar
+}
+
+func extendedScalar(key []byte) scalarKey {
+	// The scalar is the little-endian integer in the first 32 bytes, which is not necessarily reduced
+	tmpBytes := make([]byte, 64)
+	copy(tmpBytes, key[:32])
+	scalar, _ := edwards25519.NewScalar().SetUniformBytes(tmpBytes)
+	return scalarKey{scalar: scalar}
+}
+
+func (s scalarKey) publicKey() []byte {
+	return new(edwards25519.Point).ScalarBaseMult(s.scalar).Bytes()
+}
+
+func encodeBech32(prefix string, data []byte) string {
+	// Convert data to base32 and encode as bech32
+	convData, err := bech32.ConvertBits(data, 8, 5, true)
+	if err != nil {
+		panic(fmt.Sprintf("unexpected error converting data to base32: %s", err))
+	}
+	encoded, err := bech32.Encode(prefix, convData)
+	if err != nil {
+		panic(fmt.Sprintf("unexpected error encoding data as bech32: %s", err))
+	}
+	return encoded
+}
+
+func decodeBech32(data string) (string, []byte, error) {
+	prefix, convData, err := bech32.DecodeNoLimit(data)
+	if err != nil {
+		return "", nil, err
+	}
+	decoded, err := bech32.ConvertBits(convData, 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(decoded) == 0 {
+		return "", nil, errors.New("empty key data")
+	}
+	return prefix, decoded, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys_test
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/keys"
+)
+
+// Test vector from RFC 8032 section 7.1, test 1
+const (
+	testSeedHex      = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+	testPubKeyHex    = "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+	testSignatureHex = "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b"
+)
+
+func testHexBytes(t *testing.T, data string) []byte {
+	ret, err := hex.DecodeString(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return ret
+}
+
+func testExtendedKey(t *testing.T) *keys.SigningKey {
+	key := make([]byte, keys.ExtendedSigningKeySize)
+	for idx := range key {
+		key[idx] = byte(idx * 7)
+	}
+	// Clamp the scalar as required by BIP32-Ed25519
+	key[0] &= 0xf8
+	key[31] &= 0x1f
+	key[31] |= 0x40
+	chainCode := make([]byte, keys.ChainCodeSize)
+	for idx := range chainCode {
+		chainCode[idx] = byte(idx)
+	}
+	skey, err := keys.NewExtendedSigningKey(keys.KeyRolePayment, key, chainCode)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return skey
+}
+
+func TestSigningKey(t *testing.T) {
+	skey, err := keys.NewSigningKey(keys.KeyRolePayment, testHexBytes(t, testSeedHex))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vkey := skey.VerificationKey()
+	if hex.EncodeToString(vkey.Bytes()) != testPubKeyHex {
+		t.Errorf("did not get expected public key: got %x, wanted %s", vkey.Bytes(), testPubKeyHex)
+	}
+	signature := skey.Sign(nil)
+	if hex.EncodeToString(signature) != testSignatureHex {
+		t.Errorf("did not get expected signature: got %x, wanted %s", signature, testSignatureHex)
+	}
+	if !vkey.Verify(nil, signature) {
+		t.Errorf("signature should be valid")
+	}
+}
+
+func TestExtendedSigningKey(t *testing.T) {
+	skey := testExtendedKey(t)
+	vkey := skey.VerificationKey()
+	if !vkey.Extended() {
+		t.Errorf("verification key should be extended")
+	}
+	message := []byte("test message")
+	signature := skey.Sign(message)
+	if !ed25519.Verify(vkey.Bytes(), message, signature) {
+		t.Errorf("signature should be valid")
+	}
+	if vkey.Verify([]byte("other message"), signature) {
+		t.Errorf("signature should not be valid for a different message")
+	}
+}
+
+func TestBech32(t *testing.T) {
+	skey, _ := keys.NewSigningKey(keys.KeyRoleStake, testHexBytes(t, testSeedHex))
+	testDefs := []struct {
+		skey         *keys.SigningKey
+		skeyPrefix   string
+		vkeyPrefix   string
+		expectedRole keys.KeyRole
+	}{
+		{skey: skey, skeyPrefix: "stake_sk1", vkeyPrefix: "stake_vk1", expectedRole: keys.KeyRoleStake},
+		{skey: testExtendedKey(t), skeyPrefix: "addr_xsk1", vkeyPrefix: "addr_xvk1", expectedRole: keys.KeyRolePayment},
+	}
+	for _, testDef := range testDefs {
+		skeyBech32 := testDef.skey.Bech32()
+		if skeyBech32[:len(testDef.skeyPrefix)] != testDef.skeyPrefix {
+			t.Errorf("did not get expected prefix: got %s, wanted %s", skeyBech32, testDef.skeyPrefix)
+		}
+		tmpSkey, err := keys.NewSigningKeyFromBech32(skeyBech32)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tmpSkey.Role != testDef.expectedRole || tmpSkey.Bech32() != skeyBech32 {
+			t.Errorf("signing key did not round-trip: got %s, wanted %s", tmpSkey.Bech32(), skeyBech32)
+		}
+		vkeyBech32 := testDef.skey.VerificationKey().Bech32()
+		if vkeyBech32[:len(testDef.vkeyPrefix)] != testDef.vkeyPrefix {
+			t.Errorf("did not get expected prefix: got %s, wanted %s", vkeyBech32, testDef.vkeyPrefix)
+		}
+		tmpVkey, err := keys.NewVerificationKeyFromBech32(vkeyBech32)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tmpVkey.Bech32() != vkeyBech32 {
+			t.Errorf("verification key did not round-trip: got %s, wanted %s", tmpVkey.Bech32(), vkeyBech32)
+		}
+	}
+}
+
+func TestTextEnvelope(t *testing.T) {
+	skeyJson := `{
+    "type": "PaymentSigningKeyShelley_ed25519",
+    "description": "Payment Signing Key",
+    "cborHex": "5820` + testSeedHex + `"
+}
+`
+	envelope, err := keys.NewTextEnvelopeFromJson([]byte(skeyJson))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	skey, err := keys.NewSigningKeyFromTextEnvelope(envelope)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tmpJson, err := skey.TextEnvelope().Json()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(tmpJson) != skeyJson {
+		t.Errorf("did not get expected JSON:\n  got: %s\n  wanted: %s", tmpJson, skeyJson)
+	}
+	vkeyEnvelope := skey.VerificationKey().TextEnvelope()
+	if vkeyEnvelope.Type != "PaymentVerificationKeyShelley_ed25519" ||
+		vkeyEnvelope.CborHex != "5820"+testPubKeyHex {
+		t.Errorf("did not get expected verification key envelope: %#v", vkeyEnvelope)
+	}
+	t.Run("extended key file", func(t *testing.T) {
+		xskey := testExtendedKey(t)
+		xskey.Role = keys.KeyRoleDrep
+		tmpEnvelope := xskey.TextEnvelope()
+		if tmpEnvelope.Type != "DRepExtendedSigningKey_ed25519_bip32" {
+			t.Errorf("did not get expected type: %s", tmpEnvelope.Type)
+		}
+		path := filepath.Join(t.TempDir(), "drep.skey")
+		if err := tmpEnvelope.WriteFile(path); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		tmpSkey, err := keys.ReadSigningKeyFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tmpSkey.Role != keys.KeyRoleDrep || tmpSkey.Bech32() != xskey.Bech32() {
+			t.Errorf("extended signing key did not round-trip")
+		}
+	})
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := keys.NewSigningKeyFromTextEnvelope(
+			&keys.TextEnvelope{Type: "GenesisSigningKey_ed25519", CborHex: "5820" + testSeedHex},
+		)
+		if err == nil {
+			t.Errorf("did not get expected error")
+		}
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// OpCertEnvelopeType is the text envelope type for an operational certificate
+const OpCertEnvelopeType = "NodeOperationalCertificate"
+
+// opCertEnvelope is the content of an operational certificate text envelope, which includes the cold
+// vkey along with the certificate
+type opCertEnvelope struct {
+	cbor.StructAsArray
+	OpCert   common.OperationalCertificate
+	ColdVkey []byte
+}
+
+// IssueOperationalCertificate returns an operational certificate for the KES (hot) vkey signed by the
+// pool cold key
+func IssueOperationalCertificate(
+	coldKey *SigningKey,
+	hotVkey []byte,
+	sequenceNumber uint32,
+	kesPeriod uint32,
+) (*common.OperationalCertificate, error) {
+	if len(hotVkey) != VerificationKeySize {
+		return nil, fmt.Errorf("invalid KES verification key size: %d", len(hotVkey))
+	}
+	ret := &common.OperationalCertificate{
+		HotVkey:        append([]byte{}, hotVkey...),
+		SequenceNumber: sequenceNumber,
+		KesPeriod:      kesPeriod,
+	}
+	ret.Signature = coldKey.Sign(ret.SignedData())
+	return ret, nil
+}
+
+// OperationalCertificateTextEnvelope returns the operational certificate and the cold vkey that
+// signed it as a cardano-cli text envelope
+func OperationalCertificateTextEnvelope(
+	opCert *common.OperationalCertificate,
+	coldVkey *VerificationKey,
+) (*TextEnvelope, error) {
+	cborData, err := cbor.Encode(
+		&opCertEnvelope{
+			OpCert:   *opCert,
+			ColdVkey: coldVkey.Bytes(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &TextEnvelope{
+		Type:    OpCertEnvelopeType,
+		CborHex: hex.EncodeToString(cborData),
+	}, nil
+}
+
+// NewOperationalCertificateFromTextEnvelope returns the operational certificate and the cold vkey
+// that signed it from a cardano-cli text envelope. The signature is not verified
+func NewOperationalCertificateFromTextEnvelope(
+	e *TextEnvelope,
+) (*common.OperationalCertificate, *VerificationKey, error) {
+	if e.Type != OpCertEnvelopeType {
+		return nil, nil, fmt.Errorf("unexpected text envelope type for operational certificate: %s", e.Type)
+	}
+	cborData, err := hex.DecodeString(e.CborHex)
+	if err != nil {
+		return nil, nil, err
+	}
+	var tmpEnvelope opCertEnvelope
+	if _, err := cbor.Decode(cborData, &tmpEnvelope); err != nil {
+		return nil, nil, err
+	}
+	coldVkey, err := NewVerificationKey(KeyRoleStakePool, tmpEnvelope.ColdVkey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &tmpEnvelope.OpCert, coldVkey, nil
+}
+
+// ReadOperationalCertificateFile returns the operational certificate and the cold vkey that signed it
+// from a cardano-cli text envelope file
+func ReadOperationalCertificateFile(
+	path string,
+) (*common.OperationalCertificate, *VerificationKey, error) {
+	e, err := ReadTextEnvelopeFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewOperationalCertificateFromTextEnvelope(e)
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/keys"
+)
+
+// Operational certificate from the header of mainnet block 10882991, in the cardano-cli format
+const testOpCertJson = `{
+    "type": "NodeOperationalCertificate",
+    "description": "",
+    "cborHex": "828458200946347d22a3b6da29d79102424973c932b898808ff2436fa138df102484230a0a1904165840c75619c3ebad0758349eb1dedc154a8cd280d8189d6da973b4a147b0cdb0f60442d493feeba64167a05b5fc40bc695192bf1c08afad3c07ebd33cb5925f37801582012209e0b93f0128f670c9a02781c5466c4c4be003da3a51344b6a94f709ce51f"
+}
+`
+
+func TestOperationalCertificateTextEnvelope(t *testing.T) {
+	envelope, err := keys.NewTextEnvelopeFromJson([]byte(testOpCertJson))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	opCert, coldVkey, err := keys.NewOperationalCertificateFromTextEnvelope(envelope)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if opCert.SequenceNumber != 10 || opCert.KesPeriod != 1046 {
+		t.Fatalf(
+			"did not get expected counter and KES period: %d, %d",
+			opCert.SequenceNumber,
+			opCert.KesPeriod,
+		)
+	}
+	if err := opCert.Verify(coldVkey.Bytes()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tmpEnvelope, err := keys.OperationalCertificateTextEnvelope(opCert, coldVkey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tmpJson, err := tmpEnvelope.Json()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(tmpJson) != testOpCertJson {
+		t.Fatalf("did not get expected JSON:\n  got: %s\n  wanted: %s", tmpJson, testOpCertJson)
+	}
+	t.Run("wrong type", func(t *testing.T) {
+		_, _, err := keys.NewOperationalCertificateFromTextEnvelope(
+			&keys.TextEnvelope{Type: "NodeOperationalCertificateIssueCounter", CborHex: envelope.CborHex},
+		)
+		if err == nil {
+			t.Errorf("did not get expected error")
+		}
+	})
+}
+
+func TestIssueOperationalCertificate(t *testing.T) {
+	coldKey, err := keys.NewSigningKey(keys.KeyRoleStakePool, testHexBytes(t, testSeedHex))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	hotVkey := bytes.Repeat([]byte{0xab}, 32)
+	opCert, err := keys.IssueOperationalCertificate(coldKey, hotVkey, 3, 512)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	coldVkey := coldKey.VerificationKey()
+	if err := opCert.Verify(coldVkey.Bytes()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Round trip through a file
+	envelope, err := keys.OperationalCertificateTextEnvelope(opCert, coldVkey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "node.cert")
+	if err := envelope.WriteFile(path); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tmpOpCert, tmpColdVkey, err := keys.ReadOperationalCertificateFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tmpColdVkey.Role != keys.KeyRoleStakePool || !bytes.Equal(tmpColdVkey.Bytes(), coldVkey.Bytes()) {
+		t.Fatalf("did not get expected cold vkey: %x", tmpColdVkey.Bytes())
+	}
+	if err := tmpOpCert.Verify(tmpColdVkey.Bytes()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Any change to the certificate invalidates the signature
+	tmpOpCert.KesPeriod++
+	if err := tmpOpCert.Verify(coldVkey.Bytes()); err == nil {
+		t.Fatalf("did not get expected error")
+	}
+	t.Run("invalid hot vkey", func(t *testing.T) {
+		if _, err := keys.IssueOperationalCertificate(coldKey, hotVkey[:31], 0, 0); err == nil {
+			t.Errorf("did not get expected error")
+		}
+	})
+	t.Run("cold key envelope", func(t *testing.T) {
+		tmpEnvelope := coldKey.TextEnvelope()
+		if tmpEnvelope.Type != "StakePoolSigningKey_ed25519" ||
+			tmpEnvelope.Description != "Stake Pool Operator Signing Key" {
+			t.Errorf("did not get expected envelope: %#v", tmpEnvelope)
+		}
+		if !strings.HasPrefix(coldKey.Bech32(), "pool_sk1") {
+			t.Errorf("did not get expected bech32 prefix: %s", coldKey.Bech32())
+		}
+	})
+}
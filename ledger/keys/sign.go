@@ -0,0 +1,103 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// cborTagSet is the CBOR tag used for sets, which is optionally used for witness lists since Conway
+const cborTagSet = 258
+
+// SignTx returns a copy of the transaction with a vkey witness from each of the provided keys added to the witness
+// set. The transaction body bytes are not modified, so the transaction ID does not change. Keys that already have
+// a witness in the transaction are skipped
+func SignTx(tx common.Transaction, keys ...*SigningKey) (common.Transaction, error) {
+	if tx.Type() == ledger.TxTypeByron {
+		return nil, errors.New("signing Byron transactions is not supported")
+	}
+	txCbor := tx.Cbor()
+	if len(txCbor) == 0 {
+		return nil, errors.New("transaction has no CBOR")
+	}
+	var txItems []cbor.RawMessage
+	if _, err := cbor.Decode(txCbor, &txItems); err != nil {
+		return nil, fmt.Errorf("decode transaction: %w", err)
+	}
+	if len(txItems) < 2 {
+		return nil, fmt.Errorf("unexpected transaction item count: %d", len(txItems))
+	}
+	bodyHash := common.Blake2b256Hash(txItems[0])
+	witnessSet := map[uint]cbor.RawMessage{}
+	if _, err := cbor.Decode(txItems[1], &witnessSet); err != nil {
+		return nil, fmt.Errorf("decode witness set: %w", err)
+	}
+	var vkeyWitnesses []common.VkeyWitness
+	var tagged bool
+	if vkeyWitnessesCbor, ok := witnessSet[0]; ok {
+		tagged = bytes.HasPrefix(vkeyWitnessesCbor, []byte{0xd9, 0x01, 0x02})
+		if _, err := cbor.Decode(vkeyWitnessesCbor, &vkeyWitnesses); err != nil {
+			return nil, fmt.Errorf("decode vkey witnesses: %w", err)
+		}
+	}
+	for _, key := range keys {
+		vkey := key.VerificationKey().Bytes()
+		found := false
+		for _, witness := range vkeyWitnesses {
+			if bytes.Equal(witness.Vkey, vkey) {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		vkeyWitnesses = append(
+			vkeyWitnesses,
+			common.VkeyWitness{
+				Vkey:      vkey,
+				Signature: key.Sign(bodyHash.Bytes()),
+			},
+		)
+	}
+	var tmpVkeyWitnesses any = vkeyWitnesses
+	if tagged {
+		tmpVkeyWitnesses = cbor.Tag{
+			Number:  cborTagSet,
+			Content: vkeyWitnesses,
+		}
+	}
+	vkeyWitnessesCbor, err := cbor.Encode(tmpVkeyWitnesses)
+	if err != nil {
+		return nil, err
+	}
+	witnessSet[0] = vkeyWitnessesCbor
+	witnessSetCbor, err := cbor.Encode(witnessSet)
+	if err != nil {
+		return nil, err
+	}
+	txItems[1] = witnessSetCbor
+	signedTxCbor, err := cbor.Encode(txItems)
+	if err != nil {
+		return nil, err
+	}
+	return ledger.NewTransactionFromCbor(uint(tx.Type()), signedTxCbor)
+}
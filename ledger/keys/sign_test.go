@@ -0,0 +1,126 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/conway"
+	"github.com/blinklabs-io/gouroboros/ledger/keys"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+	"github.com/blinklabs-io/gouroboros/ledger/txbuilder"
+)
+
+type testLedgerState struct {
+	utxos []common.Utxo
+}
+
+func (ls testLedgerState) NetworkId() uint {
+	return common.AddressNetworkMainnet
+}
+
+func (ls testLedgerState) UtxoById(id common.TransactionInput) (common.Utxo, error) {
+	for _, tmpUtxo := range ls.utxos {
+		if id.String() == tmpUtxo.Id.String() {
+			return tmpUtxo, nil
+		}
+	}
+	return common.Utxo{}, errors.New("not found")
+}
+
+func (ls testLedgerState) StakeRegistration(common.Blake2b224) (*common.StakeRegistration, error) {
+	return nil, nil
+}
+
+func (ls testLedgerState) PoolRegistration(common.PoolKeyHash) (*common.PoolRegistration, error) {
+	return nil, nil
+}
+
+func (ls testLedgerState) DrepRegistration(common.Blake2b224) (*common.DrepRegistration, error) {
+	return nil, nil
+}
+
+func (ls testLedgerState) CommitteeMember(common.Blake2b224) (*common.CommitteeMember, error) {
+	return nil, nil
+}
+
+func TestSignTx(t *testing.T) {
+	skey, _ := keys.NewSigningKey(keys.KeyRolePayment, testHexBytes(t, testSeedHex))
+	xskey := testExtendedKey(t)
+	addr, err := common.NewAddressFromParts(
+		common.AddressTypeKeyNone,
+		common.AddressNetworkMainnet,
+		skey.VerificationKey().Hash().Bytes(),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	inputUtxo := common.Utxo{
+		Id: shelley.NewShelleyTransactionInput("d228b482a1aae768e4a796380f49e021d9c21f70d3c12cb186b188dedfc0ee22", 0),
+		Output: &babbage.BabbageTransactionOutput{
+			OutputAddress: addr,
+			OutputAmount:  mary.MaryTransactionOutputValue{Amount: 100_000_000},
+		},
+	}
+	pparams := &conway.ConwayProtocolParameters{
+		MinFeeA:        44,
+		MinFeeB:        155381,
+		AdaPerUtxoByte: 4310,
+	}
+	builder, err := txbuilder.NewTxBuilder(pparams)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tx, err := builder.
+		AddInput(inputUtxo).
+		AddRequiredSigner(xskey.VerificationKey().Hash()).
+		SetChangeAddress(addr).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ls := testLedgerState{utxos: []common.Utxo{inputUtxo}}
+	if err := conway.UtxowValidateMissingVKeyWitnesses(tx, 0, ls, pparams); err == nil {
+		t.Fatalf("unsigned transaction should fail validation")
+	}
+	signedTx, err := keys.SignTx(tx, skey, xskey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if signedTx.Hash() != tx.Hash() {
+		t.Errorf("transaction hash changed: got %s, wanted %s", signedTx.Hash(), tx.Hash())
+	}
+	for _, rule := range []common.UtxoValidationRuleFunc{
+		conway.UtxowValidateInvalidWitnesses,
+		conway.UtxowValidateMissingVKeyWitnesses,
+	} {
+		if err := rule(signedTx, 0, ls, pparams); err != nil {
+			t.Errorf("signed transaction should pass validation\n  got error: %v", err)
+		}
+	}
+	// Signing again with the same key should not add another witness
+	signedTx, err = keys.SignTx(signedTx, skey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(signedTx.Witnesses().Vkey()) != 2 {
+		t.Errorf("did not get expected number of witnesses: got %d, wanted 2", len(signedTx.Witnesses().Vkey()))
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+// TextEnvelope is the JSON format used by cardano-cli for storing keys
+type TextEnvelope struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	CborHex     string `json:"cborHex"`
+}
+
+// NewTextEnvelopeFromJson returns a TextEnvelope from its JSON representation
+func NewTextEnvelopeFromJson(data []byte) (*TextEnvelope, error) {
+	var ret TextEnvelope
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+	if ret.Type == "" {
+		return nil, fmt.Errorf("text envelope is missing type")
+	}
+	return &ret, nil
+}
+
+// ReadTextEnvelopeFile returns a TextEnvelope from the specified file
+func ReadTextEnvelopeFile(path string) (*TextEnvelope, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewTextEnvelopeFromJson(data)
+}
+
+// Json returns the JSON representation of the text envelope, using the same formatting as cardano-cli
+func (e *TextEnvelope) Json() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(e); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteFile writes the text envelope to the specified file. The file is only readable by the owner, since it
+// may contain a signing key
+func (e *TextEnvelope) WriteFile(path string) error {
+	data, err := e.Json()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// keyBytes returns the key bytes from the CBOR bytestring in the text envelope
+func (e *TextEnvelope) keyBytes() ([]byte, error) {
+	cborData, err := hex.DecodeString(e.CborHex)
+	if err != nil {
+		return nil, err
+	}
+	var ret []byte
+	if _, err := cbor.Decode(cborData, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func newTextEnvelope(envelopeType string, description string, keyBytes []byte) *TextEnvelope {
+	cborData, err := cbor.Encode(keyBytes)
+	if err != nil {
+		panic(fmt.Sprintf("unexpected error encoding key bytes as CBOR: %s", err))
+	}
+	return &TextEnvelope{
+		Type:        envelopeType,
+		Description: description,
+		CborHex:     hex.EncodeToString(cborData),
+	}
+}
+
+// TextEnvelope returns the signing key as a cardano-cli text envelope. Extended keys are stored along with the
+// public key and chain code
+func (k *SigningKey) TextEnvelope() *TextEnvelope {
+	keyBytes := k.Bytes()
+	if k.Extended() {
+		keyBytes = append(keyBytes, k.VerificationKey().Bytes()...)
+		keyBytes = append(keyBytes, k.chainCode...)
+	}
+	return newTextEnvelope(
+		k.Role.envelopeType(true, k.Extended()),
+		k.Role.envelopeDescription(true),
+		keyBytes,
+	)
+}
+
+// NewSigningKeyFromTextEnvelope returns the signing key from a cardano-cli text envelope
+func NewSigningKeyFromTextEnvelope(e *TextEnvelope) (*SigningKey, error) {
+	keyBytes, err := e.keyBytes()
+	if err != nil {
+		return nil, err
+	}
+	for role := range keyRoles {
+		switch e.Type {
+		case role.envelopeType(true, false):
+			return NewSigningKey(role, keyBytes)
+		case role.envelopeType(true, true):
+			// Extended private key, public key, chain code
+			if len(keyBytes) != ExtendedSigningKeySize+VerificationKeySize+ChainCodeSize {
+				return nil, fmt.Errorf("invalid extended signing key size: %d", len(keyBytes))
+			}
+			return NewExtendedSigningKey(
+				role,
+				keyBytes[:ExtendedSigningKeySize],
+				keyBytes[ExtendedSigningKeySize+VerificationKeySize:],
+			)
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key type: %s", e.Type)
+}
+
+// ReadSigningKeyFile returns the signing key from a cardano-cli text envelope file
+func ReadSigningKeyFile(path string) (*SigningKey, error) {
+	e, err := ReadTextEnvelopeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigningKeyFromTextEnvelope(e)
+}
+
+// TextEnvelope returns the verification key as a cardano-cli text envelope
+func (k *VerificationKey) TextEnvelope() *TextEnvelope {
+	return newTextEnvelope(
+		k.Role.envelopeType(false, k.Extended()),
+		k.Role.envelopeDescription(false),
+		append(k.Bytes(), k.chainCode...),
+	)
+}
+
+// NewVerificationKeyFromTextEnvelope returns the verification key from a cardano-cli text envelope
+func NewVerificationKeyFromTextEnvelope(e *TextEnvelope) (*VerificationKey, error) {
+	keyBytes, err := e.keyBytes()
+	if err != nil {
+		return nil, err
+	}
+	for role := range keyRoles {
+		switch e.Type {
+		case role.envelopeType(false, false):
+			return NewVerificationKey(role, keyBytes)
+		case role.envelopeType(false, true):
+			if len(keyBytes) != VerificationKeySize+ChainCodeSize {
+				return nil, fmt.Errorf("invalid extended verification key size: %d", len(keyBytes))
+			}
+			return NewExtendedVerificationKey(
+				role,
+				keyBytes[:VerificationKeySize],
+				keyBytes[VerificationKeySize:],
+			)
+		}
+	}
+	return nil, fmt.Errorf("unknown verification key type: %s", e.Type)
+}
+
+// ReadVerificationKeyFile returns the verification key from a cardano-cli text envelope file
+func ReadVerificationKeyFile(path string) (*VerificationKey, error) {
+	e, err := ReadTextEnvelopeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewVerificationKeyFromTextEnvelope(e)
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const (
+	// VrfSigningKeyEnvelopeType is the text envelope type for a VRF signing key
+	VrfSigningKeyEnvelopeType = "VrfSigningKey_PraosVRF"
+	// VrfVerificationKeyEnvelopeType is the text envelope type for a VRF verification key
+	VrfVerificationKeyEnvelopeType = "VrfVerificationKey_PraosVRF"
+)
+
+// VrfSigningKey is a stake pool VRF signing key, which is used to prove slot leadership
+type VrfSigningKey struct {
+	key []byte
+}
+
+// NewVrfSigningKey returns a VRF signing key from its 64-byte representation, which is the seed
+// followed by the verification key
+func NewVrfSigningKey(key []byte) (*VrfSigningKey, error) {
+	if len(key) != ledger.VrfSigningKeySize {
+		return nil, fmt.Errorf("invalid VRF signing key size: %d", len(key))
+	}
+	_, tmpKey, err := ledger.VrfKeyGenFromSeed(key[:ledger.VrfSeedSize])
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tmpKey, key) {
+		return nil, errors.New("VRF signing key does not match its verification key")
+	}
+	return &VrfSigningKey{
+		key: tmpKey,
+	}, nil
+}
+
+// NewVrfSigningKeyFromSeed returns a VRF signing key from a 32-byte seed
+func NewVrfSigningKeyFromSeed(seed []byte) (*VrfSigningKey, error) {
+	_, key, err := ledger.VrfKeyGenFromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+	return &VrfSigningKey{
+		key: key,
+	}, nil
+}
+
+// GenerateVrfSigningKey returns a new random VRF signing key
+func GenerateVrfSigningKey() (*VrfSigningKey, error) {
+	_, key, err := ledger.VrfKeyGen()
+	if err != nil {
+		return nil, err
+	}
+	return &VrfSigningKey{
+		key: key,
+	}, nil
+}
+
+// Bytes returns the key bytes, which are the seed followed by the verification key
+func (k *VrfSigningKey) Bytes() []byte {
+	return append([]byte{}, k.key...)
+}
+
+// VerificationKey returns the verification key corresponding to the signing key
+func (k *VrfSigningKey) VerificationKey() *VrfVerificationKey {
+	return &VrfVerificationKey{
+		key: append([]byte{}, k.key[ledger.VrfSeedSize:]...),
+	}
+}
+
+// Prove returns the VRF proof and output for the message
+func (k *VrfSigningKey) Prove(message []byte) ([]byte, []byte, error) {
+	return ledger.VrfProve(k.key, message)
+}
+
+// TextEnvelope returns the signing key as a cardano-cli text envelope
+func (k *VrfSigningKey) TextEnvelope() *TextEnvelope {
+	return newTextEnvelope(VrfSigningKeyEnvelopeType, "VRF Signing Key", k.key)
+}
+
+// NewVrfSigningKeyFromTextEnvelope returns the VRF signing key from a cardano-cli text envelope
+func NewVrfSigningKeyFromTextEnvelope(e *TextEnvelope) (*VrfSigningKey, error) {
+	if e.Type != VrfSigningKeyEnvelopeType {
+		return nil, fmt.Errorf("unexpected text envelope type for VRF signing key: %s", e.Type)
+	}
+	keyBytes, err := e.keyBytes()
+	if err != nil {
+		return nil, err
+	}
+	return NewVrfSigningKey(keyBytes)
+}
+
+// ReadVrfSigningKeyFile returns the VRF signing key from a cardano-cli text envelope file
+func ReadVrfSigningKeyFile(path string) (*VrfSigningKey, error) {
+	e, err := ReadTextEnvelopeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewVrfSigningKeyFromTextEnvelope(e)
+}
+
+// VrfVerificationKey is a stake pool VRF verification key
+type VrfVerificationKey struct {
+	key []byte
+}
+
+// NewVrfVerificationKey returns a VRF verification key from its bytes
+func NewVrfVerificationKey(key []byte) (*VrfVerificationKey, error) {
+	if len(key) != ledger.VrfVerificationKeySize {
+		return nil, fmt.Errorf("invalid VRF verification key size: %d", len(key))
+	}
+	return &VrfVerificationKey{
+		key: append([]byte{}, key...),
+	}, nil
+}
+
+// Bytes returns the key bytes
+func (k *VrfVerificationKey) Bytes() []byte {
+	return append([]byte{}, k.key...)
+}
+
+// Hash returns the Blake2b-256 hash of the key, which is used in pool registrations
+func (k *VrfVerificationKey) Hash() common.Blake2b256 {
+	return common.Blake2b256Hash(k.key)
+}
+
+// Verify checks the VRF proof for the message and returns the VRF output
+func (k *VrfVerificationKey) Verify(message []byte, proof []byte) ([]byte, error) {
+	return ledger.VrfVerifyAndHash(k.key, proof, message)
+}
+
+// TextEnvelope returns the verification key as a cardano-cli text envelope
+func (k *VrfVerificationKey) TextEnvelope() *TextEnvelope {
+	return newTextEnvelope(VrfVerificationKeyEnvelopeType, "VRF Verification Key", k.key)
+}
+
+// NewVrfVerificationKeyFromTextEnvelope returns the VRF verification key from a cardano-cli text
+// envelope
+func NewVrfVerificationKeyFromTextEnvelope(e *TextEnvelope) (*VrfVerificationKey, error) {
+	if e.Type != VrfVerificationKeyEnvelopeType {
+		return nil, fmt.Errorf("unexpected text envelope type for VRF verification key: %s", e.Type)
+	}
+	keyBytes, err := e.keyBytes()
+	if err != nil {
+		return nil, err
+	}
+	return NewVrfVerificationKey(keyBytes)
+}
+
+// ReadVrfVerificationKeyFile returns the VRF verification key from a cardano-cli text envelope file
+func ReadVrfVerificationKeyFile(path string) (*VrfVerificationKey, error) {
+	e, err := ReadTextEnvelopeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewVrfVerificationKeyFromTextEnvelope(e)
+}
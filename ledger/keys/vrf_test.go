@@ -0,0 +1,98 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/keys"
+)
+
+func TestVrfKeyTextEnvelope(t *testing.T) {
+	skey, err := keys.NewVrfSigningKeyFromSeed(testHexBytes(t, testSeedHex))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vkey := skey.VerificationKey()
+	skeyEnvelope := skey.TextEnvelope()
+	if skeyEnvelope.Type != "VrfSigningKey_PraosVRF" ||
+		skeyEnvelope.Description != "VRF Signing Key" ||
+		skeyEnvelope.CborHex != "5840"+testSeedHex+testPubKeyHex {
+		t.Fatalf("did not get expected signing key envelope: %#v", skeyEnvelope)
+	}
+	vkeyEnvelope := vkey.TextEnvelope()
+	if vkeyEnvelope.Type != "VrfVerificationKey_PraosVRF" ||
+		vkeyEnvelope.Description != "VRF Verification Key" ||
+		vkeyEnvelope.CborHex != "5820"+testPubKeyHex {
+		t.Fatalf("did not get expected verification key envelope: %#v", vkeyEnvelope)
+	}
+	// Round trip through files
+	dir := t.TempDir()
+	skeyPath := filepath.Join(dir, "vrf.skey")
+	vkeyPath := filepath.Join(dir, "vrf.vkey")
+	if err := skeyEnvelope.WriteFile(skeyPath); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := vkeyEnvelope.WriteFile(vkeyPath); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tmpSkey, err := keys.ReadVrfSigningKeyFile(skeyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(tmpSkey.Bytes(), skey.Bytes()) {
+		t.Fatalf("did not get expected signing key: %x", tmpSkey.Bytes())
+	}
+	tmpVkey, err := keys.ReadVrfVerificationKeyFile(vkeyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tmpVkey.Hash() != vkey.Hash() {
+		t.Fatalf("did not get expected verification key: %x", tmpVkey.Bytes())
+	}
+	t.Run("wrong type", func(t *testing.T) {
+		if _, err := keys.NewVrfSigningKeyFromTextEnvelope(vkeyEnvelope); err == nil {
+			t.Errorf("did not get expected error")
+		}
+	})
+	t.Run("mismatched verification key", func(t *testing.T) {
+		keyBytes := skey.Bytes()
+		keyBytes[len(keyBytes)-1] ^= 0x01
+		if _, err := keys.NewVrfSigningKey(keyBytes); err == nil {
+			t.Errorf("did not get expected error")
+		}
+	})
+}
+
+func TestVrfKeyProve(t *testing.T) {
+	skey, err := keys.GenerateVrfSigningKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	msg := []byte("test message")
+	proof, output, err := skey.Prove(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	verifyOutput, err := skey.VerificationKey().Verify(msg, proof)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(verifyOutput, output) {
+		t.Fatalf("did not get expected output from verification: %x", verifyOutput)
+	}
+}
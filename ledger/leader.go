@@ -0,0 +1,247 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const (
+	// Leader values are 256 bits for Praos (Babbage onward), which uses a hash of the VRF output
+	PraosLeaderValueBits = 256
+	// Leader values are 512 bits for TPraos (Shelley through Alonzo), which uses the VRF output directly
+	TPraosLeaderValueBits = 512
+)
+
+// PraosVrfLeaderValue returns the leader value for a Praos VRF output, which is the Blake2b-256 hash
+// of the output prefixed with "L"
+func PraosVrfLeaderValue(vrfOutput []byte) *big.Int {
+	tmpHash := common.Blake2b256Hash(append([]byte("L"), vrfOutput...))
+	return new(big.Int).SetBytes(tmpHash.Bytes())
+}
+
+// TPraosVrfLeaderValue returns the leader value for a TPraos leader VRF output, which is the output
+// itself
+func TPraosVrfLeaderValue(vrfOutput []byte) *big.Int {
+	return new(big.Int).SetBytes(vrfOutput)
+}
+
+// VrfLeaderThreshold returns the threshold below which a leader value wins a slot for a pool with
+// the specified relative stake (sigma) and active slot coefficient (f). The threshold is
+// 2^leaderValueBits * (1 - (1 - f)^sigma), and it is calculated exactly by narrowing rational bounds
+// on the result until they agree
+func VrfLeaderThreshold(
+	relativeStake *big.Rat,
+	activeSlotCoeff *big.Rat,
+	leaderValueBits uint,
+) (*big.Int, error) {
+	if relativeStake == nil || relativeStake.Sign() < 0 {
+		return nil, errors.New("relative stake must not be negative")
+	}
+	if activeSlotCoeff == nil || activeSlotCoeff.Sign() <= 0 ||
+		activeSlotCoeff.Cmp(big.NewRat(1, 1)) > 0 {
+		return nil, errors.New("active slot coefficient must be greater than 0 and at most 1")
+	}
+	certNatMax := new(big.Int).Lsh(big.NewInt(1), leaderValueBits)
+	// No stake never wins, and an active slot coefficient of 1 always wins
+	if relativeStake.Sign() == 0 {
+		return big.NewInt(0), nil
+	}
+	if activeSlotCoeff.Cmp(big.NewRat(1, 1)) == 0 {
+		return certNatMax, nil
+	}
+	certNatMaxRat := new(big.Rat).SetInt(certNatMax)
+	for i := uint(0); i < maxLeaderThresholdIterations; i++ {
+		prec := leaderValueBits + 64 + i*256
+		lo, hi := leaderProbabilityBounds(relativeStake, activeSlotCoeff, prec)
+		loThreshold := ratCeil(new(big.Rat).Mul(lo, certNatMaxRat))
+		hiThreshold := ratCeil(new(big.Rat).Mul(hi, certNatMaxRat))
+		if loThreshold.Cmp(hiThreshold) == 0 {
+			return loThreshold, nil
+		}
+		// The bounds never agree when the exact value is a whole number, since they stay on either
+		// side of it. When only one whole number is between them, it is settled with an exact check
+		if new(big.Int).Sub(hiThreshold, loThreshold).Cmp(big.NewInt(1)) == 0 {
+			candidate := new(big.Rat).SetFrac(loThreshold, certNatMax)
+			if cmp, ok := compareLeaderProbability(relativeStake, activeSlotCoeff, candidate); ok {
+				if cmp > 0 {
+					return hiThreshold, nil
+				}
+				return loThreshold, nil
+			}
+		}
+	}
+	return nil, errors.New("leader threshold could not be determined")
+}
+
+// maxLeaderThresholdIterations is the number of times the precision is increased when calculating
+// the leader threshold before giving up
+const maxLeaderThresholdIterations = 16
+
+// maxExactComparisonBits limits the size of the powers calculated by compareLeaderProbability
+const maxExactComparisonBits = 1 << 20
+
+// compareLeaderProbability compares 1 - (1 - f)^sigma with q exactly, which is done by comparing
+// (1 - f)^a with (1 - q)^b for sigma = a / b. It returns false if the powers would be too large
+func compareLeaderProbability(sigma *big.Rat, f *big.Rat, q *big.Rat) (int, bool) {
+	base := new(big.Rat).Sub(big.NewRat(1, 1), f)
+	r := new(big.Rat).Sub(big.NewRat(1, 1), q)
+	if r.Sign() <= 0 {
+		// The probability is always less than 1
+		return -1, true
+	}
+	if !sigma.Num().IsInt64() || !sigma.Denom().IsInt64() {
+		return 0, false
+	}
+	a, b := sigma.Num().Int64(), sigma.Denom().Int64()
+	baseBits := int64(base.Num().BitLen() + base.Denom().BitLen())
+	rBits := int64(r.Num().BitLen() + r.Denom().BitLen())
+	if a > maxExactComparisonBits/baseBits || b > maxExactComparisonBits/rBits {
+		return 0, false
+	}
+	lhs := ratPow(base, a)
+	rhs := ratPow(r, b)
+	// 1 - (1 - f)^sigma > q if and only if (1 - f)^sigma < 1 - q
+	return rhs.Cmp(lhs), true
+}
+
+func ratPow(r *big.Rat, n int64) *big.Rat {
+	num := new(big.Int).Exp(r.Num(), big.NewInt(n), nil)
+	denom := new(big.Int).Exp(r.Denom(), big.NewInt(n), nil)
+	return new(big.Rat).SetFrac(num, denom)
+}
+
+// leaderProbabilityBounds returns bounds on 1 - (1 - f)^sigma that are within 2^-prec of each other
+func leaderProbabilityBounds(sigma *big.Rat, f *big.Rat, prec uint) (*big.Rat, *big.Rat) {
+	// (1 - f)^sigma = exp(-sigma * c), where c = -ln(1 - f)
+	cLo, cHi := negLnOneMinusBounds(f, prec+8)
+	xLo := ratFloorDyadic(new(big.Rat).Mul(sigma, cLo), prec+8)
+	xHi := ratCeilDyadic(new(big.Rat).Mul(sigma, cHi), prec+8)
+	// exp(-x) is decreasing in x
+	expLo, _ := expNegBounds(xHi, prec+8)
+	_, expHi := expNegBounds(xLo, prec+8)
+	one := big.NewRat(1, 1)
+	return new(big.Rat).Sub(one, expHi), new(big.Rat).Sub(one, expLo)
+}
+
+// negLnOneMinusBounds returns bounds on -ln(1 - f) for 0 < f < 1. This uses the series
+// -ln(1 - f) = 2 * atanh(z) = 2 * sum(z^(2k+1) / (2k+1)) with z = f / (2 - f)
+func negLnOneMinusBounds(f *big.Rat, prec uint) (*big.Rat, *big.Rat) {
+	z := new(big.Rat).Quo(f, new(big.Rat).Sub(big.NewRat(2, 1), f))
+	zSquared := new(big.Rat).Mul(z, z)
+	// Bound on the remainder after the current term is 1 / (1 - z^2) times the next term
+	remainderFactor := new(big.Rat).Inv(new(big.Rat).Sub(big.NewRat(1, 1), zSquared))
+	epsilon := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), prec))
+	roundingError := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), prec+64))
+	sum := new(big.Rat)
+	power := new(big.Rat).Set(z)
+	for k := int64(0); ; k++ {
+		sum.Add(sum, new(big.Rat).Quo(power, big.NewRat(2*k+1, 1)))
+		power.Mul(power, zSquared)
+		remainder := new(big.Rat).Quo(power, big.NewRat(2*k+3, 1))
+		remainder.Mul(remainder, remainderFactor)
+		if remainder.Cmp(epsilon) < 0 {
+			lo := ratFloorDyadic(new(big.Rat).Mul(sum, big.NewRat(2, 1)), prec)
+			// Account for rounding down the partial sum on each previous iteration
+			hi := new(big.Rat).Add(sum, remainder)
+			hi.Add(hi, new(big.Rat).Mul(roundingError, big.NewRat(k, 1)))
+			hi = ratCeilDyadic(hi.Mul(hi, big.NewRat(2, 1)), prec)
+			return lo, hi
+		}
+		// Keep the size of the partial sum under control
+		sum = ratFloorDyadic(sum, prec+64)
+	}
+}
+
+// expNegBounds returns bounds on exp(-x) for x >= 0 using the Taylor series. Once the terms are
+// decreasing, the value is between consecutive partial sums of the alternating series. Terms are
+// rounded to keep their size under control, and the bounds are widened by the accumulated error
+func expNegBounds(x *big.Rat, prec uint) (*big.Rat, *big.Rat) {
+	if x.Cmp(big.NewRat(1, 1)) > 0 {
+		return expNegBoundsReduced(x, prec)
+	}
+	epsilon := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), prec))
+	sum := big.NewRat(1, 1)
+	term := big.NewRat(1, 1)
+	for n := int64(1); ; n++ {
+		term.Mul(term, x)
+		term.Quo(term, big.NewRat(n, 1))
+		term = ratFloorDyadic(term, prec+64)
+		prevSum := new(big.Rat).Set(sum)
+		if n%2 == 1 {
+			sum.Sub(sum, term)
+		} else {
+			sum.Add(sum, term)
+		}
+		// Terms are decreasing once n > x
+		if new(big.Rat).SetInt64(n).Cmp(x) > 0 && term.Cmp(epsilon) < 0 {
+			lo, hi := sum, prevSum
+			if prevSum.Cmp(sum) < 0 {
+				lo, hi = prevSum, sum
+			}
+			// The rounding error of each term is at most 2^-(prec+64) plus the error of the previous
+			// term scaled by x/n, which is bounded by n * e^x * 2^-(prec+64) for every term
+			expBound := new(big.Int).Exp(big.NewInt(3), ratCeil(x), nil)
+			roundingError := new(big.Rat).SetFrac(
+				new(big.Int).Mul(expBound, big.NewInt(n*n)),
+				new(big.Int).Lsh(big.NewInt(1), prec+64),
+			)
+			return lo.Sub(lo, roundingError), hi.Add(hi, roundingError)
+		}
+	}
+}
+
+// expNegBoundsReduced returns bounds on exp(-x) for x > 1 using exp(-x) = exp(-x / 2^k)^(2^k), so
+// that the series is only used for values of at most 1. The absolute error at most doubles with each
+// squaring, which is covered by the extra precision
+func expNegBoundsReduced(x *big.Rat, prec uint) (*big.Rat, *big.Rat) {
+	k := uint(ratCeil(x).BitLen())
+	innerPrec := prec + k + 8
+	lo, hi := expNegBounds(
+		new(big.Rat).Quo(x, new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), k))),
+		innerPrec,
+	)
+	for i := uint(0); i < k; i++ {
+		lo = ratFloorDyadic(new(big.Rat).Mul(lo, lo), innerPrec)
+		hi = ratCeilDyadic(new(big.Rat).Mul(hi, hi), innerPrec)
+	}
+	return lo, hi
+}
+
+// ratFloorDyadic rounds down to a multiple of 2^-bits
+func ratFloorDyadic(r *big.Rat, bits uint) *big.Rat {
+	scale := new(big.Int).Lsh(big.NewInt(1), bits)
+	num := new(big.Int).Mul(r.Num(), scale)
+	num.Div(num, r.Denom())
+	return new(big.Rat).SetFrac(num, scale)
+}
+
+// ratCeilDyadic rounds up to a multiple of 2^-bits
+func ratCeilDyadic(r *big.Rat, bits uint) *big.Rat {
+	scale := new(big.Int).Lsh(big.NewInt(1), bits)
+	return new(big.Rat).SetFrac(ratCeil(new(big.Rat).Mul(r, new(big.Rat).SetInt(scale))), scale)
+}
+
+func ratCeil(r *big.Rat) *big.Int {
+	// Euclidean division rounds down for a positive denominator
+	q, m := new(big.Int).DivMod(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
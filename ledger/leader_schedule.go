@@ -0,0 +1,90 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger
+
+import (
+	"errors"
+	"math/big"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// LeaderScheduleParams contains the parameters for calculating the Praos leader schedule for a pool
+// in an epoch
+type LeaderScheduleParams struct {
+	// EpochNonce is the nonce for the epoch. An empty value is the neutral nonce
+	EpochNonce     []byte
+	EpochFirstSlot uint64
+	EpochLength    uint64
+	// RelativeStake is the pool's share of the active stake (sigma) in the stake distribution used
+	// for the epoch
+	RelativeStake *big.Rat
+	// ActiveSlotCoeff is the active slot coefficient (f) from the Shelley genesis
+	ActiveSlotCoeff *big.Rat
+}
+
+// CalculateLeaderSchedule returns the slots in the epoch for which the pool with the specified VRF
+// signing key is a Praos slot leader. The VRF is evaluated for every slot in the epoch, and each
+// leader value is compared against the exact threshold for the pool's relative stake
+func CalculateLeaderSchedule(vrfSigningKey []byte, params LeaderScheduleParams) ([]uint64, error) {
+	if params.EpochLength == 0 {
+		return nil, errors.New("epoch length must be specified")
+	}
+	x, _, Y, err := decodeVrfSigningKey(vrfSigningKey)
+	if err != nil {
+		return nil, err
+	}
+	threshold, err := VrfLeaderThreshold(
+		params.RelativeStake,
+		params.ActiveSlotCoeff,
+		PraosLeaderValueBits,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// The slots are split into contiguous ranges that are evaluated in parallel
+	workers := uint64(runtime.GOMAXPROCS(0))
+	if workers > params.EpochLength {
+		workers = params.EpochLength
+	}
+	rangeSize := (params.EpochLength + workers - 1) / workers
+	results := make([][]uint64, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for worker := uint64(0); worker < workers; worker++ {
+		startSlot := params.EpochFirstSlot + worker*rangeSize
+		endSlot := min(startSlot+rangeSize, params.EpochFirstSlot+params.EpochLength)
+		wg.Add(1)
+		go func(worker uint64) {
+			defer wg.Done()
+			for slot := startSlot; slot < endSlot; slot++ {
+				output, err := vrfOutputWithKey(x, Y, MkInputVrf(int64(slot), params.EpochNonce))
+				if err != nil {
+					errs[worker] = err
+					return
+				}
+				if PraosVrfLeaderValue(output).Cmp(threshold) < 0 {
+					results[worker] = append(results[worker], slot)
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return slices.Concat(results...), nil
+}
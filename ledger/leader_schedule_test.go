@@ -0,0 +1,111 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+func TestCalculateLeaderSchedule(t *testing.T) {
+	seed, _ := hex.DecodeString("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	pk, sk, err := ledger.VrfKeyGenFromSeed(seed)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	params := ledger.LeaderScheduleParams{
+		EpochNonce:      bytes.Repeat([]byte{0xab}, 32),
+		EpochFirstSlot:  86400,
+		EpochLength:     1000,
+		RelativeStake:   big.NewRat(1, 2),
+		ActiveSlotCoeff: big.NewRat(1, 20),
+	}
+	schedule, err := ledger.CalculateLeaderSchedule(sk, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// About 2.5% of slots are expected for half of the stake
+	if len(schedule) < 10 || len(schedule) > 50 {
+		t.Fatalf("did not get expected number of leader slots: %d", len(schedule))
+	}
+	if !slices.IsSorted(schedule) {
+		t.Fatalf("leader slots are not sorted: %v", schedule)
+	}
+	// Check every slot against a full VRF proof
+	threshold, err := ledger.VrfLeaderThreshold(
+		params.RelativeStake,
+		params.ActiveSlotCoeff,
+		ledger.PraosLeaderValueBits,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for slot := params.EpochFirstSlot; slot < params.EpochFirstSlot+params.EpochLength; slot++ {
+		input := ledger.MkInputVrf(int64(slot), params.EpochNonce)
+		proof, _, err := ledger.VrfProve(sk, input)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		output, err := ledger.VrfVerifyAndHash(pk, proof, input)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		isLeader := ledger.PraosVrfLeaderValue(output).Cmp(threshold) < 0
+		if isLeader != slices.Contains(schedule, slot) {
+			t.Fatalf("leader schedule does not match VRF proof for slot %d", slot)
+		}
+	}
+}
+
+func TestCalculateLeaderScheduleBounds(t *testing.T) {
+	_, sk, err := ledger.VrfKeyGen()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	params := ledger.LeaderScheduleParams{
+		EpochFirstSlot:  100,
+		EpochLength:     10,
+		RelativeStake:   big.NewRat(0, 1),
+		ActiveSlotCoeff: big.NewRat(1, 20),
+	}
+	// No stake
+	schedule, err := ledger.CalculateLeaderSchedule(sk, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(schedule) != 0 {
+		t.Fatalf("did not expect leader slots: %v", schedule)
+	}
+	// Every slot is active
+	params.RelativeStake = big.NewRat(1, 100)
+	params.ActiveSlotCoeff = big.NewRat(1, 1)
+	schedule, err = ledger.CalculateLeaderSchedule(sk, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !slices.Equal(schedule, []uint64{100, 101, 102, 103, 104, 105, 106, 107, 108, 109}) {
+		t.Fatalf("did not get expected leader slots: %v", schedule)
+	}
+	// No epoch length
+	params.EpochLength = 0
+	if _, err := ledger.CalculateLeaderSchedule(sk, params); err == nil {
+		t.Fatalf("did not get expected error")
+	}
+}
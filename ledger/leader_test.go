@@ -0,0 +1,130 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+func TestVrfLeaderThreshold(t *testing.T) {
+	testDefs := []struct {
+		relativeStake   *big.Rat
+		activeSlotCoeff *big.Rat
+		bits            uint
+		expected        *big.Int
+	}{
+		{
+			relativeStake:   big.NewRat(0, 1),
+			activeSlotCoeff: big.NewRat(1, 20),
+			bits:            ledger.PraosLeaderValueBits,
+			expected:        big.NewInt(0),
+		},
+		{
+			relativeStake:   big.NewRat(1, 3),
+			activeSlotCoeff: big.NewRat(1, 1),
+			bits:            8,
+			expected:        big.NewInt(256),
+		},
+		{
+			// 2^256 / 20, rounded up
+			relativeStake:   big.NewRat(1, 1),
+			activeSlotCoeff: big.NewRat(1, 20),
+			bits:            ledger.PraosLeaderValueBits,
+			expected: new(big.Int).Add(
+				new(big.Int).Div(new(big.Int).Lsh(big.NewInt(1), 254), big.NewInt(5)),
+				big.NewInt(1),
+			),
+		},
+		{
+			// 256 * (1 - sqrt(1/2)) = 74.98...
+			relativeStake:   big.NewRat(1, 2),
+			activeSlotCoeff: big.NewRat(1, 2),
+			bits:            8,
+			expected:        big.NewInt(75),
+		},
+		{
+			// 2^8 * (1 - (1/2)^1) = 128 exactly
+			relativeStake:   big.NewRat(1, 1),
+			activeSlotCoeff: big.NewRat(1, 2),
+			bits:            8,
+			expected:        big.NewInt(128),
+		},
+		{
+			// 2^256 * (1 - (1/2)^1) = 2^255 exactly
+			relativeStake:   big.NewRat(1, 1),
+			activeSlotCoeff: big.NewRat(1, 2),
+			bits:            ledger.PraosLeaderValueBits,
+			expected:        new(big.Int).Lsh(big.NewInt(1), 255),
+		},
+		{
+			// 2^256 * (1 - (1/2)^2) = 3 * 2^254 exactly
+			relativeStake:   big.NewRat(2, 1),
+			activeSlotCoeff: big.NewRat(1, 2),
+			bits:            ledger.PraosLeaderValueBits,
+			expected:        new(big.Int).Lsh(big.NewInt(3), 254),
+		},
+		{
+			// 2^256 * (1 - (1/2)^(2^40)) rounds up to 2^256
+			relativeStake:   big.NewRat(1<<40, 1),
+			activeSlotCoeff: big.NewRat(1, 2),
+			bits:            ledger.PraosLeaderValueBits,
+			expected:        new(big.Int).Lsh(big.NewInt(1), 256),
+		},
+		{
+			// 2^512 * (1 - (1/4)^(1/2)) = 2^511 exactly
+			relativeStake:   big.NewRat(1, 2),
+			activeSlotCoeff: big.NewRat(3, 4),
+			bits:            ledger.TPraosLeaderValueBits,
+			expected:        new(big.Int).Lsh(big.NewInt(1), 511),
+		},
+		{
+			// 2^16 * (1 - 0.95^0.001) = 3.3615...
+			relativeStake:   big.NewRat(1, 1000),
+			activeSlotCoeff: big.NewRat(1, 20),
+			bits:            16,
+			expected:        big.NewInt(4),
+		},
+	}
+	for _, testDef := range testDefs {
+		threshold, err := ledger.VrfLeaderThreshold(
+			testDef.relativeStake,
+			testDef.activeSlotCoeff,
+			testDef.bits,
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if threshold.Cmp(testDef.expected) != 0 {
+			t.Fatalf(
+				"did not get expected threshold for stake %s: got %s, expected %s",
+				testDef.relativeStake,
+				threshold,
+				testDef.expected,
+			)
+		}
+	}
+}
+
+func TestVrfLeaderThresholdInvalid(t *testing.T) {
+	if _, err := ledger.VrfLeaderThreshold(big.NewRat(-1, 2), big.NewRat(1, 20), 256); err == nil {
+		t.Fatalf("did not get expected error for negative stake")
+	}
+	if _, err := ledger.VrfLeaderThreshold(big.NewRat(1, 2), big.NewRat(3, 2), 256); err == nil {
+		t.Fatalf("did not get expected error for invalid active slot coefficient")
+	}
+}
@@ -27,8 +27,8 @@ type OutputTooBigUtxoError struct {
 
 func (e OutputTooBigUtxoError) Error() string {
 	tmpOutputs := make([]string, 0, len(e.Outputs))
-	for idx, tmpOutput := range e.Outputs {
-		tmpOutputs[idx] = fmt.Sprintf("%#v", tmpOutput)
+	for _, tmpOutput := range e.Outputs {
+		tmpOutputs = append(tmpOutputs, fmt.Sprintf("%#v", tmpOutput))
 	}
 	return fmt.Sprintf(
 		"output value too large: %s",
@@ -110,11 +110,14 @@ func (b *MaryBlock) Transactions() []common.Transaction {
 	return ret
 }
 
-func (b *MaryBlock) Utxorpc() *utxorpc.Block {
+func (b *MaryBlock) Utxorpc() (*utxorpc.Block, error) {
 	var txs []*utxorpc.Tx
 	tmpHash, _ := hex.DecodeString(b.Hash())
 	for _, t := range b.Transactions() {
-		tx := t.Utxorpc()
+		tx, err := t.Utxorpc()
+		if err != nil {
+			return nil, err
+		}
 		txs = append(txs, tx)
 	}
 	body := &utxorpc.BlockBody{
@@ -129,7 +132,7 @@ func (b *MaryBlock) Utxorpc() *utxorpc.Block {
 		Body:   body,
 		Header: header,
 	}
-	return block
+	return block, nil
 }
 
 type MaryBlockHeader struct {
@@ -328,7 +331,7 @@ func (t *MaryTransaction) Cbor() []byte {
 	return cborData
 }
 
-func (t *MaryTransaction) Utxorpc() *utxorpc.Tx {
+func (t *MaryTransaction) Utxorpc() (*utxorpc.Tx, error) {
 	return t.Body.Utxorpc()
 }
 
@@ -372,12 +375,16 @@ func (o MaryTransactionOutput) Datum() *cbor.LazyValue {
 	return nil
 }
 
-func (o MaryTransactionOutput) Utxorpc() *utxorpc.TxOutput {
+func (o MaryTransactionOutput) Utxorpc() (*utxorpc.TxOutput, error) {
+	addressBytes, err := o.OutputAddress.Bytes()
+	if err != nil {
+		return nil, err
+	}
 	return &utxorpc.TxOutput{
-		Address: o.OutputAddress.Bytes(),
+		Address: addressBytes,
 		Coin:    o.Amount(),
 		// Assets: o.Assets,
-	}
+	}, nil
 }
 
 type MaryTransactionOutputValue struct {
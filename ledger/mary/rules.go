@@ -41,6 +41,24 @@ var UtxoValidationRules = []common.UtxoValidationRuleFunc{
 	UtxoValidateMaxTxSizeUtxo,
 }
 
+var UtxowValidationRules = []common.UtxoValidationRuleFunc{
+	UtxowValidateInvalidWitnesses,
+	UtxowValidateMissingVKeyWitnesses,
+	UtxowValidateMissingScriptWitnesses,
+	UtxowValidateScriptWitnessNotValidating,
+	UtxowValidateMetadataHash,
+}
+
+var CertValidationRules = []common.UtxoValidationRuleFunc{
+	DelegValidateWithdrawalsNotInRewards,
+	DelegValidateStakeKeyAlreadyRegistered,
+	DelegValidateStakeKeyNotRegistered,
+	DelegValidateStakeKeyNonZeroAccountBalance,
+	DelegValidateDelegateeNotRegistered,
+	PoolValidateStakePoolNotRegisteredOnKey,
+	PoolValidateStakePoolRetirementWrongEpoch,
+}
+
 // UtxoValidateOutputTooBigUtxo ensures that transaction output values are not too large
 func UtxoValidateOutputTooBigUtxo(tx common.Transaction, slot uint64, _ common.LedgerState, _ common.ProtocolParameters) error {
 	var badOutputs []common.TransactionOutput
@@ -121,3 +139,55 @@ func UtxoValidateMaxTxSizeUtxo(tx common.Transaction, slot uint64, ls common.Led
 	}
 	return shelley.UtxoValidateMaxTxSizeUtxo(tx, slot, ls, &tmpPparams.ShelleyProtocolParameters)
 }
+
+func UtxowValidateInvalidWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.UtxowValidateInvalidWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateMissingVKeyWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.UtxowValidateMissingVKeyWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateMissingScriptWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.UtxowValidateMissingScriptWitnesses(tx, slot, ls, pp)
+}
+
+func UtxowValidateScriptWitnessNotValidating(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.UtxowValidateScriptWitnessNotValidating(tx, slot, ls, pp)
+}
+
+func UtxowValidateMetadataHash(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.UtxowValidateMetadataHash(tx, slot, ls, pp)
+}
+
+func DelegValidateWithdrawalsNotInRewards(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.DelegValidateWithdrawalsNotInRewards(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyAlreadyRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.DelegValidateStakeKeyAlreadyRegistered(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.DelegValidateStakeKeyNotRegistered(tx, slot, ls, pp)
+}
+
+func DelegValidateStakeKeyNonZeroAccountBalance(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.DelegValidateStakeKeyNonZeroAccountBalance(tx, slot, ls, pp)
+}
+
+func DelegValidateDelegateeNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.DelegValidateDelegateeNotRegistered(tx, slot, ls, pp)
+}
+
+func PoolValidateStakePoolNotRegisteredOnKey(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return allegra.PoolValidateStakePoolNotRegisteredOnKey(tx, slot, ls, pp)
+}
+
+func PoolValidateStakePoolRetirementWrongEpoch(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*MaryProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	return allegra.PoolValidateStakePoolRetirementWrongEpoch(tx, slot, ls, &tmpPparams.AllegraProtocolParameters)
+}
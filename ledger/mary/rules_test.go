@@ -30,8 +30,12 @@ import (
 )
 
 type testLedgerState struct {
-	networkId uint
-	utxos     []common.Utxo
+	networkId          uint
+	utxos              []common.Utxo
+	stakeRegistrations map[common.Blake2b224]*common.StakeRegistration
+	poolRegistrations  map[common.PoolKeyHash]*common.PoolRegistration
+	drepRegistrations  map[common.Blake2b224]*common.DrepRegistration
+	committeeMembers   map[common.Blake2b224]*common.CommitteeMember
 }
 
 func (ls testLedgerState) NetworkId() uint {
@@ -51,6 +55,22 @@ func (ls testLedgerState) UtxoById(id common.TransactionInput) (common.Utxo, err
 	return common.Utxo{}, fmt.Errorf("not found")
 }
 
+func (ls testLedgerState) StakeRegistration(cred common.Blake2b224) (*common.StakeRegistration, error) {
+	return ls.stakeRegistrations[cred], nil
+}
+
+func (ls testLedgerState) PoolRegistration(pool common.PoolKeyHash) (*common.PoolRegistration, error) {
+	return ls.poolRegistrations[pool], nil
+}
+
+func (ls testLedgerState) DrepRegistration(cred common.Blake2b224) (*common.DrepRegistration, error) {
+	return ls.drepRegistrations[cred], nil
+}
+
+func (ls testLedgerState) CommitteeMember(cred common.Blake2b224) (*common.CommitteeMember, error) {
+	return ls.committeeMembers[cred], nil
+}
+
 func TestUtxoValidateOutsideValidityIntervalUtxo(t *testing.T) {
 	var testSlot uint64 = 555666777
 	var testZeroSlot uint64 = 0
@@ -640,6 +660,77 @@ func TestUtxoValidateValueNotConservedUtxo(t *testing.T) {
 			)
 		},
 	)
+	// Minted assets
+	testPolicyId := common.NewBlake2b224(make([]byte, 28))
+	testMint := common.NewMultiAsset[common.MultiAssetTypeMint](
+		map[common.Blake2b224]map[cbor.ByteString]int64{
+			testPolicyId: {
+				cbor.NewByteString([]byte("token")): 100,
+			},
+		},
+	)
+	testOutputAssets := common.NewMultiAsset[common.MultiAssetTypeOutput](
+		map[common.Blake2b224]map[cbor.ByteString]uint64{
+			testPolicyId: {
+				cbor.NewByteString([]byte("token")): 100,
+			},
+		},
+	)
+	t.Run(
+		"minted assets in output",
+		func(t *testing.T) {
+			testTx.Body.TxOutputs[0].OutputAmount.Amount = testOutputExactAmount
+			testTx.Body.TxOutputs[0].OutputAmount.Assets = &testOutputAssets
+			testTx.Body.TxMint = &testMint
+			defer func() {
+				testTx.Body.TxOutputs[0].OutputAmount.Assets = nil
+				testTx.Body.TxMint = nil
+			}()
+			err := mary.UtxoValidateValueNotConservedUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxoValidateValueNotConservedUtxo should succeed when minted assets are in the outputs\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	t.Run(
+		"assets in output not minted",
+		func(t *testing.T) {
+			testTx.Body.TxOutputs[0].OutputAmount.Amount = testOutputExactAmount
+			testTx.Body.TxOutputs[0].OutputAmount.Assets = &testOutputAssets
+			defer func() {
+				testTx.Body.TxOutputs[0].OutputAmount.Assets = nil
+			}()
+			err := mary.UtxoValidateValueNotConservedUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxoValidateValueNotConservedUtxo should fail when output assets are not consumed or minted",
+				)
+				return
+			}
+			testErrType := shelley.ValueNotConservedUtxoError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
 }
 
 func TestUtxoValidateOutputTooSmallUtxo(t *testing.T) {
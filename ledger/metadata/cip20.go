@@ -0,0 +1,97 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// LabelCip20 is the metadata label for CIP-20 transaction messages
+const LabelCip20 = 674
+
+// Cip20Message is a transaction message as defined by CIP-20
+type Cip20Message struct {
+	// Messages contains the lines of the message, each of which is at most 64 bytes. For encrypted
+	// messages, this contains the chunks of the base64-encoded ciphertext
+	Messages []string
+	// Encryption is the encryption method for an encrypted message, such as "basic", or empty if the
+	// message is not encrypted
+	Encryption string
+}
+
+// NewCip20MessageFromMetadata parses the CIP-20 message from transaction metadata
+func NewCip20MessageFromMetadata(metadata common.TransactionMetadata) (*Cip20Message, error) {
+	md, err := labelValue(metadata, LabelCip20)
+	if err != nil {
+		return nil, err
+	}
+	return NewCip20MessageFromMetadatum(md)
+}
+
+// NewCip20MessageFromMetadatum parses a CIP-20 message from the value for its metadata label
+func NewCip20MessageFromMetadatum(md common.TransactionMetadatum) (*Cip20Message, error) {
+	if md.Type() != common.TransactionMetadatumTypeMap {
+		return nil, errors.New("invalid CIP-20 message: expected map")
+	}
+	msg, ok := md.MapValue(textKey("msg"))
+	if !ok {
+		return nil, errors.New("invalid CIP-20 message: missing msg")
+	}
+	messages, err := metadatumStringList(msg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIP-20 message: %w", err)
+	}
+	ret := &Cip20Message{
+		Messages: messages,
+	}
+	if enc, ok := md.MapValue(textKey("enc")); ok {
+		if ret.Encryption, ok = enc.Text(); !ok {
+			return nil, errors.New("invalid CIP-20 message: expected text for enc")
+		}
+	}
+	return ret, nil
+}
+
+// Metadatum returns the value for the CIP-20 metadata label
+func (m *Cip20Message) Metadatum() (common.TransactionMetadatum, error) {
+	items := make([]common.TransactionMetadatum, len(m.Messages))
+	for idx, message := range m.Messages {
+		if len(message) > common.MetadatumMaxStringSize {
+			return common.TransactionMetadatum{}, fmt.Errorf("CIP-20 message line too long: %d bytes", len(message))
+		}
+		items[idx] = common.NewMetadatumText(message)
+	}
+	var pairs []common.TransactionMetadatumMapPair
+	if m.Encryption != "" {
+		pairs = append(
+			pairs,
+			common.TransactionMetadatumMapPair{
+				Key:   textKey("enc"),
+				Value: common.NewMetadatumText(m.Encryption),
+			},
+		)
+	}
+	pairs = append(
+		pairs,
+		common.TransactionMetadatumMapPair{
+			Key:   textKey("msg"),
+			Value: common.NewMetadatumList(items...),
+		},
+	)
+	return common.NewMetadatumMap(pairs...), nil
+}
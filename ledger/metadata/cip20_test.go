@@ -0,0 +1,90 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/metadata"
+)
+
+func TestCip20Message(t *testing.T) {
+	md, err := common.NewTransactionMetadataFromNoSchemaJSON(
+		[]byte(`{"674":{"msg":["Invoice-No: 1234","Thank you"]}}`),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	msg, err := metadata.NewCip20MessageFromMetadata(md)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !slices.Equal(msg.Messages, []string{"Invoice-No: 1234", "Thank you"}) {
+		t.Errorf("did not get expected messages: %v", msg.Messages)
+	}
+	if msg.Encryption != "" {
+		t.Errorf("did not get expected encryption: %s", msg.Encryption)
+	}
+	// Round-trip
+	tmpMd, err := msg.Metadatum()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !tmpMd.Equal(md[metadata.LabelCip20]) {
+		t.Errorf("metadata value does not match after round-trip")
+	}
+}
+
+func TestCip20MessageEncrypted(t *testing.T) {
+	md, err := common.NewTransactionMetadatumFromNoSchemaJSON(
+		[]byte(`{"enc":"basic","msg":["q8Zv","kA=="]}`),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	msg, err := metadata.NewCip20MessageFromMetadatum(md)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if msg.Encryption != "basic" {
+		t.Errorf("did not get expected encryption: %s", msg.Encryption)
+	}
+	if len(msg.Messages) != 2 {
+		t.Errorf("did not get expected messages: %v", msg.Messages)
+	}
+}
+
+func TestCip20MessageErrors(t *testing.T) {
+	if _, err := metadata.NewCip20MessageFromMetadata(common.TransactionMetadata{}); !errors.Is(err, metadata.ErrLabelNotFound) {
+		t.Errorf("did not get expected error: %v", err)
+	}
+	md, err := common.NewTransactionMetadatumFromNoSchemaJSON([]byte(`{"msg":"not a list"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := metadata.NewCip20MessageFromMetadatum(md); err == nil {
+		t.Errorf("did not get expected error for invalid message")
+	}
+	msg := &metadata.Cip20Message{
+		Messages: []string{strings.Repeat("a", 65)},
+	}
+	if _, err := msg.Metadatum(); err == nil {
+		t.Errorf("did not get expected error for long line")
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// LabelCip25 is the metadata label for CIP-25 NFT metadata
+const LabelCip25 = 721
+
+// Cip25Metadata is the NFT metadata for the assets minted by a transaction, as defined by CIP-25
+type Cip25Metadata struct {
+	// Version is the metadata version. Version 1 uses hex-encoded text for policy IDs and UTF-8 text
+	// for asset names, while version 2 uses raw bytes for both
+	Version int
+	Assets  []Cip25Asset
+}
+
+// Cip25Asset is the metadata for a single asset
+type Cip25Asset struct {
+	PolicyId    common.Blake2b224
+	AssetName   []byte
+	Name        string
+	Image       string
+	MediaType   string
+	Description string
+	Files       []Cip25File
+	// Raw is the full metadata value for the asset, which includes any additional properties
+	Raw common.TransactionMetadatum
+}
+
+// Cip25File is an entry in the files property of an asset
+type Cip25File struct {
+	Name      string
+	MediaType string
+	Src       string
+}
+
+// NewCip25MetadataFromMetadata parses the CIP-25 NFT metadata from transaction metadata
+func NewCip25MetadataFromMetadata(metadata common.TransactionMetadata) (*Cip25Metadata, error) {
+	md, err := labelValue(metadata, LabelCip25)
+	if err != nil {
+		return nil, err
+	}
+	return NewCip25MetadataFromMetadatum(md)
+}
+
+// NewCip25MetadataFromMetadatum parses CIP-25 NFT metadata from the value for its metadata label
+func NewCip25MetadataFromMetadatum(md common.TransactionMetadatum) (*Cip25Metadata, error) {
+	if md.Type() != common.TransactionMetadatumTypeMap {
+		return nil, errors.New("invalid CIP-25 metadata: expected map")
+	}
+	ret := &Cip25Metadata{
+		Version: 1,
+	}
+	if version, ok := md.MapValue(textKey("version")); ok {
+		tmpVersion, err := cip25Version(version)
+		if err != nil {
+			return nil, err
+		}
+		ret.Version = tmpVersion
+	}
+	for _, policyPair := range md.Map() {
+		if text, ok := policyPair.Key.Text(); ok && text == "version" {
+			continue
+		}
+		policyId, err := cip25PolicyId(policyPair.Key)
+		if err != nil {
+			return nil, err
+		}
+		if policyPair.Value.Type() != common.TransactionMetadatumTypeMap {
+			return nil, fmt.Errorf("invalid CIP-25 metadata: expected map for policy %s", policyId)
+		}
+		for _, assetPair := range policyPair.Value.Map() {
+			asset, err := newCip25Asset(policyId, assetPair.Key, assetPair.Value)
+			if err != nil {
+				return nil, err
+			}
+			ret.Assets = append(ret.Assets, *asset)
+		}
+	}
+	return ret, nil
+}
+
+// Asset returns the metadata for the specified asset, or nil if it's not present. Some version 1
+// metadata uses the hex encoding of the asset name as the key instead of UTF-8 text, so a key
+// which is the hex encoding of the asset name also matches
+func (m *Cip25Metadata) Asset(policyId common.Blake2b224, assetName []byte) *Cip25Asset {
+	for idx, asset := range m.Assets {
+		if asset.PolicyId == policyId && bytes.Equal(asset.AssetName, assetName) {
+			return &m.Assets[idx]
+		}
+	}
+	hexAssetName := []byte(hex.EncodeToString(assetName))
+	for idx, asset := range m.Assets {
+		if asset.PolicyId == policyId && bytes.EqualFold(asset.AssetName, hexAssetName) {
+			return &m.Assets[idx]
+		}
+	}
+	return nil
+}
+
+func cip25Version(md common.TransactionMetadatum) (int, error) {
+	if text, ok := md.Text(); ok {
+		switch text {
+		case "1.0":
+			return 1, nil
+		case "2.0":
+			return 2, nil
+		}
+	} else if tmpInt := md.Integer(); tmpInt != nil {
+		if tmpInt.Cmp(big.NewInt(1)) == 0 || tmpInt.Cmp(big.NewInt(2)) == 0 {
+			return int(tmpInt.Int64()), nil
+		}
+	}
+	return 0, errors.New("invalid CIP-25 metadata: unsupported version")
+}
+
+// cip25PolicyId decodes a policy ID key, which is hex-encoded text in version 1 and raw bytes in version 2.
+// Both forms are accepted regardless of the declared version, as both are found on chain
+func cip25PolicyId(key common.TransactionMetadatum) (common.Blake2b224, error) {
+	var policyIdBytes []byte
+	if text, ok := key.Text(); ok {
+		tmpBytes, err := hex.DecodeString(text)
+		if err != nil {
+			return common.Blake2b224{}, fmt.Errorf("invalid CIP-25 policy ID: %w", err)
+		}
+		policyIdBytes = tmpBytes
+	} else if key.Type() == common.TransactionMetadatumTypeBytes {
+		policyIdBytes = key.Bytes()
+	} else {
+		return common.Blake2b224{}, errors.New("invalid CIP-25 policy ID: expected text or bytes")
+	}
+	if len(policyIdBytes) != common.Blake2b224Size {
+		return common.Blake2b224{}, fmt.Errorf("invalid CIP-25 policy ID: unexpected length %d", len(policyIdBytes))
+	}
+	return common.NewBlake2b224(policyIdBytes), nil
+}
+
+// cip25AssetName decodes an asset name key, which is UTF-8 text in version 1 and raw bytes in version 2
+func cip25AssetName(key common.TransactionMetadatum) ([]byte, error) {
+	if text, ok := key.Text(); ok {
+		return []byte(text), nil
+	}
+	if key.Type() == common.TransactionMetadatumTypeBytes {
+		return key.Bytes(), nil
+	}
+	return nil, errors.New("invalid CIP-25 asset name: expected text or bytes")
+}
+
+func newCip25Asset(policyId common.Blake2b224, key common.TransactionMetadatum, md common.TransactionMetadatum) (*Cip25Asset, error) {
+	assetName, err := cip25AssetName(key)
+	if err != nil {
+		return nil, err
+	}
+	if md.Type() != common.TransactionMetadatumTypeMap {
+		return nil, fmt.Errorf("invalid CIP-25 metadata: expected map for asset %s", cip25AssetNameString(assetName))
+	}
+	ret := &Cip25Asset{
+		PolicyId:  policyId,
+		AssetName: assetName,
+		Raw:       md,
+	}
+	fields := []struct {
+		key      string
+		dest     *string
+		required bool
+	}{
+		{"name", &ret.Name, true},
+		{"image", &ret.Image, true},
+		{"mediaType", &ret.MediaType, false},
+		{"description", &ret.Description, false},
+	}
+	for _, field := range fields {
+		value, ok := md.MapValue(textKey(field.key))
+		if !ok {
+			if field.required {
+				return nil, fmt.Errorf("invalid CIP-25 metadata: missing %s for asset %s", field.key, cip25AssetNameString(assetName))
+			}
+			continue
+		}
+		tmpValue, err := metadatumString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIP-25 metadata: %s: %w", field.key, err)
+		}
+		*field.dest = tmpValue
+	}
+	if files, ok := md.MapValue(textKey("files")); ok {
+		if files.Type() != common.TransactionMetadatumTypeList {
+			return nil, errors.New("invalid CIP-25 metadata: expected list for files")
+		}
+		for _, file := range files.List() {
+			tmpFile, err := newCip25File(file)
+			if err != nil {
+				return nil, err
+			}
+			ret.Files = append(ret.Files, *tmpFile)
+		}
+	}
+	return ret, nil
+}
+
+func newCip25File(md common.TransactionMetadatum) (*Cip25File, error) {
+	if md.Type() != common.TransactionMetadatumTypeMap {
+		return nil, errors.New("invalid CIP-25 file: expected map")
+	}
+	ret := &Cip25File{}
+	fields := []struct {
+		key  string
+		dest *string
+	}{
+		{"name", &ret.Name},
+		{"mediaType", &ret.MediaType},
+		{"src", &ret.Src},
+	}
+	for _, field := range fields {
+		value, ok := md.MapValue(textKey(field.key))
+		if !ok {
+			if field.key == "name" {
+				continue
+			}
+			return nil, fmt.Errorf("invalid CIP-25 file: missing %s", field.key)
+		}
+		tmpValue, err := metadatumString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIP-25 file: %s: %w", field.key, err)
+		}
+		*field.dest = tmpValue
+	}
+	return ret, nil
+}
+
+// cip25AssetNameString formats an asset name for error messages
+func cip25AssetNameString(assetName []byte) string {
+	if utf8.Valid(assetName) {
+		return fmt.Sprintf("%q", assetName)
+	}
+	return hex.EncodeToString(assetName)
+}
+
+// Metadatum returns the value for the CIP-25 metadata label. Policy IDs and asset names are encoded
+// according to the metadata version, and long strings are split into lists of strings
+func (m *Cip25Metadata) Metadatum() (common.TransactionMetadatum, error) {
+	var policyPairs []common.TransactionMetadatumMapPair
+	for _, asset := range m.Assets {
+		var policyKey, assetKey common.TransactionMetadatum
+		if m.Version >= 2 {
+			policyKey = common.NewMetadatumBytes(asset.PolicyId.Bytes())
+			assetKey = common.NewMetadatumBytes(asset.AssetName)
+		} else {
+			if !utf8.Valid(asset.AssetName) {
+				return common.TransactionMetadatum{}, fmt.Errorf("asset name is not valid UTF-8: %x", asset.AssetName)
+			}
+			policyKey = common.NewMetadatumText(asset.PolicyId.String())
+			assetKey = common.NewMetadatumText(string(asset.AssetName))
+		}
+		assetPair := common.TransactionMetadatumMapPair{
+			Key:   assetKey,
+			Value: asset.metadatum(),
+		}
+		found := false
+		for idx, policyPair := range policyPairs {
+			if policyPair.Key.Equal(policyKey) {
+				policyPairs[idx].Value = common.NewMetadatumMap(append(policyPair.Value.Map(), assetPair)...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			policyPairs = append(
+				policyPairs,
+				common.TransactionMetadatumMapPair{
+					Key:   policyKey,
+					Value: common.NewMetadatumMap(assetPair),
+				},
+			)
+		}
+	}
+	if m.Version >= 2 {
+		policyPairs = append(
+			policyPairs,
+			common.TransactionMetadatumMapPair{
+				Key:   textKey("version"),
+				Value: common.NewMetadatumText(fmt.Sprintf("%d.0", m.Version)),
+			},
+		)
+	}
+	ret := common.NewMetadatumMap(policyPairs...)
+	if err := ret.Validate(); err != nil {
+		return common.TransactionMetadatum{}, err
+	}
+	return ret, nil
+}
+
+// metadatum returns the metadata value for the asset. The original value is used when available,
+// so that additional properties are kept
+func (a *Cip25Asset) metadatum() common.TransactionMetadatum {
+	if len(a.Raw.Map()) > 0 {
+		return a.Raw
+	}
+	pairs := []common.TransactionMetadatumMapPair{
+		{Key: textKey("name"), Value: cip25StringValue(a.Name)},
+		{Key: textKey("image"), Value: cip25StringValue(a.Image)},
+	}
+	if a.MediaType != "" {
+		pairs = append(pairs, common.TransactionMetadatumMapPair{Key: textKey("mediaType"), Value: cip25StringValue(a.MediaType)})
+	}
+	if a.Description != "" {
+		pairs = append(pairs, common.TransactionMetadatumMapPair{Key: textKey("description"), Value: cip25StringValue(a.Description)})
+	}
+	if len(a.Files) > 0 {
+		files := make([]common.TransactionMetadatum, len(a.Files))
+		for idx, file := range a.Files {
+			var filePairs []common.TransactionMetadatumMapPair
+			if file.Name != "" {
+				filePairs = append(filePairs, common.TransactionMetadatumMapPair{Key: textKey("name"), Value: cip25StringValue(file.Name)})
+			}
+			filePairs = append(
+				filePairs,
+				common.TransactionMetadatumMapPair{Key: textKey("mediaType"), Value: cip25StringValue(file.MediaType)},
+				common.TransactionMetadatumMapPair{Key: textKey("src"), Value: cip25StringValue(file.Src)},
+			)
+			files[idx] = common.NewMetadatumMap(filePairs...)
+		}
+		pairs = append(pairs, common.TransactionMetadatumMapPair{Key: textKey("files"), Value: common.NewMetadatumList(files...)})
+	}
+	return common.NewMetadatumMap(pairs...)
+}
+
+// cip25StringValue returns a text value, or a list of text values if the string is too long
+func cip25StringValue(value string) common.TransactionMetadatum {
+	if len(value) <= common.MetadatumMaxStringSize {
+		return common.NewMetadatumText(value)
+	}
+	return common.NewMetadatumList(splitString(value)...)
+}
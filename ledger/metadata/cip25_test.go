@@ -0,0 +1,161 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/metadata"
+)
+
+const testCip25PolicyId = "b0d07d45fe9514f80213f4020e5a61241458be626841cde717cb38a7"
+
+func TestCip25MetadataV1(t *testing.T) {
+	md, err := common.NewTransactionMetadatumFromNoSchemaJSON(
+		[]byte(`{
+			"` + testCip25PolicyId + `": {
+				"NFT1": {
+					"name": "NFT 1",
+					"image": ["ipfs://QmRhTTbUrPYEw3mJGGhQqQST9k86v1DPBiTTWJGKDJsVFw", "/path/to/image.png"],
+					"mediaType": "image/png",
+					"description": "My first NFT",
+					"files": [
+						{"name": "Full", "mediaType": "video/mp4", "src": "ipfs://QmVideo"}
+					],
+					"traits": ["hat"]
+				},
+				"4e465432": {
+					"name": "NFT 2",
+					"image": "ipfs://QmImage2"
+				}
+			}
+		}`),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	nftMetadata, err := metadata.NewCip25MetadataFromMetadatum(md)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if nftMetadata.Version != 1 {
+		t.Errorf("did not get expected version: %d", nftMetadata.Version)
+	}
+	if len(nftMetadata.Assets) != 2 {
+		t.Fatalf("did not get expected number of assets: %d", len(nftMetadata.Assets))
+	}
+	policyIdBytes, _ := hex.DecodeString(testCip25PolicyId)
+	policyId := common.NewBlake2b224(policyIdBytes)
+	asset := nftMetadata.Asset(policyId, []byte("NFT1"))
+	if asset == nil {
+		t.Fatalf("did not find asset")
+	}
+	if asset.Name != "NFT 1" || asset.MediaType != "image/png" || asset.Description != "My first NFT" {
+		t.Errorf("did not get expected asset properties: %#v", asset)
+	}
+	if asset.Image != "ipfs://QmRhTTbUrPYEw3mJGGhQqQST9k86v1DPBiTTWJGKDJsVFw/path/to/image.png" {
+		t.Errorf("did not get expected joined image: %s", asset.Image)
+	}
+	if len(asset.Files) != 1 || asset.Files[0].Src != "ipfs://QmVideo" || asset.Files[0].Name != "Full" {
+		t.Errorf("did not get expected files: %#v", asset.Files)
+	}
+	if _, ok := asset.Raw.MapValue(common.NewMetadatumText("traits")); !ok {
+		t.Errorf("did not find additional property")
+	}
+	// Asset name key in hex
+	asset = nftMetadata.Asset(policyId, []byte("NFT2"))
+	if asset == nil || asset.Name != "NFT 2" {
+		t.Fatalf("did not find asset with hex name")
+	}
+	if nftMetadata.Asset(policyId, []byte("NFT3")) != nil {
+		t.Errorf("found unexpected asset")
+	}
+}
+
+func TestCip25MetadataV2RoundTrip(t *testing.T) {
+	policyIdBytes, _ := hex.DecodeString(testCip25PolicyId)
+	nftMetadata := &metadata.Cip25Metadata{
+		Version: 2,
+		Assets: []metadata.Cip25Asset{
+			{
+				PolicyId:  common.NewBlake2b224(policyIdBytes),
+				AssetName: []byte{0x00, 0x0d, 0xe1, 0x40},
+				Name:      "Token",
+				Image:     "https://example.com/" + hex.EncodeToString(make([]byte, 48)),
+			},
+		},
+	}
+	md, err := nftMetadata.Metadatum()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cborData, err := cbor.Encode(md)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tmpMd, err := common.NewTransactionMetadatumFromCbor(cborData)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tmpMetadata, err := metadata.NewCip25MetadataFromMetadatum(tmpMd)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tmpMetadata.Version != 2 {
+		t.Errorf("did not get expected version: %d", tmpMetadata.Version)
+	}
+	asset := tmpMetadata.Asset(nftMetadata.Assets[0].PolicyId, nftMetadata.Assets[0].AssetName)
+	if asset == nil {
+		t.Fatalf("did not find asset")
+	}
+	if asset.Image != nftMetadata.Assets[0].Image {
+		t.Errorf("did not get expected image: %s", asset.Image)
+	}
+	if len(asset.Raw.Map()) == 0 || asset.Raw.Map()[1].Value.Type() != common.TransactionMetadatumTypeList {
+		t.Errorf("expected long image to be split")
+	}
+}
+
+func TestCip25MetadataErrors(t *testing.T) {
+	testDefs := []struct {
+		name string
+		json string
+	}{
+		{
+			name: "invalid policy ID",
+			json: `{"abcd":{"NFT":{"name":"a","image":"b"}}}`,
+		},
+		{
+			name: "missing name",
+			json: `{"` + testCip25PolicyId + `":{"NFT":{"image":"b"}}}`,
+		},
+		{
+			name: "invalid version",
+			json: `{"version":"3.0"}`,
+		},
+	}
+	for _, testDef := range testDefs {
+		md, err := common.NewTransactionMetadatumFromNoSchemaJSON([]byte(testDef.json))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if _, err := metadata.NewCip25MetadataFromMetadatum(md); err == nil {
+			t.Errorf("%s: did not get expected error", testDef.name)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const (
+	// LabelCip36Registration is the metadata label for CIP-36 (and CIP-15) vote key registrations
+	LabelCip36Registration = 61284
+	// LabelCip36Witness is the metadata label for the signature of a CIP-36 registration
+	LabelCip36Witness = 61285
+)
+
+// Cip36VotingPurposeCatalyst is the voting purpose for Project Catalyst, which is the default
+const Cip36VotingPurposeCatalyst = 0
+
+// Cip36Registration is a vote key registration as defined by CIP-36. Registrations in the older
+// CIP-15 format, which have a single vote key, are also supported
+type Cip36Registration struct {
+	Delegations []Cip36Delegation
+	// Legacy indicates that the registration uses the CIP-15 format with a single vote key, which is
+	// returned as a delegation with a weight of 1
+	Legacy         bool
+	StakeKey       []byte
+	PaymentAddress []byte
+	Nonce          uint64
+	VotingPurpose  uint64
+	Signature      []byte
+	// Raw is the value for the registration label, which is needed to verify the signature
+	Raw common.TransactionMetadatum
+}
+
+// Cip36Delegation is the delegation of voting power to a vote key
+type Cip36Delegation struct {
+	VotingKey []byte
+	Weight    uint32
+}
+
+// NewCip36RegistrationFromMetadata parses a CIP-36 vote key registration and its signature from
+// transaction metadata
+func NewCip36RegistrationFromMetadata(metadata common.TransactionMetadata) (*Cip36Registration, error) {
+	md, err := labelValue(metadata, LabelCip36Registration)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := NewCip36RegistrationFromMetadatum(md)
+	if err != nil {
+		return nil, err
+	}
+	witness, err := labelValue(metadata, LabelCip36Witness)
+	if err != nil {
+		return nil, err
+	}
+	signature, ok := witness.MapValue(intKey(1))
+	if !ok || signature.Type() != common.TransactionMetadatumTypeBytes {
+		return nil, errors.New("invalid CIP-36 witness: missing signature")
+	}
+	ret.Signature = signature.Bytes()
+	return ret, nil
+}
+
+// NewCip36RegistrationFromMetadatum parses a CIP-36 vote key registration from the value for its
+// metadata label. The signature is not included
+func NewCip36RegistrationFromMetadatum(md common.TransactionMetadatum) (*Cip36Registration, error) {
+	if md.Type() != common.TransactionMetadatumTypeMap {
+		return nil, errors.New("invalid CIP-36 registration: expected map")
+	}
+	ret := &Cip36Registration{
+		VotingPurpose: Cip36VotingPurposeCatalyst,
+		Raw:           md,
+	}
+	// Delegations
+	delegations, ok := md.MapValue(intKey(1))
+	if !ok {
+		return nil, errors.New("invalid CIP-36 registration: missing delegations")
+	}
+	switch delegations.Type() {
+	case common.TransactionMetadatumTypeBytes:
+		ret.Legacy = true
+		ret.Delegations = []Cip36Delegation{
+			{VotingKey: delegations.Bytes(), Weight: 1},
+		}
+	case common.TransactionMetadatumTypeList:
+		for _, item := range delegations.List() {
+			delegation := item.List()
+			if len(delegation) != 2 || delegation[0].Type() != common.TransactionMetadatumTypeBytes {
+				return nil, errors.New("invalid CIP-36 registration: invalid delegation")
+			}
+			weight, err := metadatumUint(delegation[1])
+			if err != nil || weight > uint64(^uint32(0)) {
+				return nil, errors.New("invalid CIP-36 registration: invalid delegation weight")
+			}
+			ret.Delegations = append(
+				ret.Delegations,
+				Cip36Delegation{
+					VotingKey: delegation[0].Bytes(),
+					Weight:    uint32(weight),
+				},
+			)
+		}
+	default:
+		return nil, errors.New("invalid CIP-36 registration: invalid delegations")
+	}
+	// Stake key and payment address
+	for _, field := range []struct {
+		key  int64
+		name string
+		dest *[]byte
+	}{
+		{2, "stake key", &ret.StakeKey},
+		{3, "payment address", &ret.PaymentAddress},
+	} {
+		value, ok := md.MapValue(intKey(field.key))
+		if !ok || value.Type() != common.TransactionMetadatumTypeBytes {
+			return nil, fmt.Errorf("invalid CIP-36 registration: missing %s", field.name)
+		}
+		*field.dest = value.Bytes()
+	}
+	// Nonce
+	nonce, ok := md.MapValue(intKey(4))
+	if !ok {
+		return nil, errors.New("invalid CIP-36 registration: missing nonce")
+	}
+	tmpNonce, err := metadatumUint(nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIP-36 registration: nonce: %w", err)
+	}
+	ret.Nonce = tmpNonce
+	// Voting purpose
+	if purpose, ok := md.MapValue(intKey(5)); ok {
+		tmpPurpose, err := metadatumUint(purpose)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIP-36 registration: voting purpose: %w", err)
+		}
+		ret.VotingPurpose = tmpPurpose
+	}
+	return ret, nil
+}
+
+// Address decodes the payment address for rewards
+func (r *Cip36Registration) Address() (common.Address, error) {
+	var ret common.Address
+	addrCbor, err := cbor.Encode(r.PaymentAddress)
+	if err != nil {
+		return ret, err
+	}
+	if _, err := cbor.Decode(addrCbor, &ret); err != nil {
+		return ret, err
+	}
+	return ret, nil
+}
+
+// Metadatum returns the value for the registration label. The original value is returned for a
+// decoded registration, since the signature covers its exact CBOR encoding
+func (r *Cip36Registration) Metadatum() common.TransactionMetadatum {
+	if len(r.Raw.Map()) > 0 {
+		return r.Raw
+	}
+	var delegations common.TransactionMetadatum
+	if r.Legacy && len(r.Delegations) == 1 {
+		delegations = common.NewMetadatumBytes(r.Delegations[0].VotingKey)
+	} else {
+		items := make([]common.TransactionMetadatum, len(r.Delegations))
+		for idx, delegation := range r.Delegations {
+			items[idx] = common.NewMetadatumList(
+				common.NewMetadatumBytes(delegation.VotingKey),
+				common.NewMetadatumInt(int64(delegation.Weight)),
+			)
+		}
+		delegations = common.NewMetadatumList(items...)
+	}
+	pairs := []common.TransactionMetadatumMapPair{
+		{Key: intKey(1), Value: delegations},
+		{Key: intKey(2), Value: common.NewMetadatumBytes(r.StakeKey)},
+		{Key: intKey(3), Value: common.NewMetadatumBytes(r.PaymentAddress)},
+		{Key: intKey(4), Value: common.NewMetadatumInteger(new(big.Int).SetUint64(r.Nonce))},
+	}
+	if !r.Legacy {
+		pairs = append(
+			pairs,
+			common.TransactionMetadatumMapPair{
+				Key:   intKey(5),
+				Value: common.NewMetadatumInteger(new(big.Int).SetUint64(r.VotingPurpose)),
+			},
+		)
+	}
+	return common.NewMetadatumMap(pairs...)
+}
+
+// SigningHash returns the hash signed by the stake key, which is the Blake2b-256 hash of the CBOR
+// encoding of the registration within a map keyed by its label
+func (r *Cip36Registration) SigningHash() (common.Blake2b256, error) {
+	regCbor, err := cbor.Encode(r.Metadatum())
+	if err != nil {
+		return common.Blake2b256{}, err
+	}
+	labelCbor, err := cbor.Encode(uint64(LabelCip36Registration))
+	if err != nil {
+		return common.Blake2b256{}, err
+	}
+	// Single pair map header
+	data := slices.Concat([]byte{0xa1}, labelCbor, regCbor)
+	return common.Blake2b256Hash(data), nil
+}
+
+// Verify checks the signature of the registration against the stake key
+func (r *Cip36Registration) Verify() error {
+	if len(r.StakeKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid CIP-36 stake key length: %d", len(r.StakeKey))
+	}
+	if len(r.Signature) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid CIP-36 signature length: %d", len(r.Signature))
+	}
+	hash, err := r.SigningHash()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(r.StakeKey, hash.Bytes(), r.Signature) {
+		return errors.New("invalid CIP-36 registration signature")
+	}
+	return nil
+}
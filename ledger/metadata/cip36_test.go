@@ -0,0 +1,117 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/metadata"
+)
+
+func TestCip36Registration(t *testing.T) {
+	stakePub, stakePriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	reg := &metadata.Cip36Registration{
+		Delegations: []metadata.Cip36Delegation{
+			{VotingKey: bytes.Repeat([]byte{0x01}, 32), Weight: 1},
+			{VotingKey: bytes.Repeat([]byte{0x02}, 32), Weight: 3},
+		},
+		StakeKey:       stakePub,
+		PaymentAddress: append([]byte{0xe1}, bytes.Repeat([]byte{0x03}, 28)...),
+		Nonce:          123456789,
+		VotingPurpose:  metadata.Cip36VotingPurposeCatalyst,
+	}
+	hash, err := reg.SigningHash()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	signature := ed25519.Sign(stakePriv, hash.Bytes())
+	txMetadata := common.TransactionMetadata{
+		metadata.LabelCip36Registration: reg.Metadatum(),
+		metadata.LabelCip36Witness: common.NewMetadatumMap(
+			common.TransactionMetadatumMapPair{
+				Key:   common.NewMetadatumInt(1),
+				Value: common.NewMetadatumBytes(signature),
+			},
+		),
+	}
+	// Round-trip through CBOR, so that the signature is verified against the decoded encoding
+	cborData, err := cbor.Encode(txMetadata)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var tmpMetadata common.TransactionMetadata
+	if _, err := cbor.Decode(cborData, &tmpMetadata); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tmpReg, err := metadata.NewCip36RegistrationFromMetadata(tmpMetadata)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tmpReg.Legacy || len(tmpReg.Delegations) != 2 || tmpReg.Delegations[1].Weight != 3 {
+		t.Errorf("did not get expected delegations: %#v", tmpReg.Delegations)
+	}
+	if tmpReg.Nonce != reg.Nonce || !bytes.Equal(tmpReg.StakeKey, stakePub) {
+		t.Errorf("did not get expected registration: %#v", tmpReg)
+	}
+	if _, err := tmpReg.Address(); err != nil {
+		t.Errorf("unexpected error decoding address: %s", err)
+	}
+	if err := tmpReg.Verify(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	// Modified registration
+	tmpReg.Raw = common.TransactionMetadatum{}
+	tmpReg.Nonce++
+	if err := tmpReg.Verify(); err == nil {
+		t.Errorf("did not get expected error for modified registration")
+	}
+}
+
+func TestCip36RegistrationLegacy(t *testing.T) {
+	md, err := common.NewTransactionMetadatumFromNoSchemaJSON(
+		[]byte(`{
+			"1": "0x0036ef3e1f0d3f5989e2d155ea54bdb2a72c4c456ccb959af4c94868f473f5a0",
+			"2": "0x86870efc99c453a873a16492ce87738ec79a0ebd064379a62e2c9cf4e119219e",
+			"3": "0xe0ae3a0a7aeda4aea522e74e4fe36759fca80789a613a58a4364f6ecef",
+			"4": 1234
+		}`),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	reg, err := metadata.NewCip36RegistrationFromMetadatum(md)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reg.Legacy || len(reg.Delegations) != 1 || reg.Delegations[0].Weight != 1 {
+		t.Errorf("did not get expected legacy delegation: %#v", reg.Delegations)
+	}
+	if reg.Nonce != 1234 || reg.VotingPurpose != metadata.Cip36VotingPurposeCatalyst {
+		t.Errorf("did not get expected registration: %#v", reg)
+	}
+	// The signature is required when parsing from transaction metadata
+	if _, err := metadata.NewCip36RegistrationFromMetadata(
+		common.TransactionMetadata{metadata.LabelCip36Registration: md},
+	); err == nil {
+		t.Errorf("did not get expected error for missing witness")
+	}
+}
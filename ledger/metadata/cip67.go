@@ -0,0 +1,84 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Asset name labels defined by CIP-67, including those used by CIP-68
+const (
+	// Cip67LabelReferenceNft is the label for a CIP-68 reference token, which holds the metadata datum
+	Cip67LabelReferenceNft = 100
+	// Cip67LabelNft is the label for a CIP-68 NFT user token
+	Cip67LabelNft = 222
+	// Cip67LabelFt is the label for a CIP-68 fungible user token
+	Cip67LabelFt = 333
+	// Cip67LabelRft is the label for a CIP-68 rich fungible user token
+	Cip67LabelRft = 444
+)
+
+// Cip67PrefixSize is the size of the label prefix of an asset name
+const Cip67PrefixSize = 4
+
+// Cip67Prefix returns the asset name prefix for a label. The prefix consists of a zero nibble, the
+// 16-bit label, a CRC-8 checksum of the label, and another zero nibble
+func Cip67Prefix(label uint16) []byte {
+	labelBytes := binary.BigEndian.AppendUint16(nil, label)
+	tmpPrefix := (uint32(label)<<8 | uint32(cip67Crc8(labelBytes))) << 4
+	return binary.BigEndian.AppendUint32(nil, tmpPrefix)
+}
+
+// NewCip67AssetName returns an asset name with the prefix for the label
+func NewCip67AssetName(label uint16, name []byte) []byte {
+	return slices.Concat(Cip67Prefix(label), name)
+}
+
+// ParseCip67AssetName returns the label and the remaining name from an asset name with a CIP-67 prefix.
+// An error is returned if the asset name doesn't have a valid prefix
+func ParseCip67AssetName(assetName []byte) (uint16, []byte, error) {
+	if len(assetName) < Cip67PrefixSize {
+		return 0, nil, errors.New("asset name too short for CIP-67 label")
+	}
+	tmpPrefix := binary.BigEndian.Uint32(assetName[:Cip67PrefixSize])
+	if tmpPrefix&0xf000000f != 0 {
+		return 0, nil, errors.New("asset name does not have CIP-67 label brackets")
+	}
+	label := uint16(tmpPrefix >> 12)
+	checksum := uint8(tmpPrefix >> 4)
+	if expected := cip67Crc8(binary.BigEndian.AppendUint16(nil, label)); checksum != expected {
+		return 0, nil, fmt.Errorf("invalid CIP-67 label checksum: got %#02x, expected %#02x", checksum, expected)
+	}
+	return label, assetName[Cip67PrefixSize:], nil
+}
+
+// cip67Crc8 calculates the CRC-8 checksum used by CIP-67, with polynomial 0x07 and no reflection
+func cip67Crc8(data []byte) uint8 {
+	var crc uint8
+	for _, b := range data {
+		crc ^= b
+		for range 8 {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
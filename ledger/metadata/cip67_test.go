@@ -0,0 +1,70 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/metadata"
+)
+
+func TestCip67Prefix(t *testing.T) {
+	// Test vectors from CIP-67
+	testDefs := []struct {
+		label     uint16
+		prefixHex string
+	}{
+		{label: 0, prefixHex: "00000000"},
+		{label: 1, prefixHex: "00001070"},
+		{label: 23, prefixHex: "00017650"},
+		{label: 100, prefixHex: "000643b0"},
+		{label: 222, prefixHex: "000de140"},
+		{label: 333, prefixHex: "0014df10"},
+		{label: 444, prefixHex: "001bc280"},
+		{label: 65535, prefixHex: "0ffff240"},
+	}
+	for _, testDef := range testDefs {
+		prefix := metadata.Cip67Prefix(testDef.label)
+		if hex.EncodeToString(prefix) != testDef.prefixHex {
+			t.Errorf("did not get expected prefix for label %d\n  got:    %x\n  wanted: %s", testDef.label, prefix, testDef.prefixHex)
+		}
+		label, name, err := metadata.ParseCip67AssetName(append(prefix, []byte("test")...))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if label != testDef.label || string(name) != "test" {
+			t.Errorf("did not get expected label and name: %d, %q", label, name)
+		}
+	}
+}
+
+func TestCip67ParseErrors(t *testing.T) {
+	testDefs := []struct {
+		name         string
+		assetNameHex string
+	}{
+		{name: "too short", assetNameHex: "000de1"},
+		{name: "bad checksum", assetNameHex: "000de150"},
+		{name: "missing brackets", assetNameHex: "100de140"},
+		{name: "plain name", assetNameHex: "4e4654"},
+	}
+	for _, testDef := range testDefs {
+		assetName, _ := hex.DecodeString(testDef.assetNameHex)
+		if _, _, err := metadata.ParseCip67AssetName(assetName); err == nil {
+			t.Errorf("%s: did not get expected error", testDef.name)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// Cip68Token is an asset whose name has a CIP-67 label used by CIP-68
+type Cip68Token struct {
+	PolicyId  common.Blake2b224
+	AssetName []byte
+	Label     uint16
+	// Name is the asset name without the label prefix, which is shared by the reference token and
+	// the user token
+	Name []byte
+}
+
+// NewCip68Token classifies an asset by the label prefix of its name. An error is returned if the
+// asset name doesn't have a valid CIP-67 prefix or the label isn't one used by CIP-68
+func NewCip68Token(policyId common.Blake2b224, assetName []byte) (*Cip68Token, error) {
+	label, name, err := ParseCip67AssetName(assetName)
+	if err != nil {
+		return nil, err
+	}
+	switch label {
+	case Cip67LabelReferenceNft, Cip67LabelNft, Cip67LabelFt, Cip67LabelRft:
+	default:
+		return nil, fmt.Errorf("asset name label is not a CIP-68 label: %d", label)
+	}
+	return &Cip68Token{
+		PolicyId:  policyId,
+		AssetName: assetName,
+		Label:     label,
+		Name:      name,
+	}, nil
+}
+
+// Cip68Tokens returns the CIP-68 reference and user tokens in a multi-asset, ordered by policy ID
+// and asset name. Assets without a CIP-68 label are ignored
+func Cip68Tokens[T common.MultiAssetTypeOutput | common.MultiAssetTypeMint](assets *common.MultiAsset[T]) []Cip68Token {
+	if assets == nil {
+		return nil
+	}
+	var ret []Cip68Token
+	for _, policyId := range assets.Policies() {
+		for _, assetName := range assets.Assets(policyId) {
+			token, err := NewCip68Token(policyId, assetName)
+			if err != nil {
+				continue
+			}
+			ret = append(ret, *token)
+		}
+	}
+	slices.SortFunc(
+		ret,
+		func(a, b Cip68Token) int {
+			if c := bytes.Compare(a.PolicyId.Bytes(), b.PolicyId.Bytes()); c != 0 {
+				return c
+			}
+			return bytes.Compare(a.AssetName, b.AssetName)
+		},
+	)
+	return ret
+}
+
+// IsReference returns whether the token is a reference token, which holds the metadata datum
+func (t Cip68Token) IsReference() bool {
+	return t.Label == Cip67LabelReferenceNft
+}
+
+// ReferenceToken returns the reference token for the token, which has the same policy ID and name
+func (t Cip68Token) ReferenceToken() Cip68Token {
+	return Cip68Token{
+		PolicyId:  t.PolicyId,
+		AssetName: NewCip67AssetName(Cip67LabelReferenceNft, t.Name),
+		Label:     Cip67LabelReferenceNft,
+		Name:      t.Name,
+	}
+}
+
+// Fingerprint returns the CIP-14 fingerprint of the token
+func (t Cip68Token) Fingerprint() common.AssetFingerprint {
+	return common.NewAssetFingerprint(t.PolicyId.Bytes(), t.AssetName)
+}
+
+// FindCip68ReferenceOutput returns the output which holds the reference token for the token, or nil
+// if no output holds it
+func FindCip68ReferenceOutput(token Cip68Token, outputs []common.TransactionOutput) common.TransactionOutput {
+	refToken := token.ReferenceToken()
+	for _, output := range outputs {
+		assets := output.Assets()
+		if assets == nil {
+			continue
+		}
+		if assets.Asset(refToken.PolicyId, refToken.AssetName) > 0 {
+			return output
+		}
+	}
+	return nil
+}
+
+// Cip68Datum is the inline datum on the output holding a reference token, as defined by CIP-68
+type Cip68Datum struct {
+	// Metadata contains the metadata map, whose keys are UTF-8 bytestrings
+	Metadata []common.PlutusMapPair
+	Version  int64
+	// Extra contains the custom data after the version
+	Extra common.PlutusData
+}
+
+// NewCip68DatumFromOutput decodes the CIP-68 datum from the inline datum of an output
+func NewCip68DatumFromOutput(output common.TransactionOutput) (*Cip68Datum, error) {
+	datum := output.Datum()
+	if datum == nil {
+		return nil, errors.New("output does not have an inline datum")
+	}
+	data, err := common.NewPlutusDataFromCbor(datum.Cbor())
+	if err != nil {
+		return nil, err
+	}
+	return NewCip68DatumFromPlutusData(data)
+}
+
+// NewCip68DatumFromPlutusData decodes a CIP-68 datum, which is a constructor 0 with the metadata map,
+// the version, and the extra data as its fields
+func NewCip68DatumFromPlutusData(data common.PlutusData) (*Cip68Datum, error) {
+	constr := data.Constr()
+	if constr == nil || constr.Constructor != 0 || len(constr.Fields) < 2 {
+		return nil, errors.New("invalid CIP-68 datum: expected constructor 0 with metadata and version")
+	}
+	if constr.Fields[0].Type() != common.PlutusDataTypeMap {
+		return nil, errors.New("invalid CIP-68 datum: expected map for metadata")
+	}
+	version := constr.Fields[1].Integer()
+	if version == nil || !version.IsInt64() || version.Int64() < 1 {
+		return nil, errors.New("invalid CIP-68 datum: invalid version")
+	}
+	ret := &Cip68Datum{
+		Metadata: constr.Fields[0].Map(),
+		Version:  version.Int64(),
+	}
+	if len(constr.Fields) > 2 {
+		ret.Extra = constr.Fields[2]
+	}
+	return ret, nil
+}
+
+// Field returns the metadata value for a key
+func (d *Cip68Datum) Field(key string) (common.PlutusData, bool) {
+	for _, pair := range d.Metadata {
+		if pair.Key.Type() == common.PlutusDataTypeBytes && string(pair.Key.Bytes()) == key {
+			return pair.Value, true
+		}
+	}
+	return common.PlutusData{}, false
+}
+
+// FieldString returns the metadata value for a key as a string. The value must be a UTF-8 bytestring,
+// or a list of them which are joined
+func (d *Cip68Datum) FieldString(key string) (string, bool) {
+	value, ok := d.Field(key)
+	if !ok {
+		return "", false
+	}
+	return cip68String(value)
+}
+
+// Name returns the name from the metadata
+func (d *Cip68Datum) Name() string {
+	ret, _ := d.FieldString("name")
+	return ret
+}
+
+// Image returns the image URI from the metadata
+func (d *Cip68Datum) Image() string {
+	ret, _ := d.FieldString("image")
+	return ret
+}
+
+// Description returns the description from the metadata
+func (d *Cip68Datum) Description() string {
+	ret, _ := d.FieldString("description")
+	return ret
+}
+
+// Decimals returns the number of decimals for a fungible token, or 0 if not specified
+func (d *Cip68Datum) Decimals() uint64 {
+	value, ok := d.Field("decimals")
+	if !ok {
+		return 0
+	}
+	tmpInt := value.Integer()
+	if tmpInt == nil || !tmpInt.IsUint64() {
+		return 0
+	}
+	return tmpInt.Uint64()
+}
+
+func cip68String(value common.PlutusData) (string, bool) {
+	switch value.Type() {
+	case common.PlutusDataTypeBytes:
+		if !utf8.Valid(value.Bytes()) {
+			return "", false
+		}
+		return string(value.Bytes()), true
+	case common.PlutusDataTypeList:
+		var sb strings.Builder
+		for _, item := range value.List() {
+			if item.Type() != common.PlutusDataTypeBytes || !utf8.Valid(item.Bytes()) {
+				return "", false
+			}
+			sb.Write(item.Bytes())
+		}
+		return sb.String(), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/metadata"
+)
+
+func testCip68Datum() common.PlutusData {
+	return common.NewPlutusConstr(
+		0,
+		common.NewPlutusMap(
+			common.PlutusMapPair{
+				Key:   common.NewPlutusBytes([]byte("name")),
+				Value: common.NewPlutusBytes([]byte("SpaceBud")),
+			},
+			common.PlutusMapPair{
+				Key: common.NewPlutusBytes([]byte("image")),
+				Value: common.NewPlutusList(
+					common.NewPlutusBytes([]byte("ipfs://")),
+					common.NewPlutusBytes([]byte("QmImage")),
+				),
+			},
+			common.PlutusMapPair{
+				Key:   common.NewPlutusBytes([]byte("decimals")),
+				Value: common.NewPlutusInt(6),
+			},
+		),
+		common.NewPlutusInt(1),
+		common.NewPlutusConstr(0),
+	)
+}
+
+func TestCip68Tokens(t *testing.T) {
+	policyIdBytes, _ := hex.DecodeString(testCip25PolicyId)
+	policyId := common.NewBlake2b224(policyIdBytes)
+	assets := common.NewMultiAsset[common.MultiAssetTypeOutput](
+		map[common.Blake2b224]map[cbor.ByteString]uint64{
+			policyId: {
+				cbor.NewByteString(metadata.NewCip67AssetName(metadata.Cip67LabelNft, []byte("Bud1"))):          1,
+				cbor.NewByteString(metadata.NewCip67AssetName(metadata.Cip67LabelReferenceNft, []byte("Bud1"))): 1,
+				cbor.NewByteString(metadata.NewCip67AssetName(23, []byte("Other"))):                             1,
+				cbor.NewByteString([]byte("Plain")):                                                             1,
+			},
+		},
+	)
+	tokens := metadata.Cip68Tokens(&assets)
+	if len(tokens) != 2 {
+		t.Fatalf("did not get expected number of tokens: %d", len(tokens))
+	}
+	// The reference token sorts first
+	if !tokens[0].IsReference() || tokens[1].Label != metadata.Cip67LabelNft {
+		t.Errorf("did not get expected token labels: %d, %d", tokens[0].Label, tokens[1].Label)
+	}
+	refToken := tokens[1].ReferenceToken()
+	if !bytes.Equal(refToken.AssetName, tokens[0].AssetName) || string(refToken.Name) != "Bud1" {
+		t.Errorf("did not get expected reference token: %x", refToken.AssetName)
+	}
+	expectedFingerprint := common.NewAssetFingerprint(policyIdBytes, tokens[1].AssetName).String()
+	if tokens[1].Fingerprint().String() != expectedFingerprint {
+		t.Errorf("did not get expected fingerprint: %s", tokens[1].Fingerprint())
+	}
+	if _, err := metadata.NewCip68Token(policyId, metadata.NewCip67AssetName(23, nil)); err == nil {
+		t.Errorf("did not get expected error for non-CIP-68 label")
+	}
+}
+
+func TestCip68ReferenceOutput(t *testing.T) {
+	policyIdBytes, _ := hex.DecodeString(testCip25PolicyId)
+	refAssetName := metadata.NewCip67AssetName(metadata.Cip67LabelReferenceNft, []byte("Bud1"))
+	datumCbor, err := cbor.Encode(testCip68Datum())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	outputCbor, err := cbor.Encode(
+		map[int]any{
+			0: append([]byte{0x61}, make([]byte, 28)...),
+			1: []any{
+				2000000,
+				map[cbor.ByteString]map[cbor.ByteString]uint64{
+					cbor.NewByteString(policyIdBytes): {
+						cbor.NewByteString(refAssetName): 1,
+					},
+				},
+			},
+			2: []any{1, cbor.Tag{Number: cbor.CborTagCbor, Content: datumCbor}},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	refOutput, err := babbage.NewBabbageTransactionOutputFromCbor(outputCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	userToken, err := metadata.NewCip68Token(
+		common.NewBlake2b224(policyIdBytes),
+		metadata.NewCip67AssetName(metadata.Cip67LabelNft, []byte("Bud1")),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	output := metadata.FindCip68ReferenceOutput(*userToken, []common.TransactionOutput{refOutput})
+	if output == nil {
+		t.Fatalf("did not find reference output")
+	}
+	datum, err := metadata.NewCip68DatumFromOutput(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if datum.Version != 1 {
+		t.Errorf("did not get expected version: %d", datum.Version)
+	}
+	if datum.Name() != "SpaceBud" || datum.Image() != "ipfs://QmImage" || datum.Decimals() != 6 {
+		t.Errorf("did not get expected metadata: %q, %q, %d", datum.Name(), datum.Image(), datum.Decimals())
+	}
+	if datum.Extra.Constr() == nil {
+		t.Errorf("did not get expected extra data")
+	}
+	// Token without a reference output
+	otherToken, _ := metadata.NewCip68Token(
+		common.NewBlake2b224(policyIdBytes),
+		metadata.NewCip67AssetName(metadata.Cip67LabelNft, []byte("Bud2")),
+	)
+	if metadata.FindCip68ReferenceOutput(*otherToken, []common.TransactionOutput{refOutput}) != nil {
+		t.Errorf("found unexpected reference output")
+	}
+}
+
+func TestCip68DatumErrors(t *testing.T) {
+	testDefs := []struct {
+		name  string
+		datum common.PlutusData
+	}{
+		{
+			name:  "wrong constructor",
+			datum: common.NewPlutusConstr(1, common.NewPlutusMap(), common.NewPlutusInt(1)),
+		},
+		{
+			name:  "missing version",
+			datum: common.NewPlutusConstr(0, common.NewPlutusMap()),
+		},
+		{
+			name:  "metadata not a map",
+			datum: common.NewPlutusConstr(0, common.NewPlutusList(), common.NewPlutusInt(1)),
+		},
+	}
+	for _, testDef := range testDefs {
+		if _, err := metadata.NewCip68DatumFromPlutusData(testDef.datum); err == nil {
+			t.Errorf("%s: did not get expected error", testDef.name)
+		}
+	}
+}
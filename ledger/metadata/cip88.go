@@ -0,0 +1,208 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// LabelCip88 is the metadata label for CIP-88 token policy and pool registrations, which is also used
+// for CIP-151 Calidus key registrations
+const LabelCip88 = 867
+
+// Scope types for CIP-88 registrations
+const (
+	Cip88ScopeTypeTokenPolicy = 0
+	Cip88ScopeTypeStakePool   = 1
+)
+
+// Validation methods for CIP-88 registrations
+const (
+	Cip88ValidationMethodEd25519     = 0
+	Cip88ValidationMethodBeaconToken = 1
+	Cip88ValidationMethodCip8        = 2
+)
+
+// Cip88Registration is a registration as defined by CIP-88, which links on-chain metadata to a token
+// policy or stake pool
+type Cip88Registration struct {
+	Version uint64
+	Payload Cip88Payload
+	// Witnesses contains the witnesses for the payload, whose format depends on the validation method
+	Witnesses []common.TransactionMetadatum
+}
+
+// Cip88Payload is the signed payload of a CIP-88 registration
+type Cip88Payload struct {
+	Scope Cip88Scope
+	// FeatureSets contains the CIPs for which the registration provides information
+	FeatureSets      []uint64
+	ValidationMethod Cip88ValidationMethod
+	Nonce            uint64
+	// Details contains the optional information for the feature sets
+	Details *common.TransactionMetadatum
+	// CalidusKey is the Calidus public key for a CIP-151 registration
+	CalidusKey []byte
+	// Raw is the value for the payload, which is needed to calculate the signed hash
+	Raw common.TransactionMetadatum
+}
+
+// Cip88Scope identifies the token policy or stake pool for a registration
+type Cip88Scope struct {
+	Type uint64
+	// Id is the policy ID or pool ID
+	Id []byte
+	// Extra contains any further items in the scope, such as the native script for a token policy
+	Extra []common.TransactionMetadatum
+}
+
+// Cip88ValidationMethod is the method used to validate a registration, with any method-specific items
+type Cip88ValidationMethod struct {
+	Type  uint64
+	Extra []common.TransactionMetadatum
+}
+
+// NewCip88RegistrationFromMetadata parses a CIP-88 registration from transaction metadata
+func NewCip88RegistrationFromMetadata(metadata common.TransactionMetadata) (*Cip88Registration, error) {
+	md, err := labelValue(metadata, LabelCip88)
+	if err != nil {
+		return nil, err
+	}
+	return NewCip88RegistrationFromMetadatum(md)
+}
+
+// NewCip88RegistrationFromMetadatum parses a CIP-88 registration from the value for its metadata label
+func NewCip88RegistrationFromMetadatum(md common.TransactionMetadatum) (*Cip88Registration, error) {
+	if md.Type() != common.TransactionMetadatumTypeMap {
+		return nil, errors.New("invalid CIP-88 registration: expected map")
+	}
+	ret := &Cip88Registration{}
+	version, ok := md.MapValue(intKey(0))
+	if !ok {
+		return nil, errors.New("invalid CIP-88 registration: missing version")
+	}
+	tmpVersion, err := metadatumUint(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIP-88 registration: version: %w", err)
+	}
+	ret.Version = tmpVersion
+	payload, ok := md.MapValue(intKey(1))
+	if !ok {
+		return nil, errors.New("invalid CIP-88 registration: missing payload")
+	}
+	tmpPayload, err := newCip88Payload(payload)
+	if err != nil {
+		return nil, err
+	}
+	ret.Payload = *tmpPayload
+	if witnesses, ok := md.MapValue(intKey(2)); ok {
+		if witnesses.Type() != common.TransactionMetadatumTypeList {
+			return nil, errors.New("invalid CIP-88 registration: expected list for witnesses")
+		}
+		ret.Witnesses = witnesses.List()
+	}
+	return ret, nil
+}
+
+func newCip88Payload(md common.TransactionMetadatum) (*Cip88Payload, error) {
+	if md.Type() != common.TransactionMetadatumTypeMap {
+		return nil, errors.New("invalid CIP-88 payload: expected map")
+	}
+	ret := &Cip88Payload{
+		Raw: md,
+	}
+	// Scope
+	scope, ok := md.MapValue(intKey(1))
+	if !ok {
+		return nil, errors.New("invalid CIP-88 payload: missing scope")
+	}
+	scopeItems := scope.List()
+	if len(scopeItems) < 2 || scopeItems[1].Type() != common.TransactionMetadatumTypeBytes {
+		return nil, errors.New("invalid CIP-88 payload: invalid scope")
+	}
+	scopeType, err := metadatumUint(scopeItems[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIP-88 payload: scope type: %w", err)
+	}
+	ret.Scope = Cip88Scope{
+		Type:  scopeType,
+		Id:    scopeItems[1].Bytes(),
+		Extra: scopeItems[2:],
+	}
+	// Feature sets
+	if featureSets, ok := md.MapValue(intKey(2)); ok {
+		if featureSets.Type() != common.TransactionMetadatumTypeList {
+			return nil, errors.New("invalid CIP-88 payload: expected list for feature sets")
+		}
+		for _, item := range featureSets.List() {
+			featureSet, err := metadatumUint(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIP-88 payload: feature set: %w", err)
+			}
+			ret.FeatureSets = append(ret.FeatureSets, featureSet)
+		}
+	}
+	// Validation method
+	validation, ok := md.MapValue(intKey(3))
+	if !ok {
+		return nil, errors.New("invalid CIP-88 payload: missing validation method")
+	}
+	validationItems := validation.List()
+	if len(validationItems) < 1 {
+		return nil, errors.New("invalid CIP-88 payload: invalid validation method")
+	}
+	validationType, err := metadatumUint(validationItems[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIP-88 payload: validation method: %w", err)
+	}
+	ret.ValidationMethod = Cip88ValidationMethod{
+		Type:  validationType,
+		Extra: validationItems[1:],
+	}
+	// Nonce
+	nonce, ok := md.MapValue(intKey(4))
+	if !ok {
+		return nil, errors.New("invalid CIP-88 payload: missing nonce")
+	}
+	if ret.Nonce, err = metadatumUint(nonce); err != nil {
+		return nil, fmt.Errorf("invalid CIP-88 payload: nonce: %w", err)
+	}
+	// Optional details
+	if details, ok := md.MapValue(intKey(5)); ok {
+		ret.Details = &details
+	}
+	// Calidus key
+	if calidusKey, ok := md.MapValue(intKey(7)); ok {
+		if calidusKey.Type() != common.TransactionMetadatumTypeBytes {
+			return nil, errors.New("invalid CIP-88 payload: expected bytes for Calidus key")
+		}
+		ret.CalidusKey = calidusKey.Bytes()
+	}
+	return ret, nil
+}
+
+// Hash returns the Blake2b-256 hash of the CBOR encoding of the payload, which is signed by the
+// witnesses
+func (p *Cip88Payload) Hash() (common.Blake2b256, error) {
+	payloadCbor, err := cbor.Encode(p.Raw)
+	if err != nil {
+		return common.Blake2b256{}, err
+	}
+	return common.Blake2b256Hash(payloadCbor), nil
+}
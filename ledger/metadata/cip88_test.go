@@ -0,0 +1,126 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/metadata"
+)
+
+func TestCip88Registration(t *testing.T) {
+	md, err := common.NewTransactionMetadatumFromNoSchemaJSON(
+		[]byte(`{
+			"0": 1,
+			"1": {
+				"1": [0, "0x` + testCip25PolicyId + `", ["0x8200581c", "0x4d01"]],
+				"2": [25, 68],
+				"3": [0],
+				"4": 12345,
+				"5": {"25": {"name": "Test"}}
+			},
+			"2": [["0xaa", "0xbb"]]
+		}`),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	reg, err := metadata.NewCip88RegistrationFromMetadata(
+		common.TransactionMetadata{metadata.LabelCip88: md},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if reg.Version != 1 {
+		t.Errorf("did not get expected version: %d", reg.Version)
+	}
+	payload := reg.Payload
+	if payload.Scope.Type != metadata.Cip88ScopeTypeTokenPolicy || hex.EncodeToString(payload.Scope.Id) != testCip25PolicyId || len(payload.Scope.Extra) != 1 {
+		t.Errorf("did not get expected scope: %#v", payload.Scope)
+	}
+	if !slices.Equal(payload.FeatureSets, []uint64{25, 68}) {
+		t.Errorf("did not get expected feature sets: %v", payload.FeatureSets)
+	}
+	if payload.ValidationMethod.Type != metadata.Cip88ValidationMethodEd25519 {
+		t.Errorf("did not get expected validation method: %d", payload.ValidationMethod.Type)
+	}
+	if payload.Nonce != 12345 || payload.Details == nil || payload.CalidusKey != nil {
+		t.Errorf("did not get expected payload: %#v", payload)
+	}
+	if len(reg.Witnesses) != 1 {
+		t.Errorf("did not get expected witnesses: %d", len(reg.Witnesses))
+	}
+	// The payload hash covers the payload encoding
+	payloadMd, _ := md.MapValue(common.NewMetadatumInt(1))
+	payloadCbor, err := cbor.Encode(payloadMd)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	hash, err := payload.Hash()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if hash != common.Blake2b256Hash(payloadCbor) {
+		t.Errorf("did not get expected payload hash: %s", hash)
+	}
+}
+
+func TestCip88RegistrationCalidus(t *testing.T) {
+	md, err := common.NewTransactionMetadatumFromNoSchemaJSON(
+		[]byte(`{
+			"0": 2,
+			"1": {
+				"1": [1, "0x` + testCip25PolicyId + `"],
+				"2": [],
+				"3": [2],
+				"4": 99,
+				"7": "0x57758911253f6b31df2a87c10eb08a2c9b8450768cb8dd0d378d93f7c2e220f0"
+			},
+			"2": []
+		}`),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	reg, err := metadata.NewCip88RegistrationFromMetadatum(md)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if reg.Payload.Scope.Type != metadata.Cip88ScopeTypeStakePool {
+		t.Errorf("did not get expected scope type: %d", reg.Payload.Scope.Type)
+	}
+	if len(reg.Payload.CalidusKey) != 32 {
+		t.Errorf("did not get expected Calidus key: %x", reg.Payload.CalidusKey)
+	}
+	if reg.Payload.ValidationMethod.Type != metadata.Cip88ValidationMethodCip8 {
+		t.Errorf("did not get expected validation method: %d", reg.Payload.ValidationMethod.Type)
+	}
+}
+
+func TestCip88RegistrationErrors(t *testing.T) {
+	md, err := common.NewTransactionMetadatumFromNoSchemaJSON(
+		[]byte(`{"0": 1, "1": {"1": [0], "3": [0], "4": 1}}`),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := metadata.NewCip88RegistrationFromMetadatum(md); err == nil {
+		t.Errorf("did not get expected error for invalid scope")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metadata implements parsers for on-chain metadata standards, such as CIP-20 messages and
+// CIP-25 NFT metadata in transaction metadata, and CIP-68 metadata in datums
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// ErrLabelNotFound is returned when the metadata does not contain the label for a standard
+var ErrLabelNotFound = errors.New("metadata label not found")
+
+func textKey(key string) common.TransactionMetadatum {
+	return common.NewMetadatumText(key)
+}
+
+func intKey(key int64) common.TransactionMetadatum {
+	return common.NewMetadatumInt(key)
+}
+
+// metadatumUint returns the value of an integer that must fit in a uint64
+func metadatumUint(md common.TransactionMetadatum) (uint64, error) {
+	tmpInt := md.Integer()
+	if tmpInt == nil || !tmpInt.IsUint64() {
+		return 0, errors.New("expected unsigned integer")
+	}
+	return tmpInt.Uint64(), nil
+}
+
+// metadatumString returns the value of a text string, or the concatenation of a list of text strings.
+// Lists are commonly used for values longer than the 64-byte limit on metadata strings
+func metadatumString(md common.TransactionMetadatum) (string, error) {
+	if text, ok := md.Text(); ok {
+		return text, nil
+	}
+	if md.Type() != common.TransactionMetadatumTypeList {
+		return "", errors.New("expected text or list of text")
+	}
+	var sb strings.Builder
+	for _, item := range md.List() {
+		text, ok := item.Text()
+		if !ok {
+			return "", errors.New("expected text or list of text")
+		}
+		sb.WriteString(text)
+	}
+	return sb.String(), nil
+}
+
+// metadatumStringList returns the items of a list of text strings
+func metadatumStringList(md common.TransactionMetadatum) ([]string, error) {
+	if md.Type() != common.TransactionMetadatumTypeList {
+		return nil, errors.New("expected list of text")
+	}
+	ret := make([]string, 0, len(md.List()))
+	for _, item := range md.List() {
+		text, ok := item.Text()
+		if !ok {
+			return nil, errors.New("expected list of text")
+		}
+		ret = append(ret, text)
+	}
+	return ret, nil
+}
+
+// splitString splits a string into chunks which fit within the size limit for metadata strings,
+// without splitting UTF-8 characters
+func splitString(value string) []common.TransactionMetadatum {
+	var ret []common.TransactionMetadatum
+	for len(value) > common.MetadatumMaxStringSize {
+		idx := common.MetadatumMaxStringSize
+		// Back up to the start of a UTF-8 character
+		for idx > 0 && value[idx]&0xc0 == 0x80 {
+			idx--
+		}
+		ret = append(ret, common.NewMetadatumText(value[:idx]))
+		value = value[idx:]
+	}
+	return append(ret, common.NewMetadatumText(value))
+}
+
+// labelValue returns the value for a label from transaction metadata
+func labelValue(metadata common.TransactionMetadata, label uint64) (common.TransactionMetadatum, error) {
+	md, ok := metadata[label]
+	if !ok {
+		return common.TransactionMetadatum{}, fmt.Errorf("%w: %d", ErrLabelNotFound, label)
+	}
+	return md, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/blinklabs-io/gouroboros/ledger/allegra"
+	"github.com/blinklabs-io/gouroboros/ledger/alonzo"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/conway"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+type OperationalCertificate = common.OperationalCertificate
+
+// NewOperationalCertificateFromHeader returns the operational certificate from a block header from
+// any era from Shelley onward
+func NewOperationalCertificateFromHeader(header common.BlockHeader) (*OperationalCertificate, error) {
+	var ret OperationalCertificate
+	switch h := header.(type) {
+	case *shelley.ShelleyBlockHeader:
+		ret = shelleyHeaderOpCert(h)
+	case *allegra.AllegraBlockHeader:
+		ret = shelleyHeaderOpCert(&h.ShelleyBlockHeader)
+	case *mary.MaryBlockHeader:
+		ret = shelleyHeaderOpCert(&h.ShelleyBlockHeader)
+	case *alonzo.AlonzoBlockHeader:
+		ret = shelleyHeaderOpCert(&h.ShelleyBlockHeader)
+	case *babbage.BabbageBlockHeader:
+		ret = h.Body.OpCert
+	case *conway.ConwayBlockHeader:
+		ret = h.Body.OpCert
+	default:
+		return nil, fmt.Errorf("unsupported block header type: %T", header)
+	}
+	return &ret, nil
+}
+
+// shelleyHeaderOpCert returns the operational certificate from a TPraos header, which has the
+// certificate fields inline in the header body
+func shelleyHeaderOpCert(h *shelley.ShelleyBlockHeader) OperationalCertificate {
+	return OperationalCertificate{
+		HotVkey:        h.Body.OpCertHotVkey,
+		SequenceNumber: h.Body.OpCertSequenceNumber,
+		KesPeriod:      h.Body.OpCertKesPeriod,
+		Signature:      h.Body.OpCertSignature,
+	}
+}
+
+// checkOpCertCounter checks an operational certificate counter against the last seen counter for
+// the pool. The counter may stay the same or increase by one
+func checkOpCertCounter(counter uint64, lastCounter uint64) error {
+	if counter < lastCounter {
+		return fmt.Errorf("opcert counter %d is less than last seen counter %d", counter, lastCounter)
+	}
+	if counter > lastCounter+1 {
+		return fmt.Errorf(
+			"opcert counter %d is more than one greater than last seen counter %d",
+			counter,
+			lastCounter,
+		)
+	}
+	return nil
+}
+
+// OpCertCounterTracker tracks the last seen operational certificate counter for each pool and
+// enforces that the counter never decreases and only increases by at most one at a time. A pool
+// without a last seen counter is treated as having a counter of 0, which matches the node behavior
+// for pools in the stake distribution
+type OpCertCounterTracker struct {
+	mu       sync.Mutex
+	counters map[common.Blake2b224]uint64
+}
+
+// NewOpCertCounterTracker returns a tracker starting from the specified last seen counters, which
+// may be nil
+func NewOpCertCounterTracker(counters map[common.Blake2b224]uint64) *OpCertCounterTracker {
+	ret := &OpCertCounterTracker{
+		counters: make(map[common.Blake2b224]uint64, len(counters)),
+	}
+	maps.Copy(ret.counters, counters)
+	return ret
+}
+
+// Counter returns the last seen counter for the pool
+func (t *OpCertCounterTracker) Counter(poolId common.Blake2b224) (uint64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ret, ok := t.counters[poolId]
+	return ret, ok
+}
+
+// Counters returns a copy of the last seen counters for all pools, which is suitable for use as
+// HeaderChainState.OpCertCounters
+func (t *OpCertCounterTracker) Counters() map[common.Blake2b224]uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return maps.Clone(t.counters)
+}
+
+// Check checks the counter for the pool without updating the tracker
+func (t *OpCertCounterTracker) Check(poolId common.Blake2b224, counter uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return checkOpCertCounter(counter, t.counters[poolId])
+}
+
+// Update checks the counter for the pool and records it as the last seen counter if it's valid
+func (t *OpCertCounterTracker) Update(poolId common.Blake2b224, counter uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := checkOpCertCounter(counter, t.counters[poolId]); err != nil {
+		return fmt.Errorf("pool %s: %w", poolId.String(), err)
+	}
+	t.counters[poolId] = counter
+	return nil
+}
+
+// UpdateFromHeader checks the operational certificate counter in a block header for the issuing
+// pool and records it if it's valid
+func (t *OpCertCounterTracker) UpdateFromHeader(header common.BlockHeader) error {
+	opCert, err := NewOperationalCertificateFromHeader(header)
+	if err != nil {
+		return err
+	}
+	return t.Update(header.IssuerVkey().Hash(), uint64(opCert.SequenceNumber))
+}
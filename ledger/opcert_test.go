@@ -0,0 +1,152 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+func TestNewOperationalCertificateFromHeader(t *testing.T) {
+	praosHeaderCbor, err := hex.DecodeString(testPraosHeaderHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	praosHeader, err := ledger.NewConwayBlockHeaderFromCbor(praosHeaderCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tpraosHeaderCbor, err := hex.DecodeString(testTPraosHeaderHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tpraosHeader, err := ledger.NewShelleyBlockHeaderFromCbor(tpraosHeaderCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testDefs := []struct {
+		header            common.BlockHeader
+		expectedCounter   uint32
+		expectedKesPeriod uint32
+	}{
+		{header: praosHeader, expectedCounter: 10, expectedKesPeriod: 1046},
+		{header: tpraosHeader, expectedCounter: 0, expectedKesPeriod: 0},
+	}
+	for _, testDef := range testDefs {
+		opCert, err := ledger.NewOperationalCertificateFromHeader(testDef.header)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if opCert.SequenceNumber != testDef.expectedCounter ||
+			opCert.KesPeriod != testDef.expectedKesPeriod {
+			t.Fatalf(
+				"did not get expected counter and KES period: %d, %d",
+				opCert.SequenceNumber,
+				opCert.KesPeriod,
+			)
+		}
+		issuerVkey := testDef.header.IssuerVkey()
+		if err := opCert.Verify(issuerVkey[:]); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := opCert.KesPeriodValid(uint64(opCert.KesPeriod)+62, 62); err == nil {
+			t.Fatalf("did not get expected error for expired opcert")
+		}
+	}
+	if _, err := ledger.NewOperationalCertificateFromHeader(&ledger.ByronMainBlockHeader{}); err == nil {
+		t.Fatalf("did not get expected error for Byron header")
+	}
+}
+
+func TestOpCertCounterTracker(t *testing.T) {
+	poolA := common.Blake2b224{0x01}
+	poolB := common.Blake2b224{0x02}
+	tracker := ledger.NewOpCertCounterTracker(
+		map[common.Blake2b224]uint64{
+			poolA: 5,
+		},
+	)
+	testDefs := []struct {
+		poolId    common.Blake2b224
+		counter   uint64
+		expectErr bool
+	}{
+		// Same counter
+		{poolId: poolA, counter: 5},
+		// Increase by one
+		{poolId: poolA, counter: 6},
+		// Decrease
+		{poolId: poolA, counter: 5, expectErr: true},
+		// Increase by more than one
+		{poolId: poolA, counter: 8, expectErr: true},
+		// Unknown pools start from 0
+		{poolId: poolB, counter: 2, expectErr: true},
+		{poolId: poolB, counter: 1},
+	}
+	for _, testDef := range testDefs {
+		if err := tracker.Check(testDef.poolId, testDef.counter); (err != nil) != testDef.expectErr {
+			t.Fatalf("unexpected result checking counter %d: %v", testDef.counter, err)
+		}
+		err := tracker.Update(testDef.poolId, testDef.counter)
+		if testDef.expectErr {
+			if err == nil {
+				t.Fatalf("did not get expected error for counter %d", testDef.counter)
+			}
+		} else if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	counters := tracker.Counters()
+	if counters[poolA] != 6 || counters[poolB] != 1 {
+		t.Fatalf("did not get expected counters: %v", counters)
+	}
+	if _, ok := tracker.Counter(common.Blake2b224{0x03}); ok {
+		t.Fatalf("did not expect counter for unknown pool")
+	}
+}
+
+func TestOpCertCounterTrackerUpdateFromHeader(t *testing.T) {
+	headerCbor, err := hex.DecodeString(testPraosHeaderHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	header, err := ledger.NewConwayBlockHeaderFromCbor(headerCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	poolId := header.IssuerVkey().Hash()
+	tracker := ledger.NewOpCertCounterTracker(
+		map[common.Blake2b224]uint64{
+			poolId: 9,
+		},
+	)
+	if err := tracker.UpdateFromHeader(header); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if counter, _ := tracker.Counter(poolId); counter != 10 {
+		t.Fatalf("did not get expected counter: %d", counter)
+	}
+	tracker = ledger.NewOpCertCounterTracker(
+		map[common.Blake2b224]uint64{
+			poolId: 11,
+		},
+	)
+	if err := tracker.UpdateFromHeader(header); err == nil {
+		t.Fatalf("did not get expected error")
+	}
+}
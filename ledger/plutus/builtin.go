@@ -0,0 +1,284 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus
+
+import (
+	"fmt"
+)
+
+// BuiltinFunc identifies a builtin function. The values match the flat encoding of builtins
+type BuiltinFunc uint8
+
+const (
+	BuiltinAddInteger BuiltinFunc = iota
+	BuiltinSubtractInteger
+	BuiltinMultiplyInteger
+	BuiltinDivideInteger
+	BuiltinQuotientInteger
+	BuiltinRemainderInteger
+	BuiltinModInteger
+	BuiltinEqualsInteger
+	BuiltinLessThanInteger
+	BuiltinLessThanEqualsInteger
+	BuiltinAppendByteString
+	BuiltinConsByteString
+	BuiltinSliceByteString
+	BuiltinLengthOfByteString
+	BuiltinIndexByteString
+	BuiltinEqualsByteString
+	BuiltinLessThanByteString
+	BuiltinLessThanEqualsByteString
+	BuiltinSha2_256
+	BuiltinSha3_256
+	BuiltinBlake2b_256
+	BuiltinVerifyEd25519Signature
+	BuiltinAppendString
+	BuiltinEqualsString
+	BuiltinEncodeUtf8
+	BuiltinDecodeUtf8
+	BuiltinIfThenElse
+	BuiltinChooseUnit
+	BuiltinTrace
+	BuiltinFstPair
+	BuiltinSndPair
+	BuiltinChooseList
+	BuiltinMkCons
+	BuiltinHeadList
+	BuiltinTailList
+	BuiltinNullList
+	BuiltinChooseData
+	BuiltinConstrData
+	BuiltinMapData
+	BuiltinListData
+	BuiltinIData
+	BuiltinBData
+	BuiltinUnConstrData
+	BuiltinUnMapData
+	BuiltinUnListData
+	BuiltinUnIData
+	BuiltinUnBData
+	BuiltinEqualsData
+	BuiltinMkPairData
+	BuiltinMkNilData
+	BuiltinMkNilPairData
+	BuiltinSerialiseData
+	BuiltinVerifyEcdsaSecp256k1Signature
+	BuiltinVerifySchnorrSecp256k1Signature
+	BuiltinBls12_381_G1_Add
+	BuiltinBls12_381_G1_Neg
+	BuiltinBls12_381_G1_ScalarMul
+	BuiltinBls12_381_G1_Equal
+	BuiltinBls12_381_G1_Compress
+	BuiltinBls12_381_G1_Uncompress
+	BuiltinBls12_381_G1_HashToGroup
+	BuiltinBls12_381_G2_Add
+	BuiltinBls12_381_G2_Neg
+	BuiltinBls12_381_G2_ScalarMul
+	BuiltinBls12_381_G2_Equal
+	BuiltinBls12_381_G2_Compress
+	BuiltinBls12_381_G2_Uncompress
+	BuiltinBls12_381_G2_HashToGroup
+	BuiltinBls12_381_MillerLoop
+	BuiltinBls12_381_MulMlResult
+	BuiltinBls12_381_FinalVerify
+	BuiltinKeccak_256
+	BuiltinBlake2b_224
+	BuiltinIntegerToByteString
+	BuiltinByteStringToInteger
+	BuiltinAndByteString
+	BuiltinOrByteString
+	BuiltinXorByteString
+	BuiltinComplementByteString
+	BuiltinReadBit
+	BuiltinWriteBits
+	BuiltinReplicateByte
+	BuiltinShiftByteString
+	BuiltinRotateByteString
+	BuiltinCountSetBits
+	BuiltinFindFirstSetBit
+	BuiltinRipemd_160
+)
+
+type builtinInfo struct {
+	name   string
+	forces int
+	arity  int
+}
+
+var builtinInfos = []builtinInfo{
+	BuiltinAddInteger:                      {"addInteger", 0, 2},
+	BuiltinSubtractInteger:                 {"subtractInteger", 0, 2},
+	BuiltinMultiplyInteger:                 {"multiplyInteger", 0, 2},
+	BuiltinDivideInteger:                   {"divideInteger", 0, 2},
+	BuiltinQuotientInteger:                 {"quotientInteger", 0, 2},
+	BuiltinRemainderInteger:                {"remainderInteger", 0, 2},
+	BuiltinModInteger:                      {"modInteger", 0, 2},
+	BuiltinEqualsInteger:                   {"equalsInteger", 0, 2},
+	BuiltinLessThanInteger:                 {"lessThanInteger", 0, 2},
+	BuiltinLessThanEqualsInteger:           {"lessThanEqualsInteger", 0, 2},
+	BuiltinAppendByteString:                {"appendByteString", 0, 2},
+	BuiltinConsByteString:                  {"consByteString", 0, 2},
+	BuiltinSliceByteString:                 {"sliceByteString", 0, 3},
+	BuiltinLengthOfByteString:              {"lengthOfByteString", 0, 1},
+	BuiltinIndexByteString:                 {"indexByteString", 0, 2},
+	BuiltinEqualsByteString:                {"equalsByteString", 0, 2},
+	BuiltinLessThanByteString:              {"lessThanByteString", 0, 2},
+	BuiltinLessThanEqualsByteString:        {"lessThanEqualsByteString", 0, 2},
+	BuiltinSha2_256:                        {"sha2_256", 0, 1},
+	BuiltinSha3_256:                        {"sha3_256", 0, 1},
+	BuiltinBlake2b_256:                     {"blake2b_256", 0, 1},
+	BuiltinVerifyEd25519Signature:          {"verifyEd25519Signature", 0, 3},
+	BuiltinAppendString:                    {"appendString", 0, 2},
+	BuiltinEqualsString:                    {"equalsString", 0, 2},
+	BuiltinEncodeUtf8:                      {"encodeUtf8", 0, 1},
+	BuiltinDecodeUtf8:                      {"decodeUtf8", 0, 1},
+	BuiltinIfThenElse:                      {"ifThenElse", 1, 3},
+	BuiltinChooseUnit:                      {"chooseUnit", 1, 2},
+	BuiltinTrace:                           {"trace", 1, 2},
+	BuiltinFstPair:                         {"fstPair", 2, 1},
+	BuiltinSndPair:                         {"sndPair", 2, 1},
+	BuiltinChooseList:                      {"chooseList", 2, 3},
+	BuiltinMkCons:                          {"mkCons", 1, 2},
+	BuiltinHeadList:                        {"headList", 1, 1},
+	BuiltinTailList:                        {"tailList", 1, 1},
+	BuiltinNullList:                        {"nullList", 1, 1},
+	BuiltinChooseData:                      {"chooseData", 1, 6},
+	BuiltinConstrData:                      {"constrData", 0, 2},
+	BuiltinMapData:                         {"mapData", 0, 1},
+	BuiltinListData:                        {"listData", 0, 1},
+	BuiltinIData:                           {"iData", 0, 1},
+	BuiltinBData:                           {"bData", 0, 1},
+	BuiltinUnConstrData:                    {"unConstrData", 0, 1},
+	BuiltinUnMapData:                       {"unMapData", 0, 1},
+	BuiltinUnListData:                      {"unListData", 0, 1},
+	BuiltinUnIData:                         {"unIData", 0, 1},
+	BuiltinUnBData:                         {"unBData", 0, 1},
+	BuiltinEqualsData:                      {"equalsData", 0, 2},
+	BuiltinMkPairData:                      {"mkPairData", 0, 2},
+	BuiltinMkNilData:                       {"mkNilData", 0, 1},
+	BuiltinMkNilPairData:                   {"mkNilPairData", 0, 1},
+	BuiltinSerialiseData:                   {"serialiseData", 0, 1},
+	BuiltinVerifyEcdsaSecp256k1Signature:   {"verifyEcdsaSecp256k1Signature", 0, 3},
+	BuiltinVerifySchnorrSecp256k1Signature: {"verifySchnorrSecp256k1Signature", 0, 3},
+	BuiltinBls12_381_G1_Add:                {"bls12_381_G1_add", 0, 2},
+	BuiltinBls12_381_G1_Neg:                {"bls12_381_G1_neg", 0, 1},
+	BuiltinBls12_381_G1_ScalarMul:          {"bls12_381_G1_scalarMul", 0, 2},
+	BuiltinBls12_381_G1_Equal:              {"bls12_381_G1_equal", 0, 2},
+	BuiltinBls12_381_G1_Compress:           {"bls12_381_G1_compress", 0, 1},
+	BuiltinBls12_381_G1_Uncompress:         {"bls12_381_G1_uncompress", 0, 1},
+	BuiltinBls12_381_G1_HashToGroup:        {"bls12_381_G1_hashToGroup", 0, 2},
+	BuiltinBls12_381_G2_Add:                {"bls12_381_G2_add", 0, 2},
+	BuiltinBls12_381_G2_Neg:                {"bls12_381_G2_neg", 0, 1},
+	BuiltinBls12_381_G2_ScalarMul:          {"bls12_381_G2_scalarMul", 0, 2},
+	BuiltinBls12_381_G2_Equal:              {"bls12_381_G2_equal", 0, 2},
+	BuiltinBls12_381_G2_Compress:           {"bls12_381_G2_compress", 0, 1},
+	BuiltinBls12_381_G2_Uncompress:         {"bls12_381_G2_uncompress", 0, 1},
+	BuiltinBls12_381_G2_HashToGroup:        {"bls12_381_G2_hashToGroup", 0, 2},
+	BuiltinBls12_381_MillerLoop:            {"bls12_381_millerLoop", 0, 2},
+	BuiltinBls12_381_MulMlResult:           {"bls12_381_mulMlResult", 0, 2},
+	BuiltinBls12_381_FinalVerify:           {"bls12_381_finalVerify", 0, 2},
+	BuiltinKeccak_256:                      {"keccak_256", 0, 1},
+	BuiltinBlake2b_224:                     {"blake2b_224", 0, 1},
+	BuiltinIntegerToByteString:             {"integerToByteString", 0, 3},
+	BuiltinByteStringToInteger:             {"byteStringToInteger", 0, 2},
+	BuiltinAndByteString:                   {"andByteString", 0, 3},
+	BuiltinOrByteString:                    {"orByteString", 0, 3},
+	BuiltinXorByteString:                   {"xorByteString", 0, 3},
+	BuiltinComplementByteString:            {"complementByteString", 0, 1},
+	BuiltinReadBit:                         {"readBit", 0, 2},
+	BuiltinWriteBits:                       {"writeBits", 0, 3},
+	BuiltinReplicateByte:                   {"replicateByte", 0, 2},
+	BuiltinShiftByteString:                 {"shiftByteString", 0, 2},
+	BuiltinRotateByteString:                {"rotateByteString", 0, 2},
+	BuiltinCountSetBits:                    {"countSetBits", 0, 1},
+	BuiltinFindFirstSetBit:                 {"findFirstSetBit", 0, 1},
+	BuiltinRipemd_160:                      {"ripemd_160", 0, 1},
+}
+
+func (f BuiltinFunc) valid() bool {
+	return int(f) < len(builtinInfos)
+}
+
+func (f BuiltinFunc) String() string {
+	if !f.valid() {
+		return fmt.Sprintf("unknown(%d)", uint8(f))
+	}
+	return builtinInfos[f].name
+}
+
+// Forces returns the number of times the builtin must be forced before it can be applied
+func (f BuiltinFunc) Forces() int {
+	return builtinInfos[f].forces
+}
+
+// Arity returns the number of arguments taken by the builtin
+func (f BuiltinFunc) Arity() int {
+	return builtinInfos[f].arity
+}
+
+// BuiltinFuncByName returns the builtin with the specified name
+func BuiltinFuncByName(name string) (BuiltinFunc, bool) {
+	for idx, info := range builtinInfos {
+		if info.name == name {
+			return BuiltinFunc(idx), true
+		}
+	}
+	return 0, false
+}
+
+// LanguageVersion is a Plutus ledger language version
+type LanguageVersion uint
+
+const (
+	LanguageVersionV1 LanguageVersion = 1
+	LanguageVersionV2 LanguageVersion = 2
+	LanguageVersionV3 LanguageVersion = 3
+)
+
+func (l LanguageVersion) String() string {
+	return fmt.Sprintf("PlutusV%d", uint(l))
+}
+
+// costModelKey returns the key used for the language in the protocol parameters cost models
+func (l LanguageVersion) costModelKey() uint {
+	return uint(l) - 1
+}
+
+// builtinAllowed returns whether the builtin can be used by scripts of the specified language.
+// Builtins which are allowed may still be unavailable if the cost model does not provide their
+// parameters
+func (l LanguageVersion) builtinAllowed(f BuiltinFunc) bool {
+	switch l {
+	case LanguageVersionV1:
+		return f <= BuiltinMkNilPairData
+	case LanguageVersionV2:
+		return f <= BuiltinVerifySchnorrSecp256k1Signature ||
+			f == BuiltinIntegerToByteString ||
+			f == BuiltinByteStringToInteger
+	case LanguageVersionV3:
+		return f.valid()
+	default:
+		return false
+	}
+}
+
+// programVersionAllowed returns whether the Plutus Core version can be used by scripts of the
+// specified language
+func (l LanguageVersion) programVersionAllowed(v Version) bool {
+	if v == Version100 {
+		return true
+	}
+	return v == Version110 && l == LanguageVersionV3
+}
@@ -0,0 +1,1276 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"unicode/utf8"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const (
+	// Maximum size of the result of integerToByteString and replicateByte
+	maxByteStringBuiltinSize = 8192
+)
+
+var errDivisionByZero = errors.New("division by zero")
+
+// constantSize returns the size of a constant used for costing builtins, in machine words for
+// most types
+func constantSize(c Constant) int64 {
+	switch v := c.(type) {
+	case Integer:
+		return integerSize(v.Value)
+	case ByteString:
+		return byteStringSize(len(v.Value))
+	case Text:
+		return int64(utf8.RuneCountInString(v.Value))
+	case Unit, Bool:
+		return 1
+	case List:
+		var ret int64
+		for _, item := range v.Items {
+			ret = satAdd(ret, constantSize(item))
+		}
+		return ret
+	case Pair:
+		return satAdd(satAdd(1, constantSize(v.First)), constantSize(v.Second))
+	case Data:
+		return dataSize(v.Value)
+	case G1Element:
+		return 18
+	case G2Element:
+		return 36
+	case MlResult:
+		return 72
+	default:
+		return 1
+	}
+}
+
+func integerSize(value *big.Int) int64 {
+	if value.Sign() == 0 {
+		return 1
+	}
+	return int64((value.BitLen()-1)/64 + 1)
+}
+
+func byteStringSize(length int) int64 {
+	if length == 0 {
+		return 1
+	}
+	return int64((length-1)/8 + 1)
+}
+
+func dataSize(d common.PlutusData) int64 {
+	// Each node has a fixed cost in addition to the size of its contents
+	var ret int64 = 4
+	switch d.Type() {
+	case common.PlutusDataTypeConstr:
+		for _, field := range d.Constr().Fields {
+			ret = satAdd(ret, dataSize(field))
+		}
+	case common.PlutusDataTypeMap:
+		for _, pair := range d.Map() {
+			ret = satAdd(ret, dataSize(pair.Key))
+			ret = satAdd(ret, dataSize(pair.Value))
+		}
+	case common.PlutusDataTypeList:
+		for _, item := range d.List() {
+			ret = satAdd(ret, dataSize(item))
+		}
+	case common.PlutusDataTypeInteger:
+		ret = satAdd(ret, integerSize(d.Integer()))
+	case common.PlutusDataTypeBytes:
+		ret = satAdd(ret, byteStringSize(len(d.Bytes())))
+	}
+	return ret
+}
+
+// wordsSize returns the size of a byte count as a number of machine words, as used for costing
+// the size arguments of some builtins
+func wordsSize(value *big.Int) int64 {
+	if !value.IsInt64() {
+		if value.Sign() < 0 {
+			return 0
+		}
+		return math.MaxInt64
+	}
+	n := value.Int64()
+	if n <= 0 {
+		return 0
+	}
+	return (n-1)/8 + 1
+}
+
+// builtinArgSizes returns the sizes of the builtin arguments used for costing
+func builtinArgSizes(fun BuiltinFunc, args []value) []int64 {
+	ret := make([]int64, len(args))
+	for idx, arg := range args {
+		c, ok := arg.(constantValue)
+		if !ok {
+			ret[idx] = 1
+			continue
+		}
+		ret[idx] = constantSize(c.constant)
+		switch {
+		case fun == BuiltinIntegerToByteString && idx == 1,
+			fun == BuiltinReplicateByte && idx == 0:
+			if v, ok := c.constant.(Integer); ok {
+				ret[idx] = wordsSize(v.Value)
+			}
+		case fun == BuiltinWriteBits && idx == 1:
+			if v, ok := c.constant.(List); ok {
+				ret[idx] = int64(len(v.Items))
+			}
+		}
+	}
+	return ret
+}
+
+func argConstant(v value) (Constant, error) {
+	c, ok := v.(constantValue)
+	if !ok {
+		return nil, errors.New("expected a constant argument")
+	}
+	return c.constant, nil
+}
+
+func argInteger(v value) (*big.Int, error) {
+	c, err := argConstant(v)
+	if err != nil {
+		return nil, err
+	}
+	tmp, ok := c.(Integer)
+	if !ok {
+		return nil, fmt.Errorf("expected integer argument, got %s", c.Type())
+	}
+	return tmp.Value, nil
+}
+
+func argByteString(v value) ([]byte, error) {
+	c, err := argConstant(v)
+	if err != nil {
+		return nil, err
+	}
+	tmp, ok := c.(ByteString)
+	if !ok {
+		return nil, fmt.Errorf("expected bytestring argument, got %s", c.Type())
+	}
+	return tmp.Value, nil
+}
+
+func argString(v value) (string, error) {
+	c, err := argConstant(v)
+	if err != nil {
+		return "", err
+	}
+	tmp, ok := c.(Text)
+	if !ok {
+		return "", fmt.Errorf("expected string argument, got %s", c.Type())
+	}
+	return tmp.Value, nil
+}
+
+func argBool(v value) (bool, error) {
+	c, err := argConstant(v)
+	if err != nil {
+		return false, err
+	}
+	tmp, ok := c.(Bool)
+	if !ok {
+		return false, fmt.Errorf("expected bool argument, got %s", c.Type())
+	}
+	return tmp.Value, nil
+}
+
+func argUnit(v value) error {
+	c, err := argConstant(v)
+	if err != nil {
+		return err
+	}
+	if _, ok := c.(Unit); !ok {
+		return fmt.Errorf("expected unit argument, got %s", c.Type())
+	}
+	return nil
+}
+
+func argData(v value) (common.PlutusData, error) {
+	c, err := argConstant(v)
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	tmp, ok := c.(Data)
+	if !ok {
+		return common.PlutusData{}, fmt.Errorf("expected data argument, got %s", c.Type())
+	}
+	return tmp.Value, nil
+}
+
+func argList(v value) (List, error) {
+	c, err := argConstant(v)
+	if err != nil {
+		return List{}, err
+	}
+	tmp, ok := c.(List)
+	if !ok {
+		return List{}, fmt.Errorf("expected list argument, got %s", c.Type())
+	}
+	return tmp, nil
+}
+
+func argPair(v value) (Pair, error) {
+	c, err := argConstant(v)
+	if err != nil {
+		return Pair{}, err
+	}
+	tmp, ok := c.(Pair)
+	if !ok {
+		return Pair{}, fmt.Errorf("expected pair argument, got %s", c.Type())
+	}
+	return tmp, nil
+}
+
+func argG1(v value) (G1Element, error) {
+	c, err := argConstant(v)
+	if err != nil {
+		return G1Element{}, err
+	}
+	tmp, ok := c.(G1Element)
+	if !ok {
+		return G1Element{}, fmt.Errorf("expected G1 element argument, got %s", c.Type())
+	}
+	return tmp, nil
+}
+
+func argG2(v value) (G2Element, error) {
+	c, err := argConstant(v)
+	if err != nil {
+		return G2Element{}, err
+	}
+	tmp, ok := c.(G2Element)
+	if !ok {
+		return G2Element{}, fmt.Errorf("expected G2 element argument, got %s", c.Type())
+	}
+	return tmp, nil
+}
+
+func argMlResult(v value) (MlResult, error) {
+	c, err := argConstant(v)
+	if err != nil {
+		return MlResult{}, err
+	}
+	tmp, ok := c.(MlResult)
+	if !ok {
+		return MlResult{}, fmt.Errorf("expected Miller loop result argument, got %s", c.Type())
+	}
+	return tmp, nil
+}
+
+// argDataList returns the items from a list of data
+func argDataList(v value) ([]common.PlutusData, error) {
+	list, err := argList(v)
+	if err != nil {
+		return nil, err
+	}
+	if !list.ElemType.Equal(TypeData) {
+		return nil, fmt.Errorf("expected list of data argument, got %s", list.Type())
+	}
+	ret := make([]common.PlutusData, len(list.Items))
+	for idx, item := range list.Items {
+		ret[idx] = item.(Data).Value
+	}
+	return ret, nil
+}
+
+func constInteger(value *big.Int) value {
+	return constantValue{constant: Integer{Value: value}}
+}
+
+func constByteString(data []byte) value {
+	return constantValue{constant: ByteString{Value: data}}
+}
+
+func constBool(b bool) value {
+	return constantValue{constant: Bool{Value: b}}
+}
+
+func constData(d common.PlutusData) value {
+	return constantValue{constant: Data{Value: d}}
+}
+
+func dataList(items []common.PlutusData) List {
+	ret := List{ElemType: TypeData, Items: make([]Constant, len(items))}
+	for idx, item := range items {
+		ret.Items[idx] = Data{Value: item}
+	}
+	return ret
+}
+
+var typeDataPair = TypePair(TypeData, TypeData)
+
+func dataPairList(pairs []common.PlutusMapPair) List {
+	ret := List{ElemType: typeDataPair, Items: make([]Constant, len(pairs))}
+	for idx, pair := range pairs {
+		ret.Items[idx] = Pair{First: Data{Value: pair.Key}, Second: Data{Value: pair.Value}}
+	}
+	return ret
+}
+
+// callBuiltin runs a saturated builtin
+func (m *machine) callBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	switch fun {
+	case BuiltinAddInteger,
+		BuiltinSubtractInteger,
+		BuiltinMultiplyInteger,
+		BuiltinDivideInteger,
+		BuiltinQuotientInteger,
+		BuiltinRemainderInteger,
+		BuiltinModInteger,
+		BuiltinEqualsInteger,
+		BuiltinLessThanInteger,
+		BuiltinLessThanEqualsInteger:
+		return integerBuiltin(fun, args)
+	case BuiltinAppendByteString,
+		BuiltinConsByteString,
+		BuiltinSliceByteString,
+		BuiltinLengthOfByteString,
+		BuiltinIndexByteString,
+		BuiltinEqualsByteString,
+		BuiltinLessThanByteString,
+		BuiltinLessThanEqualsByteString:
+		return m.byteStringBuiltin(fun, args)
+	case BuiltinSha2_256,
+		BuiltinSha3_256,
+		BuiltinBlake2b_256,
+		BuiltinKeccak_256,
+		BuiltinBlake2b_224,
+		BuiltinRipemd_160:
+		return hashBuiltin(fun, args)
+	case BuiltinVerifyEd25519Signature,
+		BuiltinVerifyEcdsaSecp256k1Signature,
+		BuiltinVerifySchnorrSecp256k1Signature:
+		return signatureBuiltin(fun, args)
+	case BuiltinAppendString,
+		BuiltinEqualsString,
+		BuiltinEncodeUtf8,
+		BuiltinDecodeUtf8:
+		return stringBuiltin(fun, args)
+	case BuiltinIfThenElse,
+		BuiltinChooseUnit,
+		BuiltinTrace,
+		BuiltinFstPair,
+		BuiltinSndPair,
+		BuiltinChooseList,
+		BuiltinMkCons,
+		BuiltinHeadList,
+		BuiltinTailList,
+		BuiltinNullList:
+		return m.polymorphicBuiltin(fun, args)
+	case BuiltinChooseData,
+		BuiltinConstrData,
+		BuiltinMapData,
+		BuiltinListData,
+		BuiltinIData,
+		BuiltinBData,
+		BuiltinUnConstrData,
+		BuiltinUnMapData,
+		BuiltinUnListData,
+		BuiltinUnIData,
+		BuiltinUnBData,
+		BuiltinEqualsData,
+		BuiltinMkPairData,
+		BuiltinMkNilData,
+		BuiltinMkNilPairData,
+		BuiltinSerialiseData:
+		return dataBuiltin(fun, args)
+	case BuiltinBls12_381_G1_Add,
+		BuiltinBls12_381_G1_Neg,
+		BuiltinBls12_381_G1_ScalarMul,
+		BuiltinBls12_381_G1_Equal,
+		BuiltinBls12_381_G1_Compress,
+		BuiltinBls12_381_G1_Uncompress,
+		BuiltinBls12_381_G1_HashToGroup:
+		return blsG1Builtin(fun, args)
+	case BuiltinBls12_381_G2_Add,
+		BuiltinBls12_381_G2_Neg,
+		BuiltinBls12_381_G2_ScalarMul,
+		BuiltinBls12_381_G2_Equal,
+		BuiltinBls12_381_G2_Compress,
+		BuiltinBls12_381_G2_Uncompress,
+		BuiltinBls12_381_G2_HashToGroup:
+		return blsG2Builtin(fun, args)
+	case BuiltinBls12_381_MillerLoop,
+		BuiltinBls12_381_MulMlResult,
+		BuiltinBls12_381_FinalVerify:
+		return blsPairingBuiltin(fun, args)
+	case BuiltinIntegerToByteString,
+		BuiltinByteStringToInteger:
+		return conversionBuiltin(fun, args)
+	case BuiltinAndByteString,
+		BuiltinOrByteString,
+		BuiltinXorByteString,
+		BuiltinComplementByteString,
+		BuiltinReadBit,
+		BuiltinWriteBits,
+		BuiltinReplicateByte,
+		BuiltinShiftByteString,
+		BuiltinRotateByteString,
+		BuiltinCountSetBits,
+		BuiltinFindFirstSetBit:
+		return bitwiseBuiltin(fun, args)
+	default:
+		return nil, fmt.Errorf("unknown builtin: %d", uint8(fun))
+	}
+}
+
+func integerBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	x, err := argInteger(args[0])
+	if err != nil {
+		return nil, err
+	}
+	y, err := argInteger(args[1])
+	if err != nil {
+		return nil, err
+	}
+	ret := new(big.Int)
+	switch fun {
+	case BuiltinAddInteger:
+		ret.Add(x, y)
+	case BuiltinSubtractInteger:
+		ret.Sub(x, y)
+	case BuiltinMultiplyInteger:
+		ret.Mul(x, y)
+	case BuiltinDivideInteger, BuiltinModInteger:
+		if y.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		// Division rounds towards negative infinity, so the remainder has the same sign as the
+		// divisor
+		q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+		if r.Sign() != 0 && r.Sign() != y.Sign() {
+			q.Sub(q, big.NewInt(1))
+			r.Add(r, y)
+		}
+		if fun == BuiltinDivideInteger {
+			ret = q
+		} else {
+			ret = r
+		}
+	case BuiltinQuotientInteger:
+		if y.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		ret.Quo(x, y)
+	case BuiltinRemainderInteger:
+		if y.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		ret.Rem(x, y)
+	case BuiltinEqualsInteger:
+		return constBool(x.Cmp(y) == 0), nil
+	case BuiltinLessThanInteger:
+		return constBool(x.Cmp(y) < 0), nil
+	case BuiltinLessThanEqualsInteger:
+		return constBool(x.Cmp(y) <= 0), nil
+	}
+	return constInteger(ret), nil
+}
+
+// clampInt converts an integer to an int, clamping it to the specified range
+func clampInt(value *big.Int, lower int, upper int) int {
+	if value.Cmp(big.NewInt(int64(lower))) < 0 {
+		return lower
+	}
+	if value.Cmp(big.NewInt(int64(upper))) > 0 {
+		return upper
+	}
+	return int(value.Int64())
+}
+
+func (m *machine) byteStringBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	switch fun {
+	case BuiltinConsByteString:
+		x, err := argInteger(args[0])
+		if err != nil {
+			return nil, err
+		}
+		bs, err := argByteString(args[1])
+		if err != nil {
+			return nil, err
+		}
+		var b byte
+		if m.costModel.language >= LanguageVersionV3 {
+			if x.Sign() < 0 || x.Cmp(big.NewInt(255)) > 0 {
+				return nil, fmt.Errorf("byte value out of range: %s", x)
+			}
+			b = byte(x.Int64())
+		} else {
+			// Earlier languages wrap the value to a byte
+			b = byte(new(big.Int).Mod(x, big.NewInt(256)).Int64())
+		}
+		ret := make([]byte, 0, len(bs)+1)
+		ret = append(ret, b)
+		return constByteString(append(ret, bs...)), nil
+	case BuiltinSliceByteString:
+		start, err := argInteger(args[0])
+		if err != nil {
+			return nil, err
+		}
+		length, err := argInteger(args[1])
+		if err != nil {
+			return nil, err
+		}
+		bs, err := argByteString(args[2])
+		if err != nil {
+			return nil, err
+		}
+		from := clampInt(start, 0, len(bs))
+		to := from + clampInt(length, 0, len(bs)-from)
+		return constByteString(append([]byte{}, bs[from:to]...)), nil
+	case BuiltinLengthOfByteString:
+		bs, err := argByteString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return constInteger(big.NewInt(int64(len(bs)))), nil
+	case BuiltinIndexByteString:
+		bs, err := argByteString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		idx, err := argInteger(args[1])
+		if err != nil {
+			return nil, err
+		}
+		if idx.Sign() < 0 || idx.Cmp(big.NewInt(int64(len(bs)))) >= 0 {
+			return nil, fmt.Errorf("index out of range: %s", idx)
+		}
+		return constInteger(big.NewInt(int64(bs[idx.Int64()]))), nil
+	}
+	x, err := argByteString(args[0])
+	if err != nil {
+		return nil, err
+	}
+	y, err := argByteString(args[1])
+	if err != nil {
+		return nil, err
+	}
+	switch fun {
+	case BuiltinAppendByteString:
+		ret := make([]byte, 0, len(x)+len(y))
+		ret = append(ret, x...)
+		return constByteString(append(ret, y...)), nil
+	case BuiltinEqualsByteString:
+		return constBool(bytes.Equal(x, y)), nil
+	case BuiltinLessThanByteString:
+		return constBool(bytes.Compare(x, y) < 0), nil
+	default:
+		return constBool(bytes.Compare(x, y) <= 0), nil
+	}
+}
+
+func hashBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	data, err := argByteString(args[0])
+	if err != nil {
+		return nil, err
+	}
+	var hashFunc func([]byte) []byte
+	switch fun {
+	case BuiltinSha2_256:
+		hashFunc = hashSha2_256
+	case BuiltinSha3_256:
+		hashFunc = hashSha3_256
+	case BuiltinBlake2b_256:
+		hashFunc = hashBlake2b_256
+	case BuiltinKeccak_256:
+		hashFunc = hashKeccak_256
+	case BuiltinBlake2b_224:
+		hashFunc = hashBlake2b_224
+	default:
+		hashFunc = hashRipemd_160
+	}
+	return constByteString(hashFunc(data)), nil
+}
+
+func signatureBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	var params [3][]byte
+	for idx := range params {
+		tmp, err := argByteString(args[idx])
+		if err != nil {
+			return nil, err
+		}
+		params[idx] = tmp
+	}
+	var ret bool
+	var err error
+	switch fun {
+	case BuiltinVerifyEd25519Signature:
+		ret, err = verifyEd25519(params[0], params[1], params[2])
+	case BuiltinVerifyEcdsaSecp256k1Signature:
+		ret, err = verifyEcdsaSecp256k1(params[0], params[1], params[2])
+	default:
+		ret, err = verifySchnorrSecp256k1(params[0], params[1], params[2])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return constBool(ret), nil
+}
+
+func stringBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	switch fun {
+	case BuiltinEncodeUtf8:
+		s, err := argString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return constByteString([]byte(s)), nil
+	case BuiltinDecodeUtf8:
+		bs, err := argByteString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.Valid(bs) {
+			return nil, errors.New("invalid UTF-8")
+		}
+		return constantValue{constant: Text{Value: string(bs)}}, nil
+	}
+	x, err := argString(args[0])
+	if err != nil {
+		return nil, err
+	}
+	y, err := argString(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if fun == BuiltinAppendString {
+		return constantValue{constant: Text{Value: x + y}}, nil
+	}
+	return constBool(x == y), nil
+}
+
+func (m *machine) polymorphicBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	switch fun {
+	case BuiltinIfThenElse:
+		cond, err := argBool(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if cond {
+			return args[1], nil
+		}
+		return args[2], nil
+	case BuiltinChooseUnit:
+		if err := argUnit(args[0]); err != nil {
+			return nil, err
+		}
+		return args[1], nil
+	case BuiltinTrace:
+		msg, err := argString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		m.logs = append(m.logs, msg)
+		return args[1], nil
+	case BuiltinFstPair, BuiltinSndPair:
+		pair, err := argPair(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if fun == BuiltinFstPair {
+			return constantValue{constant: pair.First}, nil
+		}
+		return constantValue{constant: pair.Second}, nil
+	case BuiltinMkCons:
+		item, err := argConstant(args[0])
+		if err != nil {
+			return nil, err
+		}
+		list, err := argList(args[1])
+		if err != nil {
+			return nil, err
+		}
+		if !item.Type().Equal(list.ElemType) {
+			return nil, fmt.Errorf(
+				"cannot add %s to %s",
+				item.Type(),
+				list.Type(),
+			)
+		}
+		items := make([]Constant, 0, len(list.Items)+1)
+		items = append(items, item)
+		items = append(items, list.Items...)
+		return constantValue{constant: List{ElemType: list.ElemType, Items: items}}, nil
+	}
+	list, err := argList(args[0])
+	if err != nil {
+		return nil, err
+	}
+	switch fun {
+	case BuiltinChooseList:
+		if len(list.Items) == 0 {
+			return args[1], nil
+		}
+		return args[2], nil
+	case BuiltinHeadList:
+		if len(list.Items) == 0 {
+			return nil, errors.New("empty list")
+		}
+		return constantValue{constant: list.Items[0]}, nil
+	case BuiltinTailList:
+		if len(list.Items) == 0 {
+			return nil, errors.New("empty list")
+		}
+		return constantValue{
+			constant: List{ElemType: list.ElemType, Items: list.Items[1:]},
+		}, nil
+	default:
+		return constBool(len(list.Items) == 0), nil
+	}
+}
+
+func dataBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	switch fun {
+	case BuiltinConstrData:
+		tag, err := argInteger(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if !tag.IsUint64() {
+			return nil, fmt.Errorf("constructor tag out of range: %s", tag)
+		}
+		fields, err := argDataList(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return constData(common.NewPlutusConstr(tag.Uint64(), fields...)), nil
+	case BuiltinMapData:
+		list, err := argList(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if !list.ElemType.Equal(typeDataPair) {
+			return nil, fmt.Errorf("expected list of data pairs, got %s", list.Type())
+		}
+		pairs := make([]common.PlutusMapPair, len(list.Items))
+		for idx, item := range list.Items {
+			pair := item.(Pair)
+			pairs[idx] = common.PlutusMapPair{
+				Key:   pair.First.(Data).Value,
+				Value: pair.Second.(Data).Value,
+			}
+		}
+		return constData(common.NewPlutusMap(pairs...)), nil
+	case BuiltinListData:
+		items, err := argDataList(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return constData(common.NewPlutusList(items...)), nil
+	case BuiltinIData:
+		x, err := argInteger(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return constData(common.NewPlutusInteger(x)), nil
+	case BuiltinBData:
+		bs, err := argByteString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return constData(common.NewPlutusBytes(bs)), nil
+	case BuiltinMkPairData:
+		x, err := argData(args[0])
+		if err != nil {
+			return nil, err
+		}
+		y, err := argData(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return constantValue{constant: Pair{First: Data{Value: x}, Second: Data{Value: y}}}, nil
+	case BuiltinMkNilData:
+		if err := argUnit(args[0]); err != nil {
+			return nil, err
+		}
+		return constantValue{constant: dataList(nil)}, nil
+	case BuiltinMkNilPairData:
+		if err := argUnit(args[0]); err != nil {
+			return nil, err
+		}
+		return constantValue{constant: dataPairList(nil)}, nil
+	}
+	d, err := argData(args[0])
+	if err != nil {
+		return nil, err
+	}
+	switch fun {
+	case BuiltinChooseData:
+		switch d.Type() {
+		case common.PlutusDataTypeConstr:
+			return args[1], nil
+		case common.PlutusDataTypeMap:
+			return args[2], nil
+		case common.PlutusDataTypeList:
+			return args[3], nil
+		case common.PlutusDataTypeInteger:
+			return args[4], nil
+		default:
+			return args[5], nil
+		}
+	case BuiltinUnConstrData:
+		constr := d.Constr()
+		if constr == nil {
+			return nil, errors.New("data is not a constructor")
+		}
+		return constantValue{
+			constant: Pair{
+				First:  Integer{Value: new(big.Int).SetUint64(constr.Constructor)},
+				Second: dataList(constr.Fields),
+			},
+		}, nil
+	case BuiltinUnMapData:
+		if d.Type() != common.PlutusDataTypeMap {
+			return nil, errors.New("data is not a map")
+		}
+		return constantValue{constant: dataPairList(d.Map())}, nil
+	case BuiltinUnListData:
+		if d.Type() != common.PlutusDataTypeList {
+			return nil, errors.New("data is not a list")
+		}
+		return constantValue{constant: dataList(d.List())}, nil
+	case BuiltinUnIData:
+		if d.Type() != common.PlutusDataTypeInteger {
+			return nil, errors.New("data is not an integer")
+		}
+		return constInteger(d.Integer()), nil
+	case BuiltinUnBData:
+		if d.Type() != common.PlutusDataTypeBytes {
+			return nil, errors.New("data is not a bytestring")
+		}
+		return constByteString(d.Bytes()), nil
+	case BuiltinEqualsData:
+		other, err := argData(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return constBool(d.Equal(other)), nil
+	default:
+		// The original CBOR is not used, since serialisation must be canonical
+		cborData, err := rebuildData(d).MarshalCBOR()
+		if err != nil {
+			return nil, err
+		}
+		return constByteString(cborData), nil
+	}
+}
+
+// rebuildData returns a copy of the data without any stored CBOR
+func rebuildData(d common.PlutusData) common.PlutusData {
+	switch d.Type() {
+	case common.PlutusDataTypeConstr:
+		constr := d.Constr()
+		fields := make([]common.PlutusData, len(constr.Fields))
+		for idx, field := range constr.Fields {
+			fields[idx] = rebuildData(field)
+		}
+		return common.NewPlutusConstr(constr.Constructor, fields...)
+	case common.PlutusDataTypeMap:
+		pairs := d.Map()
+		newPairs := make([]common.PlutusMapPair, len(pairs))
+		for idx, pair := range pairs {
+			newPairs[idx] = common.PlutusMapPair{
+				Key:   rebuildData(pair.Key),
+				Value: rebuildData(pair.Value),
+			}
+		}
+		return common.NewPlutusMap(newPairs...)
+	case common.PlutusDataTypeList:
+		items := d.List()
+		newItems := make([]common.PlutusData, len(items))
+		for idx, item := range items {
+			newItems[idx] = rebuildData(item)
+		}
+		return common.NewPlutusList(newItems...)
+	case common.PlutusDataTypeInteger:
+		return common.NewPlutusInteger(d.Integer())
+	default:
+		return common.NewPlutusBytes(d.Bytes())
+	}
+}
+
+func blsG1Builtin(fun BuiltinFunc, args []value) (value, error) {
+	switch fun {
+	case BuiltinBls12_381_G1_Uncompress:
+		data, err := argByteString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		p, err := bls12381G1Uncompress(data)
+		if err != nil {
+			return nil, err
+		}
+		return constantValue{constant: G1Element{Value: p}}, nil
+	case BuiltinBls12_381_G1_HashToGroup:
+		msg, err := argByteString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		dst, err := argByteString(args[1])
+		if err != nil {
+			return nil, err
+		}
+		p, err := bls12381G1HashToGroup(msg, dst)
+		if err != nil {
+			return nil, err
+		}
+		return constantValue{constant: G1Element{Value: p}}, nil
+	case BuiltinBls12_381_G1_ScalarMul:
+		k, err := argInteger(args[0])
+		if err != nil {
+			return nil, err
+		}
+		p, err := argG1(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return constantValue{constant: G1Element{Value: bls12381G1ScalarMul(k, p.Value)}}, nil
+	}
+	p, err := argG1(args[0])
+	if err != nil {
+		return nil, err
+	}
+	switch fun {
+	case BuiltinBls12_381_G1_Neg:
+		return constantValue{constant: G1Element{Value: bls12381G1Neg(p.Value)}}, nil
+	case BuiltinBls12_381_G1_Compress:
+		return constByteString(p.Value.BytesCompressed()), nil
+	}
+	q, err := argG1(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if fun == BuiltinBls12_381_G1_Add {
+		return constantValue{constant: G1Element{Value: bls12381G1Add(p.Value, q.Value)}}, nil
+	}
+	return constBool(p.Value.IsEqual(q.Value)), nil
+}
+
+func blsG2Builtin(fun BuiltinFunc, args []value) (value, error) {
+	switch fun {
+	case BuiltinBls12_381_G2_Uncompress:
+		data, err := argByteString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		p, err := bls12381G2Uncompress(data)
+		if err != nil {
+			return nil, err
+		}
+		return constantValue{constant: G2Element{Value: p}}, nil
+	case BuiltinBls12_381_G2_HashToGroup:
+		msg, err := argByteString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		dst, err := argByteString(args[1])
+		if err != nil {
+			return nil, err
+		}
+		p, err := bls12381G2HashToGroup(msg, dst)
+		if err != nil {
+			return nil, err
+		}
+		return constantValue{constant: G2Element{Value: p}}, nil
+	case BuiltinBls12_381_G2_ScalarMul:
+		k, err := argInteger(args[0])
+		if err != nil {
+			return nil, err
+		}
+		p, err := argG2(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return constantValue{constant: G2Element{Value: bls12381G2ScalarMul(k, p.Value)}}, nil
+	}
+	p, err := argG2(args[0])
+	if err != nil {
+		return nil, err
+	}
+	switch fun {
+	case BuiltinBls12_381_G2_Neg:
+		return constantValue{constant: G2Element{Value: bls12381G2Neg(p.Value)}}, nil
+	case BuiltinBls12_381_G2_Compress:
+		return constByteString(p.Value.BytesCompressed()), nil
+	}
+	q, err := argG2(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if fun == BuiltinBls12_381_G2_Add {
+		return constantValue{constant: G2Element{Value: bls12381G2Add(p.Value, q.Value)}}, nil
+	}
+	return constBool(p.Value.IsEqual(q.Value)), nil
+}
+
+func blsPairingBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	if fun == BuiltinBls12_381_MillerLoop {
+		p, err := argG1(args[0])
+		if err != nil {
+			return nil, err
+		}
+		q, err := argG2(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return constantValue{constant: MlResult{Value: bls12381MillerLoop(p.Value, q.Value)}}, nil
+	}
+	x, err := argMlResult(args[0])
+	if err != nil {
+		return nil, err
+	}
+	y, err := argMlResult(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if fun == BuiltinBls12_381_MulMlResult {
+		return constantValue{constant: MlResult{Value: bls12381MulMlResult(x.Value, y.Value)}}, nil
+	}
+	return constBool(bls12381FinalVerify(x.Value, y.Value)), nil
+}
+
+func reverseBytes(data []byte) {
+	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
+	}
+}
+
+func conversionBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	bigEndian, err := argBool(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if fun == BuiltinByteStringToInteger {
+		bs, err := argByteString(args[1])
+		if err != nil {
+			return nil, err
+		}
+		tmp := append([]byte{}, bs...)
+		if !bigEndian {
+			reverseBytes(tmp)
+		}
+		return constInteger(new(big.Int).SetBytes(tmp)), nil
+	}
+	width, err := argInteger(args[1])
+	if err != nil {
+		return nil, err
+	}
+	x, err := argInteger(args[2])
+	if err != nil {
+		return nil, err
+	}
+	if width.Sign() < 0 || width.Cmp(big.NewInt(maxByteStringBuiltinSize)) > 0 {
+		return nil, fmt.Errorf("invalid width: %s", width)
+	}
+	if x.Sign() < 0 {
+		return nil, fmt.Errorf("cannot convert negative integer: %s", x)
+	}
+	size := (x.BitLen() + 7) / 8
+	if size > maxByteStringBuiltinSize {
+		return nil, fmt.Errorf("integer too large: %d bytes", size)
+	}
+	targetWidth := int(width.Int64())
+	if targetWidth == 0 {
+		targetWidth = size
+	} else if size > targetWidth {
+		return nil, fmt.Errorf("integer does not fit in %d bytes", targetWidth)
+	}
+	ret := x.FillBytes(make([]byte, targetWidth))
+	if !bigEndian {
+		reverseBytes(ret)
+	}
+	return constByteString(ret), nil
+}
+
+// bitIndex returns the byte offset and bit mask for a bit index. Bit 0 is the least significant
+// bit of the last byte
+func bitIndex(length int, idx *big.Int) (int, byte, error) {
+	if idx.Sign() < 0 || idx.Cmp(big.NewInt(int64(length)*8)) >= 0 {
+		return 0, 0, fmt.Errorf("bit index out of range: %s", idx)
+	}
+	n := int(idx.Int64())
+	return length - 1 - n/8, 1 << (n % 8), nil
+}
+
+func bitwiseBuiltin(fun BuiltinFunc, args []value) (value, error) {
+	switch fun {
+	case BuiltinAndByteString, BuiltinOrByteString, BuiltinXorByteString:
+		extend, err := argBool(args[0])
+		if err != nil {
+			return nil, err
+		}
+		x, err := argByteString(args[1])
+		if err != nil {
+			return nil, err
+		}
+		y, err := argByteString(args[2])
+		if err != nil {
+			return nil, err
+		}
+		if len(x) < len(y) {
+			x, y = y, x
+		}
+		// The result has the length of the shorter input, or of the longer input when extending,
+		// in which case the remaining bytes of the longer input are unchanged
+		var ret []byte
+		if extend {
+			ret = append([]byte{}, x...)
+		} else {
+			ret = append([]byte{}, x[:len(y)]...)
+		}
+		for idx := range y {
+			switch fun {
+			case BuiltinAndByteString:
+				ret[idx] = x[idx] & y[idx]
+			case BuiltinOrByteString:
+				ret[idx] = x[idx] | y[idx]
+			default:
+				ret[idx] = x[idx] ^ y[idx]
+			}
+		}
+		return constByteString(ret), nil
+	case BuiltinReplicateByte:
+		n, err := argInteger(args[0])
+		if err != nil {
+			return nil, err
+		}
+		b, err := argInteger(args[1])
+		if err != nil {
+			return nil, err
+		}
+		if n.Sign() < 0 || n.Cmp(big.NewInt(maxByteStringBuiltinSize)) > 0 {
+			return nil, fmt.Errorf("invalid length: %s", n)
+		}
+		if b.Sign() < 0 || b.Cmp(big.NewInt(255)) > 0 {
+			return nil, fmt.Errorf("byte value out of range: %s", b)
+		}
+		return constByteString(bytes.Repeat([]byte{byte(b.Int64())}, int(n.Int64()))), nil
+	}
+	bs, err := argByteString(args[0])
+	if err != nil {
+		return nil, err
+	}
+	switch fun {
+	case BuiltinComplementByteString:
+		ret := make([]byte, len(bs))
+		for idx, b := range bs {
+			ret[idx] = ^b
+		}
+		return constByteString(ret), nil
+	case BuiltinReadBit:
+		idx, err := argInteger(args[1])
+		if err != nil {
+			return nil, err
+		}
+		offset, mask, err := bitIndex(len(bs), idx)
+		if err != nil {
+			return nil, err
+		}
+		return constBool(bs[offset]&mask != 0), nil
+	case BuiltinWriteBits:
+		indexes, err := argList(args[1])
+		if err != nil {
+			return nil, err
+		}
+		if !indexes.ElemType.Equal(TypeInteger) {
+			return nil, fmt.Errorf("expected list of integers, got %s", indexes.Type())
+		}
+		set, err := argBool(args[2])
+		if err != nil {
+			return nil, err
+		}
+		ret := append([]byte{}, bs...)
+		for _, item := range indexes.Items {
+			offset, mask, err := bitIndex(len(ret), item.(Integer).Value)
+			if err != nil {
+				return nil, err
+			}
+			if set {
+				ret[offset] |= mask
+			} else {
+				ret[offset] &^= mask
+			}
+		}
+		return constByteString(ret), nil
+	case BuiltinShiftByteString, BuiltinRotateByteString:
+		n, err := argInteger(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return constByteString(shiftBytes(bs, n, fun == BuiltinRotateByteString)), nil
+	case BuiltinCountSetBits:
+		var count int
+		for _, b := range bs {
+			count += bits.OnesCount8(b)
+		}
+		return constInteger(big.NewInt(int64(count))), nil
+	default:
+		// Find the lowest set bit, starting from the end of the bytestring
+		for idx := len(bs) - 1; idx >= 0; idx-- {
+			if bs[idx] != 0 {
+				bit := (len(bs)-1-idx)*8 + bits.TrailingZeros8(bs[idx])
+				return constInteger(big.NewInt(int64(bit))), nil
+			}
+		}
+		return constInteger(big.NewInt(-1)), nil
+	}
+}
+
+// shiftBytes shifts or rotates the bits of a bytestring towards the higher bit indexes for
+// positive amounts, and towards the lower bit indexes for negative amounts
+func shiftBytes(bs []byte, amount *big.Int, rotate bool) []byte {
+	totalBits := len(bs) * 8
+	ret := make([]byte, len(bs))
+	if totalBits == 0 {
+		return ret
+	}
+	var n int
+	if rotate {
+		n = int(new(big.Int).Mod(amount, big.NewInt(int64(totalBits))).Int64())
+	} else {
+		n = clampInt(amount, -totalBits, totalBits)
+	}
+	value := new(big.Int).SetBytes(bs)
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(totalBits)), big.NewInt(1))
+	var result *big.Int
+	switch {
+	case rotate:
+		result = new(big.Int).Lsh(value, uint(n))
+		result.Or(result, new(big.Int).Rsh(value, uint(totalBits-n)))
+	case n >= 0:
+		result = new(big.Int).Lsh(value, uint(n))
+	default:
+		result = new(big.Int).Rsh(value, uint(-n))
+	}
+	result.And(result, mask)
+	return result.FillBytes(ret)
+}
@@ -0,0 +1,283 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+
+	"github.com/cloudflare/circl/ecc/bls12381"
+)
+
+// TypeKind identifies a builtin type. The values match the flat encoding of the type tags
+type TypeKind uint8
+
+const (
+	TypeKindInteger          TypeKind = 0
+	TypeKindByteString       TypeKind = 1
+	TypeKindString           TypeKind = 2
+	TypeKindUnit             TypeKind = 3
+	TypeKindBool             TypeKind = 4
+	TypeKindList             TypeKind = 5
+	TypeKindPair             TypeKind = 6
+	TypeKindData             TypeKind = 8
+	TypeKindBls12381G1       TypeKind = 9
+	TypeKindBls12381G2       TypeKind = 10
+	TypeKindBls12381MlResult TypeKind = 11
+
+	// Type application, which is only used in the flat encoding
+	typeKindApply TypeKind = 7
+)
+
+// Type is the type of a constant. Lists have a single parameter for the element type, and pairs
+// have two parameters
+type Type struct {
+	Kind   TypeKind
+	Params []Type
+}
+
+var (
+	TypeInteger    = Type{Kind: TypeKindInteger}
+	TypeByteString = Type{Kind: TypeKindByteString}
+	TypeString     = Type{Kind: TypeKindString}
+	TypeUnit       = Type{Kind: TypeKindUnit}
+	TypeBool       = Type{Kind: TypeKindBool}
+	TypeData       = Type{Kind: TypeKindData}
+)
+
+// TypeList returns the type of a list with the specified element type
+func TypeList(elem Type) Type {
+	return Type{Kind: TypeKindList, Params: []Type{elem}}
+}
+
+// TypePair returns the type of a pair with the specified element types
+func TypePair(first Type, second Type) Type {
+	return Type{Kind: TypeKindPair, Params: []Type{first, second}}
+}
+
+// Equal returns whether both types are the same
+func (t Type) Equal(other Type) bool {
+	if t.Kind != other.Kind || len(t.Params) != len(other.Params) {
+		return false
+	}
+	for idx := range t.Params {
+		if !t.Params[idx].Equal(other.Params[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t Type) String() string {
+	switch t.Kind {
+	case TypeKindInteger:
+		return "integer"
+	case TypeKindByteString:
+		return "bytestring"
+	case TypeKindString:
+		return "string"
+	case TypeKindUnit:
+		return "unit"
+	case TypeKindBool:
+		return "bool"
+	case TypeKindList:
+		return fmt.Sprintf("(list %s)", t.Params[0])
+	case TypeKindPair:
+		return fmt.Sprintf("(pair %s %s)", t.Params[0], t.Params[1])
+	case TypeKindData:
+		return "data"
+	case TypeKindBls12381G1:
+		return "bls12_381_G1_element"
+	case TypeKindBls12381G2:
+		return "bls12_381_G2_element"
+	case TypeKindBls12381MlResult:
+		return "bls12_381_mlresult"
+	default:
+		return fmt.Sprintf("unknown(%d)", t.Kind)
+	}
+}
+
+// Constant is a constant value of one of the builtin types
+type Constant interface {
+	Type() Type
+	String() string
+}
+
+// Integer is an arbitrary precision integer constant
+type Integer struct {
+	Value *big.Int
+}
+
+// ByteString is a bytestring constant
+type ByteString struct {
+	Value []byte
+}
+
+// Text is a Unicode string constant
+type Text struct {
+	Value string
+}
+
+// Unit is the unit constant
+type Unit struct{}
+
+// Bool is a boolean constant
+type Bool struct {
+	Value bool
+}
+
+// List is a list constant, where all items are of the element type
+type List struct {
+	ElemType Type
+	Items    []Constant
+}
+
+// Pair is a pair constant
+type Pair struct {
+	First  Constant
+	Second Constant
+}
+
+// Data is a Plutus data constant
+type Data struct {
+	Value common.PlutusData
+}
+
+// G1Element is a BLS12-381 G1 point constant
+type G1Element struct {
+	Value *bls12381.G1
+}
+
+// G2Element is a BLS12-381 G2 point constant
+type G2Element struct {
+	Value *bls12381.G2
+}
+
+// MlResult is the result of a BLS12-381 Miller loop
+type MlResult struct {
+	Value *bls12381.Gt
+}
+
+// NewInteger returns an integer constant from an int64
+func NewInteger(value int64) Integer {
+	return Integer{Value: big.NewInt(value)}
+}
+
+func (Integer) Type() Type    { return TypeInteger }
+func (ByteString) Type() Type { return TypeByteString }
+func (Text) Type() Type       { return TypeString }
+func (Unit) Type() Type       { return TypeUnit }
+func (Bool) Type() Type       { return TypeBool }
+func (c List) Type() Type     { return TypeList(c.ElemType) }
+func (c Pair) Type() Type     { return TypePair(c.First.Type(), c.Second.Type()) }
+func (Data) Type() Type       { return TypeData }
+func (G1Element) Type() Type  { return Type{Kind: TypeKindBls12381G1} }
+func (G2Element) Type() Type  { return Type{Kind: TypeKindBls12381G2} }
+func (MlResult) Type() Type   { return Type{Kind: TypeKindBls12381MlResult} }
+
+func (c Integer) String() string {
+	return c.Value.String()
+}
+
+func (c ByteString) String() string {
+	return "#" + hex.EncodeToString(c.Value)
+}
+
+func (c Text) String() string {
+	return strconv.Quote(c.Value)
+}
+
+func (Unit) String() string {
+	return "()"
+}
+
+func (c Bool) String() string {
+	if c.Value {
+		return "True"
+	}
+	return "False"
+}
+
+func (c List) String() string {
+	items := make([]string, len(c.Items))
+	for idx, item := range c.Items {
+		items[idx] = item.String()
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+func (c Pair) String() string {
+	return fmt.Sprintf("(%s, %s)", c.First, c.Second)
+}
+
+func (c Data) String() string {
+	return dataString(c.Value)
+}
+
+func (c G1Element) String() string {
+	return "0x" + hex.EncodeToString(c.Value.BytesCompressed())
+}
+
+func (c G2Element) String() string {
+	return "0x" + hex.EncodeToString(c.Value.BytesCompressed())
+}
+
+func (c MlResult) String() string {
+	return "<mlresult>"
+}
+
+// dataString formats Plutus data using the syntax of data constants in textual UPLC
+func dataString(d common.PlutusData) string {
+	switch d.Type() {
+	case common.PlutusDataTypeConstr:
+		constr := d.Constr()
+		fields := make([]string, len(constr.Fields))
+		for idx, field := range constr.Fields {
+			fields[idx] = dataString(field)
+		}
+		return fmt.Sprintf(
+			"Constr %d [%s]",
+			constr.Constructor,
+			strings.Join(fields, ", "),
+		)
+	case common.PlutusDataTypeMap:
+		pairs := d.Map()
+		items := make([]string, len(pairs))
+		for idx, pair := range pairs {
+			items[idx] = fmt.Sprintf(
+				"(%s, %s)",
+				dataString(pair.Key),
+				dataString(pair.Value),
+			)
+		}
+		return "Map [" + strings.Join(items, ", ") + "]"
+	case common.PlutusDataTypeList:
+		list := d.List()
+		items := make([]string, len(list))
+		for idx, item := range list {
+			items[idx] = dataString(item)
+		}
+		return "List [" + strings.Join(items, ", ") + "]"
+	case common.PlutusDataTypeInteger:
+		return "I " + d.Integer().String()
+	default:
+		return "B #" + hex.EncodeToString(d.Bytes())
+	}
+}
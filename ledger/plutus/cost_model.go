@@ -0,0 +1,549 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ExBudget is an amount of execution units, as used for script budgets and costs
+type ExBudget struct {
+	Memory int64
+	Steps  int64
+}
+
+func (b ExBudget) String() string {
+	return fmt.Sprintf("{mem: %d, cpu: %d}", b.Memory, b.Steps)
+}
+
+// costShape identifies the form of a builtin costing function
+type costShape int
+
+const (
+	costConstant costShape = iota
+	costLinearInX
+	costLinearInY
+	costLinearInZ
+	costAddedSizes
+	costMultipliedSizes
+	costMinSize
+	costMaxSize
+	costSubtractedSizes
+	costLinearOnDiagonal
+	costConstAboveDiagonalMultiplied
+	costConstAboveDiagonalQuadratic
+	costQuadraticInY
+	costQuadraticInZ
+	costLiteralInYOrLinearInZ
+	costLinearInYAndZ
+	costLinearInMaxYZ
+)
+
+// paramSuffixes returns the suffixes of the cost model parameter names for a costing function, in
+// the order the parameters appear in the cost model
+func (s costShape) paramSuffixes() []string {
+	switch s {
+	case costConstant:
+		return []string{""}
+	case costSubtractedSizes:
+		return []string{"-intercept", "-minimum", "-slope"}
+	case costLinearOnDiagonal:
+		return []string{"-constant", "-intercept", "-slope"}
+	case costConstAboveDiagonalMultiplied:
+		return []string{
+			"-constant",
+			"-model-arguments-intercept",
+			"-model-arguments-slope",
+		}
+	case costConstAboveDiagonalQuadratic:
+		return []string{
+			"-constant",
+			"-model-arguments-c00",
+			"-model-arguments-c01",
+			"-model-arguments-c02",
+			"-model-arguments-c10",
+			"-model-arguments-c11",
+			"-model-arguments-c20",
+			"-model-arguments-minimum",
+		}
+	case costQuadraticInY, costQuadraticInZ:
+		return []string{"-c0", "-c1", "-c2"}
+	case costLinearInYAndZ:
+		return []string{"-intercept", "-slope1", "-slope2"}
+	default:
+		return []string{"-intercept", "-slope"}
+	}
+}
+
+type costingFunc struct {
+	shape  costShape
+	params []int64
+}
+
+func (c costingFunc) cost(sizes []int64) int64 {
+	p := c.params
+	size := func(idx int) int64 {
+		if idx < len(sizes) {
+			return sizes[idx]
+		}
+		return 0
+	}
+	x, y, z := size(0), size(1), size(2)
+	switch c.shape {
+	case costConstant:
+		return p[0]
+	case costLinearInX:
+		return satAdd(p[0], satMul(p[1], x))
+	case costLinearInY:
+		return satAdd(p[0], satMul(p[1], y))
+	case costLinearInZ:
+		return satAdd(p[0], satMul(p[1], z))
+	case costAddedSizes:
+		return satAdd(p[0], satMul(p[1], satAdd(x, y)))
+	case costMultipliedSizes:
+		return satAdd(p[0], satMul(p[1], satMul(x, y)))
+	case costMinSize:
+		return satAdd(p[0], satMul(p[1], min(x, y)))
+	case costMaxSize:
+		return satAdd(p[0], satMul(p[1], max(x, y)))
+	case costSubtractedSizes:
+		return satAdd(p[0], satMul(p[2], max(p[1], x-y)))
+	case costLinearOnDiagonal:
+		if x == y {
+			return satAdd(p[1], satMul(p[2], x))
+		}
+		return p[0]
+	case costConstAboveDiagonalMultiplied:
+		if x < y {
+			return p[0]
+		}
+		return satAdd(p[1], satMul(p[2], satMul(x, y)))
+	case costConstAboveDiagonalQuadratic:
+		if x < y {
+			return p[0]
+		}
+		ret := p[1]
+		ret = satAdd(ret, satMul(p[4], x))
+		ret = satAdd(ret, satMul(p[2], y))
+		ret = satAdd(ret, satMul(p[6], satMul(x, x)))
+		ret = satAdd(ret, satMul(p[5], satMul(x, y)))
+		ret = satAdd(ret, satMul(p[3], satMul(y, y)))
+		return max(p[7], ret)
+	case costQuadraticInY:
+		return satAdd(
+			satAdd(p[0], satMul(p[1], y)),
+			satMul(p[2], satMul(y, y)),
+		)
+	case costQuadraticInZ:
+		return satAdd(
+			satAdd(p[0], satMul(p[1], z)),
+			satMul(p[2], satMul(z, z)),
+		)
+	case costLiteralInYOrLinearInZ:
+		if y == 0 {
+			return satAdd(p[0], satMul(p[1], z))
+		}
+		return y
+	case costLinearInYAndZ:
+		return satAdd(satAdd(p[0], satMul(p[1], y)), satMul(p[2], z))
+	case costLinearInMaxYZ:
+		return satAdd(p[0], satMul(p[1], max(y, z)))
+	default:
+		return math.MaxInt64
+	}
+}
+
+func satAdd(a int64, b int64) int64 {
+	ret := a + b
+	if a > 0 && b > 0 && ret < 0 {
+		return math.MaxInt64
+	}
+	if a < 0 && b < 0 && ret >= 0 {
+		return math.MinInt64
+	}
+	return ret
+}
+
+func satMul(a int64, b int64) int64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	ret := a * b
+	if ret/b != a || (a == -1 && b == math.MinInt64) ||
+		(b == -1 && a == math.MinInt64) {
+		if (a < 0) != (b < 0) {
+			return math.MinInt64
+		}
+		return math.MaxInt64
+	}
+	return ret
+}
+
+type builtinCostSpec struct {
+	cpu costShape
+	mem costShape
+}
+
+// builtinCostSpecsV1 describes the costing functions used by PlutusV1 and PlutusV2
+var builtinCostSpecsV1 = map[BuiltinFunc]builtinCostSpec{
+	BuiltinAddInteger:                      {costMaxSize, costMaxSize},
+	BuiltinSubtractInteger:                 {costMaxSize, costMaxSize},
+	BuiltinMultiplyInteger:                 {costAddedSizes, costAddedSizes},
+	BuiltinDivideInteger:                   {costConstAboveDiagonalMultiplied, costSubtractedSizes},
+	BuiltinQuotientInteger:                 {costConstAboveDiagonalMultiplied, costSubtractedSizes},
+	BuiltinRemainderInteger:                {costConstAboveDiagonalMultiplied, costSubtractedSizes},
+	BuiltinModInteger:                      {costConstAboveDiagonalMultiplied, costSubtractedSizes},
+	BuiltinEqualsInteger:                   {costMinSize, costConstant},
+	BuiltinLessThanInteger:                 {costMinSize, costConstant},
+	BuiltinLessThanEqualsInteger:           {costMinSize, costConstant},
+	BuiltinAppendByteString:                {costAddedSizes, costAddedSizes},
+	BuiltinConsByteString:                  {costLinearInY, costAddedSizes},
+	BuiltinSliceByteString:                 {costLinearInZ, costLinearInZ},
+	BuiltinLengthOfByteString:              {costConstant, costConstant},
+	BuiltinIndexByteString:                 {costConstant, costConstant},
+	BuiltinEqualsByteString:                {costLinearOnDiagonal, costConstant},
+	BuiltinLessThanByteString:              {costMinSize, costConstant},
+	BuiltinLessThanEqualsByteString:        {costMinSize, costConstant},
+	BuiltinSha2_256:                        {costLinearInX, costConstant},
+	BuiltinSha3_256:                        {costLinearInX, costConstant},
+	BuiltinBlake2b_256:                     {costLinearInX, costConstant},
+	BuiltinVerifyEd25519Signature:          {costLinearInY, costConstant},
+	BuiltinAppendString:                    {costAddedSizes, costAddedSizes},
+	BuiltinEqualsString:                    {costLinearOnDiagonal, costConstant},
+	BuiltinEncodeUtf8:                      {costLinearInX, costLinearInX},
+	BuiltinDecodeUtf8:                      {costLinearInX, costLinearInX},
+	BuiltinIfThenElse:                      {costConstant, costConstant},
+	BuiltinChooseUnit:                      {costConstant, costConstant},
+	BuiltinTrace:                           {costConstant, costConstant},
+	BuiltinFstPair:                         {costConstant, costConstant},
+	BuiltinSndPair:                         {costConstant, costConstant},
+	BuiltinChooseList:                      {costConstant, costConstant},
+	BuiltinMkCons:                          {costConstant, costConstant},
+	BuiltinHeadList:                        {costConstant, costConstant},
+	BuiltinTailList:                        {costConstant, costConstant},
+	BuiltinNullList:                        {costConstant, costConstant},
+	BuiltinChooseData:                      {costConstant, costConstant},
+	BuiltinConstrData:                      {costConstant, costConstant},
+	BuiltinMapData:                         {costConstant, costConstant},
+	BuiltinListData:                        {costConstant, costConstant},
+	BuiltinIData:                           {costConstant, costConstant},
+	BuiltinBData:                           {costConstant, costConstant},
+	BuiltinUnConstrData:                    {costConstant, costConstant},
+	BuiltinUnMapData:                       {costConstant, costConstant},
+	BuiltinUnListData:                      {costConstant, costConstant},
+	BuiltinUnIData:                         {costConstant, costConstant},
+	BuiltinUnBData:                         {costConstant, costConstant},
+	BuiltinEqualsData:                      {costMinSize, costConstant},
+	BuiltinMkPairData:                      {costConstant, costConstant},
+	BuiltinMkNilData:                       {costConstant, costConstant},
+	BuiltinMkNilPairData:                   {costConstant, costConstant},
+	BuiltinSerialiseData:                   {costLinearInX, costLinearInX},
+	BuiltinVerifyEcdsaSecp256k1Signature:   {costConstant, costConstant},
+	BuiltinVerifySchnorrSecp256k1Signature: {costLinearInY, costConstant},
+	BuiltinBls12_381_G1_Add:                {costConstant, costConstant},
+	BuiltinBls12_381_G1_Neg:                {costConstant, costConstant},
+	BuiltinBls12_381_G1_ScalarMul:          {costLinearInX, costConstant},
+	BuiltinBls12_381_G1_Equal:              {costConstant, costConstant},
+	BuiltinBls12_381_G1_Compress:           {costConstant, costConstant},
+	BuiltinBls12_381_G1_Uncompress:         {costConstant, costConstant},
+	BuiltinBls12_381_G1_HashToGroup:        {costLinearInX, costConstant},
+	BuiltinBls12_381_G2_Add:                {costConstant, costConstant},
+	BuiltinBls12_381_G2_Neg:                {costConstant, costConstant},
+	BuiltinBls12_381_G2_ScalarMul:          {costLinearInX, costConstant},
+	BuiltinBls12_381_G2_Equal:              {costConstant, costConstant},
+	BuiltinBls12_381_G2_Compress:           {costConstant, costConstant},
+	BuiltinBls12_381_G2_Uncompress:         {costConstant, costConstant},
+	BuiltinBls12_381_G2_HashToGroup:        {costLinearInX, costConstant},
+	BuiltinBls12_381_MillerLoop:            {costConstant, costConstant},
+	BuiltinBls12_381_MulMlResult:           {costConstant, costConstant},
+	BuiltinBls12_381_FinalVerify:           {costConstant, costConstant},
+	BuiltinKeccak_256:                      {costLinearInX, costConstant},
+	BuiltinBlake2b_224:                     {costLinearInX, costConstant},
+	BuiltinIntegerToByteString:             {costQuadraticInZ, costLiteralInYOrLinearInZ},
+	BuiltinByteStringToInteger:             {costQuadraticInY, costLinearInY},
+	BuiltinAndByteString:                   {costLinearInYAndZ, costLinearInMaxYZ},
+	BuiltinOrByteString:                    {costLinearInYAndZ, costLinearInMaxYZ},
+	BuiltinXorByteString:                   {costLinearInYAndZ, costLinearInMaxYZ},
+	BuiltinComplementByteString:            {costLinearInX, costLinearInX},
+	BuiltinReadBit:                         {costConstant, costConstant},
+	BuiltinWriteBits:                       {costLinearInY, costLinearInX},
+	BuiltinReplicateByte:                   {costLinearInX, costLinearInX},
+	BuiltinShiftByteString:                 {costLinearInX, costLinearInX},
+	BuiltinRotateByteString:                {costLinearInX, costLinearInX},
+	BuiltinCountSetBits:                    {costLinearInX, costConstant},
+	BuiltinFindFirstSetBit:                 {costLinearInX, costConstant},
+	BuiltinRipemd_160:                      {costLinearInX, costConstant},
+}
+
+// builtinCostSpecsV3 describes the costing functions used by PlutusV3, which differ from the
+// earlier languages for integer multiplication and division
+var builtinCostSpecsV3 = func() map[BuiltinFunc]builtinCostSpec {
+	ret := make(map[BuiltinFunc]builtinCostSpec, len(builtinCostSpecsV1))
+	for k, v := range builtinCostSpecsV1 {
+		ret[k] = v
+	}
+	ret[BuiltinMultiplyInteger] = builtinCostSpec{costMultipliedSizes, costAddedSizes}
+	ret[BuiltinDivideInteger] = builtinCostSpec{costConstAboveDiagonalQuadratic, costSubtractedSizes}
+	ret[BuiltinQuotientInteger] = builtinCostSpec{costConstAboveDiagonalQuadratic, costSubtractedSizes}
+	ret[BuiltinRemainderInteger] = builtinCostSpec{costConstAboveDiagonalQuadratic, costLinearInY}
+	ret[BuiltinModInteger] = builtinCostSpec{costConstAboveDiagonalQuadratic, costLinearInY}
+	return ret
+}()
+
+// machineStep identifies a kind of CEK machine step
+type machineStep int
+
+const (
+	stepStartup machineStep = iota
+	stepVar
+	stepConst
+	stepLambda
+	stepDelay
+	stepForce
+	stepApply
+	stepBuiltin
+	stepConstr
+	stepCase
+	stepCount
+)
+
+var machineStepNames = [stepCount]string{
+	stepStartup: "cekStartupCost",
+	stepVar:     "cekVarCost",
+	stepConst:   "cekConstCost",
+	stepLambda:  "cekLamCost",
+	stepDelay:   "cekDelayCost",
+	stepForce:   "cekForceCost",
+	stepApply:   "cekApplyCost",
+	stepBuiltin: "cekBuiltinCost",
+	stepConstr:  "cekConstrCost",
+	stepCase:    "cekCaseCost",
+}
+
+// CostModel holds the machine step costs and builtin costing functions for a Plutus language
+type CostModel struct {
+	language     LanguageVersion
+	machineCosts [stepCount]ExBudget
+	machineKnown [stepCount]bool
+	builtinCPU   map[BuiltinFunc]costingFunc
+	builtinMem   map[BuiltinFunc]costingFunc
+}
+
+// costModelParamNames returns the names of the cost model parameters for the language, in the
+// order used by the protocol parameters
+func costModelParamNames(language LanguageVersion) []string {
+	var specs map[BuiltinFunc]builtinCostSpec
+	var sorted []BuiltinFunc
+	var appended []BuiltinFunc
+	var appendedSteps []machineStep
+	// The parameters of the original builtins are in alphabetical order, with later additions
+	// appended in a fixed order
+	sortedSteps := []machineStep{
+		stepStartup,
+		stepVar,
+		stepConst,
+		stepLambda,
+		stepDelay,
+		stepForce,
+		stepApply,
+		stepBuiltin,
+	}
+	switch language {
+	case LanguageVersionV1:
+		specs = builtinCostSpecsV1
+		for f := BuiltinAddInteger; f <= BuiltinMkNilPairData; f++ {
+			sorted = append(sorted, f)
+		}
+	case LanguageVersionV2:
+		specs = builtinCostSpecsV1
+		for f := BuiltinAddInteger; f <= BuiltinVerifySchnorrSecp256k1Signature; f++ {
+			sorted = append(sorted, f)
+		}
+		appended = []BuiltinFunc{
+			BuiltinIntegerToByteString,
+			BuiltinByteStringToInteger,
+		}
+	case LanguageVersionV3:
+		specs = builtinCostSpecsV3
+		for f := BuiltinAddInteger; f <= BuiltinVerifySchnorrSecp256k1Signature; f++ {
+			sorted = append(sorted, f)
+		}
+		appendedSteps = []machineStep{stepConstr, stepCase}
+		appended = []BuiltinFunc{
+			BuiltinBls12_381_G1_Add,
+			BuiltinBls12_381_G1_Compress,
+			BuiltinBls12_381_G1_Equal,
+			BuiltinBls12_381_G1_HashToGroup,
+			BuiltinBls12_381_G1_Neg,
+			BuiltinBls12_381_G1_ScalarMul,
+			BuiltinBls12_381_G1_Uncompress,
+			BuiltinBls12_381_G2_Add,
+			BuiltinBls12_381_G2_Compress,
+			BuiltinBls12_381_G2_Equal,
+			BuiltinBls12_381_G2_HashToGroup,
+			BuiltinBls12_381_G2_Neg,
+			BuiltinBls12_381_G2_ScalarMul,
+			BuiltinBls12_381_G2_Uncompress,
+			BuiltinBls12_381_FinalVerify,
+			BuiltinBls12_381_MillerLoop,
+			BuiltinBls12_381_MulMlResult,
+			BuiltinKeccak_256,
+			BuiltinBlake2b_224,
+			BuiltinIntegerToByteString,
+			BuiltinByteStringToInteger,
+			BuiltinAndByteString,
+			BuiltinOrByteString,
+			BuiltinXorByteString,
+			BuiltinComplementByteString,
+			BuiltinReadBit,
+			BuiltinWriteBits,
+			BuiltinReplicateByte,
+			BuiltinShiftByteString,
+			BuiltinRotateByteString,
+			BuiltinCountSetBits,
+			BuiltinFindFirstSetBit,
+			BuiltinRipemd_160,
+		}
+	}
+	var ret []string
+	for _, step := range sortedSteps {
+		ret = append(ret, machineStepParamNames(step)...)
+	}
+	for _, f := range sorted {
+		ret = append(ret, builtinParamNames(f, specs[f])...)
+	}
+	sort.Strings(ret)
+	for _, step := range appendedSteps {
+		ret = append(ret, machineStepParamNames(step)...)
+	}
+	for _, f := range appended {
+		ret = append(ret, builtinParamNames(f, specs[f])...)
+	}
+	return ret
+}
+
+func machineStepParamNames(step machineStep) []string {
+	return []string{
+		machineStepNames[step] + "-exBudgetCPU",
+		machineStepNames[step] + "-exBudgetMemory",
+	}
+}
+
+func builtinParamNames(f BuiltinFunc, spec builtinCostSpec) []string {
+	var ret []string
+	for _, suffix := range spec.cpu.paramSuffixes() {
+		ret = append(ret, f.String()+"-cpu-arguments"+suffix)
+	}
+	for _, suffix := range spec.mem.paramSuffixes() {
+		ret = append(ret, f.String()+"-memory-arguments"+suffix)
+	}
+	return ret
+}
+
+// NewCostModel creates a cost model for the language from the ordered parameter values in the
+// protocol parameters. Builtins whose parameters are not present in a shorter list of values
+// cannot be used by scripts
+func NewCostModel(language LanguageVersion, values []int64) (*CostModel, error) {
+	if language < LanguageVersionV1 || language > LanguageVersionV3 {
+		return nil, fmt.Errorf("unsupported language: %s", language)
+	}
+	names := costModelParamNames(language)
+	params := make(map[string]int64, len(names))
+	for idx, name := range names {
+		if idx >= len(values) {
+			break
+		}
+		params[name] = values[idx]
+	}
+	ret := &CostModel{
+		language:   language,
+		builtinCPU: make(map[BuiltinFunc]costingFunc),
+		builtinMem: make(map[BuiltinFunc]costingFunc),
+	}
+	for step := machineStep(0); step < stepCount; step++ {
+		stepNames := machineStepParamNames(step)
+		cpu, cpuOk := params[stepNames[0]]
+		mem, memOk := params[stepNames[1]]
+		if cpuOk && memOk {
+			ret.machineCosts[step] = ExBudget{Memory: mem, Steps: cpu}
+			ret.machineKnown[step] = true
+		}
+	}
+	for step := stepStartup; step <= stepBuiltin; step++ {
+		if !ret.machineKnown[step] {
+			return nil, fmt.Errorf(
+				"cost model for %s is missing machine costs: got %d values",
+				language,
+				len(values),
+			)
+		}
+	}
+	specs := builtinCostSpecsV1
+	if language == LanguageVersionV3 {
+		specs = builtinCostSpecsV3
+	}
+	for f, spec := range specs {
+		if !language.builtinAllowed(f) {
+			continue
+		}
+		cpu, ok := lookupCostingFunc(params, f.String()+"-cpu-arguments", spec.cpu)
+		if !ok {
+			continue
+		}
+		mem, ok := lookupCostingFunc(params, f.String()+"-memory-arguments", spec.mem)
+		if !ok {
+			continue
+		}
+		ret.builtinCPU[f] = cpu
+		ret.builtinMem[f] = mem
+	}
+	return ret, nil
+}
+
+func lookupCostingFunc(
+	params map[string]int64,
+	prefix string,
+	shape costShape,
+) (costingFunc, bool) {
+	ret := costingFunc{shape: shape}
+	for _, suffix := range shape.paramSuffixes() {
+		value, ok := params[prefix+suffix]
+		if !ok {
+			return costingFunc{}, false
+		}
+		ret.params = append(ret.params, value)
+	}
+	return ret, true
+}
+
+// Language returns the language of the cost model
+func (c *CostModel) Language() LanguageVersion {
+	return c.language
+}
+
+// builtinAvailable returns whether the cost model includes costs for the builtin
+func (c *CostModel) builtinAvailable(f BuiltinFunc) bool {
+	_, ok := c.builtinCPU[f]
+	return ok
+}
+
+func (c *CostModel) builtinCost(f BuiltinFunc, sizes []int64) ExBudget {
+	return ExBudget{
+		Memory: c.builtinMem[f].cost(sizes),
+		Steps:  c.builtinCPU[f].cost(sizes),
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/cloudflare/circl/ecc/bls12381"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/blake2b"
+	// This package is deprecated, but RIPEMD-160 is required by the ripemd_160 builtin
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	bls12381G1CompressedSize = 48
+	bls12381G2CompressedSize = 96
+	bls12381MaxDstSize       = 255
+)
+
+var bls12381Order = new(big.Int).SetBytes(bls12381.Order())
+
+func hashSha2_256(data []byte) []byte {
+	tmp := sha256.Sum256(data)
+	return tmp[:]
+}
+
+func hashSha3_256(data []byte) []byte {
+	tmp := sha3.Sum256(data)
+	return tmp[:]
+}
+
+func hashKeccak_256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func hashBlake2b_256(data []byte) []byte {
+	tmp := blake2b.Sum256(data)
+	return tmp[:]
+}
+
+func hashBlake2b_224(data []byte) []byte {
+	// New() only returns an error for an invalid size or key
+	h, _ := blake2b.New(28, nil)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func hashRipemd_160(data []byte) []byte {
+	h := ripemd160.New()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func verifyEd25519(pubKey []byte, msg []byte, sig []byte) (bool, error) {
+	if len(pubKey) != ed25519.PublicKeySize {
+		return false, fmt.Errorf("invalid Ed25519 public key length: %d", len(pubKey))
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return false, fmt.Errorf("invalid Ed25519 signature length: %d", len(sig))
+	}
+	return ed25519.Verify(pubKey, msg, sig), nil
+}
+
+func verifyEcdsaSecp256k1(pubKey []byte, msgHash []byte, sig []byte) (bool, error) {
+	if len(pubKey) != secp256k1.PubKeyBytesLenCompressed {
+		return false, fmt.Errorf("invalid ECDSA public key length: %d", len(pubKey))
+	}
+	if len(msgHash) != 32 {
+		return false, fmt.Errorf("invalid ECDSA message hash length: %d", len(msgHash))
+	}
+	if len(sig) != 64 {
+		return false, fmt.Errorf("invalid ECDSA signature length: %d", len(sig))
+	}
+	key, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return false, fmt.Errorf("invalid ECDSA public key: %w", err)
+	}
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:]) {
+		return false, errors.New("invalid ECDSA signature: value out of range")
+	}
+	// Signatures must use the lower of the two possible s values
+	if s.IsOverHalfOrder() {
+		return false, nil
+	}
+	return ecdsa.NewSignature(&r, &s).Verify(msgHash, key), nil
+}
+
+// verifySchnorrSecp256k1 verifies a BIP-340 Schnorr signature
+func verifySchnorrSecp256k1(pubKey []byte, msg []byte, sig []byte) (bool, error) {
+	if len(pubKey) != 32 {
+		return false, fmt.Errorf("invalid Schnorr public key length: %d", len(pubKey))
+	}
+	if len(sig) != 64 {
+		return false, fmt.Errorf("invalid Schnorr signature length: %d", len(sig))
+	}
+	// Public keys are the X coordinate of a point with an even Y coordinate
+	var px, py secp256k1.FieldVal
+	if px.SetByteSlice(pubKey) {
+		return false, errors.New("invalid Schnorr public key: value out of range")
+	}
+	if !secp256k1.DecompressY(&px, false, &py) {
+		return false, errors.New("invalid Schnorr public key: not on curve")
+	}
+	var rx secp256k1.FieldVal
+	var s secp256k1.ModNScalar
+	if rx.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:]) {
+		return false, nil
+	}
+	// e = int(tagged_hash("BIP0340/challenge", r || P || m)) mod n
+	tagHash := sha256.Sum256([]byte("BIP0340/challenge"))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	h.Write(sig[:32])
+	h.Write(pubKey)
+	h.Write(msg)
+	var e secp256k1.ModNScalar
+	e.SetByteSlice(h.Sum(nil))
+	// R = s*G - e*P
+	var p, sG, eP, r secp256k1.JacobianPoint
+	py.Normalize()
+	p = secp256k1.MakeJacobianPoint(&px, &py, new(secp256k1.FieldVal).SetInt(1))
+	secp256k1.ScalarBaseMultNonConst(&s, &sG)
+	e.Negate()
+	secp256k1.ScalarMultNonConst(&e, &p, &eP)
+	secp256k1.AddNonConst(&sG, &eP, &r)
+	if (r.X.IsZero() && r.Y.IsZero()) || r.Z.IsZero() {
+		return false, nil
+	}
+	r.ToAffine()
+	if r.Y.IsOdd() {
+		return false, nil
+	}
+	return r.X.Equals(&rx), nil
+}
+
+func bls12381Scalar(value *big.Int) *bls12381.Scalar {
+	tmp := new(big.Int).Mod(value, bls12381Order)
+	ret := new(bls12381.Scalar)
+	ret.SetBytes(tmp.Bytes())
+	return ret
+}
+
+func bls12381G1Add(a *bls12381.G1, b *bls12381.G1) *bls12381.G1 {
+	ret := new(bls12381.G1)
+	ret.Add(a, b)
+	return ret
+}
+
+func bls12381G1Neg(a *bls12381.G1) *bls12381.G1 {
+	ret := *a
+	ret.Neg()
+	return &ret
+}
+
+func bls12381G1ScalarMul(k *big.Int, a *bls12381.G1) *bls12381.G1 {
+	ret := new(bls12381.G1)
+	ret.ScalarMult(bls12381Scalar(k), a)
+	return ret
+}
+
+func bls12381G1Uncompress(data []byte) (*bls12381.G1, error) {
+	if len(data) != bls12381G1CompressedSize || data[0]&0x80 == 0 {
+		return nil, errors.New("invalid compressed G1 element")
+	}
+	ret := new(bls12381.G1)
+	if err := ret.SetBytes(data); err != nil {
+		return nil, fmt.Errorf("invalid compressed G1 element: %w", err)
+	}
+	return ret, nil
+}
+
+func bls12381G1HashToGroup(msg []byte, dst []byte) (*bls12381.G1, error) {
+	if len(dst) > bls12381MaxDstSize {
+		return nil, fmt.Errorf("hash to group DST too long: %d", len(dst))
+	}
+	ret := new(bls12381.G1)
+	ret.Hash(msg, dst)
+	return ret, nil
+}
+
+func bls12381G2Add(a *bls12381.G2, b *bls12381.G2) *bls12381.G2 {
+	ret := new(bls12381.G2)
+	ret.Add(a, b)
+	return ret
+}
+
+func bls12381G2Neg(a *bls12381.G2) *bls12381.G2 {
+	ret := *a
+	ret.Neg()
+	return &ret
+}
+
+func bls12381G2ScalarMul(k *big.Int, a *bls12381.G2) *bls12381.G2 {
+	ret := new(bls12381.G2)
+	ret.ScalarMult(bls12381Scalar(k), a)
+	return ret
+}
+
+func bls12381G2Uncompress(data []byte) (*bls12381.G2, error) {
+	if len(data) != bls12381G2CompressedSize || data[0]&0x80 == 0 {
+		return nil, errors.New("invalid compressed G2 element")
+	}
+	ret := new(bls12381.G2)
+	if err := ret.SetBytes(data); err != nil {
+		return nil, fmt.Errorf("invalid compressed G2 element: %w", err)
+	}
+	return ret, nil
+}
+
+func bls12381G2HashToGroup(msg []byte, dst []byte) (*bls12381.G2, error) {
+	if len(dst) > bls12381MaxDstSize {
+		return nil, fmt.Errorf("hash to group DST too long: %d", len(dst))
+	}
+	ret := new(bls12381.G2)
+	ret.Hash(msg, dst)
+	return ret, nil
+}
+
+// bls12381MillerLoop computes the pairing of the points. The result includes the final
+// exponentiation, which gives the same results for multiplication and final verification
+func bls12381MillerLoop(a *bls12381.G1, b *bls12381.G2) *bls12381.Gt {
+	return bls12381.Pair(a, b)
+}
+
+func bls12381MulMlResult(a *bls12381.Gt, b *bls12381.Gt) *bls12381.Gt {
+	ret := new(bls12381.Gt)
+	ret.Mul(a, b)
+	return ret
+}
+
+func bls12381FinalVerify(a *bls12381.Gt, b *bls12381.Gt) bool {
+	return a.IsEqual(b)
+}
@@ -0,0 +1,422 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/ledger/alonzo"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/conway"
+)
+
+// RedeemerResult is the result of evaluating the script for a single redeemer
+type RedeemerResult struct {
+	Tag   common.RedeemerTag
+	Index uint
+	// ExUnits is the execution units consumed by the script
+	ExUnits ExBudget
+	// Logs contains the messages emitted by the trace builtin
+	Logs []string
+	// Err is the reason the script failed, or nil if it succeeded
+	Err error
+}
+
+// ScriptError is returned when the script for a redeemer fails, and includes the trace output of
+// the script
+type ScriptError struct {
+	Tag   common.RedeemerTag
+	Index uint
+	Logs  []string
+	Err   error
+}
+
+func (e ScriptError) Error() string {
+	ret := fmt.Sprintf(
+		"script for redeemer (tag %d, index %d) failed: %s",
+		e.Tag,
+		e.Index,
+		e.Err,
+	)
+	if len(e.Logs) > 0 {
+		ret += fmt.Sprintf(": trace: %q", e.Logs)
+	}
+	return ret
+}
+
+func (e ScriptError) Unwrap() error {
+	return e.Err
+}
+
+// scriptParams contains the protocol parameters relevant to script evaluation
+type scriptParams struct {
+	protocolMajor uint
+	costModels    map[uint][]int64
+	maxTxExUnits  common.ExUnit
+}
+
+func scriptParamsFromProtocolParameters(pparams common.ProtocolParameters) (scriptParams, error) {
+	switch p := pparams.(type) {
+	case *alonzo.AlonzoProtocolParameters:
+		return scriptParams{
+			protocolMajor: p.ProtocolMajor,
+			costModels:    p.CostModels,
+			maxTxExUnits:  p.MaxTxExUnits,
+		}, nil
+	case *babbage.BabbageProtocolParameters:
+		return scriptParams{
+			protocolMajor: p.ProtocolMajor,
+			costModels:    p.CostModels,
+			maxTxExUnits:  p.MaxTxExUnits,
+		}, nil
+	case *conway.ConwayProtocolParameters:
+		return scriptParams{
+			protocolMajor: p.ProtocolVersion.Major,
+			costModels:    p.CostModels,
+			maxTxExUnits:  p.MaxTxExUnits,
+		}, nil
+	default:
+		return scriptParams{}, fmt.Errorf("protocol parameters type %T does not support Plutus scripts", pparams)
+	}
+}
+
+func (p scriptParams) costModel(language LanguageVersion) (*CostModel, error) {
+	values, ok := p.costModels[language.costModelKey()]
+	if !ok {
+		return nil, fmt.Errorf("no cost model for %s", language)
+	}
+	return NewCostModel(language, values)
+}
+
+// plutusScript is a Plutus script available to a transaction
+type plutusScript struct {
+	language LanguageVersion
+	script   []byte
+}
+
+// availableScripts returns the Plutus scripts in the transaction witnesses and the reference scripts
+// on the spent and referenced outputs
+func availableScripts(
+	tx common.Transaction,
+	b *scriptContextBuilder,
+) map[common.Blake2b224]plutusScript {
+	ret := make(map[common.Blake2b224]plutusScript)
+	add := func(language LanguageVersion, script []byte) {
+		hash := common.ScriptHash(uint8(language), script)
+		ret[hash] = plutusScript{language: language, script: script}
+	}
+	if witnesses := tx.Witnesses(); witnesses != nil {
+		for _, script := range witnesses.PlutusV1Scripts() {
+			add(LanguageVersionV1, script)
+		}
+		for _, script := range witnesses.PlutusV2Scripts() {
+			add(LanguageVersionV2, script)
+		}
+		for _, script := range witnesses.PlutusV3Scripts() {
+			add(LanguageVersionV3, script)
+		}
+	}
+	type referenceScriptOutput interface {
+		ReferenceScript() (uint, []byte, error)
+	}
+	for _, output := range b.utxos {
+		refOutput, ok := output.(referenceScriptOutput)
+		if !ok {
+			continue
+		}
+		scriptType, script, err := refOutput.ReferenceScript()
+		if err != nil || script == nil {
+			continue
+		}
+		switch scriptType {
+		case common.ScriptTypePlutusV1, common.ScriptTypePlutusV2, common.ScriptTypePlutusV3:
+			add(LanguageVersion(scriptType), script) // #nosec G115
+		}
+	}
+	return ret
+}
+
+// certCredential returns the credential that authorizes a certificate
+func certCredential(cert common.Certificate) *common.StakeCredential {
+	switch c := cert.(type) {
+	case *common.StakeRegistrationCertificate:
+		return &c.StakeRegistration
+	case *common.StakeDeregistrationCertificate:
+		return &c.StakeDeregistration
+	case *common.StakeDelegationCertificate:
+		return c.StakeCredential
+	case *common.RegistrationCertificate:
+		return &c.StakeCredential
+	case *common.DeregistrationCertificate:
+		return &c.StakeCredential
+	case *common.VoteDelegationCertificate:
+		return &c.StakeCredential
+	case *common.StakeVoteDelegationCertificate:
+		return &c.StakeCredential
+	case *common.StakeRegistrationDelegationCertificate:
+		return &c.StakeCredential
+	case *common.VoteRegistrationDelegationCertificate:
+		return &c.StakeCredential
+	case *common.StakeVoteRegistrationDelegationCertificate:
+		return &c.StakeCredential
+	case *common.AuthCommitteeHotCertificate:
+		return &c.ColdCredential
+	case *common.ResignCommitteeColdCertificate:
+		return &c.ColdCredential
+	case *common.RegistrationDrepCertificate:
+		return &c.DrepCredential
+	case *common.DeregistrationDrepCertificate:
+		return &c.DrepCredential
+	case *common.UpdateDrepCertificate:
+		return &c.DrepCredential
+	}
+	return nil
+}
+
+// redeemerScriptHash returns the hash of the script that a redeemer is for
+func (b *scriptContextBuilder) redeemerScriptHash(redeemer redeemerInfo) (common.Blake2b224, error) {
+	scriptCredential := func(cred *common.StakeCredential) (common.Blake2b224, error) {
+		if cred == nil || cred.CredType != common.StakeCredentialTypeScriptHash {
+			return common.Blake2b224{}, errors.New("redeemer does not point to a script credential")
+		}
+		return common.NewBlake2b224(cred.Credential), nil
+	}
+	switch redeemer.tag {
+	case common.RedeemerTagSpend:
+		if redeemer.index >= uint(len(b.inputs)) {
+			return common.Blake2b224{}, fmt.Errorf("spend redeemer index out of range: %d", redeemer.index)
+		}
+		addr := b.utxos[b.inputs[redeemer.index].String()].Address()
+		if addr.Type() == common.AddressTypeByron {
+			return common.Blake2b224{}, errors.New("redeemer does not point to a script credential")
+		}
+		return scriptCredential(addr.PaymentCredential())
+	case common.RedeemerTagMint:
+		if redeemer.index >= uint(len(b.policies)) {
+			return common.Blake2b224{}, fmt.Errorf("mint redeemer index out of range: %d", redeemer.index)
+		}
+		return b.policies[redeemer.index], nil
+	case common.RedeemerTagCert:
+		certs := b.tx.Certificates()
+		if redeemer.index >= uint(len(certs)) {
+			return common.Blake2b224{}, fmt.Errorf("cert redeemer index out of range: %d", redeemer.index)
+		}
+		return scriptCredential(certCredential(certs[redeemer.index]))
+	case common.RedeemerTagReward:
+		if redeemer.index >= uint(len(b.withdrawals)) {
+			return common.Blake2b224{}, fmt.Errorf("reward redeemer index out of range: %d", redeemer.index)
+		}
+		return scriptCredential(b.withdrawals[redeemer.index].address.StakeCredential())
+	case common.RedeemerTagVoting:
+		if redeemer.index >= uint(len(b.voters)) {
+			return common.Blake2b224{}, fmt.Errorf("voting redeemer index out of range: %d", redeemer.index)
+		}
+		voter := b.voters[redeemer.index]
+		switch voter.Type {
+		case common.VoterTypeConstitutionalCommitteeHotScriptHash, common.VoterTypeDRepScriptHash:
+			return common.NewBlake2b224(voter.Hash[:]), nil
+		}
+		return common.Blake2b224{}, errors.New("redeemer does not point to a script voter")
+	case common.RedeemerTagProposing:
+		proposals := b.tx.ProposalProcedures()
+		if redeemer.index >= uint(len(proposals)) {
+			return common.Blake2b224{}, fmt.Errorf("proposing redeemer index out of range: %d", redeemer.index)
+		}
+		var policyHash []byte
+		switch a := proposals[redeemer.index].GovAction.Action.(type) {
+		case *common.ParameterChangeGovAction:
+			policyHash = a.PolicyHash
+		case *common.TreasuryWithdrawalGovAction:
+			policyHash = a.PolicyHash
+		}
+		if len(policyHash) == 0 {
+			return common.Blake2b224{}, errors.New("redeemer does not point to a proposal with a guardrail script")
+		}
+		return common.NewBlake2b224(policyHash), nil
+	default:
+		return common.Blake2b224{}, fmt.Errorf("unknown redeemer tag: %d", redeemer.tag)
+	}
+}
+
+// spendingDatum returns the datum of the output spent by a spend redeemer, if any
+func (b *scriptContextBuilder) spendingDatum(redeemer redeemerInfo) (*common.PlutusData, error) {
+	if redeemer.tag != common.RedeemerTagSpend {
+		return nil, nil
+	}
+	datumHash, inlineDatum, err := outputDatum(b.utxos[b.inputs[redeemer.index].String()])
+	if err != nil {
+		return nil, err
+	}
+	if inlineDatum != nil {
+		return inlineDatum, nil
+	}
+	if datumHash != nil {
+		datum, ok := b.datums[*datumHash]
+		if !ok {
+			return nil, fmt.Errorf("missing datum for hash %s", datumHash.String())
+		}
+		return &datum, nil
+	}
+	return nil, nil
+}
+
+// evaluateRedeemer runs the script for a redeemer with the specified budget
+func (b *scriptContextBuilder) evaluateRedeemer(
+	redeemer redeemerInfo,
+	scripts map[common.Blake2b224]plutusScript,
+	params scriptParams,
+	budget ExBudget,
+) (*EvalResult, error) {
+	scriptHash, err := b.redeemerScriptHash(redeemer)
+	if err != nil {
+		return nil, err
+	}
+	script, ok := scripts[scriptHash]
+	if !ok {
+		return nil, fmt.Errorf("missing Plutus script for hash %s", scriptHash.String())
+	}
+	program, err := NewProgramFromCbor(script.script)
+	if err != nil {
+		return nil, fmt.Errorf("decode script %s: %w", scriptHash.String(), err)
+	}
+	costModel, err := params.costModel(script.language)
+	if err != nil {
+		return nil, err
+	}
+	datum, err := b.spendingDatum(redeemer)
+	if err != nil {
+		return nil, err
+	}
+	if datum == nil && redeemer.tag == common.RedeemerTagSpend && script.language < LanguageVersionV3 {
+		return nil, fmt.Errorf("missing datum for %s spending script", script.language)
+	}
+	ctx, err := b.scriptContext(script.language, redeemer, datum)
+	if err != nil {
+		return nil, fmt.Errorf("build script context: %w", err)
+	}
+	var args []Term
+	if script.language < LanguageVersionV3 {
+		if datum != nil {
+			args = append(args, Const{Value: Data{Value: *datum}})
+		}
+		args = append(args, Const{Value: Data{Value: redeemer.data}})
+	}
+	args = append(args, Const{Value: Data{Value: ctx}})
+	result, err := Evaluate(program.Apply(args...), costModel, budget)
+	if err != nil {
+		return result, err
+	}
+	// PlutusV3 scripts must return unit to succeed
+	if script.language >= LanguageVersionV3 {
+		if c, ok := result.Term.(Const); !ok || c.Value.Type().Kind != TypeKindUnit {
+			return result, fmt.Errorf("%w: script returned %s instead of unit", ErrEvaluationFailure, result.Term)
+		}
+	}
+	return result, nil
+}
+
+// EvaluateTx evaluates the scripts for all redeemers in a transaction, and returns the execution
+// units actually used by each. Each script may use up to the maximum execution units per
+// transaction, regardless of the execution units declared in the redeemer. This is useful for
+// computing the redeemer budgets of a transaction before it is submitted. A ScriptError is
+// returned for each script that fails, and the results are returned in either case
+func EvaluateTx(
+	tx common.Transaction,
+	utxoState common.UtxoState,
+	slots SlotConverter,
+	pparams common.ProtocolParameters,
+) ([]RedeemerResult, error) {
+	params, err := scriptParamsFromProtocolParameters(pparams)
+	if err != nil {
+		return nil, err
+	}
+	budget := ExBudget{
+		Memory: int64(params.maxTxExUnits.Mem),   // #nosec G115
+		Steps:  int64(params.maxTxExUnits.Steps), // #nosec G115
+	}
+	return evaluateTx(tx, utxoState, slots, params, func(redeemerInfo) ExBudget { return budget })
+}
+
+// ValidateTx performs phase-2 validation of a transaction, which runs the script for each redeemer
+// with the execution units declared in the redeemer. It returns a joined error with a ScriptError
+// for each script that fails. Transactions with the is-valid flag unset are expected to fail this
+// validation
+func ValidateTx(
+	tx common.Transaction,
+	utxoState common.UtxoState,
+	slots SlotConverter,
+	pparams common.ProtocolParameters,
+) error {
+	params, err := scriptParamsFromProtocolParameters(pparams)
+	if err != nil {
+		return err
+	}
+	_, err = evaluateTx(
+		tx,
+		utxoState,
+		slots,
+		params,
+		func(redeemer redeemerInfo) ExBudget {
+			return ExBudget{
+				Memory: int64(redeemer.exUnits.Memory), // #nosec G115
+				Steps:  int64(redeemer.exUnits.Steps),  // #nosec G115
+			}
+		},
+	)
+	return err
+}
+
+func evaluateTx(
+	tx common.Transaction,
+	utxoState common.UtxoState,
+	slots SlotConverter,
+	params scriptParams,
+	budgetFunc func(redeemerInfo) ExBudget,
+) ([]RedeemerResult, error) {
+	b, err := newScriptContextBuilder(tx, utxoState, slots, params.protocolMajor)
+	if err != nil {
+		return nil, err
+	}
+	scripts := availableScripts(tx, b)
+	ret := make([]RedeemerResult, 0, len(b.redeemers))
+	var errs []error
+	for _, redeemer := range b.redeemers {
+		result := RedeemerResult{
+			Tag:   redeemer.tag,
+			Index: redeemer.index,
+		}
+		evalResult, err := b.evaluateRedeemer(redeemer, scripts, params, budgetFunc(redeemer))
+		if evalResult != nil {
+			result.ExUnits = evalResult.Cost
+			result.Logs = evalResult.Logs
+		}
+		if err != nil {
+			result.Err = err
+			errs = append(
+				errs,
+				ScriptError{
+					Tag:   redeemer.tag,
+					Index: redeemer.index,
+					Logs:  result.Logs,
+					Err:   err,
+				},
+			)
+		}
+		ret = append(ret, result)
+	}
+	return ret, errors.Join(errs...)
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/conway"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/plutus"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+var testInputTxId = "d228b482a1aae768e4a796380f49e021d9c21f70d3c12cb186b188dedfc0ee22"
+
+type testUtxoState struct {
+	utxos []common.Utxo
+}
+
+func (s testUtxoState) UtxoById(id common.TransactionInput) (common.Utxo, error) {
+	for _, tmpUtxo := range s.utxos {
+		if id.String() == tmpUtxo.Id.String() {
+			return tmpUtxo, nil
+		}
+	}
+	return common.Utxo{}, fmt.Errorf("not found")
+}
+
+type testSlotConverter struct{}
+
+func (testSlotConverter) SlotToTime(slot uint64) (time.Time, error) {
+	return time.Unix(int64(slot), 0), nil // #nosec G115
+}
+
+// testScript returns the on-chain form of a program
+func testScript(t *testing.T, program *plutus.Program) []byte {
+	flatData, err := program.Flat()
+	if err != nil {
+		t.Fatalf("unexpected error encoding program: %s", err)
+	}
+	ret, err := cbor.Encode(flatData)
+	if err != nil {
+		t.Fatalf("unexpected error encoding program: %s", err)
+	}
+	return ret
+}
+
+func testRedeemerData(t *testing.T, data common.PlutusData) cbor.LazyValue {
+	cborData, err := cbor.Encode(&data)
+	if err != nil {
+		t.Fatalf("unexpected error encoding redeemer: %s", err)
+	}
+	var ret cbor.LazyValue
+	if err := ret.UnmarshalCBOR(cborData); err != nil {
+		t.Fatalf("unexpected error decoding redeemer: %s", err)
+	}
+	return ret
+}
+
+func testPlutusParams() *conway.ConwayProtocolParameters {
+	costModels := make(map[uint][]int64)
+	for language, size := range testCostModelSizes {
+		values := make([]int64, size)
+		for idx := range values {
+			values[idx] = 1
+		}
+		costModels[uint(language)-1] = values
+	}
+	return &conway.ConwayProtocolParameters{
+		ProtocolVersion: common.ProtocolParametersProtocolVersion{Major: 10},
+		CostModels:      costModels,
+		MaxTxExUnits:    common.ExUnit{Mem: 10_000_000, Steps: 10_000_000_000},
+	}
+}
+
+// testScriptTx returns a transaction which spends an output locked by the spending script and mints
+// a token with the minting script
+func testScriptTx(
+	t *testing.T,
+	spendScript []byte,
+	mintScript []byte,
+	exUnits common.RedeemerExUnits,
+) (*conway.ConwayTransaction, testUtxoState) {
+	spendScriptHash := common.ScriptHash(common.ScriptTypePlutusV3, spendScript)
+	mintScriptHash := common.ScriptHash(common.ScriptTypePlutusV2, mintScript)
+	scriptAddr, err := common.NewScriptAddress(0, spendScriptHash, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	input := shelley.NewShelleyTransactionInput(testInputTxId, 0)
+	utxoState := testUtxoState{
+		utxos: []common.Utxo{
+			{
+				Id: input,
+				Output: &babbage.BabbageTransactionOutput{
+					OutputAddress: scriptAddr,
+					OutputAmount:  mary.MaryTransactionOutputValue{Amount: 5_000_000},
+				},
+			},
+		},
+	}
+	mint := common.NewMultiAsset[common.MultiAssetTypeMint](
+		map[common.Blake2b224]map[cbor.ByteString]int64{
+			mintScriptHash: {
+				cbor.NewByteString([]byte("token")): 1,
+			},
+		},
+	)
+	tx := &conway.ConwayTransaction{}
+	tx.Body.TxInputs = conway.NewConwayTransactionInputSet(
+		[]shelley.ShelleyTransactionInput{input},
+	)
+	tx.Body.TxOutputs = []babbage.BabbageTransactionOutput{
+		{
+			OutputAddress: scriptAddr,
+			OutputAmount:  mary.MaryTransactionOutputValue{Amount: 4_800_000},
+		},
+	}
+	tx.Body.TxFee = 200_000
+	tx.Body.TxMint = &mint
+	tx.Body.TxValidityIntervalStart = 1000
+	tx.Body.Ttl = 2000
+	tx.WitnessSet.WsPlutusV3Scripts = [][]byte{spendScript}
+	tx.WitnessSet.WsPlutusV2Scripts = [][]byte{mintScript}
+	tx.WitnessSet.WsRedeemers = conway.ConwayRedeemers{
+		Redeemers: map[conway.ConwayRedeemerKey]conway.ConwayRedeemerValue{
+			{Tag: common.RedeemerTagSpend, Index: 0}: {
+				Data:    testRedeemerData(t, common.NewPlutusInt(42)),
+				ExUnits: exUnits,
+			},
+			{Tag: common.RedeemerTagMint, Index: 0}: {
+				Data:    testRedeemerData(t, common.NewPlutusConstr(0)),
+				ExUnits: exUnits,
+			},
+		},
+	}
+	return tx, utxoState
+}
+
+// alwaysSucceedsV2 accepts any number of arguments up to 3
+var alwaysSucceedsV2, _ = hex.DecodeString("4d01000033222220051200120011")
+
+func TestEvaluateTx(t *testing.T) {
+	spendScript := testScript(
+		t,
+		&plutus.Program{
+			Version: plutus.Version110,
+			Term:    plutus.Lambda{Body: plutus.Const{Value: plutus.Unit{}}},
+		},
+	)
+	tx, utxoState := testScriptTx(
+		t,
+		spendScript,
+		alwaysSucceedsV2,
+		common.RedeemerExUnits{Memory: 1000, Steps: 1000},
+	)
+	results, err := plutus.EvaluateTx(tx, utxoState, testSlotConverter{}, testPlutusParams())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("did not get expected number of results: got %d, wanted 2", len(results))
+	}
+	// Startup, 1 application, 1 lambda and 2 constants for the script context and the result
+	expectedSpend := plutus.ExBudget{Memory: 5, Steps: 5}
+	if results[0].Tag != common.RedeemerTagSpend || results[0].ExUnits != expectedSpend {
+		t.Errorf(
+			"did not get expected spend result: got tag %d with %s, wanted %s",
+			results[0].Tag,
+			results[0].ExUnits,
+			expectedSpend,
+		)
+	}
+	if results[1].Tag != common.RedeemerTagMint || results[1].Err != nil {
+		t.Errorf("did not get expected mint result: %+v", results[1])
+	}
+	if err := plutus.ValidateTx(tx, utxoState, testSlotConverter{}, testPlutusParams()); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+	// Declared budgets which are too small
+	tx, utxoState = testScriptTx(
+		t,
+		spendScript,
+		alwaysSucceedsV2,
+		common.RedeemerExUnits{Memory: 4, Steps: 4},
+	)
+	err = plutus.ValidateTx(tx, utxoState, testSlotConverter{}, testPlutusParams())
+	if !errors.Is(err, plutus.ErrOutOfBudget) {
+		t.Errorf("did not get expected error: got %v, wanted %s", err, plutus.ErrOutOfBudget)
+	}
+}
+
+func TestEvaluateTxFailure(t *testing.T) {
+	// Traces a message and then fails
+	spendScript := testScript(
+		t,
+		&plutus.Program{
+			Version: plutus.Version100,
+			Term: plutus.Lambda{
+				Body: plutus.Force{
+					Term: plutus.Apply{
+						Function: plutus.Apply{
+							Function: plutus.Force{Term: plutus.Builtin{Func: plutus.BuiltinTrace}},
+							Argument: plutus.Const{Value: plutus.Text{Value: "validation failed"}},
+						},
+						Argument: plutus.Delay{Body: plutus.ErrorTerm{}},
+					},
+				},
+			},
+		},
+	)
+	tx, utxoState := testScriptTx(
+		t,
+		spendScript,
+		alwaysSucceedsV2,
+		common.RedeemerExUnits{Memory: 1000, Steps: 1000},
+	)
+	results, err := plutus.EvaluateTx(tx, utxoState, testSlotConverter{}, testPlutusParams())
+	var scriptErr plutus.ScriptError
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("did not get expected script error: %v", err)
+	}
+	if scriptErr.Tag != common.RedeemerTagSpend || scriptErr.Index != 0 {
+		t.Errorf("did not get expected redeemer in error: tag %d, index %d", scriptErr.Tag, scriptErr.Index)
+	}
+	if len(scriptErr.Logs) != 1 || scriptErr.Logs[0] != "validation failed" {
+		t.Errorf("did not get expected trace: %v", scriptErr.Logs)
+	}
+	if !errors.Is(err, plutus.ErrEvaluationFailure) {
+		t.Errorf("did not get expected error: %s", err)
+	}
+	if len(results) != 2 || results[1].Err != nil {
+		t.Errorf("did not get expected results: %+v", results)
+	}
+}
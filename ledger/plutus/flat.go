@@ -0,0 +1,642 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const (
+	flatTermTagBits    = 4
+	flatTypeTagBits    = 4
+	flatBuiltinTagBits = 7
+)
+
+const (
+	flatTermVar      = 0
+	flatTermDelay    = 1
+	flatTermLambda   = 2
+	flatTermApply    = 3
+	flatTermConstant = 4
+	flatTermForce    = 5
+	flatTermError    = 6
+	flatTermBuiltin  = 7
+	flatTermConstr   = 8
+	flatTermCase     = 9
+)
+
+// NewProgramFromFlat decodes a flat-encoded program
+func NewProgramFromFlat(data []byte) (*Program, error) {
+	d := &flatDecoder{data: data}
+	var ret Program
+	var err error
+	if ret.Version.Major, err = d.word64(); err != nil {
+		return nil, err
+	}
+	if ret.Version.Minor, err = d.word64(); err != nil {
+		return nil, err
+	}
+	if ret.Version.Patch, err = d.word64(); err != nil {
+		return nil, err
+	}
+	if ret.Version != Version100 && ret.Version != Version110 {
+		return nil, fmt.Errorf("unsupported Plutus Core version: %s", ret.Version)
+	}
+	d.allowSop = ret.Version == Version110
+	if ret.Term, err = d.term(); err != nil {
+		return nil, err
+	}
+	if err := d.filler(); err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data)*8 {
+		return nil, errors.New("trailing data after flat program")
+	}
+	return &ret, nil
+}
+
+// Flat returns the flat encoding of the program
+func (p *Program) Flat() ([]byte, error) {
+	e := &flatEncoder{}
+	e.word64(p.Version.Major)
+	e.word64(p.Version.Minor)
+	e.word64(p.Version.Patch)
+	if err := e.term(p.Term); err != nil {
+		return nil, err
+	}
+	e.filler()
+	return e.buf, nil
+}
+
+type flatDecoder struct {
+	data     []byte
+	pos      int
+	allowSop bool
+}
+
+func (d *flatDecoder) bits(count int) (uint8, error) {
+	if d.pos+count > len(d.data)*8 {
+		return 0, errors.New("unexpected end of flat data")
+	}
+	var ret uint8
+	for i := 0; i < count; i++ {
+		bit := (d.data[d.pos/8] >> (7 - d.pos%8)) & 1
+		ret = ret<<1 | bit
+		d.pos++
+	}
+	return ret, nil
+}
+
+func (d *flatDecoder) bit() (bool, error) {
+	ret, err := d.bits(1)
+	return ret == 1, err
+}
+
+func (d *flatDecoder) filler() error {
+	for {
+		bit, err := d.bit()
+		if err != nil {
+			return err
+		}
+		if bit {
+			break
+		}
+	}
+	if d.pos%8 != 0 {
+		return errors.New("flat filler does not end on a byte boundary")
+	}
+	return nil
+}
+
+// natural decodes a variable length natural number, stored as 7-bit groups with the least
+// significant group first
+func (d *flatDecoder) natural() (*big.Int, error) {
+	ret := new(big.Int)
+	var shift uint
+	for {
+		group, err := d.bits(8)
+		if err != nil {
+			return nil, err
+		}
+		ret.Or(ret, new(big.Int).Lsh(big.NewInt(int64(group&0x7f)), shift))
+		shift += 7
+		if group&0x80 == 0 {
+			break
+		}
+	}
+	return ret, nil
+}
+
+func (d *flatDecoder) word64() (uint64, error) {
+	n, err := d.natural()
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("flat value out of range: %s", n)
+	}
+	return n.Uint64(), nil
+}
+
+// integer decodes a zigzag encoded integer
+func (d *flatDecoder) integer() (*big.Int, error) {
+	n, err := d.natural()
+	if err != nil {
+		return nil, err
+	}
+	if n.Bit(0) == 0 {
+		return n.Rsh(n, 1), nil
+	}
+	// Odd values are negative: -(n+1)/2
+	n.Add(n, big.NewInt(1))
+	n.Rsh(n, 1)
+	return n.Neg(n), nil
+}
+
+func (d *flatDecoder) bytes() ([]byte, error) {
+	if err := d.filler(); err != nil {
+		return nil, err
+	}
+	ret := []byte{}
+	for {
+		chunkLen, err := d.bits(8)
+		if err != nil {
+			return nil, err
+		}
+		if chunkLen == 0 {
+			break
+		}
+		start := d.pos / 8
+		end := start + int(chunkLen)
+		if end > len(d.data) {
+			return nil, errors.New("unexpected end of flat data")
+		}
+		ret = append(ret, d.data[start:end]...)
+		d.pos = end * 8
+	}
+	return ret, nil
+}
+
+// list decodes a flat list, where each item is preceded by a 1 bit and the list ends with a 0 bit
+func (d *flatDecoder) list(item func() error) error {
+	for {
+		more, err := d.bit()
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+		if err := item(); err != nil {
+			return err
+		}
+	}
+}
+
+func (d *flatDecoder) term() (Term, error) {
+	tag, err := d.bits(flatTermTagBits)
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case flatTermVar:
+		idx, err := d.word64()
+		if err != nil {
+			return nil, err
+		}
+		return Var{Index: idx}, nil
+	case flatTermDelay:
+		body, err := d.term()
+		if err != nil {
+			return nil, err
+		}
+		return Delay{Body: body}, nil
+	case flatTermLambda:
+		body, err := d.term()
+		if err != nil {
+			return nil, err
+		}
+		return Lambda{Body: body}, nil
+	case flatTermApply:
+		fun, err := d.term()
+		if err != nil {
+			return nil, err
+		}
+		arg, err := d.term()
+		if err != nil {
+			return nil, err
+		}
+		return Apply{Function: fun, Argument: arg}, nil
+	case flatTermConstant:
+		value, err := d.constant()
+		if err != nil {
+			return nil, err
+		}
+		return Const{Value: value}, nil
+	case flatTermForce:
+		body, err := d.term()
+		if err != nil {
+			return nil, err
+		}
+		return Force{Term: body}, nil
+	case flatTermError:
+		return ErrorTerm{}, nil
+	case flatTermBuiltin:
+		builtinTag, err := d.bits(flatBuiltinTagBits)
+		if err != nil {
+			return nil, err
+		}
+		fun := BuiltinFunc(builtinTag)
+		if !fun.valid() {
+			return nil, fmt.Errorf("unknown builtin function: %d", builtinTag)
+		}
+		return Builtin{Func: fun}, nil
+	case flatTermConstr, flatTermCase:
+		if !d.allowSop {
+			return nil, fmt.Errorf("term tag %d requires Plutus Core 1.1.0", tag)
+		}
+		var ret Term
+		var terms []Term
+		readTerm := func() error {
+			tmpTerm, err := d.term()
+			if err != nil {
+				return err
+			}
+			terms = append(terms, tmpTerm)
+			return nil
+		}
+		if tag == flatTermConstr {
+			constrTag, err := d.word64()
+			if err != nil {
+				return nil, err
+			}
+			if err := d.list(readTerm); err != nil {
+				return nil, err
+			}
+			ret = Constr{Tag: constrTag, Fields: terms}
+		} else {
+			scrutinee, err := d.term()
+			if err != nil {
+				return nil, err
+			}
+			if err := d.list(readTerm); err != nil {
+				return nil, err
+			}
+			ret = Case{Scrutinee: scrutinee, Branches: terms}
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("unknown term tag: %d", tag)
+	}
+}
+
+func (d *flatDecoder) constant() (Constant, error) {
+	var tags []uint8
+	err := d.list(func() error {
+		tag, err := d.bits(flatTypeTagBits)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, tag)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	constType, rest, err := decodeType(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("unexpected trailing type tags")
+	}
+	return d.constantValue(constType)
+}
+
+func decodeType(tags []uint8) (Type, []uint8, error) {
+	if len(tags) == 0 {
+		return Type{}, nil, errors.New("missing constant type")
+	}
+	switch kind := TypeKind(tags[0]); kind {
+	case TypeKindInteger,
+		TypeKindByteString,
+		TypeKindString,
+		TypeKindUnit,
+		TypeKindBool,
+		TypeKindData:
+		return Type{Kind: kind}, tags[1:], nil
+	case typeKindApply:
+		if len(tags) > 1 && TypeKind(tags[1]) == TypeKindList {
+			elem, rest, err := decodeType(tags[2:])
+			if err != nil {
+				return Type{}, nil, err
+			}
+			return TypeList(elem), rest, nil
+		}
+		if len(tags) > 2 && TypeKind(tags[1]) == typeKindApply &&
+			TypeKind(tags[2]) == TypeKindPair {
+			first, rest, err := decodeType(tags[3:])
+			if err != nil {
+				return Type{}, nil, err
+			}
+			second, rest, err := decodeType(rest)
+			if err != nil {
+				return Type{}, nil, err
+			}
+			return TypePair(first, second), rest, nil
+		}
+		return Type{}, nil, errors.New("invalid type application")
+	default:
+		return Type{}, nil, fmt.Errorf("unsupported constant type tag: %d", kind)
+	}
+}
+
+func (d *flatDecoder) constantValue(constType Type) (Constant, error) {
+	switch constType.Kind {
+	case TypeKindInteger:
+		value, err := d.integer()
+		if err != nil {
+			return nil, err
+		}
+		return Integer{Value: value}, nil
+	case TypeKindByteString:
+		value, err := d.bytes()
+		if err != nil {
+			return nil, err
+		}
+		return ByteString{Value: value}, nil
+	case TypeKindString:
+		value, err := d.bytes()
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.Valid(value) {
+			return nil, errors.New("invalid UTF-8 in string constant")
+		}
+		return Text{Value: string(value)}, nil
+	case TypeKindUnit:
+		return Unit{}, nil
+	case TypeKindBool:
+		value, err := d.bit()
+		if err != nil {
+			return nil, err
+		}
+		return Bool{Value: value}, nil
+	case TypeKindList:
+		ret := List{ElemType: constType.Params[0], Items: []Constant{}}
+		err := d.list(func() error {
+			item, err := d.constantValue(constType.Params[0])
+			if err != nil {
+				return err
+			}
+			ret.Items = append(ret.Items, item)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return ret, nil
+	case TypeKindPair:
+		first, err := d.constantValue(constType.Params[0])
+		if err != nil {
+			return nil, err
+		}
+		second, err := d.constantValue(constType.Params[1])
+		if err != nil {
+			return nil, err
+		}
+		return Pair{First: first, Second: second}, nil
+	case TypeKindData:
+		value, err := d.bytes()
+		if err != nil {
+			return nil, err
+		}
+		data, err := common.NewPlutusDataFromCbor(value)
+		if err != nil {
+			return nil, fmt.Errorf("decode data constant: %w", err)
+		}
+		return Data{Value: data}, nil
+	default:
+		return nil, fmt.Errorf("unsupported constant type: %s", constType)
+	}
+}
+
+type flatEncoder struct {
+	buf   []byte
+	nbits int
+}
+
+func (e *flatEncoder) bits(count int, value uint8) {
+	for i := count - 1; i >= 0; i-- {
+		if e.nbits%8 == 0 {
+			e.buf = append(e.buf, 0)
+		}
+		if (value>>i)&1 == 1 {
+			e.buf[len(e.buf)-1] |= 1 << (7 - e.nbits%8)
+		}
+		e.nbits++
+	}
+}
+
+func (e *flatEncoder) bit(value bool) {
+	if value {
+		e.bits(1, 1)
+	} else {
+		e.bits(1, 0)
+	}
+}
+
+func (e *flatEncoder) filler() {
+	for e.nbits%8 != 7 {
+		e.bits(1, 0)
+	}
+	e.bits(1, 1)
+}
+
+func (e *flatEncoder) natural(n *big.Int) {
+	n = new(big.Int).Set(n)
+	mask := big.NewInt(0x7f)
+	for {
+		group := uint8(new(big.Int).And(n, mask).Uint64())
+		n.Rsh(n, 7)
+		if n.Sign() == 0 {
+			e.bits(8, group)
+			return
+		}
+		e.bits(8, group|0x80)
+	}
+}
+
+func (e *flatEncoder) word64(n uint64) {
+	e.natural(new(big.Int).SetUint64(n))
+}
+
+func (e *flatEncoder) integer(n *big.Int) {
+	// Zigzag encoding maps n to 2n for non-negative values and -2n-1 for negative values
+	tmp := new(big.Int).Lsh(n, 1)
+	if n.Sign() < 0 {
+		tmp.Neg(tmp).Sub(tmp, big.NewInt(1))
+	}
+	e.natural(tmp)
+}
+
+func (e *flatEncoder) bytes(data []byte) {
+	e.filler()
+	for len(data) > 0 {
+		chunkLen := min(len(data), 255)
+		e.buf = append(e.buf, uint8(chunkLen))
+		e.buf = append(e.buf, data[:chunkLen]...)
+		e.nbits += 8 * (chunkLen + 1)
+		data = data[chunkLen:]
+	}
+	e.buf = append(e.buf, 0)
+	e.nbits += 8
+}
+
+func (e *flatEncoder) term(term Term) error {
+	switch t := term.(type) {
+	case Var:
+		e.bits(flatTermTagBits, flatTermVar)
+		e.word64(t.Index)
+	case Delay:
+		e.bits(flatTermTagBits, flatTermDelay)
+		return e.term(t.Body)
+	case Lambda:
+		e.bits(flatTermTagBits, flatTermLambda)
+		return e.term(t.Body)
+	case Apply:
+		e.bits(flatTermTagBits, flatTermApply)
+		if err := e.term(t.Function); err != nil {
+			return err
+		}
+		return e.term(t.Argument)
+	case Const:
+		e.bits(flatTermTagBits, flatTermConstant)
+		return e.constant(t.Value)
+	case Force:
+		e.bits(flatTermTagBits, flatTermForce)
+		return e.term(t.Term)
+	case ErrorTerm:
+		e.bits(flatTermTagBits, flatTermError)
+	case Builtin:
+		e.bits(flatTermTagBits, flatTermBuiltin)
+		e.bits(flatBuiltinTagBits, uint8(t.Func))
+	case Constr:
+		e.bits(flatTermTagBits, flatTermConstr)
+		e.word64(t.Tag)
+		return e.termList(t.Fields)
+	case Case:
+		e.bits(flatTermTagBits, flatTermCase)
+		if err := e.term(t.Scrutinee); err != nil {
+			return err
+		}
+		return e.termList(t.Branches)
+	default:
+		return fmt.Errorf("unknown term type: %T", term)
+	}
+	return nil
+}
+
+func (e *flatEncoder) termList(terms []Term) error {
+	for _, term := range terms {
+		e.bit(true)
+		if err := e.term(term); err != nil {
+			return err
+		}
+	}
+	e.bit(false)
+	return nil
+}
+
+func (e *flatEncoder) constant(value Constant) error {
+	tags, err := encodeType(value.Type())
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		e.bit(true)
+		e.bits(flatTypeTagBits, tag)
+	}
+	e.bit(false)
+	return e.constantValue(value)
+}
+
+func encodeType(constType Type) ([]uint8, error) {
+	switch constType.Kind {
+	case TypeKindList:
+		elem, err := encodeType(constType.Params[0])
+		if err != nil {
+			return nil, err
+		}
+		return append([]uint8{uint8(typeKindApply), uint8(TypeKindList)}, elem...), nil
+	case TypeKindPair:
+		first, err := encodeType(constType.Params[0])
+		if err != nil {
+			return nil, err
+		}
+		second, err := encodeType(constType.Params[1])
+		if err != nil {
+			return nil, err
+		}
+		ret := []uint8{uint8(typeKindApply), uint8(typeKindApply), uint8(TypeKindPair)}
+		ret = append(ret, first...)
+		return append(ret, second...), nil
+	case TypeKindBls12381G1, TypeKindBls12381G2, TypeKindBls12381MlResult:
+		return nil, fmt.Errorf("constants of type %s cannot be flat encoded", constType)
+	default:
+		return []uint8{uint8(constType.Kind)}, nil
+	}
+}
+
+func (e *flatEncoder) constantValue(value Constant) error {
+	switch v := value.(type) {
+	case Integer:
+		e.integer(v.Value)
+	case ByteString:
+		e.bytes(v.Value)
+	case Text:
+		e.bytes([]byte(v.Value))
+	case Unit:
+	case Bool:
+		e.bit(v.Value)
+	case List:
+		for _, item := range v.Items {
+			e.bit(true)
+			if err := e.constantValue(item); err != nil {
+				return err
+			}
+		}
+		e.bit(false)
+	case Pair:
+		if err := e.constantValue(v.First); err != nil {
+			return err
+		}
+		return e.constantValue(v.Second)
+	case Data:
+		cborData, err := v.Value.MarshalCBOR()
+		if err != nil {
+			return err
+		}
+		e.bytes(cborData)
+	default:
+		return fmt.Errorf("constants of type %s cannot be flat encoded", value.Type())
+	}
+	return nil
+}
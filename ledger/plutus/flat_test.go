@@ -0,0 +1,152 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/plutus"
+)
+
+func TestProgramFromCbor(t *testing.T) {
+	testDefs := []struct {
+		cborHex  string
+		expected string
+	}{
+		{
+			cborHex:  "4d01000033222220051200120011",
+			expected: "(program 1.0.0 [[(lam (lam (lam (lam (lam i5))))) (delay (lam i1))] (lam i1)])",
+		},
+		{
+			cborHex:  "450100002499",
+			expected: "(program 1.0.0 (lam (con unit ())))",
+		},
+	}
+	for _, testDef := range testDefs {
+		cborData, _ := hex.DecodeString(testDef.cborHex)
+		program, err := plutus.NewProgramFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if program.String() != testDef.expected {
+			t.Errorf(
+				"did not get expected program\n  got:    %s\n  wanted: %s",
+				program.String(),
+				testDef.expected,
+			)
+		}
+	}
+}
+
+func TestProgramFlatRoundTrip(t *testing.T) {
+	testDefs := []*plutus.Program{
+		{
+			Version: plutus.Version100,
+			Term: plutus.Lambda{
+				Body: plutus.Apply{
+					Function: plutus.Force{Term: plutus.Builtin{Func: plutus.BuiltinTrace}},
+					Argument: plutus.Const{Value: plutus.Text{Value: "hello"}},
+				},
+			},
+		},
+		{
+			Version: plutus.Version100,
+			Term: plutus.Const{
+				Value: plutus.List{
+					ElemType: plutus.TypePair(plutus.TypeInteger, plutus.TypeByteString),
+					Items: []plutus.Constant{
+						plutus.Pair{
+							First:  plutus.NewInteger(-123456789012345),
+							Second: plutus.ByteString{Value: []byte{0xde, 0xad}},
+						},
+					},
+				},
+			},
+		},
+		{
+			Version: plutus.Version100,
+			Term: plutus.Const{
+				Value: plutus.Data{
+					Value: common.NewPlutusConstr(
+						1,
+						common.NewPlutusInt(42),
+						common.NewPlutusBytes([]byte("abc")),
+					),
+				},
+			},
+		},
+		{
+			Version: plutus.Version110,
+			Term: plutus.Case{
+				Scrutinee: plutus.Constr{
+					Tag:    1,
+					Fields: []plutus.Term{plutus.Const{Value: plutus.Bool{Value: true}}},
+				},
+				Branches: []plutus.Term{
+					plutus.ErrorTerm{},
+					plutus.Lambda{Body: plutus.Var{Index: 1}},
+				},
+			},
+		},
+	}
+	for _, program := range testDefs {
+		flatData, err := program.Flat()
+		if err != nil {
+			t.Fatalf("unexpected error encoding program: %s", err)
+		}
+		decoded, err := plutus.NewProgramFromFlat(flatData)
+		if err != nil {
+			t.Fatalf("unexpected error decoding program: %s", err)
+		}
+		if decoded.String() != program.String() {
+			t.Errorf(
+				"did not get expected program after round-trip\n  got:    %s\n  wanted: %s",
+				decoded.String(),
+				program.String(),
+			)
+		}
+	}
+}
+
+func TestProgramFromFlatInvalid(t *testing.T) {
+	testDefs := []struct {
+		name    string
+		program *plutus.Program
+	}{
+		{
+			name: "constr in 1.0.0",
+			program: &plutus.Program{
+				Version: plutus.Version100,
+				Term:    plutus.Constr{Tag: 0},
+			},
+		},
+	}
+	for _, testDef := range testDefs {
+		flatData, err := testDef.program.Flat()
+		if err != nil {
+			t.Fatalf("unexpected error encoding program: %s", err)
+		}
+		if _, err := plutus.NewProgramFromFlat(flatData); err == nil {
+			t.Errorf("did not get expected error for %s", testDef.name)
+		}
+	}
+	// Trailing data
+	flatData, _ := hex.DecodeString("0100002499")
+	if _, err := plutus.NewProgramFromFlat(append(flatData, 0x00)); err == nil {
+		t.Errorf("did not get expected error for trailing data")
+	}
+}
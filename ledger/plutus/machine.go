@@ -0,0 +1,466 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrEvaluationFailure is returned when a script fails during evaluation
+	ErrEvaluationFailure = errors.New("evaluation failure")
+	// ErrOutOfBudget is returned when a script exceeds its execution budget
+	ErrOutOfBudget = errors.New("execution budget exceeded")
+)
+
+// EvalResult is the result of evaluating a program
+type EvalResult struct {
+	// Term is the result of evaluation. It is nil if evaluation failed
+	Term Term
+	// Cost is the execution units consumed during evaluation
+	Cost ExBudget
+	// Logs contains the messages emitted by the trace builtin
+	Logs []string
+}
+
+// value is a CEK machine value
+type value interface {
+	isValue()
+}
+
+type constantValue struct {
+	constant Constant
+}
+
+type delayValue struct {
+	body Term
+	env  *environment
+}
+
+type lambdaValue struct {
+	body Term
+	env  *environment
+}
+
+type builtinValue struct {
+	fun    BuiltinFunc
+	forces int
+	args   []value
+}
+
+type constrValue struct {
+	tag    uint64
+	fields []value
+}
+
+func (constantValue) isValue() {}
+func (delayValue) isValue()    {}
+func (lambdaValue) isValue()   {}
+func (builtinValue) isValue()  {}
+func (constrValue) isValue()   {}
+
+// environment is a linked list of values bound by enclosing lambdas, with the innermost first
+type environment struct {
+	value value
+	next  *environment
+}
+
+func (e *environment) extend(v value) *environment {
+	return &environment{value: v, next: e}
+}
+
+func (e *environment) lookup(idx uint64) (value, bool) {
+	for ; e != nil; e = e.next {
+		idx--
+		if idx == 0 {
+			return e.value, true
+		}
+	}
+	return nil, false
+}
+
+// frame is an entry on the CEK machine continuation stack
+type frame interface{}
+
+// frameApplyArg waits for the function value, and then evaluates the argument
+type frameApplyArg struct {
+	arg Term
+	env *environment
+}
+
+// frameApplyFun waits for the argument value, and then applies the function
+type frameApplyFun struct {
+	fun value
+}
+
+type frameForce struct{}
+
+type frameConstr struct {
+	tag       uint64
+	env       *environment
+	remaining []Term
+	done      []value
+}
+
+type frameCase struct {
+	branches []Term
+	env      *environment
+}
+
+type machine struct {
+	costModel *CostModel
+	remaining ExBudget
+	logs      []string
+}
+
+// Evaluate runs the program with the specified cost model and execution budget. The returned
+// result is non-nil when evaluation fails, and includes the cost and logs up to the failure
+func Evaluate(
+	program *Program,
+	costModel *CostModel,
+	budget ExBudget,
+) (*EvalResult, error) {
+	m := &machine{
+		costModel: costModel,
+		remaining: budget,
+	}
+	ret := &EvalResult{}
+	result, err := m.evaluate(program)
+	ret.Cost = ExBudget{
+		Memory: budget.Memory - m.remaining.Memory,
+		Steps:  budget.Steps - m.remaining.Steps,
+	}
+	ret.Logs = m.logs
+	if err != nil {
+		return ret, err
+	}
+	ret.Term = dischargeValue(result)
+	return ret, nil
+}
+
+func (m *machine) evaluate(program *Program) (value, error) {
+	if !m.costModel.language.programVersionAllowed(program.Version) {
+		return nil, fmt.Errorf(
+			"Plutus Core version %s is not allowed for %s",
+			program.Version,
+			m.costModel.language,
+		)
+	}
+	if err := checkScopes(program.Term, 0); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEvaluationFailure, err)
+	}
+	if err := m.spend(m.costModel.machineCosts[stepStartup]); err != nil {
+		return nil, err
+	}
+	return m.run(program.Term)
+}
+
+func (m *machine) spend(cost ExBudget) error {
+	m.remaining.Memory = satAdd(m.remaining.Memory, -cost.Memory)
+	m.remaining.Steps = satAdd(m.remaining.Steps, -cost.Steps)
+	if m.remaining.Memory < 0 || m.remaining.Steps < 0 {
+		return ErrOutOfBudget
+	}
+	return nil
+}
+
+func (m *machine) step(kind machineStep) error {
+	if !m.costModel.machineKnown[kind] {
+		return fmt.Errorf(
+			"%w: cost model for %s has no cost for %s",
+			ErrEvaluationFailure,
+			m.costModel.language,
+			machineStepNames[kind],
+		)
+	}
+	return m.spend(m.costModel.machineCosts[kind])
+}
+
+// run evaluates a term using the CEK machine. The machine alternates between computing a term
+// in an environment and returning a value to the frame on top of the stack
+func (m *machine) run(term Term) (value, error) {
+	var stack []frame
+	var env *environment
+	var ret value
+	computing := true
+	for {
+		if computing {
+			var err error
+			switch t := term.(type) {
+			case Var:
+				if err = m.step(stepVar); err != nil {
+					return nil, err
+				}
+				var ok bool
+				ret, ok = env.lookup(t.Index)
+				if !ok {
+					return nil, fmt.Errorf("%w: free variable: %d", ErrEvaluationFailure, t.Index)
+				}
+				computing = false
+			case Const:
+				if err = m.step(stepConst); err != nil {
+					return nil, err
+				}
+				ret = constantValue{constant: t.Value}
+				computing = false
+			case Lambda:
+				if err = m.step(stepLambda); err != nil {
+					return nil, err
+				}
+				ret = lambdaValue{body: t.Body, env: env}
+				computing = false
+			case Delay:
+				if err = m.step(stepDelay); err != nil {
+					return nil, err
+				}
+				ret = delayValue{body: t.Body, env: env}
+				computing = false
+			case Force:
+				if err = m.step(stepForce); err != nil {
+					return nil, err
+				}
+				stack = append(stack, frameForce{})
+				term = t.Term
+			case Apply:
+				if err = m.step(stepApply); err != nil {
+					return nil, err
+				}
+				stack = append(stack, frameApplyArg{arg: t.Argument, env: env})
+				term = t.Function
+			case Builtin:
+				if err = m.step(stepBuiltin); err != nil {
+					return nil, err
+				}
+				if !m.costModel.language.builtinAllowed(t.Func) ||
+					!m.costModel.builtinAvailable(t.Func) {
+					return nil, fmt.Errorf(
+						"%w: builtin %s is not available for %s",
+						ErrEvaluationFailure,
+						t.Func,
+						m.costModel.language,
+					)
+				}
+				ret = builtinValue{fun: t.Func}
+				computing = false
+			case Constr:
+				if err = m.step(stepConstr); err != nil {
+					return nil, err
+				}
+				if len(t.Fields) == 0 {
+					ret = constrValue{tag: t.Tag}
+					computing = false
+				} else {
+					stack = append(
+						stack,
+						frameConstr{tag: t.Tag, env: env, remaining: t.Fields[1:]},
+					)
+					term = t.Fields[0]
+				}
+			case Case:
+				if err = m.step(stepCase); err != nil {
+					return nil, err
+				}
+				stack = append(stack, frameCase{branches: t.Branches, env: env})
+				term = t.Scrutinee
+			case ErrorTerm:
+				return nil, fmt.Errorf("%w: explicit error term", ErrEvaluationFailure)
+			default:
+				return nil, fmt.Errorf("%w: unknown term type: %T", ErrEvaluationFailure, term)
+			}
+			continue
+		}
+		if len(stack) == 0 {
+			return ret, nil
+		}
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		switch f := top.(type) {
+		case frameApplyArg:
+			stack = append(stack, frameApplyFun{fun: ret})
+			term = f.arg
+			env = f.env
+			computing = true
+		case frameApplyFun:
+			switch fun := f.fun.(type) {
+			case lambdaValue:
+				term = fun.body
+				env = fun.env.extend(ret)
+				computing = true
+			case builtinValue:
+				var err error
+				if ret, err = m.applyBuiltin(fun, ret); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, fmt.Errorf("%w: attempted to apply a non-function", ErrEvaluationFailure)
+			}
+		case frameForce:
+			switch v := ret.(type) {
+			case delayValue:
+				term = v.body
+				env = v.env
+				computing = true
+			case builtinValue:
+				if v.forces >= v.fun.Forces() {
+					return nil, fmt.Errorf(
+						"%w: builtin %s forced too many times",
+						ErrEvaluationFailure,
+						v.fun,
+					)
+				}
+				v.forces++
+				ret = v
+			default:
+				return nil, fmt.Errorf("%w: attempted to force a non-polymorphic value", ErrEvaluationFailure)
+			}
+		case frameConstr:
+			done := make([]value, len(f.done), len(f.done)+1)
+			copy(done, f.done)
+			done = append(done, ret)
+			if len(f.remaining) == 0 {
+				ret = constrValue{tag: f.tag, fields: done}
+			} else {
+				stack = append(
+					stack,
+					frameConstr{tag: f.tag, env: f.env, remaining: f.remaining[1:], done: done},
+				)
+				term = f.remaining[0]
+				env = f.env
+				computing = true
+			}
+		case frameCase:
+			constr, ok := ret.(constrValue)
+			if !ok {
+				return nil, fmt.Errorf("%w: case scrutinee is not a constructor", ErrEvaluationFailure)
+			}
+			if constr.tag >= uint64(len(f.branches)) {
+				return nil, fmt.Errorf(
+					"%w: no case branch for constructor tag %d",
+					ErrEvaluationFailure,
+					constr.tag,
+				)
+			}
+			// The selected branch is applied to the constructor fields in order
+			for idx := len(constr.fields) - 1; idx >= 0; idx-- {
+				stack = append(stack, frameApplyFun{fun: constr.fields[idx]})
+			}
+			term = f.branches[constr.tag]
+			env = f.env
+			computing = true
+		}
+	}
+}
+
+// applyBuiltin adds an argument to a builtin, and calls the builtin once all arguments are present
+func (m *machine) applyBuiltin(fun builtinValue, arg value) (value, error) {
+	if fun.forces < fun.fun.Forces() {
+		return nil, fmt.Errorf(
+			"%w: builtin %s applied before being forced",
+			ErrEvaluationFailure,
+			fun.fun,
+		)
+	}
+	if len(fun.args) >= fun.fun.Arity() {
+		return nil, fmt.Errorf(
+			"%w: builtin %s applied to too many arguments",
+			ErrEvaluationFailure,
+			fun.fun,
+		)
+	}
+	args := make([]value, len(fun.args), len(fun.args)+1)
+	copy(args, fun.args)
+	args = append(args, arg)
+	if len(args) < fun.fun.Arity() {
+		return builtinValue{fun: fun.fun, forces: fun.forces, args: args}, nil
+	}
+	if err := m.spend(m.costModel.builtinCost(fun.fun, builtinArgSizes(fun.fun, args))); err != nil {
+		return nil, err
+	}
+	ret, err := m.callBuiltin(fun.fun, args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrEvaluationFailure, fun.fun, err)
+	}
+	return ret, nil
+}
+
+// dischargeValue converts a machine value back into a term
+func dischargeValue(v value) Term {
+	switch val := v.(type) {
+	case constantValue:
+		return Const{Value: val.constant}
+	case delayValue:
+		return Delay{Body: dischargeTerm(val.body, val.env, 0)}
+	case lambdaValue:
+		return Lambda{Body: dischargeTerm(val.body, val.env, 1)}
+	case builtinValue:
+		var ret Term = Builtin{Func: val.fun}
+		for i := 0; i < val.forces; i++ {
+			ret = Force{Term: ret}
+		}
+		for _, arg := range val.args {
+			ret = Apply{Function: ret, Argument: dischargeValue(arg)}
+		}
+		return ret
+	case constrValue:
+		fields := make([]Term, len(val.fields))
+		for idx, field := range val.fields {
+			fields[idx] = dischargeValue(field)
+		}
+		return Constr{Tag: val.tag, Fields: fields}
+	default:
+		return ErrorTerm{}
+	}
+}
+
+// dischargeTerm substitutes the values from the environment for the variables in a term which are
+// not bound within the term. The depth is the number of lambdas enclosing the term
+func dischargeTerm(term Term, env *environment, depth uint64) Term {
+	switch t := term.(type) {
+	case Var:
+		if t.Index <= depth {
+			return t
+		}
+		if v, ok := env.lookup(t.Index - depth); ok {
+			return dischargeValue(v)
+		}
+		return t
+	case Delay:
+		return Delay{Body: dischargeTerm(t.Body, env, depth)}
+	case Lambda:
+		return Lambda{Body: dischargeTerm(t.Body, env, depth+1)}
+	case Apply:
+		return Apply{
+			Function: dischargeTerm(t.Function, env, depth),
+			Argument: dischargeTerm(t.Argument, env, depth),
+		}
+	case Force:
+		return Force{Term: dischargeTerm(t.Term, env, depth)}
+	case Constr:
+		fields := make([]Term, len(t.Fields))
+		for idx, field := range t.Fields {
+			fields[idx] = dischargeTerm(field, env, depth)
+		}
+		return Constr{Tag: t.Tag, Fields: fields}
+	case Case:
+		branches := make([]Term, len(t.Branches))
+		for idx, branch := range t.Branches {
+			branches[idx] = dischargeTerm(branch, env, depth)
+		}
+		return Case{Scrutinee: dischargeTerm(t.Scrutinee, env, depth), Branches: branches}
+	default:
+		return term
+	}
+}
@@ -0,0 +1,453 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus_test
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/plutus"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// Number of cost model parameters for each language
+var testCostModelSizes = map[plutus.LanguageVersion]int{
+	plutus.LanguageVersionV1: 166,
+	plutus.LanguageVersionV2: 185,
+	plutus.LanguageVersionV3: 297,
+}
+
+// testCostModel returns a cost model with every parameter set to the same value
+func testCostModel(t *testing.T, language plutus.LanguageVersion, value int64) *plutus.CostModel {
+	values := make([]int64, testCostModelSizes[language])
+	for idx := range values {
+		values[idx] = value
+	}
+	costModel, err := plutus.NewCostModel(language, values)
+	if err != nil {
+		t.Fatalf("unexpected error creating cost model: %s", err)
+	}
+	return costModel
+}
+
+var testBudget = plutus.ExBudget{Memory: 10_000_000, Steps: 10_000_000_000}
+
+// Mainnet PlutusV1 cost model, as set by the protocol parameter update for the Vasil hard fork
+var testMainnetCostModelV1 = []int64{
+	205665, 812, 1, 1, 1000, 571, 0, 1, 1000, 24177, 4, 1, 1000, 32, 117366, 10475, 4, 23000, 100,
+	23000, 100, 23000, 100, 23000, 100, 23000, 100, 23000, 100, 100, 100, 23000, 100, 19537, 32,
+	175354, 32, 46417, 4, 221973, 511, 0, 1, 89141, 32, 497525, 14068, 4, 2, 196500, 453240, 220, 0,
+	1, 1, 1000, 28662, 4, 2, 245000, 216773, 62, 1, 1060367, 12586, 1, 208512, 421, 1, 187000, 1000,
+	52998, 1, 80436, 32, 43249, 32, 1000, 32, 80556, 1, 57667, 4, 1000, 10, 197145, 156, 1, 197145,
+	156, 1, 204924, 473, 1, 208896, 511, 1, 52467, 32, 64832, 32, 65493, 32, 22558, 32, 16563, 32,
+	76511, 32, 196500, 453240, 220, 0, 1, 1, 69522, 11687, 0, 1, 60091, 32, 196500, 453240, 220, 0, 1,
+	1, 196500, 453240, 220, 0, 1, 1, 806990, 30482, 4, 1927926, 82523, 4, 265318, 0, 4, 0, 85931, 32,
+	205665, 812, 1, 1, 41182, 32, 212342, 32, 31220, 32, 32696, 32, 43357, 32, 32247, 32, 38314, 32,
+	9462713, 1021, 10,
+}
+
+// Mainnet PlutusV2 cost model, as set by the protocol parameter update for the Vasil hard fork
+var testMainnetCostModelV2 = []int64{
+	205665, 812, 1, 1, 1000, 571, 0, 1, 1000, 24177, 4, 1, 1000, 32, 117366, 10475, 4, 23000, 100,
+	23000, 100, 23000, 100, 23000, 100, 23000, 100, 23000, 100, 100, 100, 23000, 100, 19537, 32,
+	175354, 32, 46417, 4, 221973, 511, 0, 1, 89141, 32, 497525, 14068, 4, 2, 196500, 453240, 220, 0,
+	1, 1, 1000, 28662, 4, 2, 245000, 216773, 62, 1, 1060367, 12586, 1, 208512, 421, 1, 187000, 1000,
+	52998, 1, 80436, 32, 43249, 32, 1000, 32, 80556, 1, 57667, 4, 1000, 10, 197145, 156, 1, 197145,
+	156, 1, 204924, 473, 1, 208896, 511, 1, 52467, 32, 64832, 32, 65493, 32, 22558, 32, 16563, 32,
+	76511, 32, 196500, 453240, 220, 0, 1, 1, 69522, 11687, 0, 1, 60091, 32, 196500, 453240, 220, 0, 1,
+	1, 196500, 453240, 220, 0, 1, 1, 1159724, 392670, 0, 2, 806990, 30482, 4, 1927926, 82523, 4,
+	265318, 0, 4, 0, 85931, 32, 205665, 812, 1, 1, 41182, 32, 212342, 32, 31220, 32, 32696, 32, 43357,
+	32, 32247, 32, 38314, 32, 20000000000, 20000000000, 9462713, 1021, 10, 20000000000, 0,
+	20000000000,
+}
+
+// builtinCall returns a term which applies a builtin to the arguments, forcing it as needed
+func builtinCall(f plutus.BuiltinFunc, args ...plutus.Constant) plutus.Term {
+	var term plutus.Term = plutus.Builtin{Func: f}
+	for range f.Forces() {
+		term = plutus.Force{Term: term}
+	}
+	for _, arg := range args {
+		term = plutus.Apply{Function: term, Argument: plutus.Const{Value: arg}}
+	}
+	return term
+}
+
+func bigInt(value string) plutus.Integer {
+	ret, _ := new(big.Int).SetString(value, 10)
+	return plutus.Integer{Value: ret}
+}
+
+func bytesConst(hexData string) plutus.ByteString {
+	data, _ := hex.DecodeString(hexData)
+	return plutus.ByteString{Value: data}
+}
+
+func TestCostModelParams(t *testing.T) {
+	for language, size := range testCostModelSizes {
+		if _, err := plutus.NewCostModel(language, make([]int64, size)); err != nil {
+			t.Errorf("unexpected error for %s: %s", language, err)
+		}
+		// A cost model without the machine costs is not usable
+		if _, err := plutus.NewCostModel(language, nil); err == nil {
+			t.Errorf("did not get expected error for empty %s cost model", language)
+		}
+	}
+}
+
+func TestEvaluateBuiltins(t *testing.T) {
+	testDefs := []struct {
+		name     string
+		language plutus.LanguageVersion
+		term     plutus.Term
+		expected string
+	}{
+		{
+			name:     "add",
+			term:     builtinCall(plutus.BuiltinAddInteger, plutus.NewInteger(2), plutus.NewInteger(3)),
+			expected: "(con integer 5)",
+		},
+		{
+			name:     "add big",
+			term:     builtinCall(plutus.BuiltinAddInteger, bigInt("18446744073709551615"), plutus.NewInteger(1)),
+			expected: "(con integer 18446744073709551616)",
+		},
+		{
+			name:     "divide rounds down",
+			term:     builtinCall(plutus.BuiltinDivideInteger, plutus.NewInteger(-7), plutus.NewInteger(2)),
+			expected: "(con integer -4)",
+		},
+		{
+			name:     "mod has sign of divisor",
+			term:     builtinCall(plutus.BuiltinModInteger, plutus.NewInteger(-7), plutus.NewInteger(2)),
+			expected: "(con integer 1)",
+		},
+		{
+			name:     "quotient rounds towards zero",
+			term:     builtinCall(plutus.BuiltinQuotientInteger, plutus.NewInteger(-7), plutus.NewInteger(2)),
+			expected: "(con integer -3)",
+		},
+		{
+			name:     "remainder has sign of dividend",
+			term:     builtinCall(plutus.BuiltinRemainderInteger, plutus.NewInteger(-7), plutus.NewInteger(2)),
+			expected: "(con integer -1)",
+		},
+		{
+			name: "if then else",
+			term: builtinCall(
+				plutus.BuiltinIfThenElse,
+				plutus.Bool{Value: false},
+				plutus.NewInteger(1),
+				plutus.NewInteger(2),
+			),
+			expected: "(con integer 2)",
+		},
+		{
+			name:     "sha2_256",
+			term:     builtinCall(plutus.BuiltinSha2_256, bytesConst("")),
+			expected: "(con bytestring #e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855)",
+		},
+		{
+			name:     "cons bytestring wraps",
+			language: plutus.LanguageVersionV2,
+			term:     builtinCall(plutus.BuiltinConsByteString, plutus.NewInteger(257), bytesConst("ff")),
+			expected: "(con bytestring #01ff)",
+		},
+		{
+			name:     "integer to bytestring",
+			language: plutus.LanguageVersionV3,
+			term: builtinCall(
+				plutus.BuiltinIntegerToByteString,
+				plutus.Bool{Value: true},
+				plutus.NewInteger(0),
+				plutus.NewInteger(258),
+			),
+			expected: "(con bytestring #0102)",
+		},
+		{
+			name:     "read bit",
+			language: plutus.LanguageVersionV3,
+			term:     builtinCall(plutus.BuiltinReadBit, bytesConst("f4"), plutus.NewInteger(2)),
+			expected: "(con bool True)",
+		},
+	}
+	for _, testDef := range testDefs {
+		language := testDef.language
+		if language == 0 {
+			language = plutus.LanguageVersionV1
+		}
+		program := &plutus.Program{Version: plutus.Version100, Term: testDef.term}
+		result, err := plutus.Evaluate(program, testCostModel(t, language, 1), testBudget)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", testDef.name, err)
+			continue
+		}
+		if result.Term.String() != testDef.expected {
+			t.Errorf(
+				"%s: did not get expected result: got %s, wanted %s",
+				testDef.name,
+				result.Term.String(),
+				testDef.expected,
+			)
+		}
+	}
+}
+
+func TestEvaluateFailure(t *testing.T) {
+	testDefs := []struct {
+		name        string
+		language    plutus.LanguageVersion
+		term        plutus.Term
+		budget      plutus.ExBudget
+		expectedErr error
+	}{
+		{
+			name:        "error term",
+			term:        plutus.ErrorTerm{},
+			budget:      testBudget,
+			expectedErr: plutus.ErrEvaluationFailure,
+		},
+		{
+			name:        "divide by zero",
+			term:        builtinCall(plutus.BuiltinDivideInteger, plutus.NewInteger(1), plutus.NewInteger(0)),
+			budget:      testBudget,
+			expectedErr: plutus.ErrEvaluationFailure,
+		},
+		{
+			name:        "builtin not in language",
+			term:        builtinCall(plutus.BuiltinKeccak_256, bytesConst("")),
+			budget:      testBudget,
+			expectedErr: plutus.ErrEvaluationFailure,
+		},
+		{
+			name:        "cons bytestring out of range",
+			language:    plutus.LanguageVersionV3,
+			term:        builtinCall(plutus.BuiltinConsByteString, plutus.NewInteger(256), bytesConst("")),
+			budget:      testBudget,
+			expectedErr: plutus.ErrEvaluationFailure,
+		},
+		{
+			name:        "out of budget",
+			term:        plutus.Const{Value: plutus.NewInteger(1)},
+			budget:      plutus.ExBudget{Memory: 1, Steps: 1},
+			expectedErr: plutus.ErrOutOfBudget,
+		},
+	}
+	for _, testDef := range testDefs {
+		language := testDef.language
+		if language == 0 {
+			language = plutus.LanguageVersionV1
+		}
+		program := &plutus.Program{Version: plutus.Version100, Term: testDef.term}
+		result, err := plutus.Evaluate(program, testCostModel(t, language, 1), testDef.budget)
+		if err == nil {
+			t.Errorf("%s: did not get expected error", testDef.name)
+			continue
+		}
+		if !errors.Is(err, testDef.expectedErr) {
+			t.Errorf("%s: did not get expected error: got %s, wanted %s", testDef.name, err, testDef.expectedErr)
+		}
+		if result == nil {
+			t.Errorf("%s: did not get result on failure", testDef.name)
+		}
+	}
+}
+
+func TestEvaluateCostAndTrace(t *testing.T) {
+	program := &plutus.Program{
+		Version: plutus.Version100,
+		Term: plutus.Apply{
+			Function: plutus.Apply{
+				Function: plutus.Force{Term: plutus.Builtin{Func: plutus.BuiltinTrace}},
+				Argument: plutus.Const{Value: plutus.Text{Value: "hello"}},
+			},
+			Argument: plutus.Const{Value: plutus.Unit{}},
+		},
+	}
+	result, err := plutus.Evaluate(program, testCostModel(t, plutus.LanguageVersionV1, 1), testBudget)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(result.Logs) != 1 || result.Logs[0] != "hello" {
+		t.Errorf("did not get expected logs: %v", result.Logs)
+	}
+	// Startup, 2 applications, 1 force, 1 builtin, 2 constants and the builtin call, which has a
+	// constant cost, with every cost parameter set to 1
+	expectedCost := plutus.ExBudget{Memory: 8, Steps: 8}
+	if result.Cost != expectedCost {
+		t.Errorf("did not get expected cost: got %s, wanted %s", result.Cost, expectedCost)
+	}
+}
+
+func TestEvaluateMainnetCostModels(t *testing.T) {
+	costModelV1, err := plutus.NewCostModel(plutus.LanguageVersionV1, testMainnetCostModelV1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	costModelV2, err := plutus.NewCostModel(plutus.LanguageVersionV2, testMainnetCostModelV2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Every machine step costs 23000 CPU and 100 memory, and startup costs 100 CPU and 100 memory.
+	// The builtin costs are calculated from the named cost model parameters
+	testDefs := []struct {
+		name         string
+		costModel    *plutus.CostModel
+		term         plutus.Term
+		expected     string
+		expectedCost plutus.ExBudget
+	}{
+		{
+			// 5 steps, plus addInteger: 205665 + 812 * max(1, 1) CPU and 1 + 1 * max(1, 1) memory
+			name:         "PlutusV1 addInteger",
+			costModel:    costModelV1,
+			term:         builtinCall(plutus.BuiltinAddInteger, plutus.NewInteger(1), plutus.NewInteger(2)),
+			expected:     "(con integer 3)",
+			expectedCost: plutus.ExBudget{Memory: 602, Steps: 321577},
+		},
+		{
+			// 3 steps, plus sha2_256 of 4 words: 806990 + 30482 * 4 CPU and 4 memory
+			name:         "PlutusV2 sha2_256",
+			costModel:    costModelV2,
+			term:         builtinCall(plutus.BuiltinSha2_256, plutus.ByteString{Value: make([]byte, 32)}),
+			expected:     "(con bytestring #66687aadf862bd776c8fc18b8e9f8e20089714856ee233b3902a591d0d5f2925)",
+			expectedCost: plutus.ExBudget{Memory: 404, Steps: 998018},
+		},
+		{
+			// 8 steps, plus equalsInteger: 208512 + 421 * min(1, 1) CPU and 1 memory
+			name:      "PlutusV2 lambda",
+			costModel: costModelV2,
+			term: plutus.Apply{
+				Function: plutus.Lambda{
+					Body: plutus.Apply{
+						Function: plutus.Apply{
+							Function: plutus.Builtin{Func: plutus.BuiltinEqualsInteger},
+							Argument: plutus.Var{Index: 1},
+						},
+						Argument: plutus.Var{Index: 1},
+					},
+				},
+				Argument: plutus.Const{Value: plutus.NewInteger(5)},
+			},
+			expected:     "(con bool True)",
+			expectedCost: plutus.ExBudget{Memory: 901, Steps: 393033},
+		},
+	}
+	for _, testDef := range testDefs {
+		program := &plutus.Program{Version: plutus.Version100, Term: testDef.term}
+		result, err := plutus.Evaluate(program, testDef.costModel, testBudget)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", testDef.name, err)
+			continue
+		}
+		if got := result.Term.String(); got != testDef.expected {
+			t.Errorf("%s: did not get expected result: got %s, wanted %s", testDef.name, got, testDef.expected)
+		}
+		if result.Cost != testDef.expectedCost {
+			t.Errorf("%s: did not get expected cost: got %s, wanted %s", testDef.name, result.Cost, testDef.expectedCost)
+		}
+	}
+}
+
+func TestEvaluateSignatures(t *testing.T) {
+	message := []byte("test message")
+	// Ed25519
+	edPub, edPriv, _ := ed25519.GenerateKey(nil)
+	edSig := ed25519.Sign(edPriv, message)
+	// ECDSA over secp256k1 with a 32-byte message hash
+	ecPriv, _ := secp256k1.GeneratePrivateKey()
+	msgHash := sha256.Sum256(message)
+	// The compact signature has a leading recovery byte before the R and S values
+	ecSigBytes := ecdsa.SignCompact(ecPriv, msgHash[:], true)[1:]
+	// BIP-340 test vector 0
+	schnorrPub := bytesConst("f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9")
+	schnorrMsg := bytesConst("0000000000000000000000000000000000000000000000000000000000000000")
+	schnorrSig := bytesConst(
+		"e907831f80848d1069a5371b402410364bdf1c5f8307b0084c55f1ce2dca8215" +
+			"25f66a4a85ea8b71e482a74f382d2ce5ebeee8fdb2172f477df4900d310536c0",
+	)
+	badSchnorrSig := bytesConst(
+		"e907831f80848d1069a5371b402410364bdf1c5f8307b0084c55f1ce2dca8215" +
+			"25f66a4a85ea8b71e482a74f382d2ce5ebeee8fdb2172f477df4900d310536c1",
+	)
+	testDefs := []struct {
+		name     string
+		term     plutus.Term
+		expected bool
+	}{
+		{
+			name: "ed25519 valid",
+			term: builtinCall(
+				plutus.BuiltinVerifyEd25519Signature,
+				plutus.ByteString{Value: edPub},
+				plutus.ByteString{Value: message},
+				plutus.ByteString{Value: edSig},
+			),
+			expected: true,
+		},
+		{
+			name: "ed25519 wrong message",
+			term: builtinCall(
+				plutus.BuiltinVerifyEd25519Signature,
+				plutus.ByteString{Value: edPub},
+				plutus.ByteString{Value: []byte("other message")},
+				plutus.ByteString{Value: edSig},
+			),
+			expected: false,
+		},
+		{
+			name: "ecdsa valid",
+			term: builtinCall(
+				plutus.BuiltinVerifyEcdsaSecp256k1Signature,
+				plutus.ByteString{Value: ecPriv.PubKey().SerializeCompressed()},
+				plutus.ByteString{Value: msgHash[:]},
+				plutus.ByteString{Value: ecSigBytes},
+			),
+			expected: true,
+		},
+		{
+			name:     "schnorr valid",
+			term:     builtinCall(plutus.BuiltinVerifySchnorrSecp256k1Signature, schnorrPub, schnorrMsg, schnorrSig),
+			expected: true,
+		},
+		{
+			name:     "schnorr invalid",
+			term:     builtinCall(plutus.BuiltinVerifySchnorrSecp256k1Signature, schnorrPub, schnorrMsg, badSchnorrSig),
+			expected: false,
+		},
+	}
+	for _, testDef := range testDefs {
+		program := &plutus.Program{Version: plutus.Version100, Term: testDef.term}
+		result, err := plutus.Evaluate(
+			program,
+			testCostModel(t, plutus.LanguageVersionV2, 1),
+			testBudget,
+		)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", testDef.name, err)
+			continue
+		}
+		expected := plutus.Const{Value: plutus.Bool{Value: testDef.expected}}.String()
+		if result.Term.String() != expected {
+			t.Errorf("%s: did not get expected result: got %s, wanted %s", testDef.name, result.Term, expected)
+		}
+	}
+}
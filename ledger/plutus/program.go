@@ -0,0 +1,233 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plutus implements decoding and evaluation of Untyped Plutus Core (UPLC) scripts, along with
+// the construction of the script context used when validating transactions
+package plutus
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+// Version is the Plutus Core language version of a program
+type Version struct {
+	Major uint64
+	Minor uint64
+	Patch uint64
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+var (
+	// Version100 is the only Plutus Core version allowed for PlutusV1 and PlutusV2 scripts
+	Version100 = Version{1, 0, 0}
+	// Version110 adds support for sums-of-products (constr and case), and is allowed for PlutusV3 scripts
+	Version110 = Version{1, 1, 0}
+)
+
+// Program is a UPLC program, which is a term with a language version
+type Program struct {
+	Version Version
+	Term    Term
+}
+
+// NewProgramFromCbor decodes a program from its on-chain representation, which is a CBOR
+// bytestring containing the flat-encoded program
+func NewProgramFromCbor(data []byte) (*Program, error) {
+	var flatData []byte
+	if _, err := cbor.Decode(data, &flatData); err != nil {
+		return nil, fmt.Errorf("decode script CBOR: %w", err)
+	}
+	return NewProgramFromFlat(flatData)
+}
+
+func (p *Program) String() string {
+	return fmt.Sprintf("(program %s %s)", p.Version, p.Term)
+}
+
+// Term is a UPLC term. Variables use 1-based de Bruijn indices
+type Term interface {
+	isTerm()
+	String() string
+}
+
+// Var is a variable reference, as a de Bruijn index
+type Var struct {
+	Index uint64
+}
+
+// Delay suspends evaluation of a term until it is forced
+type Delay struct {
+	Body Term
+}
+
+// Lambda is a function with a single unnamed parameter
+type Lambda struct {
+	Body Term
+}
+
+// Apply applies a function to an argument
+type Apply struct {
+	Function Term
+	Argument Term
+}
+
+// Const is a constant value
+type Const struct {
+	Value Constant
+}
+
+// Force forces evaluation of a delayed term or instantiates a polymorphic builtin
+type Force struct {
+	Term Term
+}
+
+// ErrorTerm aborts evaluation
+type ErrorTerm struct{}
+
+// Builtin is a builtin function
+type Builtin struct {
+	Func BuiltinFunc
+}
+
+// Constr is a constructor application, available from Plutus Core 1.1.0
+type Constr struct {
+	Tag    uint64
+	Fields []Term
+}
+
+// Case selects a branch based on the tag of a constructor, available from Plutus Core 1.1.0
+type Case struct {
+	Scrutinee Term
+	Branches  []Term
+}
+
+func (Var) isTerm()       {}
+func (Delay) isTerm()     {}
+func (Lambda) isTerm()    {}
+func (Apply) isTerm()     {}
+func (Const) isTerm()     {}
+func (Force) isTerm()     {}
+func (ErrorTerm) isTerm() {}
+func (Builtin) isTerm()   {}
+func (Constr) isTerm()    {}
+func (Case) isTerm()      {}
+
+func (t Var) String() string {
+	return fmt.Sprintf("i%d", t.Index)
+}
+
+func (t Delay) String() string {
+	return fmt.Sprintf("(delay %s)", t.Body)
+}
+
+func (t Lambda) String() string {
+	return fmt.Sprintf("(lam %s)", t.Body)
+}
+
+func (t Apply) String() string {
+	return fmt.Sprintf("[%s %s]", t.Function, t.Argument)
+}
+
+func (t Const) String() string {
+	return fmt.Sprintf("(con %s %s)", t.Value.Type(), t.Value)
+}
+
+func (t Force) String() string {
+	return fmt.Sprintf("(force %s)", t.Term)
+}
+
+func (ErrorTerm) String() string {
+	return "(error)"
+}
+
+func (t Builtin) String() string {
+	return fmt.Sprintf("(builtin %s)", t.Func)
+}
+
+func (t Constr) String() string {
+	return fmt.Sprintf("(constr %d%s)", t.Tag, termListString(t.Fields))
+}
+
+func (t Case) String() string {
+	return fmt.Sprintf("(case %s%s)", t.Scrutinee, termListString(t.Branches))
+}
+
+func termListString(terms []Term) string {
+	var sb strings.Builder
+	for _, term := range terms {
+		sb.WriteString(" ")
+		sb.WriteString(term.String())
+	}
+	return sb.String()
+}
+
+// checkScopes ensures that all variables in a term refer to an enclosing lambda, since evaluation
+// of an open term is not allowed
+func checkScopes(term Term, depth uint64) error {
+	switch t := term.(type) {
+	case Var:
+		if t.Index == 0 || t.Index > depth {
+			return fmt.Errorf("variable index out of scope: %d", t.Index)
+		}
+	case Delay:
+		return checkScopes(t.Body, depth)
+	case Lambda:
+		return checkScopes(t.Body, depth+1)
+	case Apply:
+		if err := checkScopes(t.Function, depth); err != nil {
+			return err
+		}
+		return checkScopes(t.Argument, depth)
+	case Force:
+		return checkScopes(t.Term, depth)
+	case Constr:
+		for _, field := range t.Fields {
+			if err := checkScopes(field, depth); err != nil {
+				return err
+			}
+		}
+	case Case:
+		if err := checkScopes(t.Scrutinee, depth); err != nil {
+			return err
+		}
+		for _, branch := range t.Branches {
+			if err := checkScopes(branch, depth); err != nil {
+				return err
+			}
+		}
+	case Const, ErrorTerm, Builtin:
+	default:
+		return errors.New("unknown term type")
+	}
+	return nil
+}
+
+// Apply returns a new program which applies the program term to the specified arguments in order
+func (p *Program) Apply(args ...Term) *Program {
+	term := p.Term
+	for _, arg := range args {
+		term = Apply{Function: term, Argument: arg}
+	}
+	return &Program{
+		Version: p.Version,
+		Term:    term,
+	}
+}
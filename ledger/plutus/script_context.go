@@ -0,0 +1,1092 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plutus
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// SlotConverter converts slot numbers to wall clock time, as required for the transaction
+// validity range in the script context. This is implemented by ledger.EraHistory
+type SlotConverter interface {
+	SlotToTime(slot uint64) (time.Time, error)
+}
+
+// withdrawal is a single transaction withdrawal
+type withdrawal struct {
+	address *common.Address
+	amount  uint64
+}
+
+// scriptContextBuilder builds the script context for the scripts in a transaction. The
+// collections which are referenced by redeemer indexes are stored in the ledger order
+type scriptContextBuilder struct {
+	tx            common.Transaction
+	slots         SlotConverter
+	protocolMajor uint
+	utxos         map[string]common.TransactionOutput
+	inputs        []common.TransactionInput
+	refInputs     []common.TransactionInput
+	policies      []common.Blake2b224
+	withdrawals   []withdrawal
+	voters        []*common.Voter
+	datums        map[common.Blake2b256]common.PlutusData
+	redeemers     []redeemerInfo
+	txInfos       map[LanguageVersion]common.PlutusData
+}
+
+// redeemerInfo is a redeemer from the transaction witnesses
+type redeemerInfo struct {
+	tag     common.RedeemerTag
+	index   uint
+	data    common.PlutusData
+	exUnits common.RedeemerExUnits
+}
+
+func newScriptContextBuilder(
+	tx common.Transaction,
+	utxoState common.UtxoState,
+	slots SlotConverter,
+	protocolMajor uint,
+) (*scriptContextBuilder, error) {
+	b := &scriptContextBuilder{
+		tx:            tx,
+		slots:         slots,
+		protocolMajor: protocolMajor,
+		utxos:         make(map[string]common.TransactionOutput),
+		datums:        make(map[common.Blake2b256]common.PlutusData),
+		txInfos:       make(map[LanguageVersion]common.PlutusData),
+	}
+	// Inputs are sorted by transaction ID and index
+	b.inputs = sortedInputs(tx.Inputs())
+	b.refInputs = sortedInputs(tx.ReferenceInputs())
+	for _, input := range slices.Concat(b.inputs, b.refInputs) {
+		utxo, err := utxoState.UtxoById(input)
+		if err != nil {
+			return nil, fmt.Errorf("resolve input %s: %w", input.String(), err)
+		}
+		b.utxos[input.String()] = utxo.Output
+	}
+	if mint := tx.AssetMint(); mint != nil {
+		b.policies = sortedPolicies(mint.Policies())
+	}
+	for addr, amount := range tx.Withdrawals() {
+		if addr.StakeCredential() == nil {
+			return nil, fmt.Errorf("invalid withdrawal address: %s", addr.String())
+		}
+		b.withdrawals = append(b.withdrawals, withdrawal{address: addr, amount: amount})
+	}
+	sort.Slice(b.withdrawals, func(i, j int) bool {
+		return compareRewardAddresses(b.withdrawals[i].address, b.withdrawals[j].address) < 0
+	})
+	for voter := range tx.VotingProcedures() {
+		b.voters = append(b.voters, voter)
+	}
+	sort.Slice(b.voters, func(i, j int) bool {
+		return compareVoters(b.voters[i], b.voters[j]) < 0
+	})
+	witnesses := tx.Witnesses()
+	if witnesses == nil {
+		return b, nil
+	}
+	for _, datum := range witnesses.PlutusData() {
+		tmpDatum, err := common.NewPlutusDataFromCbor(datum.Cbor())
+		if err != nil {
+			return nil, fmt.Errorf("decode witness datum: %w", err)
+		}
+		b.datums[tmpDatum.Hash()] = tmpDatum
+	}
+	if redeemers := witnesses.Redeemers(); redeemers != nil {
+		for _, tag := range []common.RedeemerTag{
+			common.RedeemerTagSpend,
+			common.RedeemerTagMint,
+			common.RedeemerTagCert,
+			common.RedeemerTagReward,
+			common.RedeemerTagVoting,
+			common.RedeemerTagProposing,
+		} {
+			indexes := redeemers.Indexes(tag)
+			slices.Sort(indexes)
+			for _, idx := range indexes {
+				value, exUnits := redeemers.Value(idx, tag)
+				data, err := common.NewPlutusDataFromCbor(value.Cbor())
+				if err != nil {
+					return nil, fmt.Errorf("decode redeemer: %w", err)
+				}
+				b.redeemers = append(
+					b.redeemers,
+					redeemerInfo{tag: tag, index: idx, data: data, exUnits: exUnits},
+				)
+			}
+		}
+	}
+	return b, nil
+}
+
+func sortedInputs(inputs []common.TransactionInput) []common.TransactionInput {
+	ret := slices.Clone(inputs)
+	sort.Slice(ret, func(i, j int) bool {
+		idI, idJ := ret[i].Id(), ret[j].Id()
+		if cmp := bytes.Compare(idI[:], idJ[:]); cmp != 0 {
+			return cmp < 0
+		}
+		return ret[i].Index() < ret[j].Index()
+	})
+	return ret
+}
+
+func sortedPolicies(policies []common.Blake2b224) []common.Blake2b224 {
+	ret := slices.Clone(policies)
+	sort.Slice(ret, func(i, j int) bool {
+		return bytes.Compare(ret[i][:], ret[j][:]) < 0
+	})
+	return ret
+}
+
+// compareCredentials orders credentials the same way as the ledger, with script hashes before key
+// hashes
+func compareCredentials(a *common.StakeCredential, b *common.StakeCredential) int {
+	if a.CredType != b.CredType {
+		if a.CredType == common.StakeCredentialTypeScriptHash {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(a.Credential, b.Credential)
+}
+
+func compareRewardAddresses(a *common.Address, b *common.Address) int {
+	if a.NetworkId() != b.NetworkId() {
+		if a.NetworkId() < b.NetworkId() {
+			return -1
+		}
+		return 1
+	}
+	return compareCredentials(a.StakeCredential(), b.StakeCredential())
+}
+
+// compareVoters orders voters the same way as the ledger, with committee members before DReps
+// before stake pools
+func compareVoters(a *common.Voter, b *common.Voter) int {
+	rank := func(v *common.Voter) int {
+		switch v.Type {
+		case common.VoterTypeConstitutionalCommitteeHotScriptHash:
+			return 0
+		case common.VoterTypeConstitutionalCommitteeHotKeyHash:
+			return 1
+		case common.VoterTypeDRepScriptHash:
+			return 2
+		case common.VoterTypeDRepKeyHash:
+			return 3
+		default:
+			return 4
+		}
+	}
+	if rankA, rankB := rank(a), rank(b); rankA != rankB {
+		return rankA - rankB
+	}
+	return bytes.Compare(a.Hash[:], b.Hash[:])
+}
+
+func compareGovActionIds(a *common.GovActionId, b *common.GovActionId) int {
+	if cmp := bytes.Compare(a.TransactionId[:], b.TransactionId[:]); cmp != 0 {
+		return cmp
+	}
+	return int(a.GovActionIdx) - int(b.GovActionIdx)
+}
+
+// Data construction helpers
+
+func dConstr(tag uint64, fields ...common.PlutusData) common.PlutusData {
+	return common.NewPlutusConstr(tag, fields...)
+}
+
+func dInt(value int64) common.PlutusData {
+	return common.NewPlutusInt(value)
+}
+
+func dUint(value uint64) common.PlutusData {
+	return common.NewPlutusInteger(new(big.Int).SetUint64(value))
+}
+
+func dBytes(value []byte) common.PlutusData {
+	return common.NewPlutusBytes(value)
+}
+
+func dBool(value bool) common.PlutusData {
+	if value {
+		return dConstr(1)
+	}
+	return dConstr(0)
+}
+
+func dJust(value common.PlutusData) common.PlutusData {
+	return dConstr(0, value)
+}
+
+func dNothing() common.PlutusData {
+	return dConstr(1)
+}
+
+func dPair(first common.PlutusData, second common.PlutusData) common.PlutusData {
+	return dConstr(0, first, second)
+}
+
+func dMapPair(key common.PlutusData, value common.PlutusData) common.PlutusMapPair {
+	return common.PlutusMapPair{Key: key, Value: value}
+}
+
+func (b *scriptContextBuilder) txIdData(language LanguageVersion, id common.Blake2b256) common.PlutusData {
+	if language >= LanguageVersionV3 {
+		return dBytes(id.Bytes())
+	}
+	return dConstr(0, dBytes(id.Bytes()))
+}
+
+func (b *scriptContextBuilder) txOutRefData(
+	language LanguageVersion,
+	input common.TransactionInput,
+) common.PlutusData {
+	return dConstr(0, b.txIdData(language, input.Id()), dUint(uint64(input.Index())))
+}
+
+func credentialData(cred *common.StakeCredential) common.PlutusData {
+	if cred.CredType == common.StakeCredentialTypeScriptHash {
+		return dConstr(1, dBytes(cred.Credential))
+	}
+	return dConstr(0, dBytes(cred.Credential))
+}
+
+// stakingCredentialData returns the StakingHash form of a staking credential
+func stakingCredentialData(cred *common.StakeCredential) common.PlutusData {
+	return dConstr(0, credentialData(cred))
+}
+
+func addressData(addr common.Address) (common.PlutusData, error) {
+	if addr.Type() == common.AddressTypeByron {
+		return common.PlutusData{}, errors.New("byron addresses are not supported in the script context")
+	}
+	paymentCred := addr.PaymentCredential()
+	if paymentCred == nil {
+		return common.PlutusData{}, fmt.Errorf("address has no payment credential: %s", addr.String())
+	}
+	stakingCred := dNothing()
+	if stakeCred := addr.StakeCredential(); stakeCred != nil {
+		stakingCred = dJust(stakingCredentialData(stakeCred))
+	} else if ptr := addr.Pointer(); ptr != nil {
+		stakingCred = dJust(
+			dConstr(
+				1,
+				dUint(ptr.Slot),
+				dUint(ptr.TxIndex),
+				dUint(ptr.CertIndex),
+			),
+		)
+	}
+	return dConstr(0, credentialData(paymentCred), stakingCred), nil
+}
+
+// valueData builds a value from an amount of lovelace and a multi-asset. The lovelace entry is
+// omitted when includeAda is false
+func valueData[T common.MultiAssetTypeOutput | common.MultiAssetTypeMint](
+	lovelace int64,
+	includeAda bool,
+	assets *common.MultiAsset[T],
+) common.PlutusData {
+	var pairs []common.PlutusMapPair
+	if includeAda {
+		pairs = append(
+			pairs,
+			dMapPair(dBytes(nil), common.NewPlutusMap(dMapPair(dBytes(nil), dInt(lovelace)))),
+		)
+	}
+	if assets != nil {
+		for _, policy := range sortedPolicies(assets.Policies()) {
+			names := assets.Assets(policy)
+			sort.Slice(names, func(i, j int) bool {
+				return bytes.Compare(names[i], names[j]) < 0
+			})
+			tokens := make([]common.PlutusMapPair, len(names))
+			for idx, name := range names {
+				amount := new(big.Int)
+				switch v := any(assets.Asset(policy, name)).(type) {
+				case uint64:
+					amount.SetUint64(v)
+				case int64:
+					amount.SetInt64(v)
+				}
+				tokens[idx] = dMapPair(dBytes(name), common.NewPlutusInteger(amount))
+			}
+			pairs = append(pairs, dMapPair(dBytes(policy.Bytes()), common.NewPlutusMap(tokens...)))
+		}
+	}
+	return common.NewPlutusMap(pairs...)
+}
+
+func lovelaceData(language LanguageVersion, amount uint64) common.PlutusData {
+	if language >= LanguageVersionV3 {
+		return dUint(amount)
+	}
+	return common.NewPlutusMap(
+		dMapPair(dBytes(nil), common.NewPlutusMap(dMapPair(dBytes(nil), dUint(amount)))),
+	)
+}
+
+// outputDatum returns the datum hash and inline datum of an output, if any
+func outputDatum(output common.TransactionOutput) (*common.Blake2b256, *common.PlutusData, error) {
+	if datum := output.Datum(); datum != nil {
+		tmpDatum, err := common.NewPlutusDataFromCbor(datum.Cbor())
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode inline datum: %w", err)
+		}
+		return nil, &tmpDatum, nil
+	}
+	// Some eras return an empty hash when the output has no datum
+	if hash := output.DatumHash(); hash != nil && *hash != (common.Blake2b256{}) {
+		return hash, nil, nil
+	}
+	return nil, nil, nil
+}
+
+// outputReferenceScript returns the hash of the reference script on an output, if any
+func outputReferenceScript(output common.TransactionOutput) (*common.Blake2b224, error) {
+	refOutput, ok := output.(interface {
+		ReferenceScript() (uint, []byte, error)
+	})
+	if !ok {
+		return nil, nil
+	}
+	scriptType, script, err := refOutput.ReferenceScript()
+	if err != nil {
+		return nil, err
+	}
+	if script == nil {
+		return nil, nil
+	}
+	hash := common.ScriptHash(uint8(scriptType), script) // #nosec G115
+	return &hash, nil
+}
+
+func (b *scriptContextBuilder) txOutData(
+	language LanguageVersion,
+	output common.TransactionOutput,
+) (common.PlutusData, error) {
+	addr, err := addressData(output.Address())
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	value := valueData(int64(output.Amount()), true, output.Assets()) // #nosec G115
+	datumHash, inlineDatum, err := outputDatum(output)
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	refScript, err := outputReferenceScript(output)
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	if language == LanguageVersionV1 {
+		if inlineDatum != nil {
+			return common.PlutusData{}, errors.New("inline datums are not supported by PlutusV1")
+		}
+		if refScript != nil {
+			return common.PlutusData{}, errors.New("reference scripts are not supported by PlutusV1")
+		}
+		datum := dNothing()
+		if datumHash != nil {
+			datum = dJust(dBytes(datumHash.Bytes()))
+		}
+		return dConstr(0, addr, value, datum), nil
+	}
+	datum := dConstr(0)
+	if datumHash != nil {
+		datum = dConstr(1, dBytes(datumHash.Bytes()))
+	} else if inlineDatum != nil {
+		datum = dConstr(2, *inlineDatum)
+	}
+	scriptHash := dNothing()
+	if refScript != nil {
+		scriptHash = dJust(dBytes(refScript.Bytes()))
+	}
+	return dConstr(0, addr, value, datum, scriptHash), nil
+}
+
+func (b *scriptContextBuilder) txInInfoData(
+	language LanguageVersion,
+	inputs []common.TransactionInput,
+) ([]common.PlutusData, error) {
+	ret := make([]common.PlutusData, 0, len(inputs))
+	for _, input := range inputs {
+		output, err := b.txOutData(language, b.utxos[input.String()])
+		if err != nil {
+			return nil, fmt.Errorf("input %s: %w", input.String(), err)
+		}
+		ret = append(ret, dConstr(0, b.txOutRefData(language, input), output))
+	}
+	return ret, nil
+}
+
+func (b *scriptContextBuilder) validRangeData() (common.PlutusData, error) {
+	posixTime := func(slot uint64) (common.PlutusData, error) {
+		t, err := b.slots.SlotToTime(slot)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		return dInt(t.UnixMilli()), nil
+	}
+	lower := dConstr(0, dConstr(0), dBool(true))
+	if start := b.tx.ValidityIntervalStart(); start > 0 {
+		t, err := posixTime(start)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		lower = dConstr(0, dConstr(1, t), dBool(true))
+	}
+	upper := dConstr(0, dConstr(2), dBool(true))
+	if ttl := b.tx.TTL(); ttl > 0 {
+		t, err := posixTime(ttl)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		// The upper bound is exclusive, but was inclusive before protocol version 9 when there is
+		// no lower bound
+		closed := b.tx.ValidityIntervalStart() == 0 && b.protocolMajor < 9
+		upper = dConstr(0, dConstr(1, t), dBool(closed))
+	}
+	return dConstr(0, lower, upper), nil
+}
+
+func drepData(drep common.Drep) (common.PlutusData, error) {
+	switch drep.Type {
+	case common.DrepTypeAddrKeyHash:
+		return dConstr(0, dConstr(0, dBytes(drep.Credential))), nil
+	case common.DrepTypeScriptHash:
+		return dConstr(0, dConstr(1, dBytes(drep.Credential))), nil
+	case common.DrepTypeAbstain:
+		return dConstr(1), nil
+	case common.DrepTypeNoConfidence:
+		return dConstr(2), nil
+	default:
+		return common.PlutusData{}, fmt.Errorf("unknown DRep type: %d", drep.Type)
+	}
+}
+
+// certData converts a certificate to a DCert for PlutusV1 and PlutusV2, or a TxCert for PlutusV3
+func certData(language LanguageVersion, cert common.Certificate) (common.PlutusData, error) {
+	if language < LanguageVersionV3 {
+		switch c := cert.(type) {
+		case *common.StakeRegistrationCertificate:
+			return dConstr(0, stakingCredentialData(&c.StakeRegistration)), nil
+		case *common.RegistrationCertificate:
+			return dConstr(0, stakingCredentialData(&c.StakeCredential)), nil
+		case *common.StakeDeregistrationCertificate:
+			return dConstr(1, stakingCredentialData(&c.StakeDeregistration)), nil
+		case *common.DeregistrationCertificate:
+			return dConstr(1, stakingCredentialData(&c.StakeCredential)), nil
+		case *common.StakeDelegationCertificate:
+			return dConstr(
+				2,
+				stakingCredentialData(c.StakeCredential),
+				dBytes(c.PoolKeyHash[:]),
+			), nil
+		case *common.PoolRegistrationCertificate:
+			return dConstr(3, dBytes(c.Operator[:]), dBytes(c.VrfKeyHash[:])), nil
+		case *common.PoolRetirementCertificate:
+			return dConstr(4, dBytes(c.PoolKeyHash[:]), dUint(c.Epoch)), nil
+		case *common.GenesisKeyDelegationCertificate:
+			return dConstr(5), nil
+		case *common.MoveInstantaneousRewardsCertificate:
+			return dConstr(6), nil
+		default:
+			return common.PlutusData{}, fmt.Errorf("certificate type %T is not supported by %s", cert, language)
+		}
+	}
+	depositData := func(amount int64) common.PlutusData {
+		return dJust(dInt(amount))
+	}
+	switch c := cert.(type) {
+	case *common.StakeRegistrationCertificate:
+		return dConstr(0, credentialData(&c.StakeRegistration), dNothing()), nil
+	case *common.RegistrationCertificate:
+		return dConstr(0, credentialData(&c.StakeCredential), depositData(c.Amount)), nil
+	case *common.StakeDeregistrationCertificate:
+		return dConstr(1, credentialData(&c.StakeDeregistration), dNothing()), nil
+	case *common.DeregistrationCertificate:
+		return dConstr(1, credentialData(&c.StakeCredential), depositData(c.Amount)), nil
+	case *common.StakeDelegationCertificate:
+		return dConstr(
+			2,
+			credentialData(c.StakeCredential),
+			dConstr(0, dBytes(c.PoolKeyHash[:])),
+		), nil
+	case *common.VoteDelegationCertificate:
+		drep, err := drepData(c.Drep)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		return dConstr(2, credentialData(&c.StakeCredential), dConstr(1, drep)), nil
+	case *common.StakeVoteDelegationCertificate:
+		drep, err := drepData(c.Drep)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		return dConstr(
+			2,
+			credentialData(&c.StakeCredential),
+			dConstr(2, dBytes(c.PoolKeyHash), drep),
+		), nil
+	case *common.StakeRegistrationDelegationCertificate:
+		return dConstr(
+			3,
+			credentialData(&c.StakeCredential),
+			dConstr(0, dBytes(c.PoolKeyHash)),
+			dInt(c.Amount),
+		), nil
+	case *common.VoteRegistrationDelegationCertificate:
+		drep, err := drepData(c.Drep)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		return dConstr(
+			3,
+			credentialData(&c.StakeCredential),
+			dConstr(1, drep),
+			dInt(c.Amount),
+		), nil
+	case *common.StakeVoteRegistrationDelegationCertificate:
+		drep, err := drepData(c.Drep)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		return dConstr(
+			3,
+			credentialData(&c.StakeCredential),
+			dConstr(2, dBytes(c.PoolKeyHash), drep),
+			dInt(c.Amount),
+		), nil
+	case *common.RegistrationDrepCertificate:
+		return dConstr(4, credentialData(&c.DrepCredential), dInt(c.Amount)), nil
+	case *common.UpdateDrepCertificate:
+		return dConstr(5, credentialData(&c.DrepCredential)), nil
+	case *common.DeregistrationDrepCertificate:
+		return dConstr(6, credentialData(&c.DrepCredential), dInt(c.Amount)), nil
+	case *common.PoolRegistrationCertificate:
+		return dConstr(7, dBytes(c.Operator[:]), dBytes(c.VrfKeyHash[:])), nil
+	case *common.PoolRetirementCertificate:
+		return dConstr(8, dBytes(c.PoolKeyHash[:]), dUint(c.Epoch)), nil
+	case *common.AuthCommitteeHotCertificate:
+		return dConstr(
+			9,
+			credentialData(&c.ColdCredential),
+			credentialData(&c.HostCredential),
+		), nil
+	case *common.ResignCommitteeColdCertificate:
+		return dConstr(10, credentialData(&c.ColdCredential)), nil
+	default:
+		return common.PlutusData{}, fmt.Errorf("certificate type %T is not supported by %s", cert, language)
+	}
+}
+
+func voterData(voter *common.Voter) common.PlutusData {
+	switch voter.Type {
+	case common.VoterTypeConstitutionalCommitteeHotKeyHash:
+		return dConstr(0, dConstr(0, dBytes(voter.Hash[:])))
+	case common.VoterTypeConstitutionalCommitteeHotScriptHash:
+		return dConstr(0, dConstr(1, dBytes(voter.Hash[:])))
+	case common.VoterTypeDRepKeyHash:
+		return dConstr(1, dConstr(0, dBytes(voter.Hash[:])))
+	case common.VoterTypeDRepScriptHash:
+		return dConstr(1, dConstr(1, dBytes(voter.Hash[:])))
+	default:
+		return dConstr(2, dBytes(voter.Hash[:]))
+	}
+}
+
+func govActionIdData(id *common.GovActionId) common.PlutusData {
+	return dConstr(0, dBytes(id.TransactionId[:]), dUint(uint64(id.GovActionIdx)))
+}
+
+func maybeGovActionIdData(id *common.GovActionId) common.PlutusData {
+	if id == nil {
+		return dNothing()
+	}
+	return dJust(govActionIdData(id))
+}
+
+func maybeScriptHashData(hash []byte) common.PlutusData {
+	if len(hash) == 0 {
+		return dNothing()
+	}
+	return dJust(dBytes(hash))
+}
+
+func rationalData(r *big.Rat) common.PlutusData {
+	return dConstr(0, common.NewPlutusInteger(r.Num()), common.NewPlutusInteger(r.Denom()))
+}
+
+func (b *scriptContextBuilder) govActionData(action common.GovActionWrapper) (common.PlutusData, error) {
+	switch a := action.Action.(type) {
+	case *common.ParameterChangeGovAction:
+		params, err := cborToData(a.ParamUpdate)
+		if err != nil {
+			return common.PlutusData{}, fmt.Errorf("convert parameter update: %w", err)
+		}
+		return dConstr(
+			0,
+			maybeGovActionIdData(a.ActionId),
+			params,
+			maybeScriptHashData(a.PolicyHash),
+		), nil
+	case *common.HardForkInitiationGovAction:
+		return dConstr(
+			1,
+			maybeGovActionIdData(a.ActionId),
+			dConstr(0, dUint(uint64(a.ProtocolVersion.Major)), dUint(uint64(a.ProtocolVersion.Minor))),
+		), nil
+	case *common.TreasuryWithdrawalGovAction:
+		var pairs []withdrawal
+		for addr, amount := range a.Withdrawals {
+			if addr.StakeCredential() == nil {
+				return common.PlutusData{}, fmt.Errorf("invalid withdrawal address: %s", addr.String())
+			}
+			pairs = append(pairs, withdrawal{address: addr, amount: amount})
+		}
+		sort.Slice(pairs, func(i, j int) bool {
+			return compareRewardAddresses(pairs[i].address, pairs[j].address) < 0
+		})
+		items := make([]common.PlutusMapPair, len(pairs))
+		for idx, pair := range pairs {
+			items[idx] = dMapPair(credentialData(pair.address.StakeCredential()), dUint(pair.amount))
+		}
+		return dConstr(2, common.NewPlutusMap(items...), maybeScriptHashData(a.PolicyHash)), nil
+	case *common.NoConfidenceGovAction:
+		return dConstr(3, maybeGovActionIdData(a.ActionId)), nil
+	case *common.UpdateCommitteeGovAction:
+		removed := slices.Clone(a.Credentials)
+		sort.Slice(removed, func(i, j int) bool {
+			return compareCredentials(&removed[i], &removed[j]) < 0
+		})
+		removedData := make([]common.PlutusData, len(removed))
+		for idx := range removed {
+			removedData[idx] = credentialData(&removed[idx])
+		}
+		var added []*common.StakeCredential
+		for cred := range a.CredEpochs {
+			added = append(added, cred)
+		}
+		sort.Slice(added, func(i, j int) bool {
+			return compareCredentials(added[i], added[j]) < 0
+		})
+		addedData := make([]common.PlutusMapPair, len(added))
+		for idx, cred := range added {
+			addedData[idx] = dMapPair(credentialData(cred), dUint(uint64(a.CredEpochs[cred])))
+		}
+		quorum := new(big.Rat)
+		if a.Unknown.Rat != nil {
+			quorum = a.Unknown.Rat
+		}
+		return dConstr(
+			4,
+			maybeGovActionIdData(a.ActionId),
+			common.NewPlutusList(removedData...),
+			common.NewPlutusMap(addedData...),
+			rationalData(quorum),
+		), nil
+	case *common.NewConstitutionGovAction:
+		return dConstr(
+			5,
+			maybeGovActionIdData(a.ActionId),
+			dConstr(0, maybeScriptHashData(a.Constitution.ScriptHash)),
+		), nil
+	case *common.InfoGovAction:
+		return dConstr(6), nil
+	default:
+		return common.PlutusData{}, fmt.Errorf("unsupported governance action type: %T", action.Action)
+	}
+}
+
+func (b *scriptContextBuilder) proposalData(proposal common.ProposalProcedure) (common.PlutusData, error) {
+	stakeCred := proposal.RewardAccount.StakeCredential()
+	if stakeCred == nil {
+		return common.PlutusData{}, errors.New("invalid proposal reward account")
+	}
+	action, err := b.govActionData(proposal.GovAction)
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	return dConstr(0, dUint(proposal.Deposit), credentialData(stakeCred), action), nil
+}
+
+// cborToData converts arbitrary CBOR to Plutus data, as used for the changed parameters of a
+// parameter change governance action. Rationals are converted to a list of the numerator and
+// denominator
+func cborToData(cborData []byte) (common.PlutusData, error) {
+	var tmp any
+	if _, err := cbor.Decode(cborData, &tmp); err != nil {
+		return common.PlutusData{}, err
+	}
+	return anyToData(tmp)
+}
+
+func anyToData(value any) (common.PlutusData, error) {
+	switch v := value.(type) {
+	case uint64:
+		return dUint(v), nil
+	case int64:
+		return dInt(v), nil
+	case big.Int:
+		return common.NewPlutusInteger(&v), nil
+	case []byte:
+		return dBytes(v), nil
+	case cbor.Rat:
+		return common.NewPlutusList(
+			common.NewPlutusInteger(v.Num()),
+			common.NewPlutusInteger(v.Denom()),
+		), nil
+	case cbor.Set:
+		return anyToData([]any(v))
+	case cbor.Map:
+		return anyToData(map[any]any(v))
+	case []any:
+		items := make([]common.PlutusData, len(v))
+		for idx, item := range v {
+			tmpItem, err := anyToData(item)
+			if err != nil {
+				return common.PlutusData{}, err
+			}
+			items[idx] = tmpItem
+		}
+		return common.NewPlutusList(items...), nil
+	case map[any]any:
+		pairs := make([]common.PlutusMapPair, 0, len(v))
+		for key, val := range v {
+			tmpKey, err := anyToData(key)
+			if err != nil {
+				return common.PlutusData{}, err
+			}
+			tmpVal, err := anyToData(val)
+			if err != nil {
+				return common.PlutusData{}, err
+			}
+			pairs = append(pairs, dMapPair(tmpKey, tmpVal))
+		}
+		// Keys are parameter numbers, which are sorted numerically
+		sort.Slice(pairs, func(i, j int) bool {
+			keyI, keyJ := pairs[i].Key.Integer(), pairs[j].Key.Integer()
+			if keyI == nil || keyJ == nil {
+				return keyI != nil
+			}
+			return keyI.Cmp(keyJ) < 0
+		})
+		return common.NewPlutusMap(pairs...), nil
+	default:
+		return common.PlutusData{}, fmt.Errorf("unsupported CBOR type: %T", value)
+	}
+}
+
+// purposeData returns the script purpose for a redeemer. This is the ScriptPurpose type for all
+// languages when spendingDatum is nil, and the ScriptInfo type for PlutusV3 otherwise
+func (b *scriptContextBuilder) purposeData(
+	language LanguageVersion,
+	tag common.RedeemerTag,
+	index uint,
+	scriptInfo bool,
+	spendingDatum *common.PlutusData,
+) (common.PlutusData, error) {
+	switch tag {
+	case common.RedeemerTagMint:
+		if index >= uint(len(b.policies)) {
+			return common.PlutusData{}, fmt.Errorf("mint redeemer index out of range: %d", index)
+		}
+		return dConstr(0, dBytes(b.policies[index].Bytes())), nil
+	case common.RedeemerTagSpend:
+		if index >= uint(len(b.inputs)) {
+			return common.PlutusData{}, fmt.Errorf("spend redeemer index out of range: %d", index)
+		}
+		outRef := b.txOutRefData(language, b.inputs[index])
+		if scriptInfo {
+			datum := dNothing()
+			if spendingDatum != nil {
+				datum = dJust(*spendingDatum)
+			}
+			return dConstr(1, outRef, datum), nil
+		}
+		return dConstr(1, outRef), nil
+	case common.RedeemerTagReward:
+		if index >= uint(len(b.withdrawals)) {
+			return common.PlutusData{}, fmt.Errorf("reward redeemer index out of range: %d", index)
+		}
+		cred := b.withdrawals[index].address.StakeCredential()
+		if language >= LanguageVersionV3 {
+			return dConstr(2, credentialData(cred)), nil
+		}
+		return dConstr(2, stakingCredentialData(cred)), nil
+	case common.RedeemerTagCert:
+		certs := b.tx.Certificates()
+		if index >= uint(len(certs)) {
+			return common.PlutusData{}, fmt.Errorf("cert redeemer index out of range: %d", index)
+		}
+		cert, err := certData(language, certs[index])
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		if language >= LanguageVersionV3 {
+			return dConstr(3, dUint(uint64(index)), cert), nil
+		}
+		return dConstr(3, cert), nil
+	case common.RedeemerTagVoting:
+		if language < LanguageVersionV3 {
+			return common.PlutusData{}, fmt.Errorf("voting scripts are not supported by %s", language)
+		}
+		if index >= uint(len(b.voters)) {
+			return common.PlutusData{}, fmt.Errorf("voting redeemer index out of range: %d", index)
+		}
+		return dConstr(4, voterData(b.voters[index])), nil
+	case common.RedeemerTagProposing:
+		if language < LanguageVersionV3 {
+			return common.PlutusData{}, fmt.Errorf("proposing scripts are not supported by %s", language)
+		}
+		proposals := b.tx.ProposalProcedures()
+		if index >= uint(len(proposals)) {
+			return common.PlutusData{}, fmt.Errorf("proposing redeemer index out of range: %d", index)
+		}
+		proposal, err := b.proposalData(proposals[index])
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		return dConstr(5, dUint(uint64(index)), proposal), nil
+	default:
+		return common.PlutusData{}, fmt.Errorf("unknown redeemer tag: %d", tag)
+	}
+}
+
+// txInfo returns the TxInfo for the language, which is built once and shared by all scripts of the
+// same language
+func (b *scriptContextBuilder) txInfo(language LanguageVersion) (common.PlutusData, error) {
+	if ret, ok := b.txInfos[language]; ok {
+		return ret, nil
+	}
+	ret, err := b.buildTxInfo(language)
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	b.txInfos[language] = ret
+	return ret, nil
+}
+
+func (b *scriptContextBuilder) buildTxInfo(language LanguageVersion) (common.PlutusData, error) {
+	tx := b.tx
+	if language == LanguageVersionV1 && len(b.refInputs) > 0 {
+		return common.PlutusData{}, errors.New("reference inputs are not supported by PlutusV1")
+	}
+	inputs, err := b.txInInfoData(language, b.inputs)
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	refInputs, err := b.txInInfoData(language, b.refInputs)
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	var outputs []common.PlutusData
+	for idx, output := range tx.Outputs() {
+		tmpOutput, err := b.txOutData(language, output)
+		if err != nil {
+			return common.PlutusData{}, fmt.Errorf("output %d: %w", idx, err)
+		}
+		outputs = append(outputs, tmpOutput)
+	}
+	fee := lovelaceData(language, tx.Fee())
+	// PlutusV1 and PlutusV2 include a zero lovelace entry in the minted value
+	mint := valueData(0, language < LanguageVersionV3, tx.AssetMint())
+	var certs []common.PlutusData
+	for _, cert := range tx.Certificates() {
+		tmpCert, err := certData(language, cert)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		certs = append(certs, tmpCert)
+	}
+	var withdrawals []common.PlutusMapPair
+	for _, w := range b.withdrawals {
+		cred := w.address.StakeCredential()
+		if language >= LanguageVersionV3 {
+			withdrawals = append(withdrawals, dMapPair(credentialData(cred), dUint(w.amount)))
+		} else {
+			withdrawals = append(withdrawals, dMapPair(stakingCredentialData(cred), dUint(w.amount)))
+		}
+	}
+	validRange, err := b.validRangeData()
+	if err != nil {
+		return common.PlutusData{}, fmt.Errorf("convert validity interval: %w", err)
+	}
+	signers := tx.RequiredSigners()
+	sortedSigners := slices.Clone(signers)
+	sort.Slice(sortedSigners, func(i, j int) bool {
+		return bytes.Compare(sortedSigners[i][:], sortedSigners[j][:]) < 0
+	})
+	signatories := make([]common.PlutusData, len(sortedSigners))
+	for idx, signer := range sortedSigners {
+		signatories[idx] = dBytes(signer.Bytes())
+	}
+	datumHashes := make([]common.Blake2b256, 0, len(b.datums))
+	for hash := range b.datums {
+		datumHashes = append(datumHashes, hash)
+	}
+	sort.Slice(datumHashes, func(i, j int) bool {
+		return bytes.Compare(datumHashes[i][:], datumHashes[j][:]) < 0
+	})
+	datums := make([]common.PlutusMapPair, len(datumHashes))
+	for idx, hash := range datumHashes {
+		datums[idx] = dMapPair(dBytes(hash.Bytes()), b.datums[hash])
+	}
+	txHash, err := hex.DecodeString(tx.Hash())
+	if err != nil {
+		return common.PlutusData{}, fmt.Errorf("decode transaction hash: %w", err)
+	}
+	txId := b.txIdData(language, common.NewBlake2b256(txHash))
+	if language == LanguageVersionV1 {
+		withdrawalList := make([]common.PlutusData, len(withdrawals))
+		for idx, pair := range withdrawals {
+			withdrawalList[idx] = dPair(pair.Key, pair.Value)
+		}
+		datumList := make([]common.PlutusData, len(datums))
+		for idx, pair := range datums {
+			datumList[idx] = dPair(pair.Key, pair.Value)
+		}
+		return dConstr(
+			0,
+			common.NewPlutusList(inputs...),
+			common.NewPlutusList(outputs...),
+			fee,
+			mint,
+			common.NewPlutusList(certs...),
+			common.NewPlutusList(withdrawalList...),
+			validRange,
+			common.NewPlutusList(signatories...),
+			common.NewPlutusList(datumList...),
+			txId,
+		), nil
+	}
+	var redeemers []common.PlutusMapPair
+	for _, redeemer := range b.redeemers {
+		purpose, err := b.purposeData(language, redeemer.tag, redeemer.index, false, nil)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		redeemers = append(redeemers, dMapPair(purpose, redeemer.data))
+	}
+	fields := []common.PlutusData{
+		common.NewPlutusList(inputs...),
+		common.NewPlutusList(refInputs...),
+		common.NewPlutusList(outputs...),
+		fee,
+		mint,
+		common.NewPlutusList(certs...),
+		common.NewPlutusMap(withdrawals...),
+		validRange,
+		common.NewPlutusList(signatories...),
+		common.NewPlutusMap(redeemers...),
+		common.NewPlutusMap(datums...),
+		txId,
+	}
+	if language == LanguageVersionV2 {
+		return dConstr(0, fields...), nil
+	}
+	votes, err := b.votesData()
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	var proposals []common.PlutusData
+	for _, proposal := range tx.ProposalProcedures() {
+		tmpProposal, err := b.proposalData(proposal)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		proposals = append(proposals, tmpProposal)
+	}
+	treasury := dNothing()
+	if value := tx.CurrentTreasuryValue(); value > 0 {
+		treasury = dJust(dInt(value))
+	}
+	donation := dNothing()
+	if value := tx.Donation(); value > 0 {
+		donation = dJust(dUint(value))
+	}
+	fields = append(
+		fields,
+		votes,
+		common.NewPlutusList(proposals...),
+		treasury,
+		donation,
+	)
+	return dConstr(0, fields...), nil
+}
+
+func (b *scriptContextBuilder) votesData() (common.PlutusData, error) {
+	procedures := b.tx.VotingProcedures()
+	votes := make([]common.PlutusMapPair, 0, len(b.voters))
+	for _, voter := range b.voters {
+		var actionIds []*common.GovActionId
+		for actionId := range procedures[voter] {
+			actionIds = append(actionIds, actionId)
+		}
+		sort.Slice(actionIds, func(i, j int) bool {
+			return compareGovActionIds(actionIds[i], actionIds[j]) < 0
+		})
+		voterVotes := make([]common.PlutusMapPair, len(actionIds))
+		for idx, actionId := range actionIds {
+			vote := procedures[voter][actionId].Vote
+			if vote > common.GovVoteAbstain {
+				return common.PlutusData{}, fmt.Errorf("unknown vote: %d", vote)
+			}
+			voterVotes[idx] = dMapPair(govActionIdData(actionId), dConstr(uint64(vote)))
+		}
+		votes = append(votes, dMapPair(voterData(voter), common.NewPlutusMap(voterVotes...)))
+	}
+	return common.NewPlutusMap(votes...), nil
+}
+
+// scriptContext returns the script context for a redeemer
+func (b *scriptContextBuilder) scriptContext(
+	language LanguageVersion,
+	redeemer redeemerInfo,
+	spendingDatum *common.PlutusData,
+) (common.PlutusData, error) {
+	txInfo, err := b.txInfo(language)
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	if language < LanguageVersionV3 {
+		purpose, err := b.purposeData(language, redeemer.tag, redeemer.index, false, nil)
+		if err != nil {
+			return common.PlutusData{}, err
+		}
+		return dConstr(0, txInfo, purpose), nil
+	}
+	scriptInfo, err := b.purposeData(language, redeemer.tag, redeemer.index, true, spendingDatum)
+	if err != nil {
+		return common.PlutusData{}, err
+	}
+	return dConstr(0, txInfo, redeemer.data, scriptInfo), nil
+}
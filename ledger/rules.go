@@ -0,0 +1,204 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/ledger/allegra"
+	"github.com/blinklabs-io/gouroboros/ledger/alonzo"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/conway"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+type UtxoValidationRuleFunc = common.UtxoValidationRuleFunc
+
+// ValidateTxOptionFunc is a type that represents functions that modify the transaction validation config
+type ValidateTxOptionFunc func(*validateTxConfig)
+
+type validateTxConfig struct {
+	skipWitnessRules bool
+	skipRules        []string
+	extraRules       []UtxoValidationRuleFunc
+}
+
+// WithoutWitnessValidation disables the UTXOW (witness) rules. This is useful for validating unsigned transactions
+func WithoutWitnessValidation() ValidateTxOptionFunc {
+	return func(c *validateTxConfig) {
+		c.skipWitnessRules = true
+	}
+}
+
+// WithSkipRules specifies validation rules that should not be run, using the rule names from ValidationFailure,
+// such as "shelley.UtxoValidateFeeTooSmallUtxo". A name without the package prefix matches the rule in any era
+func WithSkipRules(names ...string) ValidateTxOptionFunc {
+	return func(c *validateTxConfig) {
+		c.skipRules = append(c.skipRules, names...)
+	}
+}
+
+// WithExtraRules specifies additional validation rules to run after the era rules
+func WithExtraRules(rules ...UtxoValidationRuleFunc) ValidateTxOptionFunc {
+	return func(c *validateTxConfig) {
+		c.extraRules = append(c.extraRules, rules...)
+	}
+}
+
+// ValidationFailure represents the failure of a single validation rule
+type ValidationFailure struct {
+	// Rule is the name of the failed rule function, such as "shelley.UtxoValidateFeeTooSmallUtxo"
+	Rule string
+	Err  error
+}
+
+func (f ValidationFailure) Error() string {
+	return fmt.Sprintf("%s: %s", f.Rule, f.Err)
+}
+
+func (f ValidationFailure) Unwrap() error {
+	return f.Err
+}
+
+// ValidationError is returned by ValidateTx and contains all rule failures in the order the rules were run
+type ValidationError struct {
+	Failures []ValidationFailure
+}
+
+func (e ValidationError) Error() string {
+	tmpErrs := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		tmpErrs = append(tmpErrs, failure.Err.Error())
+	}
+	return fmt.Sprintf(
+		"transaction validation failed: %s",
+		strings.Join(tmpErrs, "; "),
+	)
+}
+
+// Unwrap returns the individual rule errors, which allows using errors.Is() and errors.As() on the aggregate error
+func (e ValidationError) Unwrap() []error {
+	ret := make([]error, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		ret = append(ret, failure.Err)
+	}
+	return ret
+}
+
+// TxValidationRules returns the certificate, UTXOW and UTXO validation rules for the specified transaction type
+func TxValidationRules(txType uint) (certRules []UtxoValidationRuleFunc, utxowRules []UtxoValidationRuleFunc, utxoRules []UtxoValidationRuleFunc, err error) {
+	switch txType {
+	case TxTypeShelley:
+		return shelley.CertValidationRules, shelley.UtxowValidationRules, shelley.UtxoValidationRules, nil
+	case TxTypeAllegra:
+		return allegra.CertValidationRules, allegra.UtxowValidationRules, allegra.UtxoValidationRules, nil
+	case TxTypeMary:
+		return mary.CertValidationRules, mary.UtxowValidationRules, mary.UtxoValidationRules, nil
+	case TxTypeAlonzo:
+		return alonzo.CertValidationRules, alonzo.UtxowValidationRules, alonzo.UtxoValidationRules, nil
+	case TxTypeBabbage:
+		return babbage.CertValidationRules, babbage.UtxowValidationRules, babbage.UtxoValidationRules, nil
+	case TxTypeConway:
+		return conway.CertValidationRules, conway.UtxowValidationRules, conway.UtxoValidationRules, nil
+	}
+	return nil, nil, nil, fmt.Errorf("transaction validation not supported for transaction type: %d", txType)
+}
+
+// ValidateTx runs all validation rules for the transaction's era against the provided ledger state and protocol
+// parameters. Unlike calling the individual rules, all rules are run and any failures are returned together
+// as a ValidationError
+func ValidateTx(
+	tx Transaction,
+	slot uint64,
+	ls common.LedgerState,
+	pp common.ProtocolParameters,
+	opts ...ValidateTxOptionFunc,
+) error {
+	cfg := validateTxConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if tx.Type() < 0 {
+		return fmt.Errorf("invalid transaction type: %d", tx.Type())
+	}
+	certRules, utxowRules, utxoRules, err := TxValidationRules(uint(tx.Type()))
+	if err != nil {
+		return err
+	}
+	// The node processes certificates before the UTXOW and UTXO rules
+	rules := make([]UtxoValidationRuleFunc, 0, len(certRules)+len(utxowRules)+len(utxoRules)+len(cfg.extraRules))
+	rules = append(rules, certRules...)
+	if !cfg.skipWitnessRules {
+		rules = append(rules, utxowRules...)
+	}
+	rules = append(rules, utxoRules...)
+	rules = append(rules, cfg.extraRules...)
+	skipRules := make(map[string]bool, len(cfg.skipRules))
+	for _, name := range cfg.skipRules {
+		skipRules[name] = true
+	}
+	var validationErr ValidationError
+	for _, rule := range rules {
+		ruleName := ruleFuncName(rule)
+		if skipRules[ruleName] || skipRules[ruleName[strings.Index(ruleName, ".")+1:]] {
+			continue
+		}
+		if err := rule(tx, slot, ls, pp); err != nil {
+			validationErr.Failures = append(
+				validationErr.Failures,
+				ValidationFailure{
+					Rule: ruleName,
+					Err:  err,
+				},
+			)
+		}
+	}
+	if len(validationErr.Failures) > 0 {
+		return validationErr
+	}
+	return nil
+}
+
+// ValidationFailures returns the individual rule failures from an error returned by ValidateTx
+func ValidationFailures(err error) []ValidationFailure {
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Failures
+	}
+	return nil
+}
+
+func ruleFuncPtr(rule UtxoValidationRuleFunc) uintptr {
+	return reflect.ValueOf(rule).Pointer()
+}
+
+func ruleFuncName(rule UtxoValidationRuleFunc) string {
+	fn := runtime.FuncForPC(ruleFuncPtr(rule))
+	if fn == nil {
+		return "unknown"
+	}
+	// Strip the package path prefix
+	name := fn.Name()
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return name
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/byron"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+type testLedgerState struct{}
+
+func (testLedgerState) NetworkId() uint {
+	return 0
+}
+
+func (testLedgerState) UtxoById(id common.TransactionInput) (common.Utxo, error) {
+	return common.Utxo{}, errors.New("not found")
+}
+
+func (testLedgerState) StakeRegistration(cred common.Blake2b224) (*common.StakeRegistration, error) {
+	return nil, nil
+}
+
+func (testLedgerState) PoolRegistration(pool common.PoolKeyHash) (*common.PoolRegistration, error) {
+	return nil, nil
+}
+
+func (testLedgerState) DrepRegistration(cred common.Blake2b224) (*common.DrepRegistration, error) {
+	return nil, nil
+}
+
+func (testLedgerState) CommitteeMember(cred common.Blake2b224) (*common.CommitteeMember, error) {
+	return nil, nil
+}
+
+var errTestExtraRule = errors.New("extra rule failed")
+
+func testExtraRule(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return errTestExtraRule
+}
+
+func TestValidateTx(t *testing.T) {
+	// Transaction with no inputs, no fee, and an expired TTL
+	testTx := &shelley.ShelleyTransaction{
+		Body: shelley.ShelleyTransactionBody{
+			Ttl: 100,
+		},
+	}
+	testProtocolParams := &shelley.ShelleyProtocolParameters{
+		MinFeeB:   155381,
+		MaxTxSize: 16384,
+	}
+	testSlot := uint64(200)
+	// All failures are reported
+	err := ledger.ValidateTx(
+		testTx,
+		testSlot,
+		testLedgerState{},
+		testProtocolParams,
+	)
+	if err == nil {
+		t.Fatalf("ValidateTx should fail")
+	}
+	failures := ledger.ValidationFailures(err)
+	expectedRules := []string{
+		"shelley.UtxoValidateTimeToLive",
+		"shelley.UtxoValidateInputSetEmptyUtxo",
+		"shelley.UtxoValidateFeeTooSmallUtxo",
+	}
+	if len(failures) != len(expectedRules) {
+		t.Fatalf("did not get expected number of failures: got %d, wanted %d\n  got error: %v", len(failures), len(expectedRules), err)
+	}
+	for idx, failure := range failures {
+		if failure.Rule != expectedRules[idx] {
+			t.Errorf("did not get expected rule for failure %d: got %s, wanted %s", idx, failure.Rule, expectedRules[idx])
+		}
+	}
+	var feeErr shelley.FeeTooSmallUtxoError
+	if !errors.As(err, &feeErr) {
+		t.Errorf("aggregate error should contain FeeTooSmallUtxoError\n  got error: %v", err)
+	}
+	// Skipped and extra rules
+	err = ledger.ValidateTx(
+		testTx,
+		testSlot,
+		testLedgerState{},
+		testProtocolParams,
+		ledger.WithoutWitnessValidation(),
+		ledger.WithSkipRules(
+			"shelley.UtxoValidateTimeToLive",
+			// Matches the rule in any era
+			"UtxoValidateFeeTooSmallUtxo",
+			// Rule from another era
+			"allegra.UtxoValidateInputSetEmptyUtxo",
+		),
+		ledger.WithExtraRules(testExtraRule),
+	)
+	failures = ledger.ValidationFailures(err)
+	if len(failures) != 2 {
+		t.Fatalf("did not get expected number of failures: got %d, wanted %d\n  got error: %v", len(failures), 2, err)
+	}
+	if !errors.Is(err, errTestExtraRule) {
+		t.Errorf("aggregate error should contain extra rule error\n  got error: %v", err)
+	}
+	if errors.As(err, &feeErr) {
+		t.Errorf("aggregate error should not contain skipped rule error\n  got error: %v", err)
+	}
+}
+
+func TestValidateTxUnsupportedEra(t *testing.T) {
+	err := ledger.ValidateTx(
+		&byron.ByronTransaction{},
+		0,
+		testLedgerState{},
+		nil,
+	)
+	if err == nil {
+		t.Fatalf("ValidateTx should fail for Byron transactions")
+	}
+	if ledger.ValidationFailures(err) != nil {
+		t.Errorf("unsupported era should not return a ValidationError\n  got error: %v", err)
+	}
+}
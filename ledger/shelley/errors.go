@@ -15,6 +15,7 @@
 package shelley
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -59,8 +60,8 @@ type BadInputsUtxoError struct {
 
 func (e BadInputsUtxoError) Error() string {
 	tmpInputs := make([]string, 0, len(e.Inputs))
-	for idx, tmpInput := range e.Inputs {
-		tmpInputs[idx] = tmpInput.String()
+	for _, tmpInput := range e.Inputs {
+		tmpInputs = append(tmpInputs, tmpInput.String())
 	}
 	return fmt.Sprintf(
 		"bad input(s): %s",
@@ -75,8 +76,8 @@ type WrongNetworkError struct {
 
 func (e WrongNetworkError) Error() string {
 	tmpAddrs := make([]string, 0, len(e.Addrs))
-	for idx, tmpAddr := range e.Addrs {
-		tmpAddrs[idx] = tmpAddr.String()
+	for _, tmpAddr := range e.Addrs {
+		tmpAddrs = append(tmpAddrs, tmpAddr.String())
 	}
 	return fmt.Sprintf(
 		"wrong network: %s",
@@ -91,8 +92,8 @@ type WrongNetworkWithdrawalError struct {
 
 func (e WrongNetworkWithdrawalError) Error() string {
 	tmpAddrs := make([]string, 0, len(e.Addrs))
-	for idx, tmpAddr := range e.Addrs {
-		tmpAddrs[idx] = tmpAddr.String()
+	for _, tmpAddr := range e.Addrs {
+		tmpAddrs = append(tmpAddrs, tmpAddr.String())
 	}
 	return fmt.Sprintf(
 		"wrong network withdrawals: %s",
@@ -101,13 +102,13 @@ func (e WrongNetworkWithdrawalError) Error() string {
 }
 
 type ValueNotConservedUtxoError struct {
-	Consumed uint64
-	Produced uint64
+	Consumed common.Value
+	Produced common.Value
 }
 
 func (e ValueNotConservedUtxoError) Error() string {
 	return fmt.Sprintf(
-		"value not conserved: consumed %d, produced %d",
+		"value not conserved: consumed %s, produced %s",
 		e.Consumed,
 		e.Produced,
 	)
@@ -119,8 +120,8 @@ type OutputTooSmallUtxoError struct {
 
 func (e OutputTooSmallUtxoError) Error() string {
 	tmpOutputs := make([]string, 0, len(e.Outputs))
-	for idx, tmpOutput := range e.Outputs {
-		tmpOutputs[idx] = fmt.Sprintf("%#v", tmpOutput)
+	for _, tmpOutput := range e.Outputs {
+		tmpOutputs = append(tmpOutputs, fmt.Sprintf("%#v", tmpOutput))
 	}
 	return fmt.Sprintf(
 		"output too small: %s",
@@ -134,8 +135,8 @@ type OutputBootAddrAttrsTooBigError struct {
 
 func (e OutputBootAddrAttrsTooBigError) Error() string {
 	tmpOutputs := make([]string, 0, len(e.Outputs))
-	for idx, tmpOutput := range e.Outputs {
-		tmpOutputs[idx] = fmt.Sprintf("%#v", tmpOutput)
+	for _, tmpOutput := range e.Outputs {
+		tmpOutputs = append(tmpOutputs, fmt.Sprintf("%#v", tmpOutput))
 	}
 	return fmt.Sprintf(
 		"output bootstrap address attributes too big: %s",
@@ -155,3 +156,198 @@ func (e MaxTxSizeUtxoError) Error() string {
 		e.MaxTxSize,
 	)
 }
+
+type InvalidWitnessesUtxowError struct {
+	Vkeys [][]byte
+}
+
+func (e InvalidWitnessesUtxowError) Error() string {
+	tmpVkeys := make([]string, 0, len(e.Vkeys))
+	for _, tmpVkey := range e.Vkeys {
+		tmpVkeys = append(tmpVkeys, hex.EncodeToString(tmpVkey))
+	}
+	return fmt.Sprintf(
+		"invalid witness signature(s) for vkey(s): %s",
+		strings.Join(tmpVkeys, ", "),
+	)
+}
+
+type MissingVKeyWitnessesUtxowError struct {
+	Hashes []common.Blake2b224
+}
+
+func (e MissingVKeyWitnessesUtxowError) Error() string {
+	tmpHashes := make([]string, 0, len(e.Hashes))
+	for _, tmpHash := range e.Hashes {
+		tmpHashes = append(tmpHashes, tmpHash.String())
+	}
+	return fmt.Sprintf(
+		"missing vkey witness(es) for key hash(es): %s",
+		strings.Join(tmpHashes, ", "),
+	)
+}
+
+type MissingScriptWitnessesUtxowError struct {
+	Hashes []common.Blake2b224
+}
+
+func (e MissingScriptWitnessesUtxowError) Error() string {
+	tmpHashes := make([]string, 0, len(e.Hashes))
+	for _, tmpHash := range e.Hashes {
+		tmpHashes = append(tmpHashes, tmpHash.String())
+	}
+	return fmt.Sprintf(
+		"missing script witness(es) for script hash(es): %s",
+		strings.Join(tmpHashes, ", "),
+	)
+}
+
+type ScriptWitnessNotValidatingUtxowError struct {
+	Hashes []common.Blake2b224
+}
+
+func (e ScriptWitnessNotValidatingUtxowError) Error() string {
+	tmpHashes := make([]string, 0, len(e.Hashes))
+	for _, tmpHash := range e.Hashes {
+		tmpHashes = append(tmpHashes, tmpHash.String())
+	}
+	return fmt.Sprintf(
+		"script witness(es) not validating: %s",
+		strings.Join(tmpHashes, ", "),
+	)
+}
+
+type MissingTxBodyMetadataHashError struct {
+	Hash common.Blake2b256
+}
+
+func (e MissingTxBodyMetadataHashError) Error() string {
+	return fmt.Sprintf(
+		"missing transaction body metadata hash: expected %s",
+		e.Hash.String(),
+	)
+}
+
+type MissingTxMetadataError struct {
+	Hash common.Blake2b256
+}
+
+func (e MissingTxMetadataError) Error() string {
+	return fmt.Sprintf(
+		"missing transaction metadata for metadata hash %s",
+		e.Hash.String(),
+	)
+}
+
+type ConflictingMetadataHashError struct {
+	Supplied common.Blake2b256
+	Expected common.Blake2b256
+}
+
+func (e ConflictingMetadataHashError) Error() string {
+	return fmt.Sprintf(
+		"conflicting metadata hash: supplied %s, expected %s",
+		e.Supplied.String(),
+		e.Expected.String(),
+	)
+}
+
+type WithdrawalsNotInRewardsError struct {
+	Addrs []common.Address
+}
+
+func (e WithdrawalsNotInRewardsError) Error() string {
+	tmpAddrs := make([]string, 0, len(e.Addrs))
+	for _, tmpAddr := range e.Addrs {
+		tmpAddrs = append(tmpAddrs, tmpAddr.String())
+	}
+	return fmt.Sprintf(
+		"withdrawals not in rewards: %s",
+		strings.Join(tmpAddrs, ", "),
+	)
+}
+
+type StakeKeyAlreadyRegisteredError struct {
+	Credential common.Blake2b224
+}
+
+func (e StakeKeyAlreadyRegisteredError) Error() string {
+	return fmt.Sprintf(
+		"stake key already registered: %s",
+		e.Credential.String(),
+	)
+}
+
+type StakeKeyNotRegisteredError struct {
+	Credential common.Blake2b224
+}
+
+func (e StakeKeyNotRegisteredError) Error() string {
+	return fmt.Sprintf(
+		"stake key not registered: %s",
+		e.Credential.String(),
+	)
+}
+
+type StakeKeyNonZeroAccountBalanceError struct {
+	Credential common.Blake2b224
+	Balance    uint64
+}
+
+func (e StakeKeyNonZeroAccountBalanceError) Error() string {
+	return fmt.Sprintf(
+		"stake key has non-zero account balance: %s, balance %d",
+		e.Credential.String(),
+		e.Balance,
+	)
+}
+
+type DelegateeNotRegisteredError struct {
+	PoolKeyHash common.PoolKeyHash
+}
+
+func (e DelegateeNotRegisteredError) Error() string {
+	return fmt.Sprintf(
+		"delegatee pool not registered: %s",
+		common.Blake2b224(e.PoolKeyHash).String(),
+	)
+}
+
+type StakePoolNotRegisteredOnKeyError struct {
+	PoolKeyHash common.PoolKeyHash
+}
+
+func (e StakePoolNotRegisteredOnKeyError) Error() string {
+	return fmt.Sprintf(
+		"stake pool not registered: %s",
+		common.Blake2b224(e.PoolKeyHash).String(),
+	)
+}
+
+type StakePoolRetirementWrongEpochError struct {
+	CurrentEpoch uint64
+	Epoch        uint64
+	MaxEpoch     uint64
+}
+
+func (e StakePoolRetirementWrongEpochError) Error() string {
+	return fmt.Sprintf(
+		"stake pool retirement wrong epoch: current epoch %d, retirement epoch %d, max epoch %d",
+		e.CurrentEpoch,
+		e.Epoch,
+		e.MaxEpoch,
+	)
+}
+
+type StakePoolCostTooLowError struct {
+	Cost    uint64
+	MinCost uint64
+}
+
+func (e StakePoolCostTooLowError) Error() string {
+	return fmt.Sprintf(
+		"stake pool cost too low: cost %d, minimum %d",
+		e.Cost,
+		e.MinCost,
+	)
+}
@@ -15,10 +15,16 @@
 package shelley
 
 import (
+	"crypto/ed25519"
+	"encoding/hex"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/blinklabs-io/gouroboros/cbor"
 	common "github.com/blinklabs-io/gouroboros/ledger/common"
+
+	"golang.org/x/crypto/sha3"
 )
 
 var UtxoValidationRules = []common.UtxoValidationRuleFunc{
@@ -34,6 +40,24 @@ var UtxoValidationRules = []common.UtxoValidationRuleFunc{
 	UtxoValidateMaxTxSizeUtxo,
 }
 
+var UtxowValidationRules = []common.UtxoValidationRuleFunc{
+	UtxowValidateInvalidWitnesses,
+	UtxowValidateMissingVKeyWitnesses,
+	UtxowValidateMissingScriptWitnesses,
+	UtxowValidateScriptWitnessNotValidating,
+	UtxowValidateMetadataHash,
+}
+
+var CertValidationRules = []common.UtxoValidationRuleFunc{
+	DelegValidateWithdrawalsNotInRewards,
+	DelegValidateStakeKeyAlreadyRegistered,
+	DelegValidateStakeKeyNotRegistered,
+	DelegValidateStakeKeyNonZeroAccountBalance,
+	DelegValidateDelegateeNotRegistered,
+	PoolValidateStakePoolNotRegisteredOnKey,
+	PoolValidateStakePoolRetirementWrongEpoch,
+}
+
 // UtxoValidateTimeToLive ensures that the current tip slot is not after the specified TTL value
 func UtxoValidateTimeToLive(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
 	ttl := tx.TTL()
@@ -127,34 +151,82 @@ func UtxoValidateWrongNetworkWithdrawal(tx common.Transaction, slot uint64, ls c
 
 // UtxoValidateValueNotConservedUtxo ensures that the consumed value equals the produced value
 func UtxoValidateValueNotConservedUtxo(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ShelleyProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	consumedValue, producedValue, err := TxConsumedProducedValue(
+		tx,
+		ls,
+		uint64(tmpPparams.KeyDeposit),
+		uint64(tmpPparams.PoolDeposit),
+	)
+	if err != nil {
+		return err
+	}
+	if consumedValue.Equal(producedValue) {
+		return nil
+	}
+	return ValueNotConservedUtxoError{
+		Consumed: consumedValue,
+		Produced: producedValue,
+	}
+}
+
+// TxConsumedProducedValue calculates the value consumed and the value produced by a transaction, including
+// any minted or burned assets
+//
+//	consumed = value from input(s) + withdrawals + refunds + minted assets
+//	produced = value from output(s) + fee + deposits + burned assets
+func TxConsumedProducedValue(tx common.Transaction, ls common.LedgerState, keyDeposit uint64, poolDeposit uint64) (common.Value, common.Value, error) {
+	deposits, refunds, err := common.TxCertDeposits(
+		tx,
+		ls,
+		keyDeposit,
+		poolDeposit,
+	)
+	if err != nil {
+		return common.Value{}, common.Value{}, err
+	}
+	minted, burned := common.NewValueFromMint(tx.AssetMint())
 	// Calculate consumed value
-	// consumed = value from input(s) + withdrawals + refunds(?)
-	var consumedValue uint64
+	consumedValue, err := minted.Add(common.Value{Coin: refunds})
+	if err != nil {
+		return common.Value{}, common.Value{}, err
+	}
+	for _, tmpWithdrawalAmount := range tx.Withdrawals() {
+		consumedValue, err = consumedValue.Add(common.Value{Coin: tmpWithdrawalAmount})
+		if err != nil {
+			return common.Value{}, common.Value{}, err
+		}
+	}
 	for _, tmpInput := range tx.Inputs() {
 		tmpUtxo, err := ls.UtxoById(tmpInput)
 		// Ignore errors fetching the UTxO and exclude it from calculations
 		if err != nil {
 			continue
 		}
-		consumedValue += tmpUtxo.Output.Amount()
-	}
-	for _, tmpWithdrawalAmount := range tx.Withdrawals() {
-		consumedValue += tmpWithdrawalAmount
+		consumedValue, err = consumedValue.Add(common.NewValueFromOutput(tmpUtxo.Output))
+		if err != nil {
+			return common.Value{}, common.Value{}, err
+		}
 	}
 	// Calculate produced value
-	// produced = value from output(s) + fee + deposits(?)
-	var producedValue uint64
-	for _, tmpOutput := range tx.Outputs() {
-		producedValue += tmpOutput.Amount()
+	producedValue, err := burned.Add(common.Value{Coin: tx.Fee()})
+	if err != nil {
+		return common.Value{}, common.Value{}, err
 	}
-	producedValue += tx.Fee()
-	if consumedValue == producedValue {
-		return nil
+	producedValue, err = producedValue.Add(common.Value{Coin: deposits})
+	if err != nil {
+		return common.Value{}, common.Value{}, err
 	}
-	return ValueNotConservedUtxoError{
-		Consumed: consumedValue,
-		Produced: producedValue,
+	for _, tmpOutput := range tx.Outputs() {
+		producedValue, err = producedValue.Add(common.NewValueFromOutput(tmpOutput))
+		if err != nil {
+			return common.Value{}, common.Value{}, err
+		}
 	}
+	return consumedValue, producedValue, nil
 }
 
 // UtxoValidateOutputTooSmallUtxo ensures that outputs have at least the minimum value
@@ -242,3 +314,597 @@ func MinCoinTxOut(_ common.Transaction, pparams common.ProtocolParameters) (uint
 	minCoinTxOut := uint64(tmpPparams.MinUtxoValue)
 	return minCoinTxOut, nil
 }
+
+// UtxowValidateInvalidWitnesses ensures that all vkey and bootstrap witness signatures are valid for the transaction body
+func UtxowValidateInvalidWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	txBodyHash, err := hex.DecodeString(tx.Hash())
+	if err != nil {
+		return err
+	}
+	var badVkeys [][]byte
+	for _, vkeyWitness := range tx.Witnesses().Vkey() {
+		if verifyWitnessSignature(vkeyWitness.Vkey, txBodyHash, vkeyWitness.Signature) {
+			continue
+		}
+		badVkeys = append(badVkeys, vkeyWitness.Vkey)
+	}
+	for _, bootstrapWitness := range tx.Witnesses().Bootstrap() {
+		if verifyWitnessSignature(bootstrapWitness.PublicKey, txBodyHash, bootstrapWitness.Signature) {
+			continue
+		}
+		badVkeys = append(badVkeys, bootstrapWitness.PublicKey)
+	}
+	if len(badVkeys) == 0 {
+		return nil
+	}
+	return InvalidWitnessesUtxowError{
+		Vkeys: badVkeys,
+	}
+}
+
+// UtxowValidateMissingVKeyWitnesses ensures that there is a vkey or bootstrap witness for every key hash that must sign the transaction.
+// This includes the payment keys of spent inputs and collateral, the credentials of withdrawals and certificates,
+// voters, and the required signers
+func UtxowValidateMissingVKeyWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	witnessKeyHashes := WitnessKeyHashes(tx)
+	var missingKeyHashes []common.Blake2b224
+	for _, keyHash := range RequiredKeyHashes(tx, ls) {
+		if witnessKeyHashes[keyHash] {
+			continue
+		}
+		missingKeyHashes = append(missingKeyHashes, keyHash)
+	}
+	if len(missingKeyHashes) == 0 {
+		return nil
+	}
+	return MissingVKeyWitnessesUtxowError{
+		Hashes: missingKeyHashes,
+	}
+}
+
+// UtxowValidateMissingScriptWitnesses ensures that a script is available for every script hash needed by the transaction.
+// Scripts can be provided in the witness set or as reference scripts on spent or referenced UTxOs
+func UtxowValidateMissingScriptWitnesses(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	availableScripts := availableScriptHashes(tx, ls)
+	var missingScriptHashes []common.Blake2b224
+	for _, scriptHash := range RequiredScriptHashes(tx, ls) {
+		if availableScripts[scriptHash] {
+			continue
+		}
+		missingScriptHashes = append(missingScriptHashes, scriptHash)
+	}
+	if len(missingScriptHashes) == 0 {
+		return nil
+	}
+	return MissingScriptWitnessesUtxowError{
+		Hashes: missingScriptHashes,
+	}
+}
+
+// UtxowValidateScriptWitnessNotValidating ensures that all native scripts in the witness set are satisfied by the
+// transaction witnesses and validity interval
+func UtxowValidateScriptWitnessNotValidating(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	witnessKeyHashes := WitnessKeyHashes(tx)
+	nativeScripts := tx.Witnesses().NativeScripts()
+	var badScriptHashes []common.Blake2b224
+	for idx := range nativeScripts {
+		nativeScript := &nativeScripts[idx]
+		if nativeScript.Evaluate(witnessKeyHashes, tx.ValidityIntervalStart(), tx.TTL()) {
+			continue
+		}
+		badScriptHashes = append(badScriptHashes, nativeScript.Hash())
+	}
+	if len(badScriptHashes) == 0 {
+		return nil
+	}
+	return ScriptWitnessNotValidatingUtxowError{
+		Hashes: badScriptHashes,
+	}
+}
+
+// UtxowValidateMetadataHash ensures that the auxiliary data hash in the transaction body matches the auxiliary data
+func UtxowValidateMetadataHash(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	auxDataHash := tx.AuxDataHash()
+	metadata := tx.Metadata()
+	if metadata == nil {
+		if auxDataHash == nil {
+			return nil
+		}
+		return MissingTxMetadataError{
+			Hash: *auxDataHash,
+		}
+	}
+	expectedHash := common.Blake2b256Hash(metadata.Cbor())
+	if auxDataHash == nil {
+		return MissingTxBodyMetadataHashError{
+			Hash: expectedHash,
+		}
+	}
+	if *auxDataHash == expectedHash {
+		return nil
+	}
+	return ConflictingMetadataHashError{
+		Supplied: *auxDataHash,
+		Expected: expectedHash,
+	}
+}
+
+// DelegValidateWithdrawalsNotInRewards ensures that each withdrawal is from a registered reward account and
+// withdraws the full balance
+func DelegValidateWithdrawalsNotInRewards(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	badAddrs := []common.Address{}
+	for addr, amount := range tx.Withdrawals() {
+		reg, err := ls.StakeRegistration(addr.StakeKeyHash())
+		if err != nil {
+			return err
+		}
+		if reg == nil || reg.Reward != amount {
+			badAddrs = append(badAddrs, *addr)
+		}
+	}
+	if len(badAddrs) == 0 {
+		return nil
+	}
+	slices.SortFunc(
+		badAddrs,
+		func(a, b common.Address) int {
+			return strings.Compare(a.String(), b.String())
+		},
+	)
+	return WithdrawalsNotInRewardsError{
+		Addrs: badAddrs,
+	}
+}
+
+// DelegValidateStakeKeyAlreadyRegistered ensures that certificates don't register an already registered stake credential
+func DelegValidateStakeKeyAlreadyRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return processCertificates(
+		tx,
+		ls,
+		func(cert common.Certificate, certState common.CertState) error {
+			var cred common.StakeCredential
+			switch c := cert.(type) {
+			case *common.StakeRegistrationCertificate:
+				cred = c.StakeRegistration
+			case *common.RegistrationCertificate:
+				cred = c.StakeCredential
+			case *common.StakeRegistrationDelegationCertificate:
+				cred = c.StakeCredential
+			case *common.VoteRegistrationDelegationCertificate:
+				cred = c.StakeCredential
+			case *common.StakeVoteRegistrationDelegationCertificate:
+				cred = c.StakeCredential
+			default:
+				return nil
+			}
+			credHash := common.NewBlake2b224(cred.Credential)
+			reg, err := certState.StakeRegistration(credHash)
+			if err != nil {
+				return err
+			}
+			if reg != nil {
+				return StakeKeyAlreadyRegisteredError{
+					Credential: credHash,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// DelegValidateStakeKeyNotRegistered ensures that certificates only deregister or delegate registered stake credentials
+func DelegValidateStakeKeyNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return processCertificates(
+		tx,
+		ls,
+		func(cert common.Certificate, certState common.CertState) error {
+			var cred common.StakeCredential
+			switch c := cert.(type) {
+			case *common.StakeDeregistrationCertificate:
+				cred = c.StakeDeregistration
+			case *common.DeregistrationCertificate:
+				cred = c.StakeCredential
+			case *common.StakeDelegationCertificate:
+				if c.StakeCredential == nil {
+					return nil
+				}
+				cred = *c.StakeCredential
+			case *common.VoteDelegationCertificate:
+				cred = c.StakeCredential
+			case *common.StakeVoteDelegationCertificate:
+				cred = c.StakeCredential
+			default:
+				return nil
+			}
+			credHash := common.NewBlake2b224(cred.Credential)
+			reg, err := certState.StakeRegistration(credHash)
+			if err != nil {
+				return err
+			}
+			if reg == nil {
+				return StakeKeyNotRegisteredError{
+					Credential: credHash,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// DelegValidateStakeKeyNonZeroAccountBalance ensures that deregistered stake credentials have an empty reward account,
+// after accounting for any withdrawals in the same transaction
+func DelegValidateStakeKeyNonZeroAccountBalance(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return processCertificates(
+		tx,
+		ls,
+		func(cert common.Certificate, certState common.CertState) error {
+			var cred common.StakeCredential
+			switch c := cert.(type) {
+			case *common.StakeDeregistrationCertificate:
+				cred = c.StakeDeregistration
+			case *common.DeregistrationCertificate:
+				cred = c.StakeCredential
+			default:
+				return nil
+			}
+			credHash := common.NewBlake2b224(cred.Credential)
+			reg, err := certState.StakeRegistration(credHash)
+			if err != nil {
+				return err
+			}
+			if reg != nil && reg.Reward > 0 {
+				return StakeKeyNonZeroAccountBalanceError{
+					Credential: credHash,
+					Balance:    reg.Reward,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// DelegValidateDelegateeNotRegistered ensures that stake is only delegated to registered pools
+func DelegValidateDelegateeNotRegistered(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return processCertificates(
+		tx,
+		ls,
+		func(cert common.Certificate, certState common.CertState) error {
+			var pool common.PoolKeyHash
+			switch c := cert.(type) {
+			case *common.StakeDelegationCertificate:
+				pool = c.PoolKeyHash
+			case *common.StakeVoteDelegationCertificate:
+				pool = common.PoolKeyHash(common.NewBlake2b224(c.PoolKeyHash))
+			case *common.StakeRegistrationDelegationCertificate:
+				pool = common.PoolKeyHash(common.NewBlake2b224(c.PoolKeyHash))
+			case *common.StakeVoteRegistrationDelegationCertificate:
+				pool = common.PoolKeyHash(common.NewBlake2b224(c.PoolKeyHash))
+			default:
+				return nil
+			}
+			reg, err := certState.PoolRegistration(pool)
+			if err != nil {
+				return err
+			}
+			if reg == nil {
+				return DelegateeNotRegisteredError{
+					PoolKeyHash: pool,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// PoolValidateStakePoolNotRegisteredOnKey ensures that only registered pools are retired
+func PoolValidateStakePoolNotRegisteredOnKey(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	return processCertificates(
+		tx,
+		ls,
+		func(cert common.Certificate, certState common.CertState) error {
+			c, ok := cert.(*common.PoolRetirementCertificate)
+			if !ok {
+				return nil
+			}
+			reg, err := certState.PoolRegistration(c.PoolKeyHash)
+			if err != nil {
+				return err
+			}
+			if reg == nil {
+				return StakePoolNotRegisteredOnKeyError{
+					PoolKeyHash: c.PoolKeyHash,
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// PoolValidateStakePoolRetirementWrongEpoch ensures that pool retirements are scheduled after the current epoch and
+// no more than MaxEpoch epochs in the future. This rule is skipped if the ledger state does not provide the current epoch
+func PoolValidateStakePoolRetirementWrongEpoch(tx common.Transaction, slot uint64, ls common.LedgerState, pp common.ProtocolParameters) error {
+	tmpPparams, ok := pp.(*ShelleyProtocolParameters)
+	if !ok {
+		return fmt.Errorf("pparams are not expected type")
+	}
+	epochState, ok := ls.(common.EpochState)
+	if !ok {
+		return nil
+	}
+	currentEpoch, err := epochState.CurrentEpoch()
+	if err != nil {
+		return err
+	}
+	maxEpoch := currentEpoch + uint64(tmpPparams.MaxEpoch)
+	for _, cert := range tx.Certificates() {
+		c, ok := cert.(*common.PoolRetirementCertificate)
+		if !ok {
+			continue
+		}
+		if c.Epoch <= currentEpoch || c.Epoch > maxEpoch {
+			return StakePoolRetirementWrongEpochError{
+				CurrentEpoch: currentEpoch,
+				Epoch:        c.Epoch,
+				MaxEpoch:     maxEpoch,
+			}
+		}
+	}
+	return nil
+}
+
+// WitnessKeyHashes returns the key hashes of all vkey and bootstrap witnesses in the transaction
+func WitnessKeyHashes(tx common.Transaction) map[common.Blake2b224]bool {
+	ret := make(map[common.Blake2b224]bool)
+	for _, vkeyWitness := range tx.Witnesses().Vkey() {
+		ret[common.Blake2b224Hash(vkeyWitness.Vkey)] = true
+	}
+	for _, bootstrapWitness := range tx.Witnesses().Bootstrap() {
+		ret[BootstrapWitnessKeyHash(bootstrapWitness)] = true
+	}
+	return ret
+}
+
+// BootstrapWitnessKeyHash returns the Byron address root that corresponds to a bootstrap witness, which is
+// what's stored in the Byron address that the witness is able to spend from
+func BootstrapWitnessKeyHash(witness common.BootstrapWitness) common.Blake2b224 {
+	// The address root is the hash of the CBOR-encoded address spending data and attributes:
+	// [0, [0, pubkey || chaincode], attributes]
+	// The attributes are already CBOR-encoded, so we build this manually rather than encoding the whole structure
+	tmpData := []byte{0x83, 0x00, 0x82, 0x00, 0x58, 0x40}
+	tmpData = append(tmpData, witness.PublicKey...)
+	tmpData = append(tmpData, witness.ChainCode...)
+	tmpData = append(tmpData, witness.Attributes...)
+	sha3Sum := sha3.Sum256(tmpData)
+	return common.Blake2b224Hash(sha3Sum[:])
+}
+
+func verifyWitnessSignature(vkey []byte, msg []byte, signature []byte) bool {
+	if len(vkey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(vkey), msg, signature)
+}
+
+// RequiredKeyHashes returns the key hashes that must provide a witness for the transaction. This includes the payment
+// keys of spent inputs and collateral, the credentials of withdrawals, certificates and voters, and required signers
+func RequiredKeyHashes(tx common.Transaction, ls common.UtxoState) []common.Blake2b224 {
+	var ret []common.Blake2b224
+	seen := make(map[common.Blake2b224]bool)
+	addKeyHash := func(keyHash common.Blake2b224) {
+		if seen[keyHash] {
+			return
+		}
+		seen[keyHash] = true
+		ret = append(ret, keyHash)
+	}
+	// Payment keys of spent inputs and collateral
+	for _, tmpInput := range slices.Concat(tx.Inputs(), tx.Collateral()) {
+		utxo, err := ls.UtxoById(tmpInput)
+		// Ignore errors fetching the UTxO, which are covered by other rules
+		if err != nil {
+			continue
+		}
+		addr := utxo.Output.Address()
+		switch addr.Type() {
+		case common.AddressTypeKeyKey,
+			common.AddressTypeKeyScript,
+			common.AddressTypeKeyPointer,
+			common.AddressTypeKeyNone,
+			common.AddressTypeByron:
+			// The payment key hash of a Byron address is the address root
+			addKeyHash(addr.PaymentKeyHash())
+		}
+	}
+	// Withdrawals
+	for addr := range tx.Withdrawals() {
+		if addr.Type() == common.AddressTypeNoneKey {
+			addKeyHash(addr.StakeKeyHash())
+		}
+	}
+	// Certificates
+	for _, cert := range tx.Certificates() {
+		keyHashes, _ := certWitnessHashes(cert)
+		for _, keyHash := range keyHashes {
+			addKeyHash(keyHash)
+		}
+	}
+	// Voters
+	for voter := range tx.VotingProcedures() {
+		switch voter.Type {
+		case common.VoterTypeConstitutionalCommitteeHotKeyHash,
+			common.VoterTypeDRepKeyHash,
+			common.VoterTypeStakingPoolKeyHash:
+			addKeyHash(common.Blake2b224(voter.Hash))
+		}
+	}
+	// Required signers
+	for _, keyHash := range tx.RequiredSigners() {
+		addKeyHash(keyHash)
+	}
+	return ret
+}
+
+// RequiredScriptHashes returns the script hashes that must be satisfied for the transaction, in a stable order
+func RequiredScriptHashes(tx common.Transaction, ls common.LedgerState) []common.Blake2b224 {
+	var ret []common.Blake2b224
+	seen := make(map[common.Blake2b224]bool)
+	addScriptHash := func(scriptHash common.Blake2b224) {
+		if seen[scriptHash] {
+			return
+		}
+		seen[scriptHash] = true
+		ret = append(ret, scriptHash)
+	}
+	// Script-locked inputs
+	for _, tmpInput := range tx.Inputs() {
+		utxo, err := ls.UtxoById(tmpInput)
+		// Ignore errors fetching the UTxO, which are covered by other rules
+		if err != nil {
+			continue
+		}
+		addr := utxo.Output.Address()
+		switch addr.Type() {
+		case common.AddressTypeScriptKey,
+			common.AddressTypeScriptScript,
+			common.AddressTypeScriptPointer,
+			common.AddressTypeScriptNone:
+			addScriptHash(addr.PaymentKeyHash())
+		}
+	}
+	// Withdrawals
+	for addr := range tx.Withdrawals() {
+		if addr.Type() == common.AddressTypeNoneScript {
+			addScriptHash(addr.StakeKeyHash())
+		}
+	}
+	// Certificates
+	for _, cert := range tx.Certificates() {
+		_, scriptHashes := certWitnessHashes(cert)
+		for _, scriptHash := range scriptHashes {
+			addScriptHash(scriptHash)
+		}
+	}
+	// Minting policies
+	if assetMint := tx.AssetMint(); assetMint != nil {
+		for _, policyId := range assetMint.Policies() {
+			addScriptHash(policyId)
+		}
+	}
+	// Voters
+	for voter := range tx.VotingProcedures() {
+		switch voter.Type {
+		case common.VoterTypeConstitutionalCommitteeHotScriptHash,
+			common.VoterTypeDRepScriptHash:
+			addScriptHash(common.Blake2b224(voter.Hash))
+		}
+	}
+	return ret
+}
+
+// availableScriptHashes returns the hashes of all scripts provided by the transaction witnesses or as reference
+// scripts on spent or referenced UTxOs
+func availableScriptHashes(tx common.Transaction, ls common.LedgerState) map[common.Blake2b224]bool {
+	ret := make(map[common.Blake2b224]bool)
+	witnesses := tx.Witnesses()
+	nativeScripts := witnesses.NativeScripts()
+	for idx := range nativeScripts {
+		ret[nativeScripts[idx].Hash()] = true
+	}
+	for _, script := range witnesses.PlutusV1Scripts() {
+		ret[common.ScriptHash(common.ScriptTypePlutusV1, script)] = true
+	}
+	for _, script := range witnesses.PlutusV2Scripts() {
+		ret[common.ScriptHash(common.ScriptTypePlutusV2, script)] = true
+	}
+	for _, script := range witnesses.PlutusV3Scripts() {
+		ret[common.ScriptHash(common.ScriptTypePlutusV3, script)] = true
+	}
+	// Reference scripts
+	type referenceScriptOutput interface {
+		ReferenceScript() (uint, []byte, error)
+	}
+	for _, tmpInput := range slices.Concat(tx.Inputs(), tx.ReferenceInputs()) {
+		utxo, err := ls.UtxoById(tmpInput)
+		if err != nil {
+			continue
+		}
+		tmpOutput, ok := utxo.Output.(referenceScriptOutput)
+		if !ok {
+			continue
+		}
+		scriptType, script, err := tmpOutput.ReferenceScript()
+		if err != nil || script == nil {
+			continue
+		}
+		ret[common.ScriptHash(uint8(scriptType), script)] = true // #nosec G115
+	}
+	return ret
+}
+
+// processCertificates calls the provided function for each certificate in the transaction, along with the
+// certificate state resulting from the withdrawals and the certificates before it
+func processCertificates(tx common.Transaction, ls common.LedgerState, certFunc func(common.Certificate, common.CertState) error) error {
+	// Deposit amounts are not relevant to the rules that use this
+	certState := common.NewTxCertState(ls, 0, 0)
+	return certState.ProcessTx(
+		tx,
+		func(cert common.Certificate) error {
+			return certFunc(cert, certState)
+		},
+	)
+}
+
+// certWitnessHashes returns the key hashes and script hashes of the credentials that must authorize a certificate
+func certWitnessHashes(cert common.Certificate) ([]common.Blake2b224, []common.Blake2b224) {
+	var keyHashes, scriptHashes []common.Blake2b224
+	addCredential := func(cred *common.StakeCredential) {
+		if cred == nil {
+			return
+		}
+		credHash := common.NewBlake2b224(cred.Credential)
+		switch cred.CredType {
+		case common.StakeCredentialTypeAddrKeyHash:
+			keyHashes = append(keyHashes, credHash)
+		case common.StakeCredentialTypeScriptHash:
+			scriptHashes = append(scriptHashes, credHash)
+		}
+	}
+	switch c := cert.(type) {
+	case *common.StakeDeregistrationCertificate:
+		addCredential(&c.StakeDeregistration)
+	case *common.StakeDelegationCertificate:
+		addCredential(c.StakeCredential)
+	case *common.PoolRegistrationCertificate:
+		keyHashes = append(keyHashes, common.Blake2b224(c.Operator))
+		for _, owner := range c.PoolOwners {
+			keyHashes = append(keyHashes, common.Blake2b224(owner))
+		}
+	case *common.PoolRetirementCertificate:
+		keyHashes = append(keyHashes, common.Blake2b224(c.PoolKeyHash))
+	case *common.RegistrationCertificate:
+		addCredential(&c.StakeCredential)
+	case *common.DeregistrationCertificate:
+		addCredential(&c.StakeCredential)
+	case *common.VoteDelegationCertificate:
+		addCredential(&c.StakeCredential)
+	case *common.StakeVoteDelegationCertificate:
+		addCredential(&c.StakeCredential)
+	case *common.StakeRegistrationDelegationCertificate:
+		addCredential(&c.StakeCredential)
+	case *common.VoteRegistrationDelegationCertificate:
+		addCredential(&c.StakeCredential)
+	case *common.StakeVoteRegistrationDelegationCertificate:
+		addCredential(&c.StakeCredential)
+	case *common.AuthCommitteeHotCertificate:
+		addCredential(&c.ColdCredential)
+	case *common.ResignCommitteeColdCertificate:
+		addCredential(&c.ColdCredential)
+	case *common.RegistrationDrepCertificate:
+		addCredential(&c.DrepCredential)
+	case *common.DeregistrationDrepCertificate:
+		addCredential(&c.DrepCredential)
+	case *common.UpdateDrepCertificate:
+		addCredential(&c.DrepCredential)
+	}
+	return keyHashes, scriptHashes
+}
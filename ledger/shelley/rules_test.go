@@ -15,20 +15,29 @@
 package shelley_test
 
 import (
+	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"testing"
 
+	"github.com/blinklabs-io/gouroboros/cbor"
 	"github.com/blinklabs-io/gouroboros/ledger/common"
 	"github.com/blinklabs-io/gouroboros/ledger/shelley"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/sha3"
 )
 
 type testLedgerState struct {
-	networkId uint
-	utxos     []common.Utxo
+	networkId          uint
+	utxos              []common.Utxo
+	stakeRegistrations map[common.Blake2b224]*common.StakeRegistration
+	poolRegistrations  map[common.PoolKeyHash]*common.PoolRegistration
+	drepRegistrations  map[common.Blake2b224]*common.DrepRegistration
+	committeeMembers   map[common.Blake2b224]*common.CommitteeMember
 }
 
 func (ls testLedgerState) NetworkId() uint {
@@ -48,6 +57,22 @@ func (ls testLedgerState) UtxoById(id common.TransactionInput) (common.Utxo, err
 	return common.Utxo{}, fmt.Errorf("not found")
 }
 
+func (ls testLedgerState) StakeRegistration(cred common.Blake2b224) (*common.StakeRegistration, error) {
+	return ls.stakeRegistrations[cred], nil
+}
+
+func (ls testLedgerState) PoolRegistration(pool common.PoolKeyHash) (*common.PoolRegistration, error) {
+	return ls.poolRegistrations[pool], nil
+}
+
+func (ls testLedgerState) DrepRegistration(cred common.Blake2b224) (*common.DrepRegistration, error) {
+	return ls.drepRegistrations[cred], nil
+}
+
+func (ls testLedgerState) CommitteeMember(cred common.Blake2b224) (*common.CommitteeMember, error) {
+	return ls.committeeMembers[cred], nil
+}
+
 func TestUtxoValidateTimeToLive(t *testing.T) {
 	var testSlot uint64 = 555666777
 	var testZeroSlot uint64 = 0
@@ -611,6 +636,26 @@ func TestUtxoValidateValueNotConservedUtxo(t *testing.T) {
 			)
 		},
 	)
+	// Produced value overflows
+	t.Run(
+		"overflow",
+		func(t *testing.T) {
+			testTx.Body.TxFee = math.MaxUint64
+			testTx.Body.TxOutputs[0].OutputAmount = testOutputExactAmount
+			err := shelley.UtxoValidateValueNotConservedUtxo(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if !errors.Is(err, common.ErrValueOverflow) {
+				t.Errorf(
+					"UtxoValidateValueNotConservedUtxo should fail when the produced value overflows\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
 }
 
 func TestUtxoValidateOutputTooSmallUtxo(t *testing.T) {
@@ -812,3 +857,824 @@ func TestUtxoValidateMaxTxSizeUtxo(t *testing.T) {
 		},
 	)
 }
+
+const testWitnessInputTxId = "d228b482a1aae768e4a796380f49e021d9c21f70d3c12cb186b188dedfc0ee22"
+
+// testWitnessTx returns a transaction with a single input locked by the specified address, along with a
+// ledger state containing the input
+func testWitnessTx(t *testing.T, addr common.Address) (*shelley.ShelleyTransaction, testLedgerState) {
+	testBodyCbor := make([]byte, 32)
+	if _, err := rand.Read(testBodyCbor); err != nil {
+		t.Fatalf("could not read random bytes")
+	}
+	testInput := shelley.NewShelleyTransactionInput(testWitnessInputTxId, 0)
+	testTx := &shelley.ShelleyTransaction{
+		Body: shelley.ShelleyTransactionBody{
+			TxInputs: shelley.NewShelleyTransactionInputSet(
+				[]shelley.ShelleyTransactionInput{testInput},
+			),
+		},
+	}
+	testTx.Body.SetCbor(testBodyCbor)
+	testLedgerState := testLedgerState{
+		utxos: []common.Utxo{
+			{
+				Id: testInput,
+				Output: shelley.ShelleyTransactionOutput{
+					OutputAddress: addr,
+				},
+			},
+		},
+	}
+	return testTx, testLedgerState
+}
+
+func testVkeyWitness(t *testing.T, tx common.Transaction, privKey ed25519.PrivateKey) common.VkeyWitness {
+	txBodyHash, err := hex.DecodeString(tx.Hash())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return common.VkeyWitness{
+		Vkey:      privKey.Public().(ed25519.PublicKey),
+		Signature: ed25519.Sign(privKey, txBodyHash),
+	}
+}
+
+func testKeyAddress(t *testing.T, addrType uint8, keyHash common.Blake2b224) common.Address {
+	addr, err := common.NewAddressFromParts(addrType, 0, keyHash.Bytes(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return addr
+}
+
+func testNativeScript(t *testing.T, script any) common.NativeScript {
+	scriptCbor, err := cbor.Encode(script)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var ret common.NativeScript
+	if _, err := cbor.Decode(scriptCbor, &ret); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return ret
+}
+
+func TestUtxowValidateInvalidWitnesses(t *testing.T) {
+	_, testPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testTx, testLedgerState := testWitnessTx(t, common.Address{})
+	testProtocolParams := &shelley.ShelleyProtocolParameters{}
+	testSlot := uint64(0)
+	// Valid signature
+	t.Run(
+		"valid signature",
+		func(t *testing.T) {
+			testTx.WitnessSet.VkeyWitnesses = []common.VkeyWitness{
+				testVkeyWitness(t, testTx, testPrivKey),
+			}
+			err := shelley.UtxowValidateInvalidWitnesses(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxowValidateInvalidWitnesses should succeed when provided a valid signature\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Bad signature
+	t.Run(
+		"bad signature",
+		func(t *testing.T) {
+			testWitness := testVkeyWitness(t, testTx, testPrivKey)
+			testWitness.Signature[0] ^= 0xff
+			testTx.WitnessSet.VkeyWitnesses = []common.VkeyWitness{
+				testWitness,
+			}
+			err := shelley.UtxowValidateInvalidWitnesses(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxowValidateInvalidWitnesses should fail when provided a bad signature",
+				)
+				return
+			}
+			testErrType := shelley.InvalidWitnessesUtxowError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxowValidateMissingVKeyWitnesses(t *testing.T) {
+	testPubKey, testPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, testOtherPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testAddr := testKeyAddress(t, common.AddressTypeKeyNone, common.Blake2b224Hash(testPubKey))
+	testTx, testLedgerState := testWitnessTx(t, testAddr)
+	testProtocolParams := &shelley.ShelleyProtocolParameters{}
+	testSlot := uint64(0)
+	// Witness present
+	t.Run(
+		"witness present",
+		func(t *testing.T) {
+			testTx.WitnessSet.VkeyWitnesses = []common.VkeyWitness{
+				testVkeyWitness(t, testTx, testPrivKey),
+			}
+			err := shelley.UtxowValidateMissingVKeyWitnesses(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxowValidateMissingVKeyWitnesses should succeed when the input key has a witness\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Witness missing
+	t.Run(
+		"witness missing",
+		func(t *testing.T) {
+			testTx.WitnessSet.VkeyWitnesses = []common.VkeyWitness{
+				testVkeyWitness(t, testTx, testOtherPrivKey),
+			}
+			err := shelley.UtxowValidateMissingVKeyWitnesses(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxowValidateMissingVKeyWitnesses should fail when the input key has no witness",
+				)
+				return
+			}
+			testErrType := shelley.MissingVKeyWitnessesUtxowError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+	// Witness missing for stake deregistration
+	t.Run(
+		"certificate witness missing",
+		func(t *testing.T) {
+			testTx.WitnessSet.VkeyWitnesses = []common.VkeyWitness{
+				testVkeyWitness(t, testTx, testPrivKey),
+			}
+			testTx.Body.TxCertificates = []common.CertificateWrapper{
+				{
+					Type: common.CertificateTypeStakeDeregistration,
+					Certificate: &common.StakeDeregistrationCertificate{
+						StakeDeregistration: common.StakeCredential{
+							CredType:   common.StakeCredentialTypeAddrKeyHash,
+							Credential: make([]byte, 28),
+						},
+					},
+				},
+			}
+			defer func() {
+				testTx.Body.TxCertificates = nil
+			}()
+			err := shelley.UtxowValidateMissingVKeyWitnesses(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxowValidateMissingVKeyWitnesses should fail when the certificate credential has no witness",
+				)
+				return
+			}
+			testErrType := shelley.MissingVKeyWitnessesUtxowError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestUtxowValidateNativeScriptWitnesses(t *testing.T) {
+	testPubKey, testPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testKeyHash := common.Blake2b224Hash(testPubKey)
+	testScript := testNativeScript(t, []any{0, testKeyHash.Bytes()})
+	testAddr := testKeyAddress(t, common.AddressTypeScriptNone, testScript.Hash())
+	testTx, testLedgerState := testWitnessTx(t, testAddr)
+	testProtocolParams := &shelley.ShelleyProtocolParameters{}
+	testSlot := uint64(0)
+	// Script missing
+	t.Run(
+		"script missing",
+		func(t *testing.T) {
+			testTx.WitnessSet.WsNativeScripts = nil
+			err := shelley.UtxowValidateMissingScriptWitnesses(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxowValidateMissingScriptWitnesses should fail when the input script is not provided",
+				)
+				return
+			}
+			testErrType := shelley.MissingScriptWitnessesUtxowError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+	// Script not satisfied
+	t.Run(
+		"script not satisfied",
+		func(t *testing.T) {
+			testTx.WitnessSet.WsNativeScripts = []common.NativeScript{testScript}
+			testTx.WitnessSet.VkeyWitnesses = nil
+			err := shelley.UtxowValidateMissingScriptWitnesses(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxowValidateMissingScriptWitnesses should succeed when the input script is provided\n  got error: %v",
+					err,
+				)
+			}
+			err = shelley.UtxowValidateScriptWitnessNotValidating(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err == nil {
+				t.Errorf(
+					"UtxowValidateScriptWitnessNotValidating should fail when the script signer has no witness",
+				)
+				return
+			}
+			testErrType := shelley.ScriptWitnessNotValidatingUtxowError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+	// Script satisfied
+	t.Run(
+		"script satisfied",
+		func(t *testing.T) {
+			testTx.WitnessSet.WsNativeScripts = []common.NativeScript{testScript}
+			testTx.WitnessSet.VkeyWitnesses = []common.VkeyWitness{
+				testVkeyWitness(t, testTx, testPrivKey),
+			}
+			err := shelley.UtxowValidateScriptWitnessNotValidating(
+				testTx,
+				testSlot,
+				testLedgerState,
+				testProtocolParams,
+			)
+			if err != nil {
+				t.Errorf(
+					"UtxowValidateScriptWitnessNotValidating should succeed when the script signer has a witness\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+}
+
+func TestUtxowValidateMetadataHash(t *testing.T) {
+	testMetadataCbor, err := cbor.Encode(
+		map[uint]any{
+			674: map[string]any{
+				"msg": []string{"hi"},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var testMetadata cbor.LazyValue
+	if _, err := cbor.Decode(testMetadataCbor, &testMetadata); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testMetadataHash := common.Blake2b256Hash(testMetadataCbor)
+	testOtherHash := common.Blake2b256Hash([]byte("abcdef"))
+	testLedgerState := testLedgerState{}
+	testProtocolParams := &shelley.ShelleyProtocolParameters{}
+	testSlot := uint64(0)
+	testRun := func(t *testing.T, name string, metadata *cbor.LazyValue, auxDataHash *common.Blake2b256, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testTx := &shelley.ShelleyTransaction{
+					Body: shelley.ShelleyTransactionBody{
+						TxAuxDataHash: auxDataHash,
+					},
+					TxMetadata: metadata,
+				}
+				err := shelley.UtxowValidateMetadataHash(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	validateSuccess := func(t *testing.T, err error) {
+		if err != nil {
+			t.Errorf(
+				"UtxowValidateMetadataHash should succeed\n  got error: %v",
+				err,
+			)
+		}
+	}
+	validateErrType := func(testErrType error) func(*testing.T, error) {
+		return func(t *testing.T, err error) {
+			if err == nil {
+				t.Errorf(
+					"UtxowValidateMetadataHash should fail",
+				)
+				return
+			}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		}
+	}
+	testRun(t, "no metadata", nil, nil, validateSuccess)
+	testRun(t, "matching hash", &testMetadata, &testMetadataHash, validateSuccess)
+	testRun(t, "missing hash", &testMetadata, nil, validateErrType(shelley.MissingTxBodyMetadataHashError{}))
+	testRun(t, "missing metadata", nil, &testMetadataHash, validateErrType(shelley.MissingTxMetadataError{}))
+	testRun(t, "conflicting hash", &testMetadata, &testOtherHash, validateErrType(shelley.ConflictingMetadataHashError{}))
+}
+
+func TestBootstrapWitnessKeyHash(t *testing.T) {
+	testNetwork := uint32(1097911063)
+	testAttr := common.ByronAddressAttributes{
+		Network: &testNetwork,
+	}
+	testAttrCbor, err := cbor.Encode(&testAttr)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testWitness := common.BootstrapWitness{
+		PublicKey:  make([]byte, 32),
+		ChainCode:  make([]byte, 32),
+		Attributes: testAttrCbor,
+	}
+	if _, err := rand.Read(testWitness.PublicKey); err != nil {
+		t.Fatalf("could not read random bytes")
+	}
+	// The address root is the hash of [0, [0, xpub], attributes]
+	testAddrRootCbor, err := cbor.Encode(
+		[]any{
+			0,
+			[]any{
+				0,
+				append(testWitness.PublicKey[:], testWitness.ChainCode...),
+			},
+			cbor.RawMessage(testAttrCbor),
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedKeyHash := common.Blake2b224Hash(sha3Sum256(testAddrRootCbor))
+	keyHash := shelley.BootstrapWitnessKeyHash(testWitness)
+	if keyHash != expectedKeyHash {
+		t.Errorf(
+			"did not get expected key hash: got %s, wanted %s",
+			keyHash.String(),
+			expectedKeyHash.String(),
+		)
+	}
+}
+
+func sha3Sum256(data []byte) []byte {
+	tmpHash := sha3.Sum256(data)
+	return tmpHash[:]
+}
+
+func testRewardAddress(t *testing.T, cred common.Blake2b224) *common.Address {
+	addrCbor, err := cbor.Encode(
+		append([]byte{common.AddressTypeNoneKey << 4}, cred.Bytes()...),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var addr common.Address
+	if _, err := cbor.Decode(addrCbor, &addr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return &addr
+}
+
+func testStakeCredential(cred common.Blake2b224) common.StakeCredential {
+	return common.StakeCredential{
+		CredType:   common.StakeCredentialTypeAddrKeyHash,
+		Credential: cred.Bytes(),
+	}
+}
+
+type testEpochLedgerState struct {
+	testLedgerState
+	epoch uint64
+}
+
+func (ls testEpochLedgerState) CurrentEpoch() (uint64, error) {
+	return ls.epoch, nil
+}
+
+func TestDelegValidateWithdrawalsNotInRewards(t *testing.T) {
+	testCred := common.Blake2b224Hash([]byte("stake"))
+	testRewardAddr := testRewardAddress(t, testCred)
+	testLedgerState := testLedgerState{
+		stakeRegistrations: map[common.Blake2b224]*common.StakeRegistration{
+			testCred: {Deposit: 2_000_000, Reward: 1_000_000},
+		},
+	}
+	testProtocolParams := &shelley.ShelleyProtocolParameters{}
+	testSlot := uint64(0)
+	testRun := func(t *testing.T, name string, amount uint64, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testTx := &shelley.ShelleyTransaction{
+					Body: shelley.ShelleyTransactionBody{
+						TxWithdrawals: map[*common.Address]uint64{
+							testRewardAddr: amount,
+						},
+					},
+				}
+				err := shelley.DelegValidateWithdrawalsNotInRewards(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	// Full balance
+	testRun(
+		t,
+		"full balance",
+		1_000_000,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"DelegValidateWithdrawalsNotInRewards should succeed when withdrawing the full balance\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Partial balance
+	testRun(
+		t,
+		"partial balance",
+		500_000,
+		func(t *testing.T, err error) {
+			if err == nil {
+				t.Errorf(
+					"DelegValidateWithdrawalsNotInRewards should fail when withdrawing a partial balance",
+				)
+				return
+			}
+			testErrType := shelley.WithdrawalsNotInRewardsError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestDelegValidateStakeKeyRegistration(t *testing.T) {
+	testCred := common.Blake2b224Hash([]byte("stake"))
+	testOtherCred := common.Blake2b224Hash([]byte("other"))
+	testRewardAddr := testRewardAddress(t, testCred)
+	testLedgerState := testLedgerState{
+		stakeRegistrations: map[common.Blake2b224]*common.StakeRegistration{
+			testCred: {Deposit: 2_000_000, Reward: 1_000_000},
+		},
+	}
+	testProtocolParams := &shelley.ShelleyProtocolParameters{}
+	testSlot := uint64(0)
+	testRegCert := func(cred common.Blake2b224) common.CertificateWrapper {
+		return common.CertificateWrapper{
+			Type: common.CertificateTypeStakeRegistration,
+			Certificate: &common.StakeRegistrationCertificate{
+				StakeRegistration: testStakeCredential(cred),
+			},
+		}
+	}
+	testDeregCert := func(cred common.Blake2b224) common.CertificateWrapper {
+		return common.CertificateWrapper{
+			Type: common.CertificateTypeStakeDeregistration,
+			Certificate: &common.StakeDeregistrationCertificate{
+				StakeDeregistration: testStakeCredential(cred),
+			},
+		}
+	}
+	testDefs := []struct {
+		name         string
+		certs        []common.CertificateWrapper
+		withdrawals  map[*common.Address]uint64
+		validateFunc common.UtxoValidationRuleFunc
+		expectedErr  error
+	}{
+		{
+			name:         "register new credential",
+			certs:        []common.CertificateWrapper{testRegCert(testOtherCred)},
+			validateFunc: shelley.DelegValidateStakeKeyAlreadyRegistered,
+		},
+		{
+			name:         "register registered credential",
+			certs:        []common.CertificateWrapper{testRegCert(testCred)},
+			validateFunc: shelley.DelegValidateStakeKeyAlreadyRegistered,
+			expectedErr:  shelley.StakeKeyAlreadyRegisteredError{},
+		},
+		{
+			name:         "register credential twice",
+			certs:        []common.CertificateWrapper{testRegCert(testOtherCred), testRegCert(testOtherCred)},
+			validateFunc: shelley.DelegValidateStakeKeyAlreadyRegistered,
+			expectedErr:  shelley.StakeKeyAlreadyRegisteredError{},
+		},
+		{
+			name:         "deregister unregistered credential",
+			certs:        []common.CertificateWrapper{testDeregCert(testOtherCred)},
+			validateFunc: shelley.DelegValidateStakeKeyNotRegistered,
+			expectedErr:  shelley.StakeKeyNotRegisteredError{},
+		},
+		{
+			name:         "register and deregister credential",
+			certs:        []common.CertificateWrapper{testRegCert(testOtherCred), testDeregCert(testOtherCred)},
+			validateFunc: shelley.DelegValidateStakeKeyNotRegistered,
+		},
+		{
+			name:         "deregister with reward balance",
+			certs:        []common.CertificateWrapper{testDeregCert(testCred)},
+			validateFunc: shelley.DelegValidateStakeKeyNonZeroAccountBalance,
+			expectedErr:  shelley.StakeKeyNonZeroAccountBalanceError{},
+		},
+		{
+			name:         "deregister with reward withdrawal",
+			certs:        []common.CertificateWrapper{testDeregCert(testCred)},
+			withdrawals:  map[*common.Address]uint64{testRewardAddr: 1_000_000},
+			validateFunc: shelley.DelegValidateStakeKeyNonZeroAccountBalance,
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(
+			testDef.name,
+			func(t *testing.T) {
+				testTx := &shelley.ShelleyTransaction{
+					Body: shelley.ShelleyTransactionBody{
+						TxCertificates: testDef.certs,
+						TxWithdrawals:  testDef.withdrawals,
+					},
+				}
+				err := testDef.validateFunc(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				if testDef.expectedErr == nil {
+					if err != nil {
+						t.Errorf("validation should succeed\n  got error: %v", err)
+					}
+					return
+				}
+				if err == nil {
+					t.Errorf("validation should fail")
+					return
+				}
+				assert.IsType(
+					t,
+					testDef.expectedErr,
+					err,
+					"did not get expected error type: got %T, wanted %T",
+					err,
+					testDef.expectedErr,
+				)
+			},
+		)
+	}
+}
+
+func TestDelegValidateDelegateeNotRegistered(t *testing.T) {
+	testCred := common.Blake2b224Hash([]byte("stake"))
+	testPool := common.PoolKeyHash(common.Blake2b224Hash([]byte("pool")))
+	testOtherPool := common.PoolKeyHash(common.Blake2b224Hash([]byte("other pool")))
+	testLedgerState := testLedgerState{
+		stakeRegistrations: map[common.Blake2b224]*common.StakeRegistration{
+			testCred: {Deposit: 2_000_000},
+		},
+		poolRegistrations: map[common.PoolKeyHash]*common.PoolRegistration{
+			testPool: {Deposit: 500_000_000},
+		},
+	}
+	testProtocolParams := &shelley.ShelleyProtocolParameters{}
+	testSlot := uint64(0)
+	testRun := func(t *testing.T, name string, pool common.PoolKeyHash, validateFunc func(*testing.T, error)) {
+		t.Run(
+			name,
+			func(t *testing.T) {
+				testStakeCred := testStakeCredential(testCred)
+				testTx := &shelley.ShelleyTransaction{
+					Body: shelley.ShelleyTransactionBody{
+						TxCertificates: []common.CertificateWrapper{
+							{
+								Type: common.CertificateTypeStakeDelegation,
+								Certificate: &common.StakeDelegationCertificate{
+									StakeCredential: &testStakeCred,
+									PoolKeyHash:     pool,
+								},
+							},
+						},
+					},
+				}
+				err := shelley.DelegValidateDelegateeNotRegistered(
+					testTx,
+					testSlot,
+					testLedgerState,
+					testProtocolParams,
+				)
+				validateFunc(t, err)
+			},
+		)
+	}
+	// Registered pool
+	testRun(
+		t,
+		"registered pool",
+		testPool,
+		func(t *testing.T, err error) {
+			if err != nil {
+				t.Errorf(
+					"DelegValidateDelegateeNotRegistered should succeed when delegating to a registered pool\n  got error: %v",
+					err,
+				)
+			}
+		},
+	)
+	// Unregistered pool
+	testRun(
+		t,
+		"unregistered pool",
+		testOtherPool,
+		func(t *testing.T, err error) {
+			if err == nil {
+				t.Errorf(
+					"DelegValidateDelegateeNotRegistered should fail when delegating to an unregistered pool",
+				)
+				return
+			}
+			testErrType := shelley.DelegateeNotRegisteredError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		},
+	)
+}
+
+func TestPoolValidateStakePoolRetirementWrongEpoch(t *testing.T) {
+	testPool := common.PoolKeyHash(common.Blake2b224Hash([]byte("pool")))
+	testLedgerState := testEpochLedgerState{
+		testLedgerState: testLedgerState{
+			poolRegistrations: map[common.PoolKeyHash]*common.PoolRegistration{
+				testPool: {Deposit: 500_000_000},
+			},
+		},
+		epoch: 100,
+	}
+	testProtocolParams := &shelley.ShelleyProtocolParameters{
+		MaxEpoch: 18,
+	}
+	testSlot := uint64(0)
+	testDefs := []struct {
+		epoch       uint64
+		expectedErr bool
+	}{
+		{epoch: 100, expectedErr: true},
+		{epoch: 101, expectedErr: false},
+		{epoch: 118, expectedErr: false},
+		{epoch: 119, expectedErr: true},
+	}
+	for _, testDef := range testDefs {
+		testTx := &shelley.ShelleyTransaction{
+			Body: shelley.ShelleyTransactionBody{
+				TxCertificates: []common.CertificateWrapper{
+					{
+						Type: common.CertificateTypePoolRetirement,
+						Certificate: &common.PoolRetirementCertificate{
+							PoolKeyHash: testPool,
+							Epoch:       testDef.epoch,
+						},
+					},
+				},
+			},
+		}
+		err := shelley.PoolValidateStakePoolRetirementWrongEpoch(
+			testTx,
+			testSlot,
+			testLedgerState,
+			testProtocolParams,
+		)
+		if testDef.expectedErr {
+			if err == nil {
+				t.Errorf("PoolValidateStakePoolRetirementWrongEpoch should fail for epoch %d", testDef.epoch)
+				continue
+			}
+			testErrType := shelley.StakePoolRetirementWrongEpochError{}
+			assert.IsType(
+				t,
+				testErrType,
+				err,
+				"did not get expected error type: got %T, wanted %T",
+				err,
+				testErrType,
+			)
+		} else if err != nil {
+			t.Errorf(
+				"PoolValidateStakePoolRetirementWrongEpoch should succeed for epoch %d\n  got error: %v",
+				testDef.epoch,
+				err,
+			)
+		}
+	}
+}
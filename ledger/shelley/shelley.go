@@ -107,11 +107,14 @@ func (b *ShelleyBlock) Transactions() []common.Transaction {
 	return ret
 }
 
-func (b *ShelleyBlock) Utxorpc() *utxorpc.Block {
+func (b *ShelleyBlock) Utxorpc() (*utxorpc.Block, error) {
 	var txs []*utxorpc.Tx
 	tmpHash, _ := hex.DecodeString(b.Hash())
 	for _, t := range b.Transactions() {
-		tx := t.Utxorpc()
+		tx, err := t.Utxorpc()
+		if err != nil {
+			return nil, err
+		}
 		txs = append(txs, tx)
 	}
 	body := &utxorpc.BlockBody{
@@ -126,7 +129,7 @@ func (b *ShelleyBlock) Utxorpc() *utxorpc.Block {
 		Body:   body,
 		Header: header,
 	}
-	return block
+	return block, nil
 }
 
 type ShelleyBlockHeader struct {
@@ -327,7 +330,7 @@ func (b *ShelleyTransactionBody) Donation() uint64 {
 	return 0
 }
 
-func (b *ShelleyTransactionBody) Utxorpc() *utxorpc.Tx {
+func (b *ShelleyTransactionBody) Utxorpc() (*utxorpc.Tx, error) {
 	var txi []*utxorpc.TxInput
 	var txo []*utxorpc.TxOutput
 	for _, i := range b.Inputs() {
@@ -335,12 +338,15 @@ func (b *ShelleyTransactionBody) Utxorpc() *utxorpc.Tx {
 		txi = append(txi, input)
 	}
 	for _, o := range b.Outputs() {
-		output := o.Utxorpc()
+		output, err := o.Utxorpc()
+		if err != nil {
+			return nil, err
+		}
 		txo = append(txo, output)
 	}
 	tmpHash, err := hex.DecodeString(b.Hash())
 	if err != nil {
-		return &utxorpc.Tx{}
+		return nil, err
 	}
 	tx := &utxorpc.Tx{
 		Inputs:  txi,
@@ -354,7 +360,7 @@ func (b *ShelleyTransactionBody) Utxorpc() *utxorpc.Tx {
 		// Auxiliary:    b.AuxData(),
 		Hash: tmpHash,
 	}
-	return tx
+	return tx, nil
 }
 
 type ShelleyTransactionInputSet struct {
@@ -459,11 +465,15 @@ func (o ShelleyTransactionOutput) Datum() *cbor.LazyValue {
 	return nil
 }
 
-func (o ShelleyTransactionOutput) Utxorpc() *utxorpc.TxOutput {
+func (o ShelleyTransactionOutput) Utxorpc() (*utxorpc.TxOutput, error) {
+	addressBytes, err := o.OutputAddress.Bytes()
+	if err != nil {
+		return nil, err
+	}
 	return &utxorpc.TxOutput{
-		Address: o.OutputAddress.Bytes(),
+		Address: addressBytes,
 		Coin:    o.Amount(),
-	}
+	}, nil
 }
 
 type ShelleyTransactionWitnessSet struct {
@@ -636,7 +646,7 @@ func (t ShelleyTransaction) Witnesses() common.TransactionWitnessSet {
 	return t.WitnessSet
 }
 
-func (t ShelleyTransaction) Utxorpc() *utxorpc.Tx {
+func (t ShelleyTransaction) Utxorpc() (*utxorpc.Tx, error) {
 	return t.Body.Utxorpc()
 }
 
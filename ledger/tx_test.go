@@ -16,6 +16,7 @@ package ledger_test
 
 import (
 	"encoding/hex"
+	"reflect"
 	"testing"
 
 	"github.com/blinklabs-io/gouroboros/ledger"
@@ -30,6 +31,10 @@ func TestDetermineTransactionType(t *testing.T) {
 			txCborHex:      "84a500d9010281825820279184037d249e397d97293738370756da559718fcdefae9924834840046b37b01018282583900923d4b64e1d730a4baf3e6dc433a9686983940f458363f37aad7a1a9568b72f85522e4a17d44a45cd021b9741b55d7cbc635c911625b015e1a00a9867082583900923d4b64e1d730a4baf3e6dc433a9686983940f458363f37aad7a1a9568b72f85522e4a17d44a45cd021b9741b55d7cbc635c911625b015e1b00000001267d7b04021a0002938d031a04e304e70800a100d9010281825820b829480e5d5827d2e1bd7c89176a5ca125c30812e54be7dbdf5c47c835a17f3d5840b13a76e7f2b19cde216fcad55ceeeb489ebab3dcf63ef1539ac4f535dece00411ee55c9b8188ef04b4aa3c72586e4a0ec9b89949367d7270fdddad3b18731403f5f6",
 			expectedTxType: 6,
 		},
+		{
+			txCborHex:      "83818200d8185824825820abababababababababababababababababababababababababababababababab01818282d818582183581c04865e42d2373addbebd5d2acf81c760c848970142889f7ee763091ba0001af01916d51a000f4240a0",
+			expectedTxType: 0,
+		},
 	}
 	for _, testDef := range testDefs {
 		txCbor, err := hex.DecodeString(testDef.txCborHex)
@@ -45,3 +50,37 @@ func TestDetermineTransactionType(t *testing.T) {
 		}
 	}
 }
+
+func TestNewTransactionOutputFromCbor(t *testing.T) {
+	testDefs := []struct {
+		txOutCborHex string
+		expectedType any
+		expectedAddr string
+	}{
+		{
+			txOutCborHex: "8282d818582183581c04865e42d2373addbebd5d2acf81c760c848970142889f7ee763091ba0001af01916d51a000f4240",
+			expectedType: &ledger.ByronTransactionOutput{},
+			expectedAddr: "Ae2tdPwUPEYwFx4dmJheyNPPYXtvHbJLeCaA96o6Y2iiUL18cAt7AizN2zG",
+		},
+		{
+			txOutCborHex: "82583900923d4b64e1d730a4baf3e6dc433a9686983940f458363f37aad7a1a9568b72f85522e4a17d44a45cd021b9741b55d7cbc635c911625b015e1a00a98670",
+			expectedType: &ledger.ShelleyTransactionOutput{},
+		},
+	}
+	for _, testDef := range testDefs {
+		txOutCbor, err := hex.DecodeString(testDef.txOutCborHex)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		txOut, err := ledger.NewTransactionOutputFromCbor(txOutCbor)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if reflect.TypeOf(txOut) != reflect.TypeOf(testDef.expectedType) {
+			t.Fatalf("did not get expected TX output type: got %T, wanted %T", txOut, testDef.expectedType)
+		}
+		if testDef.expectedAddr != "" && txOut.Address().String() != testDef.expectedAddr {
+			t.Fatalf("did not get expected address: got %s, wanted %s", txOut.Address().String(), testDef.expectedAddr)
+		}
+	}
+}
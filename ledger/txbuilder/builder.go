@@ -0,0 +1,681 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txbuilder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/conway"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+// maxBuildIterations limits the number of coin selection and fee calculation rounds when building a transaction
+const maxBuildIterations = 20
+
+// TxBuilder assembles Babbage and Conway era transactions. It selects additional inputs using the configured
+// coin selector, calculates the minimum fee, and adds a change output for any remaining value. The era of the
+// transaction is determined by the type of the protocol parameters
+type TxBuilder struct {
+	pparams            common.ProtocolParameters
+	txType             int
+	keyDeposit         uint64
+	poolDeposit        uint64
+	costModels         map[uint][]int64
+	inputs             []common.Utxo
+	referenceInputs    []common.Utxo
+	outputs            []TxOutput
+	mint               map[common.Blake2b224]map[cbor.ByteString]int64
+	certificates       []common.Certificate
+	certState          common.CertState
+	withdrawals        map[*common.Address]uint64
+	votingProcedures   common.VotingProcedures
+	proposalProcedures []common.ProposalProcedure
+	metadata           any
+	validityStart      uint64
+	ttl                uint64
+	requiredSigners    []common.Blake2b224
+	nativeScripts      []common.NativeScript
+	datums             []common.PlutusData
+	networkId          *uint8
+	donation           uint64
+	changeAddress      *common.Address
+	coinSelector       CoinSelector
+	availableUtxos     []common.Utxo
+	extraWitnesses     int
+}
+
+// NewTxBuilder returns a new TxBuilder using the provided Babbage or Conway protocol parameters
+func NewTxBuilder(pparams common.ProtocolParameters) (*TxBuilder, error) {
+	b := &TxBuilder{
+		pparams: pparams,
+	}
+	switch p := pparams.(type) {
+	case *babbage.BabbageProtocolParameters:
+		b.txType = babbage.TxTypeBabbage
+		b.keyDeposit = uint64(p.KeyDeposit)
+		b.poolDeposit = uint64(p.PoolDeposit)
+		b.costModels = p.CostModels
+	case *conway.ConwayProtocolParameters:
+		b.txType = conway.TxTypeConway
+		b.keyDeposit = uint64(p.KeyDeposit)
+		b.poolDeposit = uint64(p.PoolDeposit)
+		b.costModels = p.CostModels
+	default:
+		return nil, fmt.Errorf("unsupported protocol parameters type: %T", pparams)
+	}
+	return b, nil
+}
+
+// AddInput adds UTxOs to spend in the transaction
+func (b *TxBuilder) AddInput(utxos ...common.Utxo) *TxBuilder {
+	b.inputs = append(b.inputs, utxos...)
+	return b
+}
+
+// AddReferenceInput adds UTxOs to reference in the transaction
+func (b *TxBuilder) AddReferenceInput(utxos ...common.Utxo) *TxBuilder {
+	b.referenceInputs = append(b.referenceInputs, utxos...)
+	return b
+}
+
+// AddOutput adds outputs to the transaction
+func (b *TxBuilder) AddOutput(outputs ...TxOutput) *TxBuilder {
+	b.outputs = append(b.outputs, outputs...)
+	return b
+}
+
+// AddMint adds an asset to mint, or to burn if the amount is negative. The policy script must be provided
+// separately, such as with AddNativeScript
+func (b *TxBuilder) AddMint(policyId common.Blake2b224, assetName []byte, amount int64) *TxBuilder {
+	if b.mint == nil {
+		b.mint = make(map[common.Blake2b224]map[cbor.ByteString]int64)
+	}
+	if b.mint[policyId] == nil {
+		b.mint[policyId] = make(map[cbor.ByteString]int64)
+	}
+	b.mint[policyId][cbor.NewByteString(assetName)] += amount
+	return b
+}
+
+// AddNativeScript adds native scripts to the witness set
+func (b *TxBuilder) AddNativeScript(scripts ...common.NativeScript) *TxBuilder {
+	b.nativeScripts = append(b.nativeScripts, scripts...)
+	return b
+}
+
+// AddDatum adds datums to the witness set, such as the datums for outputs that only specify a datum hash.
+// The script data hash is calculated from the datums and set in the transaction body
+func (b *TxBuilder) AddDatum(datums ...common.PlutusData) *TxBuilder {
+	b.datums = append(b.datums, datums...)
+	return b
+}
+
+// AddCertificate adds certificates to the transaction. The CertType field of each certificate must be set.
+// Deposits use the KeyDeposit and PoolDeposit protocol parameters for certificates without an explicit amount.
+// Refunds and pool re-registrations are determined from the certificate state, which must be provided with
+// SetCertState for transactions that deregister stake credentials using pre-Conway certificates
+func (b *TxBuilder) AddCertificate(certs ...common.Certificate) *TxBuilder {
+	b.certificates = append(b.certificates, certs...)
+	return b
+}
+
+// AddWithdrawal adds a withdrawal from the specified reward account. Adding another withdrawal from the same
+// reward account replaces the amount
+func (b *TxBuilder) AddWithdrawal(rewardAddr common.Address, amount uint64) *TxBuilder {
+	if b.withdrawals == nil {
+		b.withdrawals = make(map[*common.Address]uint64)
+	}
+	for tmpAddr := range b.withdrawals {
+		if tmpAddr.String() == rewardAddr.String() {
+			b.withdrawals[tmpAddr] = amount
+			return b
+		}
+	}
+	b.withdrawals[&rewardAddr] = amount
+	return b
+}
+
+// AddVote adds a vote on a governance action. This is only supported for Conway transactions
+func (b *TxBuilder) AddVote(voter common.Voter, govActionId common.GovActionId, procedure common.VotingProcedure) *TxBuilder {
+	if b.votingProcedures == nil {
+		b.votingProcedures = make(common.VotingProcedures)
+	}
+	for tmpVoter, votes := range b.votingProcedures {
+		if *tmpVoter == voter {
+			votes[&govActionId] = procedure
+			return b
+		}
+	}
+	b.votingProcedures[&voter] = map[*common.GovActionId]common.VotingProcedure{
+		&govActionId: procedure,
+	}
+	return b
+}
+
+// AddProposal adds governance action proposals. This is only supported for Conway transactions
+func (b *TxBuilder) AddProposal(proposals ...common.ProposalProcedure) *TxBuilder {
+	b.proposalProcedures = append(b.proposalProcedures, proposals...)
+	return b
+}
+
+// SetMetadata sets the transaction metadata. The metadata must be encodable as CBOR
+func (b *TxBuilder) SetMetadata(metadata map[uint64]any) *TxBuilder {
+	b.metadata = metadata
+	return b
+}
+
+// SetValidityStart sets the first slot in which the transaction is valid
+func (b *TxBuilder) SetValidityStart(slot uint64) *TxBuilder {
+	b.validityStart = slot
+	return b
+}
+
+// SetTtl sets the slot from which the transaction is no longer valid
+func (b *TxBuilder) SetTtl(slot uint64) *TxBuilder {
+	b.ttl = slot
+	return b
+}
+
+// AddRequiredSigner adds key hashes that must sign the transaction
+func (b *TxBuilder) AddRequiredSigner(keyHashes ...common.Blake2b224) *TxBuilder {
+	b.requiredSigners = append(b.requiredSigners, keyHashes...)
+	return b
+}
+
+// SetNetworkId sets the network ID in the transaction body
+func (b *TxBuilder) SetNetworkId(networkId uint8) *TxBuilder {
+	b.networkId = &networkId
+	return b
+}
+
+// SetDonation sets the treasury donation. This is only supported for Conway transactions
+func (b *TxBuilder) SetDonation(amount uint64) *TxBuilder {
+	b.donation = amount
+	return b
+}
+
+// SetCertState sets the certificate state used to determine the deposits and refunds for the transaction
+// certificates. Without it, all stake credentials, pools and DReps are treated as unregistered
+func (b *TxBuilder) SetCertState(certState common.CertState) *TxBuilder {
+	b.certState = certState
+	return b
+}
+
+// SetChangeAddress sets the address that receives any remaining value
+func (b *TxBuilder) SetChangeAddress(addr common.Address) *TxBuilder {
+	b.changeAddress = &addr
+	return b
+}
+
+// SetCoinSelection sets the coin selector and the UTxOs that it can select from to fund the transaction
+func (b *TxBuilder) SetCoinSelection(selector CoinSelector, utxos []common.Utxo) *TxBuilder {
+	b.coinSelector = selector
+	b.availableUtxos = utxos
+	return b
+}
+
+// SetExtraWitnesses sets the number of vkey witnesses to account for in the fee in addition to those required by
+// the transaction inputs, certificates, withdrawals, votes and required signers. This is useful for native scripts
+// that require signatures
+func (b *TxBuilder) SetExtraWitnesses(count int) *TxBuilder {
+	b.extraWitnesses = count
+	return b
+}
+
+// Build builds the transaction. The returned transaction has no vkey witnesses
+func (b *TxBuilder) Build() (common.Transaction, error) {
+	if b.txType != conway.TxTypeConway {
+		if len(b.votingProcedures) > 0 || len(b.proposalProcedures) > 0 ||
+			b.donation > 0 {
+			return nil, errors.New("votes, proposals and donations require a Conway transaction")
+		}
+	}
+	if b.changeAddress == nil {
+		return nil, errors.New("change address not set")
+	}
+	outputs, err := b.outputsWithMinCoin()
+	if err != nil {
+		return nil, err
+	}
+	deposits, refunds, err := b.certDeposits()
+	if err != nil {
+		return nil, err
+	}
+	var selected []common.Utxo
+	var fee uint64
+	for range maxBuildIterations {
+		inputs := slices.Concat(b.inputs, selected)
+		consumed, produced, err := b.balance(inputs, outputs, deposits, refunds)
+		if err != nil {
+			return nil, err
+		}
+		produced, err = produced.Add(common.NewValue(fee, nil))
+		if err != nil {
+			return nil, err
+		}
+		surplus, deficit := consumed.Diff(produced)
+		missingCoin, missingAssets := deficit.Coin, deficit.Assets
+		changeOutput := TxOutput{
+			Address: *b.changeAddress,
+			Amount:  surplus.Coin,
+			Assets:  surplus.Assets,
+		}
+		minChange, err := b.minCoin(&changeOutput)
+		if err != nil {
+			return nil, err
+		}
+		if changeOutput.Assets != nil && changeOutput.Amount < minChange {
+			missingCoin += minChange - changeOutput.Amount
+		}
+		if missingCoin > 0 || missingAssets != nil {
+			if b.coinSelector == nil {
+				return nil, insufficientFundsError(missingCoin, missingAssets)
+			}
+			// Leave room for a change output
+			newUtxos, err := b.coinSelector.SelectCoins(
+				b.remainingUtxos(inputs),
+				missingCoin+minChange,
+				missingAssets,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if len(newUtxos) == 0 {
+				return nil, insufficientFundsError(missingCoin, missingAssets)
+			}
+			selected = append(selected, newUtxos...)
+			continue
+		}
+		txOutputs := outputs
+		txFee := fee
+		if changeOutput.Assets != nil || changeOutput.Amount >= minChange {
+			txOutputs = append(slices.Clone(outputs), changeOutput)
+		} else {
+			// Change that is too small for an output is added to the fee
+			txFee += changeOutput.Amount
+		}
+		body, err := b.buildBody(inputs, txOutputs, txFee)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := b.buildTx(body, 0)
+		if err != nil {
+			return nil, err
+		}
+		utxoState := b.utxoState(inputs)
+		witnessCount := len(shelley.RequiredKeyHashes(tx, utxoState)) + b.extraWitnesses
+		feeTx, err := b.buildTx(body, witnessCount)
+		if err != nil {
+			return nil, err
+		}
+		minFee, err := b.minFee(feeTx, utxoState)
+		if err != nil {
+			return nil, err
+		}
+		if txFee >= minFee {
+			return tx, nil
+		}
+		fee = minFee
+	}
+	return nil, errors.New("could not balance transaction")
+}
+
+// outputsWithMinCoin returns the outputs with any zero amounts set to the minimum amount for the output
+func (b *TxBuilder) outputsWithMinCoin() ([]TxOutput, error) {
+	ret := slices.Clone(b.outputs)
+	for idx := range ret {
+		if ret[idx].Amount > 0 {
+			continue
+		}
+		// The size of the output depends on the amount, so we repeat until it's stable
+		for {
+			minCoin, err := b.minCoin(&ret[idx])
+			if err != nil {
+				return nil, err
+			}
+			if ret[idx].Amount >= minCoin {
+				break
+			}
+			ret[idx].Amount = minCoin
+		}
+	}
+	return ret, nil
+}
+
+// balance returns the consumed and produced values, not including the fee or change
+func (b *TxBuilder) balance(inputs []common.Utxo, outputs []TxOutput, deposits uint64, refunds uint64) (common.Value, common.Value, error) {
+	var consumed, produced common.Value
+	if b.mint != nil {
+		mint := common.NewMultiAsset(b.mint)
+		consumed, produced = common.NewValueFromMint(&mint)
+	}
+	consumedCoins := []uint64{refunds}
+	for _, amount := range b.withdrawals {
+		consumedCoins = append(consumedCoins, amount)
+	}
+	producedCoins := []uint64{deposits, b.donation}
+	for _, proposal := range b.proposalProcedures {
+		producedCoins = append(producedCoins, proposal.Deposit)
+	}
+	var err error
+	for _, coin := range consumedCoins {
+		consumed, err = consumed.Add(common.Value{Coin: coin})
+		if err != nil {
+			return common.Value{}, common.Value{}, err
+		}
+	}
+	for _, coin := range producedCoins {
+		produced, err = produced.Add(common.Value{Coin: coin})
+		if err != nil {
+			return common.Value{}, common.Value{}, err
+		}
+	}
+	for _, utxo := range inputs {
+		consumed, err = consumed.Add(common.NewValueFromOutput(utxo.Output))
+		if err != nil {
+			return common.Value{}, common.Value{}, err
+		}
+	}
+	for _, output := range outputs {
+		produced, err = produced.Add(common.NewValue(output.Amount, output.Assets))
+		if err != nil {
+			return common.Value{}, common.Value{}, err
+		}
+	}
+	return consumed, produced, nil
+}
+
+// certDeposits returns the total deposits and refunds for the certificates
+func (b *TxBuilder) certDeposits() (uint64, uint64, error) {
+	if len(b.certificates) == 0 {
+		return 0, 0, nil
+	}
+	certState := b.certState
+	if certState == nil {
+		for _, cert := range b.certificates {
+			if _, ok := cert.(*common.StakeDeregistrationCertificate); ok {
+				return 0, 0, errors.New("certificate state is required to determine the stake deregistration refund")
+			}
+		}
+		certState = emptyCertState{}
+	}
+	// The deposits only depend on the certificates and withdrawals, so the inputs and outputs are left empty
+	body, err := b.buildBody(nil, nil, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	tx, err := b.buildTx(body, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	return common.TxCertDeposits(tx, certState, b.keyDeposit, b.poolDeposit)
+}
+
+// remainingUtxos returns the available UTxOs that are not already used as inputs
+func (b *TxBuilder) remainingUtxos(inputs []common.Utxo) []common.Utxo {
+	used := make(map[string]bool)
+	for _, utxo := range inputs {
+		used[utxo.Id.String()] = true
+	}
+	var ret []common.Utxo
+	for _, utxo := range b.availableUtxos {
+		if !used[utxo.Id.String()] {
+			ret = append(ret, utxo)
+		}
+	}
+	return ret
+}
+
+func (b *TxBuilder) utxoState(inputs []common.Utxo) utxoState {
+	ret := make(utxoState)
+	for _, utxo := range slices.Concat(inputs, b.referenceInputs) {
+		ret[utxo.Id.String()] = utxo
+	}
+	return ret
+}
+
+func (b *TxBuilder) minCoin(output *TxOutput) (uint64, error) {
+	babbageOutput, err := output.babbageOutput()
+	if err != nil {
+		return 0, err
+	}
+	if b.txType == conway.TxTypeConway {
+		return conway.MinCoinTxOut(babbageOutput, b.pparams)
+	}
+	return babbage.MinCoinTxOut(babbageOutput, b.pparams)
+}
+
+func (b *TxBuilder) minFee(tx common.Transaction, utxoState common.UtxoState) (uint64, error) {
+	if b.txType == conway.TxTypeConway {
+		return conway.MinFeeTx(tx, utxoState, b.pparams)
+	}
+	return babbage.MinFeeTx(tx, b.pparams)
+}
+
+// buildBody returns the transaction body with the specified inputs, outputs and fee
+func (b *TxBuilder) buildBody(inputs []common.Utxo, outputs []TxOutput, fee uint64) (map[uint]any, error) {
+	body := map[uint]any{
+		0: encodeInputs(inputs),
+		1: outputs,
+		2: fee,
+	}
+	if b.ttl > 0 {
+		body[3] = b.ttl
+	}
+	if len(b.certificates) > 0 {
+		body[4] = b.certificates
+	}
+	if len(b.withdrawals) > 0 {
+		body[5] = b.withdrawals
+	}
+	if b.metadata != nil {
+		auxDataCbor, err := cbor.Encode(b.metadata)
+		if err != nil {
+			return nil, err
+		}
+		body[7] = common.Blake2b256Hash(auxDataCbor).Bytes()
+	}
+	if b.validityStart > 0 {
+		body[8] = b.validityStart
+	}
+	if len(b.mint) > 0 {
+		mint := common.NewMultiAsset(b.mint)
+		body[9] = &mint
+	}
+	if len(b.requiredSigners) > 0 {
+		requiredSigners := make([][]byte, 0, len(b.requiredSigners))
+		for _, keyHash := range b.requiredSigners {
+			requiredSigners = append(requiredSigners, keyHash.Bytes())
+		}
+		body[14] = requiredSigners
+	}
+	scriptDataHash, err := b.scriptDataHash()
+	if err != nil {
+		return nil, err
+	}
+	if scriptDataHash != nil {
+		body[11] = scriptDataHash.Bytes()
+	}
+	if b.networkId != nil {
+		body[15] = *b.networkId
+	}
+	if len(b.referenceInputs) > 0 {
+		body[18] = encodeInputs(b.referenceInputs)
+	}
+	if len(b.votingProcedures) > 0 {
+		body[19] = b.votingProcedures
+	}
+	if len(b.proposalProcedures) > 0 {
+		proposals := make([]*common.ProposalProcedure, 0, len(b.proposalProcedures))
+		for idx := range b.proposalProcedures {
+			proposals = append(proposals, &b.proposalProcedures[idx])
+		}
+		body[20] = proposals
+	}
+	if b.donation > 0 {
+		body[22] = b.donation
+	}
+	return body, nil
+}
+
+// buildTx encodes and decodes the transaction with the provided body. The specified number of placeholder vkey
+// witnesses are added, which is used to account for the size of the witnesses when calculating the fee
+func (b *TxBuilder) buildTx(body map[uint]any, vkeyWitnessCount int) (common.Transaction, error) {
+	witnessSet := map[uint]any{}
+	if vkeyWitnessCount > 0 {
+		vkeyWitnesses := make([]any, 0, vkeyWitnessCount)
+		for range vkeyWitnessCount {
+			vkeyWitnesses = append(
+				vkeyWitnesses,
+				[]any{make([]byte, 32), make([]byte, 64)},
+			)
+		}
+		witnessSet[0] = vkeyWitnesses
+	}
+	if len(b.nativeScripts) > 0 {
+		nativeScripts := make([]cbor.RawMessage, 0, len(b.nativeScripts))
+		for _, script := range b.nativeScripts {
+			nativeScripts = append(nativeScripts, cbor.RawMessage(script.Cbor()))
+		}
+		witnessSet[1] = nativeScripts
+	}
+	datums, err := b.witnessDatums()
+	if err != nil {
+		return nil, err
+	}
+	if datums != nil {
+		witnessSet[4] = cbor.RawMessage(datums)
+	}
+	txCbor, err := cbor.Encode(
+		[]any{
+			body,
+			witnessSet,
+			true,
+			b.metadata,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if b.txType == conway.TxTypeConway {
+		return conway.NewConwayTransactionFromCbor(txCbor)
+	}
+	return babbage.NewBabbageTransactionFromCbor(txCbor)
+}
+
+// witnessDatums returns the CBOR encoding of the witness set datums, or nil if there are none
+func (b *TxBuilder) witnessDatums() ([]byte, error) {
+	if len(b.datums) == 0 {
+		return nil, nil
+	}
+	datums := make([]cbor.RawMessage, 0, len(b.datums))
+	for _, datum := range b.datums {
+		datumCbor, err := cbor.Encode(datum)
+		if err != nil {
+			return nil, err
+		}
+		datums = append(datums, cbor.RawMessage(datumCbor))
+	}
+	return cbor.Encode(datums)
+}
+
+// scriptDataHash returns the script data hash for the witness set datums, or nil if there are none. The
+// transaction has no redeemers, which are encoded as an empty list for Babbage and an empty map for Conway
+func (b *TxBuilder) scriptDataHash() (*common.Blake2b256, error) {
+	datums, err := b.witnessDatums()
+	if err != nil || datums == nil {
+		return nil, err
+	}
+	redeemers := []byte{0x80}
+	if b.txType == conway.TxTypeConway {
+		redeemers = []byte{0xa0}
+	}
+	return common.CalculateScriptDataHash(b.costModels, nil, redeemers, datums)
+}
+
+// encodeInputs returns the inputs of the provided UTxOs in the order used by the ledger
+func encodeInputs(utxos []common.Utxo) []any {
+	inputs := make([]common.TransactionInput, 0, len(utxos))
+	for _, utxo := range utxos {
+		inputs = append(inputs, utxo.Id)
+	}
+	slices.SortFunc(
+		inputs,
+		func(a, b common.TransactionInput) int {
+			if ret := bytes.Compare(a.Id().Bytes(), b.Id().Bytes()); ret != 0 {
+				return ret
+			}
+			return int(a.Index()) - int(b.Index())
+		},
+	)
+	ret := make([]any, 0, len(inputs))
+	for _, input := range inputs {
+		ret = append(ret, []any{input.Id().Bytes(), input.Index()})
+	}
+	return ret
+}
+
+func insufficientFundsError(missingCoin uint64, missingAssets *common.MultiAsset[common.MultiAssetTypeOutput]) error {
+	if missingAssets != nil {
+		for _, policyId := range missingAssets.Policies() {
+			for _, assetName := range missingAssets.Assets(policyId) {
+				return InsufficientFundsError{
+					PolicyId:  &policyId,
+					AssetName: assetName,
+					Required:  missingAssets.Asset(policyId, assetName),
+				}
+			}
+		}
+	}
+	return InsufficientFundsError{
+		Required: missingCoin,
+	}
+}
+
+// utxoState provides lookups of the UTxOs known to the builder
+type utxoState map[string]common.Utxo
+
+func (s utxoState) UtxoById(id common.TransactionInput) (common.Utxo, error) {
+	utxo, ok := s[id.String()]
+	if !ok {
+		return common.Utxo{}, fmt.Errorf("UTxO not found: %s", id.String())
+	}
+	return utxo, nil
+}
+
+// emptyCertState is used when no certificate state is provided, and treats everything as unregistered
+type emptyCertState struct{}
+
+func (emptyCertState) StakeRegistration(common.Blake2b224) (*common.StakeRegistration, error) {
+	return nil, nil
+}
+
+func (emptyCertState) PoolRegistration(common.PoolKeyHash) (*common.PoolRegistration, error) {
+	return nil, nil
+}
+
+func (emptyCertState) DrepRegistration(common.Blake2b224) (*common.DrepRegistration, error) {
+	return nil, nil
+}
+
+func (emptyCertState) CommitteeMember(common.Blake2b224) (*common.CommitteeMember, error) {
+	return nil, nil
+}
@@ -0,0 +1,422 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txbuilder_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/conway"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+	"github.com/blinklabs-io/gouroboros/ledger/txbuilder"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testTxId       = "d228b482a1aae768e4a796380f49e021d9c21f70d3c12cb186b188dedfc0ee22"
+	testAddress    = "addr1qytna5k2fq9ler0fuk45j7zfwv7t2zwhp777nvdjqqfr5tz8ztpwnk8zq5ngetcz5k5mckgkajnygtsra9aej2h3ek5seupmvd"
+	testPolicyId   = "29a8fb8318718bd756124f0c144f56d4b4579dc5edf2dd42d669ac61"
+	testAssetName  = "test"
+	testOutputCoin = 5_000_000
+)
+
+func testPparams() *conway.ConwayProtocolParameters {
+	return &conway.ConwayProtocolParameters{
+		MinFeeA:        44,
+		MinFeeB:        155381,
+		MaxTxSize:      16384,
+		KeyDeposit:     2_000_000,
+		PoolDeposit:    500_000_000,
+		AdaPerUtxoByte: 4310,
+		MaxValueSize:   5000,
+	}
+}
+
+func testUtxo(t *testing.T, index uint32, amount uint64, assets *common.MultiAsset[common.MultiAssetTypeOutput]) common.Utxo {
+	addr, err := common.NewAddress(testAddress)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	output := &babbage.BabbageTransactionOutput{
+		OutputAddress: addr,
+		OutputAmount: mary.MaryTransactionOutputValue{
+			Amount: amount,
+			Assets: assets,
+		},
+	}
+	return common.Utxo{
+		Id:     shelley.NewShelleyTransactionInput(testTxId, int(index)),
+		Output: output,
+	}
+}
+
+func testAssets(amount uint64) *common.MultiAsset[common.MultiAssetTypeOutput] {
+	assets := common.NewMultiAsset(
+		map[common.Blake2b224]map[cbor.ByteString]common.MultiAssetTypeOutput{
+			common.NewBlake2b224(testHexBytes(testPolicyId)): {
+				cbor.NewByteString([]byte(testAssetName)): amount,
+			},
+		},
+	)
+	return &assets
+}
+
+func testHexBytes(data string) []byte {
+	ret, _ := hex.DecodeString(data)
+	return ret
+}
+
+// testLedgerState provides the UTxOs used by a transaction for validating the built transaction
+type testLedgerState struct {
+	utxos              []common.Utxo
+	stakeRegistrations map[common.Blake2b224]*common.StakeRegistration
+}
+
+func (ls testLedgerState) NetworkId() uint {
+	return common.AddressNetworkMainnet
+}
+
+func (ls testLedgerState) UtxoById(id common.TransactionInput) (common.Utxo, error) {
+	for _, tmpUtxo := range ls.utxos {
+		if id.String() == tmpUtxo.Id.String() {
+			return tmpUtxo, nil
+		}
+	}
+	return common.Utxo{}, errors.New("not found")
+}
+
+func (ls testLedgerState) StakeRegistration(cred common.Blake2b224) (*common.StakeRegistration, error) {
+	return ls.stakeRegistrations[cred], nil
+}
+
+func (ls testLedgerState) PoolRegistration(common.PoolKeyHash) (*common.PoolRegistration, error) {
+	return nil, nil
+}
+
+func (ls testLedgerState) DrepRegistration(common.Blake2b224) (*common.DrepRegistration, error) {
+	return nil, nil
+}
+
+func (ls testLedgerState) CommitteeMember(common.Blake2b224) (*common.CommitteeMember, error) {
+	return nil, nil
+}
+
+// testValidateTx checks the built transaction against the Conway UTxO rules that the builder is responsible for
+func testValidateTx(t *testing.T, tx common.Transaction, utxos []common.Utxo) {
+	testValidateTxWithState(t, tx, testLedgerState{utxos: utxos})
+}
+
+func testValidateTxWithState(t *testing.T, tx common.Transaction, ls testLedgerState) {
+	pp := testPparams()
+	for _, rule := range []common.UtxoValidationRuleFunc{
+		conway.UtxoValidateInputSetEmptyUtxo,
+		conway.UtxoValidateBadInputsUtxo,
+		conway.UtxoValidateFeeTooSmallUtxo,
+		conway.UtxoValidateValueNotConservedUtxo,
+		conway.UtxoValidateOutputTooSmallUtxo,
+		conway.UtxoValidateMaxTxSizeUtxo,
+	} {
+		if err := rule(tx, 0, ls, pp); err != nil {
+			t.Fatalf("built transaction should pass validation\n  got error: %v", err)
+		}
+	}
+}
+
+func TestBuildSimple(t *testing.T) {
+	addr, _ := common.NewAddress(testAddress)
+	inputUtxo := testUtxo(t, 0, 100_000_000, nil)
+	builder, err := txbuilder.NewTxBuilder(testPparams())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tx, err := builder.
+		AddInput(inputUtxo).
+		AddOutput(txbuilder.TxOutput{Address: addr, Amount: testOutputCoin}).
+		SetChangeAddress(addr).
+		SetTtl(1000).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.IsType(t, &conway.ConwayTransaction{}, tx)
+	outputs := tx.Outputs()
+	if len(outputs) != 2 {
+		t.Fatalf("did not get expected number of outputs: got %d, wanted 2", len(outputs))
+	}
+	if outputs[0].Amount() != testOutputCoin {
+		t.Errorf("did not get expected output amount: got %d, wanted %d", outputs[0].Amount(), testOutputCoin)
+	}
+	if outputs[1].Amount()+tx.Fee() != 100_000_000-testOutputCoin {
+		t.Errorf("change and fee do not add up: change %d, fee %d", outputs[1].Amount(), tx.Fee())
+	}
+	if tx.TTL() != 1000 {
+		t.Errorf("did not get expected TTL: got %d, wanted 1000", tx.TTL())
+	}
+	testValidateTx(t, tx, []common.Utxo{inputUtxo})
+}
+
+func TestBuildMinCoinOutput(t *testing.T) {
+	addr, _ := common.NewAddress(testAddress)
+	inputUtxo := testUtxo(t, 0, 100_000_000, testAssets(100))
+	builder, _ := txbuilder.NewTxBuilder(testPparams())
+	tx, err := builder.
+		AddInput(inputUtxo).
+		AddOutput(txbuilder.TxOutput{Address: addr, Assets: testAssets(40)}).
+		SetChangeAddress(addr).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	outputs := tx.Outputs()
+	if len(outputs) != 2 {
+		t.Fatalf("did not get expected number of outputs: got %d, wanted 2", len(outputs))
+	}
+	policyId := common.NewBlake2b224(testHexBytes(testPolicyId))
+	if amount := outputs[1].Assets().Asset(policyId, []byte(testAssetName)); amount != 60 {
+		t.Errorf("did not get expected change assets: got %d, wanted 60", amount)
+	}
+	minCoin, err := conway.MinCoinTxOut(outputs[0], testPparams())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if outputs[0].Amount() != minCoin {
+		t.Errorf("output amount should be the minimum: got %d, wanted %d", outputs[0].Amount(), minCoin)
+	}
+	testValidateTx(t, tx, []common.Utxo{inputUtxo})
+}
+
+func TestBuildCoinSelection(t *testing.T) {
+	addr, _ := common.NewAddress(testAddress)
+	availableUtxos := []common.Utxo{
+		testUtxo(t, 0, 2_000_000, nil),
+		testUtxo(t, 1, 3_000_000, nil),
+		testUtxo(t, 2, 10_000_000, nil),
+		testUtxo(t, 3, 1_500_000, testAssets(10)),
+	}
+	t.Run("largest first", func(t *testing.T) {
+		builder, _ := txbuilder.NewTxBuilder(testPparams())
+		tx, err := builder.
+			AddOutput(txbuilder.TxOutput{Address: addr, Amount: 11_000_000, Assets: testAssets(5)}).
+			SetChangeAddress(addr).
+			SetCoinSelection(txbuilder.LargestFirst{}, availableUtxos).
+			Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		testValidateTx(t, tx, availableUtxos)
+	})
+	t.Run("insufficient funds", func(t *testing.T) {
+		builder, _ := txbuilder.NewTxBuilder(testPparams())
+		_, err := builder.
+			AddOutput(txbuilder.TxOutput{Address: addr, Amount: 20_000_000}).
+			SetChangeAddress(addr).
+			SetCoinSelection(txbuilder.LargestFirst{}, availableUtxos).
+			Build()
+		assert.IsType(t, txbuilder.InsufficientFundsError{}, err)
+	})
+	t.Run("no coin selection", func(t *testing.T) {
+		builder, _ := txbuilder.NewTxBuilder(testPparams())
+		_, err := builder.
+			AddInput(availableUtxos[0]).
+			AddOutput(txbuilder.TxOutput{Address: addr, Amount: testOutputCoin}).
+			SetChangeAddress(addr).
+			Build()
+		assert.IsType(t, txbuilder.InsufficientFundsError{}, err)
+	})
+}
+
+func TestBuildDeposits(t *testing.T) {
+	addr, _ := common.NewAddress(testAddress)
+	inputUtxo := testUtxo(t, 0, 100_000_000, nil)
+	builder, _ := txbuilder.NewTxBuilder(testPparams())
+	tx, err := builder.
+		AddInput(inputUtxo).
+		AddCertificate(
+			&common.StakeRegistrationCertificate{
+				CertType: common.CertificateTypeStakeRegistration,
+				StakeRegistration: common.StakeCredential{
+					Credential: make([]byte, 28),
+				},
+			},
+		).
+		SetChangeAddress(addr).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	outputs := tx.Outputs()
+	if outputs[0].Amount()+tx.Fee()+2_000_000 != 100_000_000 {
+		t.Errorf("change, fee and deposit do not add up: change %d, fee %d", outputs[0].Amount(), tx.Fee())
+	}
+	testValidateTx(t, tx, []common.Utxo{inputUtxo})
+}
+
+func TestBuildRefunds(t *testing.T) {
+	addr, _ := common.NewAddress(testAddress)
+	inputUtxo := testUtxo(t, 0, 100_000_000, nil)
+	stakeCred := common.StakeCredential{
+		Credential: make([]byte, 28),
+	}
+	ls := testLedgerState{
+		utxos: []common.Utxo{inputUtxo},
+		stakeRegistrations: map[common.Blake2b224]*common.StakeRegistration{
+			common.NewBlake2b224(stakeCred.Credential): {Deposit: 2_000_000},
+		},
+	}
+	testDefs := []struct {
+		name string
+		cert common.Certificate
+	}{
+		{
+			name: "stake deregistration",
+			cert: &common.StakeDeregistrationCertificate{
+				CertType:            common.CertificateTypeStakeDeregistration,
+				StakeDeregistration: stakeCred,
+			},
+		},
+		{
+			name: "Conway deregistration",
+			cert: &common.DeregistrationCertificate{
+				CertType:        common.CertificateTypeDeregistration,
+				StakeCredential: stakeCred,
+				Amount:          2_000_000,
+			},
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			builder, _ := txbuilder.NewTxBuilder(testPparams())
+			tx, err := builder.
+				AddInput(inputUtxo).
+				AddCertificate(testDef.cert).
+				SetCertState(ls).
+				SetChangeAddress(addr).
+				Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			outputs := tx.Outputs()
+			if outputs[0].Amount()+tx.Fee() != 100_000_000+2_000_000 {
+				t.Errorf("change, fee and refund do not add up: change %d, fee %d", outputs[0].Amount(), tx.Fee())
+			}
+			testValidateTxWithState(t, tx, ls)
+		})
+	}
+	t.Run("no cert state", func(t *testing.T) {
+		builder, _ := txbuilder.NewTxBuilder(testPparams())
+		_, err := builder.
+			AddInput(inputUtxo).
+			AddCertificate(testDefs[0].cert).
+			SetChangeAddress(addr).
+			Build()
+		if err == nil {
+			t.Fatalf("did not get expected error")
+		}
+	})
+}
+
+func TestBuildWithdrawals(t *testing.T) {
+	addr, _ := common.NewAddress(testAddress)
+	rewardAddr := addr.StakeAddress()
+	inputUtxo := testUtxo(t, 0, 100_000_000, nil)
+	builder, _ := txbuilder.NewTxBuilder(testPparams())
+	tx, err := builder.
+		AddInput(inputUtxo).
+		AddWithdrawal(*rewardAddr, 1_000_000).
+		AddWithdrawal(*addr.StakeAddress(), 3_000_000).
+		SetChangeAddress(addr).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	withdrawals := tx.Withdrawals()
+	if len(withdrawals) != 1 {
+		t.Fatalf("did not get expected number of withdrawals: got %d, wanted 1", len(withdrawals))
+	}
+	for tmpAddr, amount := range withdrawals {
+		if tmpAddr.String() != rewardAddr.String() || amount != 3_000_000 {
+			t.Errorf("did not get expected withdrawal: got %s %d", tmpAddr.String(), amount)
+		}
+	}
+	outputs := tx.Outputs()
+	if outputs[0].Amount()+tx.Fee() != 100_000_000+3_000_000 {
+		t.Errorf("change, fee and withdrawal do not add up: change %d, fee %d", outputs[0].Amount(), tx.Fee())
+	}
+}
+
+func TestBuildWithdrawalOverflow(t *testing.T) {
+	addr, _ := common.NewAddress(testAddress)
+	inputUtxo := testUtxo(t, 0, 100_000_000, nil)
+	builder, _ := txbuilder.NewTxBuilder(testPparams())
+	_, err := builder.
+		AddInput(inputUtxo).
+		AddWithdrawal(*addr.StakeAddress(), math.MaxUint64).
+		SetChangeAddress(addr).
+		Build()
+	if !errors.Is(err, common.ErrValueOverflow) {
+		t.Fatalf("did not get expected overflow error: %v", err)
+	}
+}
+
+func TestBuildDatums(t *testing.T) {
+	addr, _ := common.NewAddress(testAddress)
+	inputUtxo := testUtxo(t, 0, 100_000_000, nil)
+	datum := common.NewPlutusConstr(0, common.NewPlutusInt(42), common.NewPlutusBytes([]byte("test")))
+	pparams := testPparams()
+	pparams.CostModels = map[uint][]int64{1: {4, -5}}
+	builder, _ := txbuilder.NewTxBuilder(pparams)
+	tx, err := builder.
+		AddInput(inputUtxo).
+		AddDatum(datum).
+		SetChangeAddress(addr).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tx.Witnesses().PlutusData()) != 1 {
+		t.Fatalf("did not get expected witness datums")
+	}
+	if tx.ScriptDataHash() == nil {
+		t.Fatalf("did not get expected script data hash")
+	}
+	testValidateTx(t, tx, []common.Utxo{inputUtxo})
+	err = conway.UtxoValidateScriptDataHash(tx, 0, testLedgerState{utxos: []common.Utxo{inputUtxo}}, pparams)
+	if err != nil {
+		t.Fatalf("built transaction should pass validation\n  got error: %v", err)
+	}
+}
+
+func TestBuildBabbageConwayOnlyFields(t *testing.T) {
+	addr, _ := common.NewAddress(testAddress)
+	builder, err := txbuilder.NewTxBuilder(&babbage.BabbageProtocolParameters{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = builder.
+		AddInput(testUtxo(t, 0, 100_000_000, nil)).
+		SetDonation(1_000_000).
+		SetChangeAddress(addr).
+		Build()
+	if err == nil {
+		t.Fatalf("did not get expected error")
+	}
+}
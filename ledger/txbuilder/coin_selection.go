@@ -0,0 +1,203 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txbuilder
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// CoinSelector selects UTxOs to fund a transaction
+type CoinSelector interface {
+	// SelectCoins returns a subset of the available UTxOs that contains at least the specified lovelace and assets
+	SelectCoins(available []common.Utxo, coin uint64, assets *common.MultiAsset[common.MultiAssetTypeOutput]) ([]common.Utxo, error)
+}
+
+// LargestFirst is a coin selector that selects the UTxOs with the largest amounts first. Each asset is covered
+// first, followed by the lovelace
+type LargestFirst struct{}
+
+func (LargestFirst) SelectCoins(available []common.Utxo, coin uint64, assets *common.MultiAsset[common.MultiAssetTypeOutput]) ([]common.Utxo, error) {
+	remaining := slices.Clone(available)
+	var selected []common.Utxo
+	selectLargest := func(target uint64, amountFunc func(common.Utxo) uint64) bool {
+		have := sumUtxos(selected, amountFunc)
+		if have >= target {
+			return true
+		}
+		slices.SortStableFunc(
+			remaining,
+			func(a, b common.Utxo) int {
+				amountA, amountB := amountFunc(a), amountFunc(b)
+				if amountA > amountB {
+					return -1
+				}
+				if amountA < amountB {
+					return 1
+				}
+				return 0
+			},
+		)
+		for len(remaining) > 0 && have < target {
+			amount := amountFunc(remaining[0])
+			if amount == 0 {
+				break
+			}
+			have += amount
+			selected = append(selected, remaining[0])
+			remaining = remaining[1:]
+		}
+		return have >= target
+	}
+	if err := selectTargets(coin, assets, selectLargest); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// RandomImprove is a coin selector that implements the Random-Improve algorithm from CIP-2. UTxOs are selected
+// randomly until each asset and the lovelace are covered, and then more UTxOs are added to get closer to twice the
+// target amount, which leaves change outputs that are useful for future transactions
+type RandomImprove struct {
+	// Rand is the source of randomness. If not set, the global source is used
+	Rand *rand.Rand
+}
+
+func (r RandomImprove) SelectCoins(available []common.Utxo, coin uint64, assets *common.MultiAsset[common.MultiAssetTypeOutput]) ([]common.Utxo, error) {
+	shuffle := rand.Shuffle
+	if r.Rand != nil {
+		shuffle = r.Rand.Shuffle
+	}
+	remaining := slices.Clone(available)
+	var selected []common.Utxo
+	selectRandom := func(target uint64, amountFunc func(common.Utxo) uint64) bool {
+		have := sumUtxos(selected, amountFunc)
+		shuffle(
+			len(remaining),
+			func(i, j int) {
+				remaining[i], remaining[j] = remaining[j], remaining[i]
+			},
+		)
+		// Random selection phase
+		var unused []common.Utxo
+		for idx, utxo := range remaining {
+			if have >= target {
+				unused = append(unused, remaining[idx:]...)
+				break
+			}
+			amount := amountFunc(utxo)
+			if amount == 0 {
+				unused = append(unused, utxo)
+				continue
+			}
+			have += amount
+			selected = append(selected, utxo)
+		}
+		remaining = unused
+		if have < target {
+			return false
+		}
+		// Improvement phase
+		// Add UTxOs that bring the total closer to the ideal amount without exceeding the maximum amount
+		idealAmount := target * 2
+		maxAmount := target * 3
+		unused = nil
+		for _, utxo := range remaining {
+			amount := amountFunc(utxo)
+			if amount == 0 || have >= idealAmount || have+amount > maxAmount ||
+				absDiff(idealAmount, have+amount) >= absDiff(idealAmount, have) {
+				unused = append(unused, utxo)
+				continue
+			}
+			have += amount
+			selected = append(selected, utxo)
+		}
+		remaining = unused
+		return true
+	}
+	if err := selectTargets(coin, assets, selectRandom); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// selectTargets calls the provided selection function for each asset in a stable order, followed by the lovelace
+func selectTargets(
+	coin uint64,
+	assets *common.MultiAsset[common.MultiAssetTypeOutput],
+	selectFunc func(uint64, func(common.Utxo) uint64) bool,
+) error {
+	if assets != nil {
+		policies := assets.Policies()
+		slices.SortFunc(
+			policies,
+			func(a, b common.Blake2b224) int {
+				return bytes.Compare(a.Bytes(), b.Bytes())
+			},
+		)
+		for _, policyId := range policies {
+			assetNames := assets.Assets(policyId)
+			slices.SortFunc(assetNames, bytes.Compare)
+			for _, assetName := range assetNames {
+				target := assets.Asset(policyId, assetName)
+				if !selectFunc(target, utxoAssetAmountFunc(policyId, assetName)) {
+					return InsufficientFundsError{
+						PolicyId:  &policyId,
+						AssetName: assetName,
+						Required:  target,
+					}
+				}
+			}
+		}
+	}
+	if !selectFunc(coin, utxoCoin) {
+		return InsufficientFundsError{
+			Required: coin,
+		}
+	}
+	return nil
+}
+
+func utxoCoin(utxo common.Utxo) uint64 {
+	return utxo.Output.Amount()
+}
+
+func utxoAssetAmountFunc(policyId common.Blake2b224, assetName []byte) func(common.Utxo) uint64 {
+	return func(utxo common.Utxo) uint64 {
+		assets := utxo.Output.Assets()
+		if assets == nil {
+			return 0
+		}
+		return assets.Asset(policyId, assetName)
+	}
+}
+
+func sumUtxos(utxos []common.Utxo, amountFunc func(common.Utxo) uint64) uint64 {
+	var ret uint64
+	for _, utxo := range utxos {
+		ret += amountFunc(utxo)
+	}
+	return ret
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
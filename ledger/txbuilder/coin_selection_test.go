@@ -0,0 +1,81 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txbuilder_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/txbuilder"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoinSelection(t *testing.T) {
+	policyId := common.NewBlake2b224(testHexBytes(testPolicyId))
+	availableUtxos := []common.Utxo{
+		testUtxo(t, 0, 1_000_000, nil),
+		testUtxo(t, 1, 4_000_000, nil),
+		testUtxo(t, 2, 2_000_000, testAssets(50)),
+		testUtxo(t, 3, 8_000_000, nil),
+		testUtxo(t, 4, 3_000_000, nil),
+	}
+	selectors := map[string]txbuilder.CoinSelector{
+		"largest first":  txbuilder.LargestFirst{},
+		"random improve": txbuilder.RandomImprove{Rand: rand.New(rand.NewSource(1))},
+	}
+	for name, selector := range selectors {
+		t.Run(name, func(t *testing.T) {
+			selected, err := selector.SelectCoins(availableUtxos, 10_000_000, testAssets(20))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var coin, assets uint64
+			for _, utxo := range selected {
+				coin += utxo.Output.Amount()
+				if utxo.Output.Assets() != nil {
+					assets += utxo.Output.Assets().Asset(policyId, []byte(testAssetName))
+				}
+			}
+			if coin < 10_000_000 {
+				t.Errorf("selected UTxOs do not cover lovelace: got %d", coin)
+			}
+			if assets < 20 {
+				t.Errorf("selected UTxOs do not cover assets: got %d", assets)
+			}
+			_, err = selector.SelectCoins(availableUtxos, 100_000_000, nil)
+			assert.IsType(t, txbuilder.InsufficientFundsError{}, err)
+			_, err = selector.SelectCoins(availableUtxos, 0, testAssets(100))
+			assert.IsType(t, txbuilder.InsufficientFundsError{}, err)
+		})
+	}
+}
+
+func TestLargestFirstOrder(t *testing.T) {
+	availableUtxos := []common.Utxo{
+		testUtxo(t, 0, 1_000_000, nil),
+		testUtxo(t, 1, 8_000_000, nil),
+		testUtxo(t, 2, 4_000_000, nil),
+	}
+	selected, err := txbuilder.LargestFirst{}.SelectCoins(availableUtxos, 10_000_000, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(selected) != 2 || selected[0].Output.Amount() != 8_000_000 ||
+		selected[1].Output.Amount() != 4_000_000 {
+		t.Errorf("did not select largest UTxOs first: %v", selected)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txbuilder
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+type InsufficientFundsError struct {
+	// PolicyId and AssetName identify the missing asset, and are empty for missing lovelace
+	PolicyId  *common.Blake2b224
+	AssetName []byte
+	Required  uint64
+}
+
+func (e InsufficientFundsError) Error() string {
+	if e.PolicyId != nil {
+		return fmt.Sprintf(
+			"insufficient funds: required %d of asset %s.%s",
+			e.Required,
+			e.PolicyId.String(),
+			hex.EncodeToString(e.AssetName),
+		)
+	}
+	return fmt.Sprintf(
+		"insufficient funds: required %d lovelace",
+		e.Required,
+	)
+}
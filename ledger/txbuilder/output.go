@@ -0,0 +1,89 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txbuilder
+
+import (
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// TxOutput represents a transaction output to be added to a transaction
+type TxOutput struct {
+	Address common.Address
+	// Amount is the amount of lovelace in the output. If zero, it will be set to the minimum amount for the output
+	Amount uint64
+	Assets *common.MultiAsset[common.MultiAssetTypeOutput]
+	// DatumHash is the hash of a datum to attach to the output
+	DatumHash *common.Blake2b256
+	// Datum is the CBOR of an inline datum to attach to the output
+	Datum []byte
+	// ScriptRef is a reference script to attach to the output
+	ScriptRef *TxOutputScriptRef
+}
+
+// TxOutputScriptRef represents a reference script in a transaction output
+type TxOutputScriptRef struct {
+	// Type is one of the babbage.ScriptRefType* constants
+	Type uint
+	// Script is the CBOR of a native script or the bytes of a Plutus script
+	Script []byte
+}
+
+func (o *TxOutput) MarshalCBOR() ([]byte, error) {
+	tmpOutput := map[uint]any{
+		0: &o.Address,
+	}
+	if o.Assets == nil || len(o.Assets.Policies()) == 0 {
+		tmpOutput[1] = o.Amount
+	} else {
+		tmpOutput[1] = []any{o.Amount, o.Assets}
+	}
+	if o.DatumHash != nil {
+		tmpOutput[2] = []any{babbage.DatumOptionTypeHash, o.DatumHash.Bytes()}
+	} else if o.Datum != nil {
+		tmpOutput[2] = []any{
+			babbage.DatumOptionTypeData,
+			cbor.Tag{
+				Number:  24,
+				Content: o.Datum,
+			},
+		}
+	}
+	if o.ScriptRef != nil {
+		var script any = o.ScriptRef.Script
+		if o.ScriptRef.Type == babbage.ScriptRefTypeNativeScript {
+			script = cbor.RawMessage(o.ScriptRef.Script)
+		}
+		scriptRefCbor, err := cbor.Encode([]any{o.ScriptRef.Type, script})
+		if err != nil {
+			return nil, err
+		}
+		tmpOutput[3] = cbor.Tag{
+			Number:  24,
+			Content: scriptRefCbor,
+		}
+	}
+	return cbor.Encode(tmpOutput)
+}
+
+// babbageOutput returns the output as a decoded Babbage transaction output
+func (o *TxOutput) babbageOutput() (*babbage.BabbageTransactionOutput, error) {
+	outputCbor, err := cbor.Encode(o)
+	if err != nil {
+		return nil, err
+	}
+	return babbage.NewBabbageTransactionOutputFromCbor(outputCbor)
+}
@@ -0,0 +1,446 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/allegra"
+	"github.com/blinklabs-io/gouroboros/ledger/alonzo"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/conway"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+)
+
+// Sum6KesSignatureSize is the size of a KES signature in a block header
+const Sum6KesSignatureSize = SIGMA_SIZE + 6*PUBLIC_KEY_SIZE*2
+
+// HeaderChainState is the chain state needed to validate a block header. It should reflect the
+// epoch of the header
+type HeaderChainState struct {
+	// EpochNonce is the nonce for the epoch. An empty value is the neutral nonce
+	EpochNonce              []byte
+	SlotsPerKesPeriod       uint64
+	MaxKesEvolutions        uint64
+	ActiveSlotCoeff         *big.Rat
+	MaxMajorProtocolVersion uint64
+	MaxHeaderSize           uint64
+	// Pools contains the stake distribution for the epoch
+	Pools map[common.Blake2b224]HeaderPoolState
+	// OpCertCounters contains the last seen operational certificate counter for each pool
+	OpCertCounters map[common.Blake2b224]uint64
+	// Decentralization is the decentralization parameter (d) for TPraos eras. Slots in the overlay
+	// schedule are assigned to genesis delegates instead of stake pools
+	Decentralization *big.Rat
+	// EpochFirstSlot is the first slot of the epoch, which is used for the overlay schedule
+	EpochFirstSlot uint64
+	// GenesisDelegates contains the delegate for each genesis key, which is used for the overlay
+	// schedule
+	GenesisDelegates map[common.Blake2b224]HeaderGenesisDelegate
+}
+
+// HeaderPoolState is the state of a pool in the stake distribution
+type HeaderPoolState struct {
+	RelativeStake *big.Rat
+	VrfKeyHash    common.Blake2b256
+}
+
+// HeaderGenesisDelegate is the delegate for a genesis key
+type HeaderGenesisDelegate struct {
+	DelegateKeyHash common.Blake2b224
+	VrfKeyHash      common.Blake2b256
+}
+
+// HeaderValidationResult contains the result of each check performed when validating a block
+// header. A nil value means that the check passed
+type HeaderValidationResult struct {
+	// TPraos indicates that the header has separate nonce and leader VRFs (Shelley through Alonzo)
+	TPraos          bool
+	KesPeriod       error
+	KesSignature    error
+	VrfKey          error
+	VrfProof        error
+	LeaderThreshold error
+	OpCertSignature error
+	OpCertCounter   error
+	ProtocolVersion error
+	HeaderSize      error
+}
+
+// Valid returns true if all checks passed
+func (r *HeaderValidationResult) Valid() bool {
+	return r.Err() == nil
+}
+
+// Err returns the errors for all failed checks
+func (r *HeaderValidationResult) Err() error {
+	return errors.Join(
+		r.KesPeriod,
+		r.KesSignature,
+		r.VrfKey,
+		r.VrfProof,
+		r.LeaderThreshold,
+		r.OpCertSignature,
+		r.OpCertCounter,
+		r.ProtocolVersion,
+		r.HeaderSize,
+	)
+}
+
+// headerView contains the header fields needed for validation, independent of era
+type headerView struct {
+	bodyCbor          []byte
+	signature         []byte
+	slot              uint64
+	issuerVkey        common.IssuerVkey
+	vrfKey            []byte
+	nonceVrf          *common.VrfResult
+	leaderVrf         common.VrfResult
+	opCert            common.OperationalCertificate
+	protoMajorVersion uint64
+}
+
+func newHeaderView(header common.BlockHeader) (*headerView, error) {
+	switch h := header.(type) {
+	case *shelley.ShelleyBlockHeader:
+		return newTPraosHeaderView(h)
+	case *allegra.AllegraBlockHeader:
+		return newTPraosHeaderView(&h.ShelleyBlockHeader)
+	case *mary.MaryBlockHeader:
+		return newTPraosHeaderView(&h.ShelleyBlockHeader)
+	case *alonzo.AlonzoBlockHeader:
+		return newTPraosHeaderView(&h.ShelleyBlockHeader)
+	case *babbage.BabbageBlockHeader:
+		return newPraosHeaderView(h)
+	case *conway.ConwayBlockHeader:
+		return newPraosHeaderView(&h.BabbageBlockHeader)
+	}
+	return nil, fmt.Errorf("unsupported block header type: %T", header)
+}
+
+// headerBodyCbor returns the original CBOR for the header body, which is signed by the KES key
+func headerBodyCbor(headerCbor []byte) ([]byte, error) {
+	var tmpHeader struct {
+		cbor.StructAsArray
+		Body      cbor.RawMessage
+		Signature []byte
+	}
+	if _, err := cbor.Decode(headerCbor, &tmpHeader); err != nil {
+		return nil, err
+	}
+	return tmpHeader.Body, nil
+}
+
+func newTPraosHeaderView(h *shelley.ShelleyBlockHeader) (*headerView, error) {
+	bodyCbor, err := headerBodyCbor(h.Cbor())
+	if err != nil {
+		return nil, err
+	}
+	return &headerView{
+		bodyCbor:          bodyCbor,
+		signature:         h.Signature,
+		slot:              h.Body.Slot,
+		issuerVkey:        h.Body.IssuerVkey,
+		vrfKey:            h.Body.VrfKey,
+		nonceVrf:          &h.Body.NonceVrf,
+		leaderVrf:         h.Body.LeaderVrf,
+		opCert:            shelleyHeaderOpCert(h),
+		protoMajorVersion: h.Body.ProtoMajorVersion,
+	}, nil
+}
+
+func newPraosHeaderView(h *babbage.BabbageBlockHeader) (*headerView, error) {
+	bodyCbor, err := headerBodyCbor(h.Cbor())
+	if err != nil {
+		return nil, err
+	}
+	return &headerView{
+		bodyCbor:          bodyCbor,
+		signature:         h.Signature,
+		slot:              h.Body.Slot,
+		issuerVkey:        h.Body.IssuerVkey,
+		vrfKey:            h.Body.VrfKey,
+		leaderVrf:         h.Body.VrfResult,
+		opCert:            h.Body.OpCert,
+		protoMajorVersion: h.Body.ProtoVersion.Major,
+	}, nil
+}
+
+// ValidateHeader validates a block header from any era from Shelley onward against the chain
+// state. An error is returned if the header cannot be validated at all, and the result of each
+// check is returned otherwise
+func ValidateHeader(
+	header common.BlockHeader,
+	chainState HeaderChainState,
+) (*HeaderValidationResult, error) {
+	if chainState.SlotsPerKesPeriod == 0 {
+		return nil, errors.New("slots per KES period must be specified")
+	}
+	if chainState.ActiveSlotCoeff == nil {
+		return nil, errors.New("active slot coefficient must be specified")
+	}
+	view, err := newHeaderView(header)
+	if err != nil {
+		return nil, err
+	}
+	ret := &HeaderValidationResult{
+		TPraos: view.nonceVrf != nil,
+	}
+	// Protocol version and header size
+	if view.protoMajorVersion > chainState.MaxMajorProtocolVersion {
+		ret.ProtocolVersion = fmt.Errorf(
+			"protocol major version %d is greater than maximum %d",
+			view.protoMajorVersion,
+			chainState.MaxMajorProtocolVersion,
+		)
+	}
+	if headerSize := uint64(len(header.Cbor())); headerSize > chainState.MaxHeaderSize {
+		ret.HeaderSize = fmt.Errorf(
+			"header size %d is greater than maximum %d",
+			headerSize,
+			chainState.MaxHeaderSize,
+		)
+	}
+	// Operational certificate and KES
+	ret.OpCertSignature = view.opCert.Verify(view.issuerVkey[:])
+	ret.KesPeriod, ret.KesSignature = verifyHeaderKes(view, chainState)
+	// Determine the expected VRF key hash and leader threshold for the slot leader
+	poolId := view.issuerVkey.Hash()
+	vrfKeyHash, relativeStake, isKnownIssuer, err := headerSlotLeader(view, poolId, chainState)
+	if err != nil {
+		ret.VrfKey = err
+		ret.LeaderThreshold = err
+	} else if common.Blake2b256Hash(view.vrfKey) != vrfKeyHash {
+		ret.VrfKey = errors.New("VRF key does not match registered VRF key")
+	}
+	ret.OpCertCounter = verifyOpCertCounter(view, poolId, isKnownIssuer, chainState)
+	// VRF proofs
+	leaderVrfOutput, err := verifyHeaderVrf(view, chainState.EpochNonce)
+	if err != nil {
+		ret.VrfProof = err
+		if ret.LeaderThreshold == nil {
+			ret.LeaderThreshold = errors.New("leader VRF output could not be verified")
+		}
+	} else if ret.LeaderThreshold == nil && relativeStake != nil {
+		ret.LeaderThreshold = verifyLeaderThreshold(
+			leaderVrfOutput,
+			view.nonceVrf != nil,
+			relativeStake,
+			chainState.ActiveSlotCoeff,
+		)
+	}
+	return ret, nil
+}
+
+// verifyHeaderKes checks that the current KES period is within the validity of the operational
+// certificate and that the header body was signed by its KES key
+func verifyHeaderKes(view *headerView, chainState HeaderChainState) (error, error) {
+	var sigErr error
+	currentPeriod := view.slot / chainState.SlotsPerKesPeriod
+	periodErr := view.opCert.KesPeriodValid(currentPeriod, chainState.MaxKesEvolutions)
+	if len(view.signature) != Sum6KesSignatureSize {
+		return periodErr, fmt.Errorf("invalid KES signature length: %d", len(view.signature))
+	}
+	if len(view.opCert.HotVkey) != PUBLIC_KEY_SIZE {
+		return periodErr, fmt.Errorf("invalid KES verification key length: %d", len(view.opCert.HotVkey))
+	}
+	if periodErr != nil {
+		return periodErr, errors.New("KES signature cannot be verified outside of the opcert validity")
+	}
+	if !verifySignedKES(
+		view.opCert.HotVkey,
+		currentPeriod-uint64(view.opCert.KesPeriod),
+		view.bodyCbor,
+		view.signature,
+	) {
+		sigErr = errors.New("invalid KES signature")
+	}
+	return periodErr, sigErr
+}
+
+// headerSlotLeader returns the expected VRF key hash and relative stake for the header issuer. The
+// relative stake is nil for a slot in the TPraos overlay schedule, which has no leader threshold
+func headerSlotLeader(
+	view *headerView,
+	poolId common.Blake2b224,
+	chainState HeaderChainState,
+) (common.Blake2b256, *big.Rat, bool, error) {
+	if view.nonceVrf != nil && chainState.Decentralization != nil &&
+		chainState.Decentralization.Sign() > 0 {
+		genesisKey, isOverlay, err := overlaySlotGenesisKey(view.slot, chainState)
+		if err != nil {
+			return common.Blake2b256{}, nil, false, err
+		}
+		if isOverlay {
+			if genesisKey == nil {
+				return common.Blake2b256{}, nil, false, fmt.Errorf(
+					"slot %d is a non-active overlay slot",
+					view.slot,
+				)
+			}
+			delegate := chainState.GenesisDelegates[*genesisKey]
+			if delegate.DelegateKeyHash != poolId {
+				return common.Blake2b256{}, nil, false, fmt.Errorf(
+					"block issuer is not the genesis delegate for overlay slot %d",
+					view.slot,
+				)
+			}
+			return delegate.VrfKeyHash, nil, true, nil
+		}
+	}
+	pool, ok := chainState.Pools[poolId]
+	if !ok || pool.RelativeStake == nil {
+		return common.Blake2b256{}, nil, false, fmt.Errorf(
+			"pool %s is not in the stake distribution",
+			poolId.String(),
+		)
+	}
+	return pool.VrfKeyHash, pool.RelativeStake, true, nil
+}
+
+// overlaySlotGenesisKey determines whether a slot is in the TPraos overlay schedule, and returns the
+// genesis key assigned to the slot if it's an active overlay slot
+func overlaySlotGenesisKey(
+	slot uint64,
+	chainState HeaderChainState,
+) (*common.Blake2b224, bool, error) {
+	if slot < chainState.EpochFirstSlot {
+		return nil, false, errors.New("slot is before the first slot of the epoch")
+	}
+	d := chainState.Decentralization
+	relSlot := slot - chainState.EpochFirstSlot
+	// A slot is an overlay slot when ceil(n * d) increases between n and n + 1
+	position := ratCeil(new(big.Rat).Mul(new(big.Rat).SetInt64(int64(relSlot)), d))
+	nextPosition := ratCeil(new(big.Rat).Mul(new(big.Rat).SetInt64(int64(relSlot+1)), d))
+	if position.Cmp(nextPosition) >= 0 {
+		return nil, false, nil
+	}
+	// Every (1 / f)th overlay slot is active, and active slots are assigned to genesis keys in turn
+	ascInv := new(big.Int).Quo(
+		chainState.ActiveSlotCoeff.Denom(),
+		chainState.ActiveSlotCoeff.Num(),
+	)
+	if ascInv.Sign() == 0 {
+		return nil, false, errors.New("invalid active slot coefficient")
+	}
+	if new(big.Int).Mod(position, ascInv).Sign() != 0 {
+		return nil, true, nil
+	}
+	if len(chainState.GenesisDelegates) == 0 {
+		return nil, true, errors.New("no genesis delegates for overlay slot")
+	}
+	genesisKeys := make([]common.Blake2b224, 0, len(chainState.GenesisDelegates))
+	for genesisKey := range chainState.GenesisDelegates {
+		genesisKeys = append(genesisKeys, genesisKey)
+	}
+	slices.SortFunc(genesisKeys, func(a, b common.Blake2b224) int {
+		return bytes.Compare(a.Bytes(), b.Bytes())
+	})
+	idx := new(big.Int).Quo(position, ascInv)
+	idx.Mod(idx, big.NewInt(int64(len(genesisKeys))))
+	return &genesisKeys[idx.Int64()], true, nil
+}
+
+// verifyOpCertCounter checks that the opcert counter has not gone down and has increased by at most
+// one since the last block from the same issuer
+func verifyOpCertCounter(
+	view *headerView,
+	poolId common.Blake2b224,
+	isKnownIssuer bool,
+	chainState HeaderChainState,
+) error {
+	lastCounter, ok := chainState.OpCertCounters[poolId]
+	if !ok && !isKnownIssuer {
+		return fmt.Errorf("no opcert counter for pool %s", poolId.String())
+	}
+	return checkOpCertCounter(uint64(view.opCert.SequenceNumber), lastCounter)
+}
+
+// verifyHeaderVrf checks the VRF proofs in the header and returns the leader VRF output
+func verifyHeaderVrf(view *headerView, epochNonce []byte) ([]byte, error) {
+	if view.nonceVrf != nil {
+		// TPraos uses separate VRF inputs for the nonce and leader values
+		nonceInput := tpraosVrfInput(view.slot, epochNonce, tpraosSeedEta)
+		if err := verifyVrfResult(view.vrfKey, *view.nonceVrf, nonceInput); err != nil {
+			return nil, fmt.Errorf("nonce VRF: %w", err)
+		}
+		leaderInput := tpraosVrfInput(view.slot, epochNonce, tpraosSeedL)
+		if err := verifyVrfResult(view.vrfKey, view.leaderVrf, leaderInput); err != nil {
+			return nil, fmt.Errorf("leader VRF: %w", err)
+		}
+		return view.leaderVrf.Output, nil
+	}
+	input := MkInputVrf(int64(view.slot), epochNonce)
+	if err := verifyVrfResult(view.vrfKey, view.leaderVrf, input); err != nil {
+		return nil, err
+	}
+	return view.leaderVrf.Output, nil
+}
+
+func verifyVrfResult(vrfKey []byte, result common.VrfResult, input []byte) error {
+	output, err := VrfVerifyAndHash(vrfKey, result.Proof, input)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(output, result.Output) {
+		return errors.New("VRF output does not match proof")
+	}
+	return nil
+}
+
+var (
+	// Seeds for the TPraos nonce and leader VRF inputs, which are the hashes of 0 and 1 as 64-bit integers
+	tpraosSeedEta = common.Blake2b256Hash(binary.BigEndian.AppendUint64(nil, 0))
+	tpraosSeedL   = common.Blake2b256Hash(binary.BigEndian.AppendUint64(nil, 1))
+)
+
+// tpraosVrfInput builds the VRF input for TPraos, which is the Praos VRF input XORed with the seed
+func tpraosVrfInput(slot uint64, epochNonce []byte, seed common.Blake2b256) []byte {
+	ret := MkInputVrf(int64(slot), epochNonce)
+	for idx := range ret {
+		ret[idx] ^= seed[idx]
+	}
+	return ret
+}
+
+func verifyLeaderThreshold(
+	vrfOutput []byte,
+	tpraos bool,
+	relativeStake *big.Rat,
+	activeSlotCoeff *big.Rat,
+) error {
+	leaderValue := PraosVrfLeaderValue(vrfOutput)
+	leaderValueBits := uint(PraosLeaderValueBits)
+	if tpraos {
+		leaderValue = TPraosVrfLeaderValue(vrfOutput)
+		leaderValueBits = TPraosLeaderValueBits
+	}
+	threshold, err := VrfLeaderThreshold(relativeStake, activeSlotCoeff, leaderValueBits)
+	if err != nil {
+		return err
+	}
+	if leaderValue.Cmp(threshold) >= 0 {
+		return errors.New("VRF leader value is not below the leader threshold")
+	}
+	return nil
+}
@@ -0,0 +1,414 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const (
+	// Mainnet block 10882991
+	testPraosHeaderHex  = "828a1a00a60faf1a0817580c58204eac1e7264c0e80436b04687e75d46d6a0d6b2338c2abb73a14fafbd689f69b2582012209e0b93f0128f670c9a02781c5466c4c4be003da3a51344b6a94f709ce51f58209c1a5fc5dec0a4b822d5a3b254ce9b168299479127aadcf97506ef257517fff682584023c2d70c24c44041644f5152f7e8a1bb580e516eb8e73c7df287116adb5f009c0c001feccfeebdf34c2275d1fce859c6c46182631b6306d5fd2724ac7ab1c6be58500dbe31ef7c00c34b6522e983d223e05075359cb170668d960b8cebfced178287ee6ca5cfc6e8e60aec97fd197aebfefc24aae695680631d575c6dacdfd9efc5687e46eb2a5c04a755c7f260af9ef830819c5ea5820d2b74b6333637801f2e9c7265792d5b8fc1647f9056d67c769dbac27f25f2fd08458200946347d22a3b6da29d79102424973c932b898808ff2436fa138df102484230a0a1904165840c75619c3ebad0758349eb1dedc154a8cd280d8189d6da973b4a147b0cdb0f60442d493feeba64167a05b5fc40bc695192bf1c08afad3c07ebd33cb5925f378018209015901c00a8442332bd3f33a4d78fe2736a75110b528a1e7501bc7887910d1475fc0e425f49a84f94e98f87047916cf622f3db1f61b60c5f06709769f98c4cc67de8f50c320c6772b647ac9916765b6985d4eafccb54e71064d01df41f8d0638ed5cd62b7b6e49ba15dd87cc687ab87d3fb22490d355e8fa9c5f7c24ed88b800fcc4cb1f1b54e65b5ba82c442f4643caadc86583072b8b6956f4f9a4530c29873f7231605efd7a7f961a863530512ef86b50f9b1004748c31fa07978f2ece7d8e76ffde67d713015824b28e19f05f0383c2def3cdeb67247f33f5eae329c38a375b2eb06a586dcc2e102a776a6deaad1741f2a7f5aa604074698e876afab4455278fd84a1db5768078e2848cc85e3c8a0b48630a2622832ecd2dbb3c505df2a70b93b49ce99616f601e5e2004a8ce8926319c23f2a26ac8550cb1c05c9d2d25fc5fcd122fc35b057a71d6e961250c99b19a7bfd9acdc60a8151d6c81ef2d7d69a62fd0f17d184dd753cce9a2e9c32b53baf317e31c6c5e3cf8ea8b203b413ae8b0253db53d0cbe19b0f0547a0e67d3591d1cade6ceb4a47779ba4a09e7526280acb62200f42c98f6185ea9da3daf47aa3d10ffe5307331fa3430af6c6361154943c39375"
+	testPraosEpochNonce = "4ef95a10f639d0cf16bb963c3a580d4bf2a95b6ae7848702665884843e3c661d"
+	// Legacy testnet Shelley block 02b1c561715da9e540411123a6135ee319b02f60b9a11a603d3305556c04329f
+	testTPraosHeaderHex = "828f1a00185ecd1a001863c058207e16781b40ebf8b6da18f7b5e8ade855d6738095ef2f1c58c77e88b6e45997a4582032a954b521c0b19514408965831ef6839637de7a1a6168bcf8455c504ba93b9c5820a7b41d9c81c6129d2e4576873086e206434424e203bf4b3c7bb092d6763524e682584074e791c4a55a68418953d17b5a3c31c2e15d5971eb372321a13a938151ec78cfc37aaa9bb66d778db687f9d1b286335f3aa76287cc34cd5aace6a3e21912e2b6585021cab43a4c292a12fa018d5620f05a040ab7f58d1abf035122049b410127a04c44fdcc5af9812f69b2ed709b8cf08eb7294c478971f810118257b7a2957f363d5b35e12f31389ac03df2ffb50cbebe09825840fe4d8c01858f45a7af363ac50025eeebba3f594c52ee9224db0fbaa0f889b419b74408d586c33f6be98cb2d6b5151beabc4cf826db3760974ac21fade8d8e8b75850fe3209f881ce5d3048ac358b96bf809e95b0156d156c267ddcfc6f34ec2ae75f04d536285874b2bffaee3fc5fcd630d42e3bceda39174664bf96406d03454f03a109dcaae5a54dd12bc82d97c66708020358201033376be025cb705fd8dd02eda11cc73975a062b5d14ffd74d6ff69e69a2ff758206330dd04a06d755d7ac32eb44f9aa5ee67c389efdc22b9846e6025f2bd4b277d000058407fd4c77bc9d55234116178fee307ab67fc6f7af6b3642a993b5bba7ec65b10d3967e7c204ec0bfa92dfa992071e36afcec1bb0044dd635e9b1c828901e8e610402005901c0b4d5b2d1d66c71c0137fc2c5a611badf03fbe5679c12680b42c932abd043507839a02d4c5e04069cf51f46b3284f1da6f567a36c1f1adef37f6cfa0ea7dfd406c98ce19cf50af501845b0260919b4b9b9ce074af6ac02a28da1037884f3301b3efb030d7e61abd90b2de66dccb48afd315c25381af9bf3f676fdf5405a6a557d33c07bc6be69bf414de79f69ad20e38980f4afb4df55581572ec6ee935383ef6fa813084d940049297373a2d4f5fc09e70735615b9266066c2b890afef7fc9dfd1198b7e1403c94bccb793435e6b6c24db51bbbfb4c986898a653b9095f89a49d00c624752bba3843a4284d964de5b1dbf6a9ad67d6b351c59f74aa1c016f3b85e8417bd6d55274442410d2004e5f98d28fc1dab88f40a34e1af0bad15610072adfdad2ef982e6e2d093bc2c3cb0747523197c83059458322ae08fb03363e361516da86c3234416db647a98193e4881b310f1a05a7400d5e4cfa589647ad7b9075765ef450fbc4121c32467aaf68cdd69cd0e6ec7197122cfc877d6dcfe1f152b0cd2b0490c9d1e56c64eadc70b2aafdc11999078ef815100433ebbf70df842c2d56e9149e4d7876dd2f18c232489a56651ab2d94c5fcd3dc8753478552ebabe"
+)
+
+func testPraosHeaderChainState(t *testing.T, header *ledger.ConwayBlockHeader) ledger.HeaderChainState {
+	epochNonce, err := hex.DecodeString(testPraosEpochNonce)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	poolId := header.Body.IssuerVkey.Hash()
+	return ledger.HeaderChainState{
+		EpochNonce:              epochNonce,
+		SlotsPerKesPeriod:       129600,
+		MaxKesEvolutions:        62,
+		ActiveSlotCoeff:         big.NewRat(1, 20),
+		MaxMajorProtocolVersion: 10,
+		MaxHeaderSize:           1100,
+		Pools: map[common.Blake2b224]ledger.HeaderPoolState{
+			poolId: {
+				RelativeStake: big.NewRat(1, 100),
+				VrfKeyHash:    common.Blake2b256Hash(header.Body.VrfKey),
+			},
+		},
+		OpCertCounters: map[common.Blake2b224]uint64{
+			poolId: uint64(header.Body.OpCert.SequenceNumber),
+		},
+	}
+}
+
+func TestValidateHeaderPraos(t *testing.T) {
+	headerCbor, err := hex.DecodeString(testPraosHeaderHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	header, err := ledger.NewConwayBlockHeaderFromCbor(headerCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	poolId := header.Body.IssuerVkey.Hash()
+	testDefs := []struct {
+		name     string
+		modify   func(*ledger.HeaderChainState)
+		checkErr func(*ledger.HeaderValidationResult) error
+	}{
+		{
+			name:   "valid",
+			modify: func(*ledger.HeaderChainState) {},
+		},
+		{
+			name: "counter increased by one",
+			modify: func(s *ledger.HeaderChainState) {
+				s.OpCertCounters[poolId]--
+			},
+		},
+		{
+			name: "wrong epoch nonce",
+			modify: func(s *ledger.HeaderChainState) {
+				s.EpochNonce = make([]byte, 32)
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.VrfProof },
+		},
+		{
+			name: "insufficient stake",
+			modify: func(s *ledger.HeaderChainState) {
+				s.Pools[poolId] = ledger.HeaderPoolState{
+					RelativeStake: big.NewRat(1, 1_000_000_000_000),
+					VrfKeyHash:    s.Pools[poolId].VrfKeyHash,
+				}
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.LeaderThreshold },
+		},
+		{
+			name: "wrong VRF key hash",
+			modify: func(s *ledger.HeaderChainState) {
+				s.Pools[poolId] = ledger.HeaderPoolState{
+					RelativeStake: s.Pools[poolId].RelativeStake,
+				}
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.VrfKey },
+		},
+		{
+			name: "counter went down",
+			modify: func(s *ledger.HeaderChainState) {
+				s.OpCertCounters[poolId]++
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.OpCertCounter },
+		},
+		{
+			name: "counter increased by more than one",
+			modify: func(s *ledger.HeaderChainState) {
+				s.OpCertCounters[poolId] -= 2
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.OpCertCounter },
+		},
+		{
+			name: "opcert expired",
+			modify: func(s *ledger.HeaderChainState) {
+				s.MaxKesEvolutions = 1
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.KesPeriod },
+		},
+		{
+			name: "wrong KES period",
+			modify: func(s *ledger.HeaderChainState) {
+				s.SlotsPerKesPeriod = 129000
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.KesSignature },
+		},
+		{
+			name: "protocol version too high",
+			modify: func(s *ledger.HeaderChainState) {
+				s.MaxMajorProtocolVersion = 8
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.ProtocolVersion },
+		},
+		{
+			name: "header too large",
+			modify: func(s *ledger.HeaderChainState) {
+				s.MaxHeaderSize = 800
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.HeaderSize },
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			chainState := testPraosHeaderChainState(t, header)
+			testDef.modify(&chainState)
+			result, err := ledger.ValidateHeader(header, chainState)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if result.TPraos {
+				t.Fatalf("did not expect TPraos header")
+			}
+			if testDef.checkErr == nil {
+				if !result.Valid() {
+					t.Fatalf("unexpected error: %s", result.Err())
+				}
+				return
+			}
+			if testDef.checkErr(result) == nil {
+				t.Fatalf("did not get expected error, got: %v", result.Err())
+			}
+		})
+	}
+}
+
+func TestValidateHeaderTPraos(t *testing.T) {
+	headerCbor, err := hex.DecodeString(testTPraosHeaderHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	header, err := ledger.NewShelleyBlockHeaderFromCbor(headerCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	chainState := ledger.HeaderChainState{
+		SlotsPerKesPeriod:       129600,
+		MaxKesEvolutions:        62,
+		ActiveSlotCoeff:         big.NewRat(1, 20),
+		MaxMajorProtocolVersion: 2,
+		MaxHeaderSize:           1100,
+	}
+	result, err := ledger.ValidateHeader(header, chainState)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !result.TPraos {
+		t.Fatalf("expected TPraos header")
+	}
+	// The KES signature and opcert don't depend on the chain state
+	for _, checkErr := range []error{
+		result.KesPeriod,
+		result.KesSignature,
+		result.OpCertSignature,
+		result.ProtocolVersion,
+		result.HeaderSize,
+	} {
+		if checkErr != nil {
+			t.Fatalf("unexpected error: %s", checkErr)
+		}
+	}
+	// The epoch nonce is not known and the issuer is not in the stake distribution
+	if result.VrfProof == nil || result.VrfKey == nil || result.OpCertCounter == nil {
+		t.Fatalf("did not get expected errors, got: %v", result.Err())
+	}
+}
+
+// testTPraosVrfInput builds the TPraos VRF input, which is the Praos VRF input XORed with the hash of the
+// seed value (0 for the nonce VRF, 1 for the leader VRF)
+func testTPraosVrfInput(slot uint64, epochNonce []byte, seedValue byte) []byte {
+	seed := common.Blake2b256Hash([]byte{0, 0, 0, 0, 0, 0, 0, seedValue})
+	ret := ledger.MkInputVrf(int64(slot), epochNonce)
+	for idx := range ret {
+		ret[idx] ^= seed[idx]
+	}
+	return ret
+}
+
+// testSignedTPraosHeader builds a Shelley header for the first slot at or after startSlot that the pool
+// leads, and signs it with keys derived from fixed seeds. The chain state for validating the header is
+// also returned
+func testSignedTPraosHeader(t *testing.T, startSlot uint64, epochNonce []byte) (*ledger.ShelleyBlockHeader, ledger.HeaderChainState) {
+	coldKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))
+	vrfVkey, vrfSkey, err := ledger.VrfKeyGenFromSeed(bytes.Repeat([]byte{2}, ledger.VrfSeedSize))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	kesKey, err := ledger.NewSumKesSigningKeyFromSeed(ledger.Sum6KesDepth, bytes.Repeat([]byte{3}, ledger.KesSeedSize))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var issuerVkey common.IssuerVkey
+	copy(issuerVkey[:], coldKey.Public().(ed25519.PublicKey))
+	poolId := issuerVkey.Hash()
+	chainState := ledger.HeaderChainState{
+		EpochNonce:              epochNonce,
+		SlotsPerKesPeriod:       129600,
+		MaxKesEvolutions:        62,
+		ActiveSlotCoeff:         big.NewRat(1, 20),
+		MaxMajorProtocolVersion: 6,
+		MaxHeaderSize:           1100,
+		Pools: map[common.Blake2b224]ledger.HeaderPoolState{
+			poolId: {
+				RelativeStake: big.NewRat(1, 4),
+				VrfKeyHash:    common.Blake2b256Hash(vrfVkey),
+			},
+		},
+		OpCertCounters: map[common.Blake2b224]uint64{
+			poolId: 2,
+		},
+	}
+	threshold, err := ledger.VrfLeaderThreshold(
+		chainState.Pools[poolId].RelativeStake,
+		chainState.ActiveSlotCoeff,
+		ledger.TPraosLeaderValueBits,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Find a slot that the pool leads
+	slot := startSlot
+	var leaderProof, leaderOutput []byte
+	for {
+		leaderProof, leaderOutput, err = ledger.VrfProve(vrfSkey, testTPraosVrfInput(slot, epochNonce, 1))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if ledger.TPraosVrfLeaderValue(leaderOutput).Cmp(threshold) < 0 {
+			break
+		}
+		slot++
+	}
+	nonceProof, nonceOutput, err := ledger.VrfProve(vrfSkey, testTPraosVrfInput(slot, epochNonce, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The opcert starts one KES period before the slot
+	kesPeriod := slot / chainState.SlotsPerKesPeriod
+	opCert := common.OperationalCertificate{
+		HotVkey:        kesKey.VerificationKey(),
+		SequenceNumber: 3,
+		KesPeriod:      uint32(kesPeriod - 1),
+	}
+	opCert.Signature = ed25519.Sign(coldKey, opCert.SignedData())
+	bodyCbor, err := cbor.Encode(
+		[]any{
+			uint64(1234),
+			slot,
+			make([]byte, 32),
+			issuerVkey[:],
+			vrfVkey,
+			[]any{nonceOutput, nonceProof},
+			[]any{leaderOutput, leaderProof},
+			uint64(0),
+			common.Blake2b256Hash(nil).Bytes(),
+			opCert.HotVkey,
+			opCert.SequenceNumber,
+			opCert.KesPeriod,
+			opCert.Signature,
+			uint64(4),
+			uint64(0),
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := kesKey.Evolve(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	signature, err := kesKey.Sign(1, bodyCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	headerCbor, err := cbor.Encode([]any{cbor.RawMessage(bodyCbor), signature})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	header, err := ledger.NewShelleyBlockHeaderFromCbor(headerCbor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return header, chainState
+}
+
+func TestValidateHeaderTPraosSigned(t *testing.T) {
+	epochNonce, err := hex.DecodeString(testPraosEpochNonce)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	header, chainState := testSignedTPraosHeader(t, 5_000_000, epochNonce)
+	poolId := header.Body.IssuerVkey.Hash()
+	testDefs := []struct {
+		name     string
+		modify   func(*ledger.HeaderChainState)
+		checkErr func(*ledger.HeaderValidationResult) error
+	}{
+		{
+			name:   "valid",
+			modify: func(*ledger.HeaderChainState) {},
+		},
+		{
+			name: "wrong epoch nonce",
+			modify: func(s *ledger.HeaderChainState) {
+				s.EpochNonce = make([]byte, 32)
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.VrfProof },
+		},
+		{
+			name: "insufficient stake",
+			modify: func(s *ledger.HeaderChainState) {
+				s.Pools[poolId] = ledger.HeaderPoolState{
+					RelativeStake: big.NewRat(1, 1_000_000_000_000),
+					VrfKeyHash:    s.Pools[poolId].VrfKeyHash,
+				}
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.LeaderThreshold },
+		},
+		{
+			name: "wrong KES period",
+			modify: func(s *ledger.HeaderChainState) {
+				s.SlotsPerKesPeriod = 86400
+			},
+			checkErr: func(r *ledger.HeaderValidationResult) error { return r.KesSignature },
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			tmpChainState := chainState
+			tmpChainState.Pools = map[common.Blake2b224]ledger.HeaderPoolState{
+				poolId: chainState.Pools[poolId],
+			}
+			testDef.modify(&tmpChainState)
+			result, err := ledger.ValidateHeader(header, tmpChainState)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !result.TPraos {
+				t.Fatalf("expected TPraos header")
+			}
+			if testDef.checkErr == nil {
+				if !result.Valid() {
+					t.Fatalf("unexpected error: %s", result.Err())
+				}
+				return
+			}
+			if testDef.checkErr(result) == nil {
+				t.Fatalf("did not get expected error, got: %v", result.Err())
+			}
+		})
+	}
+}
+
+func TestValidateHeaderUnsupported(t *testing.T) {
+	chainState := ledger.HeaderChainState{
+		SlotsPerKesPeriod: 129600,
+		ActiveSlotCoeff:   big.NewRat(1, 20),
+	}
+	if _, err := ledger.ValidateHeader(&ledger.ByronMainBlockHeader{}, chainState); err == nil {
+		t.Fatalf("did not get expected error for Byron header")
+	}
+}
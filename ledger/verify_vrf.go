@@ -37,7 +37,8 @@ const (
 
 func MkInputVrf(slot int64, eta0 []byte) []byte {
 	// Ref: https://github.com/IntersectMBO/ouroboros-consensus/blob/de74882102236fdc4dd25aaa2552e8b3e208448c/ouroboros-consensus-protocol/src/ouroboros-consensus-protocol/Ouroboros/Consensus/Protocol/Praos/VRF.hs#L60
-	concat := make([]byte, 8+32)
+	// The epoch nonce is omitted for the neutral nonce
+	concat := make([]byte, 8+len(eta0))
 	binary.BigEndian.PutUint64(concat[:8], uint64(slot))
 	copy(concat[8:], eta0)
 	h, err := blake2b.New(32, nil)
@@ -0,0 +1,127 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"slices"
+
+	"filippo.io/edwards25519"
+)
+
+const (
+	// VrfSeedSize is the size of the seed for a VRF key pair
+	VrfSeedSize = 32
+	// VrfSigningKeySize is the size of a VRF signing key, which is the seed followed by the
+	// verification key
+	VrfSigningKeySize = 64
+	// VrfVerificationKeySize is the size of a VRF verification key
+	VrfVerificationKeySize = 32
+	// VrfProofSize is the size of a VRF proof
+	VrfProofSize = 80
+	// VrfOutputSize is the size of a VRF output
+	VrfOutputSize = 64
+)
+
+// VrfKeyGenFromSeed returns the VRF verification key and signing key for the specified seed
+func VrfKeyGenFromSeed(seed []byte) ([]byte, []byte, error) {
+	if len(seed) != VrfSeedSize {
+		return nil, nil, fmt.Errorf("invalid VRF seed length: %d", len(seed))
+	}
+	x, _ := vrfExpandSeed(seed)
+	pk := new(edwards25519.Point).ScalarBaseMult(x).Bytes()
+	return pk, slices.Concat(seed, pk), nil
+}
+
+// VrfKeyGen returns a new random VRF verification key and signing key
+func VrfKeyGen() ([]byte, []byte, error) {
+	seed := make([]byte, VrfSeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, nil, err
+	}
+	return VrfKeyGenFromSeed(seed)
+}
+
+// VrfProve returns the ECVRF-ED25519-SHA512-Elligator2 proof and output for the message using the
+// specified signing key. The output is the same as that returned by VrfVerifyAndHash for the proof
+func VrfProve(signingKey []byte, msg []byte) ([]byte, []byte, error) {
+	// Ref: https://github.com/input-output-hk/libsodium/blob/draft-irtf-cfrg-vrf-03/src/libsodium/crypto_vrf/ietfdraft03/prove.c
+	x, noncePrefix, Y, err := decodeVrfSigningKey(signingKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	H, err := vrfHashToCurveElligator225519(Y, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	Gamma := new(edwards25519.Point).ScalarMult(x, H)
+	// The nonce is derived from the second half of the hashed seed and the point for the message
+	nonceHash := sha512.Sum512(slices.Concat(noncePrefix, H.Bytes()))
+	k, err := edwards25519.NewScalar().SetUniformBytes(nonceHash[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	kB := new(edwards25519.Point).ScalarBaseMult(k)
+	kH := new(edwards25519.Point).ScalarMult(k, H)
+	c := vrfHashPoints(H, Gamma, kB, kH)
+	s := edwards25519.NewScalar().MultiplyAdd(c, x, k)
+	proof := slices.Concat(Gamma.Bytes(), c.Bytes()[:16], s.Bytes())
+	output, err := cryptoVrfIetfdraft03ProofToHash(proof)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, output, nil
+}
+
+// vrfOutputWithKey returns the VRF output for the message without calculating the full proof
+func vrfOutputWithKey(x *edwards25519.Scalar, Y *edwards25519.Point, msg []byte) ([]byte, error) {
+	H, err := vrfHashToCurveElligator225519(Y, msg)
+	if err != nil {
+		return nil, err
+	}
+	Gamma := new(edwards25519.Point).ScalarMult(x, H)
+	hashInput := append([]byte{VRF_SUITE, 0x03}, Gamma.MultByCofactor(Gamma).Bytes()...)
+	output := sha512.Sum512(hashInput)
+	return output[:], nil
+}
+
+// decodeVrfSigningKey returns the secret scalar, nonce prefix and public key point for a VRF signing key
+func decodeVrfSigningKey(
+	signingKey []byte,
+) (*edwards25519.Scalar, []byte, *edwards25519.Point, error) {
+	if len(signingKey) != VrfSigningKeySize {
+		return nil, nil, nil, fmt.Errorf("invalid VRF signing key length: %d", len(signingKey))
+	}
+	x, noncePrefix := vrfExpandSeed(signingKey[:VrfSeedSize])
+	Y := new(edwards25519.Point).ScalarBaseMult(x)
+	if !slices.Equal(Y.Bytes(), signingKey[VrfSeedSize:]) {
+		return nil, nil, nil, errors.New("VRF signing key does not match its verification key")
+	}
+	return x, noncePrefix, Y, nil
+}
+
+// vrfExpandSeed returns the secret scalar and nonce prefix for a VRF seed, which are derived in the
+// same way as for ed25519
+func vrfExpandSeed(seed []byte) (*edwards25519.Scalar, []byte) {
+	h := sha512.Sum512(seed)
+	x, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		panic(fmt.Sprintf("unexpected error creating VRF scalar: %s", err))
+	}
+	return x, h[32:]
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+func TestVrfProve(t *testing.T) {
+	// Test vectors from draft-irtf-cfrg-vrf-03 for ECVRF-ED25519-SHA512-Elligator2
+	testDefs := []struct {
+		seed   string
+		pk     string
+		alpha  string
+		proof  string
+		output string
+	}{
+		{
+			seed:   "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			pk:     "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			alpha:  "",
+			proof:  "b6b4699f87d56126c9117a7da55bd0085246f4c56dbc95d20172612e9d38e8d7ca65e573a126ed88d4e30a46f80a666854d675cf3ba81de0de043c3774f061560f55edc256a787afe701677c0f602900",
+			output: "5b49b554d05c0cd5a5325376b3387de59d924fd1e13ded44648ab33c21349a603f25b84ec5ed887995b33da5e3bfcb87cd2f64521c4c62cf825cffabbe5d31cc",
+		},
+		{
+			seed:   "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+			pk:     "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+			alpha:  "72",
+			proof:  "ae5b66bdf04b4c010bfe32b2fc126ead2107b697634f6f7337b9bff8785ee111200095ece87dde4dbe87343f6df3b107d91798c8a7eb1245d3bb9c5aafb093358c13e6ae1111a55717e895fd15f99f07",
+			output: "94f4487e1b2fec954309ef1289ecb2e15043a2461ecc7b2ae7d4470607ef82eb1cfa97d84991fe4a7bfdfd715606bc27e2967a6c557cfb5875879b671740b7d8",
+		},
+	}
+	for _, testDef := range testDefs {
+		seed, _ := hex.DecodeString(testDef.seed)
+		alpha, _ := hex.DecodeString(testDef.alpha)
+		pk, sk, err := ledger.VrfKeyGenFromSeed(seed)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if hex.EncodeToString(pk) != testDef.pk {
+			t.Fatalf("did not get expected public key: got %x, wanted %s", pk, testDef.pk)
+		}
+		proof, output, err := ledger.VrfProve(sk, alpha)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if hex.EncodeToString(proof) != testDef.proof {
+			t.Fatalf("did not get expected proof: got %x, wanted %s", proof, testDef.proof)
+		}
+		if hex.EncodeToString(output) != testDef.output {
+			t.Fatalf("did not get expected output: got %x, wanted %s", output, testDef.output)
+		}
+		verifyOutput, err := ledger.VrfVerifyAndHash(pk, proof, alpha)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(verifyOutput, output) {
+			t.Fatalf("did not get expected output from verification: %x", verifyOutput)
+		}
+	}
+}
+
+func TestVrfProveRandom(t *testing.T) {
+	pk, sk, err := ledger.VrfKeyGen()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	msg := ledger.MkInputVrf(12345, bytes.Repeat([]byte{0x01}, 32))
+	proof, output, err := ledger.VrfProve(sk, msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	verifyOutput, err := ledger.VrfVerifyAndHash(pk, proof, msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(verifyOutput, output) {
+		t.Fatalf("did not get expected output from verification: %x", verifyOutput)
+	}
+	if _, err := ledger.VrfVerifyAndHash(pk, proof, msg[1:]); err == nil {
+		t.Fatalf("did not get expected error verifying proof for a different message")
+	}
+	// The verification key in the signing key must match the seed
+	sk[len(sk)-1] ^= 0x01
+	if _, _, err := ledger.VrfProve(sk, msg); err == nil {
+		t.Fatalf("did not get expected error for mismatched signing key")
+	}
+}
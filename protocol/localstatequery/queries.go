@@ -16,6 +16,8 @@ package localstatequery
 
 import (
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/blinklabs-io/gouroboros/cbor"
 	"github.com/blinklabs-io/gouroboros/ledger"
@@ -421,6 +423,15 @@ type SystemStartResult struct {
 	Picoseconds uint64
 }
 
+// Time returns the system start as a time.Time
+func (s SystemStartResult) Time() time.Time {
+	ret := time.Date(s.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// The day value is the 1-based day of the year
+	ret = ret.AddDate(0, 0, s.Day-1)
+	ret = ret.Add(time.Duration(s.Picoseconds / 1000)) // #nosec G115
+	return ret
+}
+
 type ChainBlockNoQuery struct {
 	simpleQueryBase
 }
@@ -468,6 +479,77 @@ type eraHistoryResultParams struct {
 	Unknown int
 }
 
+// EraSummary converts the era history result into a ledger.EraSummary
+func (r EraHistoryResult) EraSummary() (ledger.EraSummary, error) {
+	var ret ledger.EraSummary
+	start, err := r.Begin.eraBound()
+	if err != nil {
+		return ret, err
+	}
+	ret.Start = start
+	// The end is only omitted for an unbounded era
+	if r.End.Timespan != nil {
+		end, err := r.End.eraBound()
+		if err != nil {
+			return ret, err
+		}
+		ret.End = &end
+	}
+	ret.Params = ledger.EraParams{
+		EpochLength: uint64(r.Params.EpochLength), // #nosec G115
+		// Slot length is provided in milliseconds
+		SlotLength: time.Duration(r.Params.SlotLength) * time.Millisecond,
+		SafeZone:   uint64(r.Params.SlotsPerKESPeriod.Value), // #nosec G115
+	}
+	return ret, nil
+}
+
+func (b eraHistoryResultBeginEnd) eraBound() (ledger.EraBound, error) {
+	var ret ledger.EraBound
+	// The relative time is provided in picoseconds, which can overflow a uint64
+	var picoseconds *big.Int
+	switch v := b.Timespan.(type) {
+	case uint64:
+		picoseconds = new(big.Int).SetUint64(v)
+	case int64:
+		picoseconds = big.NewInt(v)
+	case big.Int:
+		picoseconds = &v
+	case *big.Int:
+		picoseconds = v
+	default:
+		return ret, fmt.Errorf("unexpected era bound time type: %T", v)
+	}
+	nanoseconds := new(big.Int).Quo(picoseconds, big.NewInt(1000))
+	if !nanoseconds.IsInt64() {
+		return ret, fmt.Errorf("era bound time out of range: %s", picoseconds)
+	}
+	ret.Time = time.Duration(nanoseconds.Int64())
+	ret.Slot = uint64(b.SlotNo)   // #nosec G115
+	ret.Epoch = uint64(b.EpochNo) // #nosec G115
+	return ret, nil
+}
+
+// NewEraHistory returns a ledger.EraHistory built from the results of the GetSystemStart and
+// GetEraHistory queries
+func NewEraHistory(
+	systemStart *SystemStartResult,
+	eraHistory []EraHistoryResult,
+) (*ledger.EraHistory, error) {
+	if systemStart == nil {
+		return nil, fmt.Errorf("system start must be provided")
+	}
+	eras := make([]ledger.EraSummary, 0, len(eraHistory))
+	for idx, era := range eraHistory {
+		tmpEra, err := era.EraSummary()
+		if err != nil {
+			return nil, fmt.Errorf("era %d: %w", idx, err)
+		}
+		eras = append(eras, tmpEra)
+	}
+	return ledger.NewEraHistory(systemStart.Time(), eras)
+}
+
 // TODO (#860)
 /*
 result	[{ *[0 int] => non_myopic_rewards }]	for each stake display reward
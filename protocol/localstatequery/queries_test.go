@@ -0,0 +1,93 @@
+// Copyright 2025 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localstatequery_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/protocol/localstatequery"
+)
+
+func TestSystemStartResultTime(t *testing.T) {
+	// Mainnet system start (2017-09-23T21:44:51Z)
+	systemStart := localstatequery.SystemStartResult{
+		Year:        2017,
+		Day:         266,
+		Picoseconds: 78291000000000000,
+	}
+	expected := time.Date(2017, time.September, 23, 21, 44, 51, 0, time.UTC)
+	if !systemStart.Time().Equal(expected) {
+		t.Fatalf(
+			"did not get expected time: got %s, wanted %s",
+			systemStart.Time(),
+			expected,
+		)
+	}
+}
+
+func TestNewEraHistory(t *testing.T) {
+	// Mainnet Byron and Shelley eras, with relative times in picoseconds
+	byronEndPicoseconds, _ := new(big.Int).SetString("89856000000000000000", 10)
+	shelleyEndPicoseconds, _ := new(big.Int).SetString("101952000000000000000", 10)
+	eraHistoryData := []any{
+		[]any{
+			[]any{0, 0, 0},
+			[]any{byronEndPicoseconds, 4492800, 208},
+			[]any{21600, 20000, []any{0, 4320, []any{0}}, 4320},
+		},
+		[]any{
+			[]any{byronEndPicoseconds, 4492800, 208},
+			[]any{shelleyEndPicoseconds, 16588800, 236},
+			[]any{432000, 1000, []any{0, 129600, []any{0}}, 129600},
+		},
+	}
+	cborData, err := cbor.Encode(eraHistoryData)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var eraHistoryResult []localstatequery.EraHistoryResult
+	if _, err := cbor.Decode(cborData, &eraHistoryResult); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	eraHistory, err := localstatequery.NewEraHistory(
+		&localstatequery.SystemStartResult{
+			Year:        2017,
+			Day:         266,
+			Picoseconds: 78291000000000000,
+		},
+		eraHistoryResult,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	slotTime, err := eraHistory.SlotToTime(4492800)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if slotTime.Unix() != 1596059091 {
+		t.Errorf(
+			"did not get expected time: got %d, wanted %d",
+			slotTime.Unix(),
+			1596059091,
+		)
+	}
+	// Slot at the end of the known history
+	if _, err := eraHistory.SlotToTime(16588800); err == nil {
+		t.Errorf("did not get expected error for slot past horizon")
+	}
+}